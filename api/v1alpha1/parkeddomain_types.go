@@ -24,31 +24,689 @@ import (
 // NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
 
 // ParkedDomainSpec defines the desired state of ParkedDomain.
+// +kubebuilder:validation:XValidation:rule="!has(self.sharedBucket) || !has(self.objectLock)",message="objectLock cannot be set on a shared bucket"
 type ParkedDomainSpec struct {
 	// INSERT ADDITIONAL SPEC FIELDS - desired state of cluster
 	// Important: Run "make" to regenerate code after modifying this file
 
-	// DomainName is the fully qualified domain name to park.
+	// DomainName is the fully qualified domain name to park. It is immutable,
+	// as the AWS resources of the previous domain would be left behind.
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="domainName is immutable; delete the ParkedDomain and create a new one"
 	DomainName string `json:"domainName"`
+	// RecordName is the host the page is served at, within the DomainName
+	// zone, e.g. shop.example.com to park a single host in the example.com
+	// zone. The bucket is named after it and templates render it as the
+	// domain name. Defaults to DomainName, the zone apex.
+	// +optional
+	RecordName string `json:"recordName,omitempty"`
 	Region     string `json:"region,omitempty"`
+	// Wildcard, when true, also points *.<RecordName> at the page, parking
+	// every host below it. S3 website endpoints pick the bucket by the Host
+	// header, so wildcard hosts are only served through a target that
+	// rewrites it, e.g. a CloudFront distribution set as AliasTarget.
+	// +optional
+	Wildcard bool `json:"wildcard,omitempty"`
+	// PlaceholderAddress is an IPv4 address, e.g. of a maintenance page, the
+	// domain's A record points at while its bucket is first provisioned, so
+	// the domain resolves right after the Hosted Zone is created. The record
+	// is swapped for the alias to the bucket once the bucket is ready.
+	// +optional
+	PlaceholderAddress string `json:"placeholderAddress,omitempty"`
+	// RecordComment is appended to the comment of the Route 53 change
+	// batches made for the domain, e.g. a team or ticket, so the changes
+	// can be traced in CloudTrail. The comment always starts with
+	// "Managed by ParkedDomain Operator".
+	// +optional
+	// +kubebuilder:validation:MaxLength=222
+	RecordComment string `json:"recordComment,omitempty"`
 	// TemplateName is the name of the template file (e.g., "index.html")
 	// to copy from the configmap.
 	// +optional
 	TemplateName string `json:"templateName,omitempty"`
+	// ParkingMode selects the default template for the kind of parked page
+	// when TemplateName is empty: "coming-soon.html", "for-sale.html" or
+	// "maintenance.html". When unset, "default.html" is used.
+	// +optional
+	// +kubebuilder:validation:Enum=ComingSoon;ForSale;Maintenance
+	ParkingMode ParkingMode `json:"parkingMode,omitempty"`
+	// TemplateSource selects where the page template is read from: a
+	// ConfigMap, a Secret (templateSecretRef), a URL (templateURL) or the
+	// spec itself (inlineTemplate). When unset, Inline is used if
+	// inlineTemplate is set, URL if templateURL is set and ConfigMap otherwise.
+	// +optional
+	// +kubebuilder:validation:Enum=ConfigMap;Secret;URL;Inline
+	TemplateSource TemplateSourceType `json:"templateSource,omitempty"`
+	// TemplateURL is an http(s) URL to fetch the template from at reconcile
+	// time. When set, it is used instead of the template ConfigMap.
+	// +optional
+	// +kubebuilder:validation:Pattern=`^https?://`
+	TemplateURL string `json:"templateURL,omitempty"`
+	// TemplateConfigMapRef selects the ConfigMap templates are read from,
+	// overriding the operator's TEMPLATE_CONFIGMAP_NAME default. Reading a
+	// ConfigMap outside the ParkedDomain's namespace must be enabled on the
+	// operator with --allow-cross-namespace-templates.
+	// +optional
+	TemplateConfigMapRef *TemplateConfigMapRef `json:"templateConfigMapRef,omitempty"`
+	// TemplateSecretRef selects the Secret templates are read from when
+	// TemplateSource is Secret. The same namespace rules as for
+	// TemplateConfigMapRef apply.
+	// +optional
+	TemplateSecretRef *TemplateSecretRef `json:"templateSecretRef,omitempty"`
+	// InlineTemplate is the page template itself, for one-off pages that need
+	// no ConfigMap. Setting it selects the Inline template source. It is
+	// rendered like any other template and limited to 256 KiB.
+	// +optional
+	// +kubebuilder:validation:MaxLength=262144
+	InlineTemplate string `json:"inlineTemplate,omitempty"`
+	// Locales are languages the page is also published in, e.g. "de". For
+	// each locale, the template named with the locale before its extension,
+	// e.g. default.de.html, is rendered to index.de.html next to index.html,
+	// which stays the page served by default. S3 cannot pick a page by the
+	// visitor's language; routing by Accept-Language needs a CDN in front of
+	// the bucket, e.g. CloudFront with a Lambda@Edge function.
+	// +optional
+	// +listType=set
+	// +kubebuilder:validation:MaxItems=20
+	// +kubebuilder:validation:items:Pattern=`^[a-z]{2,3}(-[A-Za-z0-9]{2,8})*$`
+	Locales []string `json:"locales,omitempty"`
+	// ContentTypes overrides the content type objects are uploaded with, keyed
+	// by file extension (e.g. "html" or ".html"). Extensions without a known
+	// content type are uploaded as application/octet-stream.
+	// +optional
+	ContentTypes map[string]string `json:"contentTypes,omitempty"`
+	// ObjectMetadata is user-defined metadata stored with the uploaded page and
+	// returned as x-amz-meta-<key> response headers. Keys are given without the
+	// x-amz-meta- prefix.
+	// +optional
+	ObjectMetadata map[string]string `json:"objectMetadata,omitempty"`
+	// Compress, when true, uploads the page gzip-compressed with a
+	// Content-Encoding: gzip header. Pages too small to benefit are uploaded
+	// as they are.
+	// +optional
+	Compress bool `json:"compress,omitempty"`
+	// SPAFallback, when true, sets the website's error document to the index
+	// page, so every path of a single-page app is answered with index.html.
+	// S3 answers such paths with the status of the error, e.g. 404; a CDN in
+	// front of the bucket can map it to 200, as described in the README.
+	// +optional
+	SPAFallback bool `json:"spaFallback,omitempty"`
+	// ManifestKey, if set, is the key of an object, e.g. .pdo-manifest.json,
+	// listing the pages the operator uploaded and their hashes. Pages whose
+	// hash is unchanged are not uploaded again, and pages the manifest lists
+	// but that are no longer published are deleted. The bucket policy keeps
+	// the manifest from being served.
+	// +optional
+	// +kubebuilder:validation:MaxLength=1024
+	// +kubebuilder:validation:Pattern=`^[A-Za-z0-9._-][A-Za-z0-9._/-]*$`
+	ManifestKey string `json:"manifestKey,omitempty"`
+	// LifecycleRules are applied to the bucket to expire objects, e.g. access
+	// logs or noncurrent versions. Removing all rules removes the bucket's
+	// lifecycle configuration.
+	// +optional
+	// +listType=map
+	// +listMapKey=id
+	LifecycleRules []LifecycleRule `json:"lifecycleRules,omitempty"`
+	// ObjectOwnership sets the bucket's object ownership controls. When unset,
+	// the bucket keeps the AWS default (BucketOwnerEnforced, ACLs disabled).
+	// +optional
+	// +kubebuilder:validation:Enum=BucketOwnerEnforced;BucketOwnerPreferred;ObjectWriter
+	ObjectOwnership string `json:"objectOwnership,omitempty"`
+	// StorageClass is the S3 storage class the page is uploaded with, e.g.
+	// INTELLIGENT_TIERING for rarely visited domains. Archive classes are not
+	// allowed, as their objects cannot be served without a restore. Defaults to
+	// STANDARD.
+	// +optional
+	// +kubebuilder:validation:Enum=STANDARD;INTELLIGENT_TIERING;STANDARD_IA;ONEZONE_IA;GLACIER_IR
+	StorageClass string `json:"storageClass,omitempty"`
+	// Encryption, when set, uploads the page with explicit server-side
+	// encryption, regardless of the bucket's default encryption.
+	// +optional
+	Encryption *Encryption `json:"encryption,omitempty"`
+	// ObjectLock, when set, creates the bucket with S3 Object Lock enabled,
+	// which also turns on versioning, and retains every uploaded page version
+	// by default. Object Lock can only be enabled when the bucket is created,
+	// and is kept when ObjectLock is removed. Retained versions cannot be
+	// deleted, so deleting the ParkedDomain fails with reason
+	// ObjectLockRetention until the last retention expires.
+	// +optional
+	ObjectLock *ObjectLock `json:"objectLock,omitempty"`
+	// RequireCDNWhenBPAEnforced, when true, keeps the bucket private if S3
+	// Block Public Access prevents its public read policy, e.g. because the
+	// organization enforces it. The page must then be served through a CDN
+	// such as a CloudFront distribution with an origin access control.
+	// Otherwise such a ParkedDomain fails with reason PublicAccessBlocked.
+	// +optional
+	RequireCDNWhenBPAEnforced bool `json:"requireCDNWhenBPAEnforced,omitempty"`
+	// DelegationSetID is the ID of a reusable delegation set to create the
+	// Hosted Zone with, so parked domains share the same nameservers. It
+	// overrides the operator's default and has no effect on an existing zone.
+	// +optional
+	DelegationSetID string `json:"delegationSetID,omitempty"`
+	// PrivateZone, when set, parks the domain in a private Hosted Zone
+	// associated with a VPC instead of a public one. The bucket is then only
+	// readable from that VPC, through an S3 gateway endpoint.
+	// +optional
+	PrivateZone *PrivateZone `json:"privateZone,omitempty"`
+	// AdditionalVPCs are associated with the private Hosted Zone besides
+	// PrivateZone's VPC, e.g. for split-horizon DNS across VPCs. VPCs removed
+	// from the list are disassociated again. Requires PrivateZone.
+	// +optional
+	// +listType=map
+	// +listMapKey=vpcID
+	AdditionalVPCs []VPCRef `json:"additionalVPCs,omitempty"`
+	// AliasTarget, when set, points the alias record at another AWS resource,
+	// e.g. a load balancer in front of a landing app, instead of the bucket.
+	// No bucket is provisioned then, unless SharedBucket is set.
+	// +optional
+	AliasTarget *AliasTarget `json:"aliasTarget,omitempty"`
+	// ParentZoneID is the ID of a Hosted Zone for a parent domain. When set,
+	// NS records delegating DomainName to its zone are kept in the parent zone
+	// and removed again when the Hosted Zone is deleted.
+	// +optional
+	ParentZoneID string `json:"parentZoneID,omitempty"`
+	// DelegateInParentZone keeps the delegating NS records in the closest
+	// public Hosted Zone of a parent domain, looked up by name, e.g. the
+	// example.com zone for promo.example.com. ParentZoneID takes precedence.
+	// +optional
+	DelegateInParentZone bool `json:"delegateInParentZone,omitempty"`
+	// QueryLogging, when set, logs the DNS queries Route 53 answers for the
+	// public Hosted Zone to a CloudWatch Logs log group.
+	// +optional
+	QueryLogging *QueryLogging `json:"queryLogging,omitempty"`
+	// DNSFirewallRuleGroupID is an advanced option for internal parking: the
+	// ID of a Route 53 Resolver DNS Firewall rule group, e.g.
+	// rslvr-frg-0123456789abcdef, to associate with the private zone's VPC,
+	// so the VPC's DNS queries are filtered by it. It is only used with
+	// PrivateZone and associated at priority 9000, after rule groups
+	// associated with lower priorities. The association is removed again when
+	// the field is cleared or the ParkedDomain is deleted, unless another
+	// ParkedDomain still uses it. Requires the route53resolver permissions
+	// listed in the README.
+	// +optional
+	// +kubebuilder:validation:Pattern=`^rslvr-frg-[0-9a-z]+$`
+	DNSFirewallRuleGroupID string `json:"dnsFirewallRuleGroupID,omitempty"`
+	// NameServersExport, when set, writes the zone's nameservers into a
+	// ConfigMap or Secret in the ParkedDomain's namespace, for tooling that
+	// updates the registrar without reading the status. The object is owned
+	// by the ParkedDomain and deleted with it.
+	// +optional
+	NameServersExport *NameServersExport `json:"nameServersExport,omitempty"`
+	// DNSRoleARN is an IAM role assumed for all Route 53 calls, e.g. to manage
+	// DNS in a central account while buckets live in another one.
+	// +optional
+	// +kubebuilder:validation:Pattern=`^arn:aws[a-z-]*:iam::[0-9]{12}:role/.+$`
+	DNSRoleARN string `json:"dnsRoleARN,omitempty"`
+	// StorageRoleARN is an IAM role assumed for all S3 calls.
+	// +optional
+	// +kubebuilder:validation:Pattern=`^arn:aws[a-z-]*:iam::[0-9]{12}:role/.+$`
+	StorageRoleARN string `json:"storageRoleARN,omitempty"`
+	// StorageEndpoint is the URL of an S3-compatible service, e.g. MinIO, that
+	// hosts the bucket instead of AWS S3. It overrides the operator's
+	// --s3-endpoint. Route 53 cannot alias such endpoints, so dnsEnabled must
+	// be false.
+	// +optional
+	// +kubebuilder:validation:Pattern=`^https?://[^/]+/?$`
+	StorageEndpoint string `json:"storageEndpoint,omitempty"`
+	// AutoSuffixBucket, when true, creates the bucket under the host name
+	// followed by a short hash of the ParkedDomain's namespace and name when
+	// another AWS account owns the host name's bucket, instead of failing.
+	// S3 website endpoints only serve the bucket named after the requested
+	// host, so such a bucket serves the page at its own endpoint only, and
+	// dnsEnabled should be false.
+	// +optional
+	AutoSuffixBucket bool `json:"autoSuffixBucket,omitempty"`
+	// SharedBucket, when set, publishes the page into a bucket shared by many
+	// ParkedDomains, under a key prefix named after the host name, instead of
+	// into a bucket of its own. The shared bucket serves no website: AliasTarget
+	// must be a CloudFront distribution that reads the bucket and prefixes each
+	// request path with its Host header. Deleting the ParkedDomain deletes its
+	// prefix only.
+	// +optional
+	SharedBucket *SharedBucket `json:"sharedBucket,omitempty"`
+	// VerifyHTTP, when true, checks after provisioning that the website
+	// endpoint serves the page, and reports it in the EndpointHealthy condition.
+	// +optional
+	VerifyHTTP bool `json:"verifyHTTP,omitempty"`
+	// ForceHTTPS, when true, declares that plain-HTTP requests for the domain
+	// are redirected to HTTPS. S3 website endpoints cannot redirect by
+	// protocol, so it requires an AliasTarget of type CloudFront whose
+	// behaviors use the viewer protocol policy redirect-to-https. With
+	// VerifyHTTP, the endpoint check then expects that redirect.
+	// +optional
+	ForceHTTPS bool `json:"forceHTTPS,omitempty"`
+	// StorageEnabled, when false, tears down the bucket and the alias record
+	// pointing at it while keeping the Hosted Zone. Defaults to true.
+	// +optional
+	StorageEnabled *bool `json:"storageEnabled,omitempty"`
+	// DNSEnabled, when false, tears down the Hosted Zone while keeping the
+	// bucket. Defaults to true.
+	// +optional
+	DNSEnabled *bool `json:"dnsEnabled,omitempty"`
+	// DeletionPolicy decides what deleting the ParkedDomain does to its AWS
+	// resources. Delete removes them. Orphan leaves the bucket, the Hosted
+	// Zone and everything else running unmanaged, e.g. to hand the domain
+	// off to another system. Defaults to Delete.
+	// +optional
+	// +kubebuilder:validation:Enum=Delete;Orphan
+	DeletionPolicy DeletionPolicy `json:"deletionPolicy,omitempty"`
+	// CleanupOrder decides whether deleting the ParkedDomain removes its DNS
+	// or its bucket first. Defaults to DNSFirst, so the domain stops resolving
+	// before its content disappears and no alias record is left pointing at a
+	// deleted bucket whose name anyone could claim.
+	// +optional
+	// +kubebuilder:validation:Enum=DNSFirst;StorageFirst
+	CleanupOrder CleanupOrder `json:"cleanupOrder,omitempty"`
+	// MaintenanceWindow, when set, defers changes to an already provisioned
+	// ParkedDomain, such as spec updates and drift repair, until the window
+	// opens. First provisioning, deletion and nameserver changes proceed
+	// immediately.
+	// +optional
+	MaintenanceWindow *MaintenanceWindow `json:"maintenanceWindow,omitempty"`
+	// TransferAcceleration, when set, is enforced on the bucket. Buckets named
+	// after a domain contain dots, which S3 Transfer Acceleration does not
+	// support, so only false is accepted. When unset, the setting is not checked.
+	// +optional
+	TransferAcceleration *bool `json:"transferAcceleration,omitempty"`
+	// RequesterPays, when set, is enforced on the bucket. Website endpoints do
+	// not serve Requester Pays buckets, so only false is accepted. When unset,
+	// the setting is not checked.
+	// +optional
+	RequesterPays *bool `json:"requesterPays,omitempty"`
+	// AccessLogBucket, if set, receives the S3 server access logs of the
+	// bucket. It must be in the same region and must not be the bucket itself.
+	// +optional
+	// +kubebuilder:validation:MinLength=3
+	// +kubebuilder:validation:MaxLength=63
+	AccessLogBucket string `json:"accessLogBucket,omitempty"`
+	// AccessLogPrefix is prepended to the keys of the access log objects.
+	// +optional
+	AccessLogPrefix string `json:"accessLogPrefix,omitempty"`
+	// CreateAccessLogBucket, when true, creates AccessLogBucket if it does not
+	// exist, with a policy that lets S3 deliver the logs. The operator never
+	// deletes it, so logs outlive the ParkedDomain.
+	// +optional
+	CreateAccessLogBucket bool `json:"createAccessLogBucket,omitempty"`
+	// Alarm, when set, creates a CloudWatch alarm on the error responses of
+	// the bucket, notifying an SNS topic when the parked page starts failing.
+	// +optional
+	Alarm *Alarm `json:"alarm,omitempty"`
+	// Tags are applied to the bucket and the Hosted Zone. Tags removed from
+	// the spec are removed from them again, while tags set outside the
+	// operator are kept. AWS allows at most 50 tags per resource.
+	// +optional
+	// +kubebuilder:validation:MaxProperties=50
+	Tags map[string]string `json:"tags,omitempty"`
+}
+
+// PrivateZone associates the Hosted Zone with a VPC.
+type PrivateZone struct {
+	// VPCID is the ID of the VPC the zone is associated with.
+	// +kubebuilder:validation:Pattern=`^vpc-[0-9a-f]+$`
+	VPCID string `json:"vpcID"`
+	// VPCRegion is the region of the VPC. Defaults to the ParkedDomain's region.
+	// +optional
+	VPCRegion string `json:"vpcRegion,omitempty"`
+}
+
+// VPCRef is a VPC associated with a private Hosted Zone.
+type VPCRef struct {
+	// VPCID is the ID of the VPC.
+	// +kubebuilder:validation:Pattern=`^vpc-[0-9a-f]+$`
+	VPCID string `json:"vpcID"`
+	// VPCRegion is the region of the VPC. Defaults to the ParkedDomain's region.
+	// +optional
+	VPCRegion string `json:"vpcRegion,omitempty"`
+}
+
+// Alarm configures a CloudWatch alarm on the bucket's S3 request metrics.
+type Alarm struct {
+	// SNSTopicARN is the SNS topic notified when the alarm fires and when it
+	// recovers. It must be in the bucket's region.
+	// +kubebuilder:validation:MinLength=1
+	SNSTopicARN string `json:"snsTopicARN"`
+	// Metric is the S3 request metric the alarm watches. Defaults to 5xxErrors.
+	// +optional
+	// +kubebuilder:validation:Enum="4xxErrors";"5xxErrors"
+	Metric string `json:"metric,omitempty"`
+	// Threshold is the number of error responses within five minutes that
+	// fires the alarm. Defaults to 10.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	Threshold int32 `json:"threshold,omitempty"`
+}
+
+// QueryLogging configures Route 53 query logging for the Hosted Zone.
+type QueryLogging struct {
+	// LogGroupARN is the ARN of the CloudWatch Logs log group queries are
+	// logged to. Route 53 only logs to groups in us-east-1 whose resource
+	// policy allows route53.amazonaws.com to call logs:CreateLogStream and
+	// logs:PutLogEvents.
+	// +kubebuilder:validation:MinLength=1
+	LogGroupARN string `json:"logGroupARN"`
+}
+
+// NameServersExportKind is the kind of object the nameservers are exported to.
+// +kubebuilder:validation:Enum=ConfigMap;Secret
+type NameServersExportKind string
+
+// Supported nameserver export kinds.
+const (
+	NameServersExportConfigMap NameServersExportKind = "ConfigMap"
+	NameServersExportSecret    NameServersExportKind = "Secret"
+)
+
+// NameServersExport is the object the zone's nameservers are written to. It
+// holds the keys domainName, zoneID and nameServers, one nameserver per line.
+type NameServersExport struct {
+	// Kind is the kind of object written. Defaults to ConfigMap.
+	// +optional
+	// +kubebuilder:default=ConfigMap
+	Kind NameServersExportKind `json:"kind,omitempty"`
+	// Name of the object. Defaults to "<ParkedDomain name>-nameservers".
+	// +optional
+	// +kubebuilder:validation:MaxLength=253
+	Name string `json:"name,omitempty"`
+}
+
+// AliasTargetType is the kind of AWS resource an alias record points at.
+// +kubebuilder:validation:Enum=LoadBalancer;APIGateway;CloudFront
+type AliasTargetType string
+
+// Supported alias target types.
+const (
+	AliasTargetLoadBalancer AliasTargetType = "LoadBalancer"
+	AliasTargetAPIGateway   AliasTargetType = "APIGateway"
+	AliasTargetCloudFront   AliasTargetType = "CloudFront"
+)
+
+// AliasTarget is an AWS resource the domain's alias record points at.
+type AliasTarget struct {
+	// HostedZoneID is the canonical hosted zone ID of the target, e.g. the
+	// CanonicalHostedZoneId of a load balancer.
+	// +kubebuilder:validation:Pattern=`^Z[0-9A-Z]+$`
+	HostedZoneID string `json:"hostedZoneID"`
+	// DNSName is the DNS name of the target.
+	// +kubebuilder:validation:MinLength=1
+	DNSName string `json:"dnsName"`
+	// Type is the kind of target. Route 53 evaluates the health of load
+	// balancer targets.
+	Type AliasTargetType `json:"type"`
 }
 
+// SharedBucket is a bucket many ParkedDomains publish their pages into.
+type SharedBucket struct {
+	// Name of the bucket. It is created when missing, but never deleted.
+	// +kubebuilder:validation:MinLength=3
+	// +kubebuilder:validation:MaxLength=63
+	Name string `json:"name"`
+}
+
+// TemplateConfigMapRef references a ConfigMap holding page templates.
+type TemplateConfigMapRef struct {
+	// Name of the ConfigMap.
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+	// Namespace of the ConfigMap. Defaults to the ParkedDomain's namespace.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// ObjectLock configures the default S3 Object Lock retention of the bucket.
+type ObjectLock struct {
+	// Mode is the retention mode: GOVERNANCE, which users with the
+	// s3:BypassGovernanceRetention permission can override, or COMPLIANCE,
+	// which no one can, including the account's root user.
+	// +kubebuilder:validation:Enum=GOVERNANCE;COMPLIANCE
+	Mode string `json:"mode"`
+	// Days is how long each page version is retained after it is uploaded.
+	// +kubebuilder:validation:Minimum=1
+	Days int32 `json:"days"`
+}
+
+// Encryption configures the server-side encryption of uploaded objects.
+type Encryption struct {
+	// Algorithm is the server-side encryption algorithm: AES256 for S3 managed
+	// keys, or aws:kms for KMS keys. S3 does not serve aws:kms objects to
+	// anonymous requests, so such pages must be read through a signing proxy,
+	// e.g. a CloudFront origin access control.
+	// +kubebuilder:validation:Enum=AES256;"aws:kms"
+	Algorithm string `json:"algorithm"`
+	// KMSKeyID is the ID or ARN of the KMS key used with aws:kms. Defaults to
+	// the account's AWS managed aws/s3 key.
+	// +optional
+	KMSKeyID string `json:"kmsKeyID,omitempty"`
+}
+
+// TemplateSecretRef references a Secret holding page templates.
+type TemplateSecretRef struct {
+	// Name of the Secret.
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+	// Namespace of the Secret. Defaults to the ParkedDomain's namespace.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// TemplateSourceType is where the page template of a parked domain is read from.
+type TemplateSourceType string
+
+// Supported template sources.
+const (
+	TemplateSourceConfigMap TemplateSourceType = "ConfigMap"
+	TemplateSourceSecret    TemplateSourceType = "Secret"
+	TemplateSourceURL       TemplateSourceType = "URL"
+	TemplateSourceInline    TemplateSourceType = "Inline"
+)
+
+// ParkingMode is the kind of page served for a parked domain.
+type ParkingMode string
+
+// Supported parking modes.
+const (
+	ParkingModeComingSoon  ParkingMode = "ComingSoon"
+	ParkingModeForSale     ParkingMode = "ForSale"
+	ParkingModeMaintenance ParkingMode = "Maintenance"
+)
+
+// MaintenanceWindow is a daily window, in UTC, during which changes are applied.
+type MaintenanceWindow struct {
+	// Start is the time of day the window opens, as "HH:MM" in UTC.
+	// +kubebuilder:validation:Pattern=`^([01][0-9]|2[0-3]):[0-5][0-9]$`
+	Start string `json:"start"`
+	// End is the time of day the window closes, as "HH:MM" in UTC. A window
+	// ending before it starts spans midnight, e.g. 22:00 to 02:00.
+	// +kubebuilder:validation:Pattern=`^([01][0-9]|2[0-3]):[0-5][0-9]$`
+	End string `json:"end"`
+}
+
+// DeletionPolicy is what deleting a ParkedDomain does to its AWS resources.
+type DeletionPolicy string
+
+// Supported deletion policies.
+const (
+	DeletionPolicyDelete DeletionPolicy = "Delete"
+	DeletionPolicyOrphan DeletionPolicy = "Orphan"
+)
+
+// CleanupOrder is the order deleting a ParkedDomain removes its AWS resources in.
+type CleanupOrder string
+
+// Supported cleanup orders.
+const (
+	CleanupOrderDNSFirst     CleanupOrder = "DNSFirst"
+	CleanupOrderStorageFirst CleanupOrder = "StorageFirst"
+)
+
+// LifecycleRule expires objects in the bucket after a number of days.
+type LifecycleRule struct {
+	// ID uniquely identifies the rule within the bucket.
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=255
+	ID string `json:"id"`
+	// Prefix limits the rule to object keys starting with this prefix.
+	// +optional
+	Prefix string `json:"prefix,omitempty"`
+	// ExpirationDays expires current objects this many days after creation.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	ExpirationDays *int32 `json:"expirationDays,omitempty"`
+	// NoncurrentVersionExpirationDays expires noncurrent object versions this
+	// many days after they become noncurrent.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	NoncurrentVersionExpirationDays *int32 `json:"noncurrentVersionExpirationDays,omitempty"`
+}
+
+// Condition types reported in ParkedDomainStatus.Conditions. Each provisioning
+// step records its own condition so a retry can resume after the last
+// step that succeeded for the current generation.
+const (
+	// ConditionSpecValid indicates the spec passed validation. It is only
+	// reported when it fails, which stops the ParkedDomain before any AWS
+	// resource is touched; the message lists the invalid fields.
+	ConditionSpecValid = "SpecValid"
+	// ConditionZoneReady indicates the Route 53 Hosted Zone exists.
+	ConditionZoneReady = "ZoneReady"
+	// ConditionBucketReady indicates the S3 bucket is configured for website hosting.
+	ConditionBucketReady = "BucketReady"
+	// ConditionRecordReady indicates the alias A record points at the website endpoint.
+	ConditionRecordReady = "RecordReady"
+	// ConditionContentReady indicates the page template could be loaded and rendered.
+	ConditionContentReady = "ContentReady"
+	// ConditionEndpointHealthy indicates the website endpoint serves the parked
+	// page. It is only reported when Spec.VerifyHTTP is set.
+	ConditionEndpointHealthy = "EndpointHealthy"
+	// ConditionNameServersChanged indicates the zone's nameservers differ from
+	// the ones previously reported, so the registrar delegation must be updated.
+	ConditionNameServersChanged = "NameServersChanged"
+	// ConditionCleanupFailed indicates a finalizer cleanup step failed and is
+	// holding up deletion. The reason names the failing step.
+	ConditionCleanupFailed = "CleanupFailed"
+	// ConditionDriftRepaired indicates a resource deleted outside the operator
+	// was recreated. The reason names what was repaired.
+	ConditionDriftRepaired = "DriftRepaired"
+	// ConditionPublicAccessBlocked indicates S3 Block Public Access prevents the
+	// bucket's public read policy. The reason tells whether the bucket is kept
+	// private for a CDN or the ParkedDomain is failing.
+	ConditionPublicAccessBlocked = "PublicAccessBlocked"
+	// ConditionOrphaned indicates the ParkedDomain was deleted with
+	// DeletionPolicy Orphan. The message lists the AWS resources left running.
+	ConditionOrphaned = "Orphaned"
+	// ConditionContentSourceMissing indicates the template ConfigMap the page is
+	// rendered from was deleted. The page keeps being served, but cannot be
+	// rendered again until the ConfigMap is restored.
+	ConditionContentSourceMissing = "ContentSourceMissing"
+)
+
 // ParkedDomainStatus defines the observed state of ParkedDomain.
 type ParkedDomainStatus struct {
 	// Status indicates the current state, e.g., "Provisioned", "Error".
 	Status string `json:"status,omitempty"`
+	// Ready is true once every provisioning step succeeded for the current
+	// generation, for automation that doesn't want to parse conditions.
+	// +optional
+	Ready bool `json:"ready"`
 	// ZoneID is the ID of the created Route 53 Hosted Zone.
 	ZoneID string `json:"zoneID,omitempty"`
 	// NameServers are the authoritative nameservers for the zone.
 	NameServers []string `json:"nameServers,omitempty"`
+	// QueryLoggingConfigID is the ID of the zone's query logging configuration.
+	// +optional
+	QueryLoggingConfigID string `json:"queryLoggingConfigID,omitempty"`
+	// DNSFirewallAssociationID is the ID of the association of
+	// Spec.DNSFirewallRuleGroupID with the private zone's VPC.
+	// +optional
+	DNSFirewallAssociationID string `json:"dnsFirewallAssociationID,omitempty"`
+	// PendingChangeID is the ID of the Route 53 change deleting a batch of the
+	// zone's records, which cleanup waits to become INSYNC before it deletes
+	// the next batch.
+	// +optional
+	PendingChangeID string `json:"pendingChangeID,omitempty"`
+	// AssociatedVPCs are the AdditionalVPCs associated with the private zone,
+	// so VPCs removed from the spec can be told apart from VPCs associated
+	// outside the operator.
+	// +optional
+	AssociatedVPCs []VPCRef `json:"associatedVPCs,omitempty"`
+	// TagKeys are the keys of the Tags last applied, so tags later removed
+	// from the spec can be told apart from tags set outside the operator.
+	// +optional
+	TagKeys []string `json:"tagKeys,omitempty"`
+	// Locales are the Spec.Locales whose pages were last published, so the
+	// pages of locales removed from the spec can be deleted.
+	// +optional
+	Locales []string `json:"locales,omitempty"`
+	// TemplateConfigMapVersion is the resourceVersion of the template
+	// ConfigMap the page was last rendered from, so an edit of the ConfigMap
+	// is published although the generation is already reconciled.
+	// +optional
+	TemplateConfigMapVersion string `json:"templateConfigMapVersion,omitempty"`
+	// BucketName is the name of the bucket when it differs from the host
+	// name, because Spec.AutoSuffixBucket found the host name taken.
+	// +optional
+	BucketName string `json:"bucketName,omitempty"`
+	// AlarmName is the name of the CloudWatch alarm created for Spec.Alarm.
+	// +optional
+	AlarmName string `json:"alarmName,omitempty"`
+	// Endpoint is the DNS name the domain's alias record points at.
+	Endpoint string `json:"endpoint,omitempty"`
+	// WebsiteURL is the URL a browser uses to reach the parked page.
+	WebsiteURL string `json:"websiteURL,omitempty"`
+	// ObjectCount is the number of objects in the bucket at UsageUpdatedAt.
+	// +optional
+	ObjectCount int64 `json:"objectCount,omitempty"`
+	// TotalSizeBytes is the total size of the bucket's objects at UsageUpdatedAt.
+	// +optional
+	TotalSizeBytes int64 `json:"totalSizeBytes,omitempty"`
+	// UsageUpdatedAt is when ObjectCount and TotalSizeBytes were last counted.
+	// +optional
+	UsageUpdatedAt *metav1.Time `json:"usageUpdatedAt,omitempty"`
+	// EstimatedMonthlyCostUSD is a rough estimate, in US dollars, of what the
+	// ParkedDomain's resources cost per month, e.g. "0.60". It is computed
+	// from us-east-1 list prices of the Hosted Zone, the stored bytes and the
+	// alarm, and leaves out all traffic-based charges such as requests, DNS
+	// queries and CloudFront. It is an estimate, not a bill.
+	// +optional
+	EstimatedMonthlyCostUSD string `json:"estimatedMonthlyCostUSD,omitempty"`
+	// DeferredUntil is when the maintenance window next opens, while changes
+	// to the ParkedDomain wait for it.
+	// +optional
+	DeferredUntil *metav1.Time `json:"deferredUntil,omitempty"`
+	// ObservedGeneration is the most recent generation that was fully reconciled.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// Conditions represent the latest observations of each provisioning step.
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+	// History lists the most recent changes of Status, oldest first. Unlike
+	// events it does not expire, but only the last 10 changes are kept.
+	// +optional
+	// +kubebuilder:validation:MaxItems=10
+	History []TransitionRecord `json:"history,omitempty"`
+}
+
+// TransitionRecord is a change of a ParkedDomain's status.
+type TransitionRecord struct {
+	// Time is when the status changed.
+	Time metav1.Time `json:"time"`
+	// From is the previous status, empty for the first one.
+	// +optional
+	From string `json:"from,omitempty"`
+	// To is the new status.
+	To string `json:"to"`
+	// Reason is a CamelCase reason for the change, e.g. ReconcileFailed.
+	// +optional
+	Reason string `json:"reason,omitempty"`
 }
 
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Ready",type=boolean,JSONPath=`.status.ready`
+// +kubebuilder:printcolumn:name="Status",type=string,JSONPath=`.status.status`
+// +kubebuilder:printcolumn:name="URL",type=string,JSONPath=`.status.websiteURL`
+// +kubebuilder:printcolumn:name="Objects",type=integer,JSONPath=`.status.objectCount`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
 
 // ParkedDomain is the Schema for the parkeddomains API.
 type ParkedDomain struct {
@@ -0,0 +1,105 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ParkedDomainClaimSpec defines the desired state of ParkedDomainClaim.
+type ParkedDomainClaimSpec struct {
+	// DomainName is the fully qualified domain name to park. It must be
+	// allowed by the parking.minibaev.eu/allowed-domains annotation of the
+	// claim's namespace. It is immutable, like the DomainName of the
+	// ParkedDomain created for the claim.
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="domainName is immutable; delete the claim and create a new one"
+	DomainName string `json:"domainName"`
+	// Region is the AWS region of the domain's bucket. If the claim's
+	// namespace has a parking.minibaev.eu/allowed-regions annotation, it
+	// must be one of them. Defaults to the operator's region.
+	// +optional
+	Region string `json:"region,omitempty"`
+	// ParkingMode selects the default template for the kind of parked page.
+	// When unset, "default.html" is used.
+	// +optional
+	// +kubebuilder:validation:Enum=ComingSoon;ForSale;Maintenance
+	ParkingMode ParkingMode `json:"parkingMode,omitempty"`
+}
+
+// Condition types of a ParkedDomainClaim.
+const (
+	// ConditionBound reports whether the claim was allowed and its ParkedDomain created.
+	ConditionBound = "Bound"
+)
+
+// ParkedDomainClaimStatus defines the observed state of ParkedDomainClaim.
+type ParkedDomainClaimStatus struct {
+	// ParkedDomainName is the name of the ParkedDomain created for the claim
+	// in the operator's claim namespace.
+	// +optional
+	ParkedDomainName string `json:"parkedDomainName,omitempty"`
+	// Ready mirrors the Ready status of the claim's ParkedDomain.
+	// +optional
+	Ready bool `json:"ready"`
+	// NameServers are the authoritative nameservers for the domain, to set
+	// at its registrar.
+	// +optional
+	NameServers []string `json:"nameServers,omitempty"`
+	// WebsiteURL is the URL a browser uses to reach the parked page.
+	// +optional
+	WebsiteURL string `json:"websiteURL,omitempty"`
+	// ObservedGeneration is the most recent generation that was reconciled.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// Conditions represent the latest observations of the claim.
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Domain",type=string,JSONPath=`.spec.domainName`
+// +kubebuilder:printcolumn:name="Bound",type=string,JSONPath=`.status.conditions[?(@.type=="Bound")].status`
+// +kubebuilder:printcolumn:name="Ready",type=boolean,JSONPath=`.status.ready`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// ParkedDomainClaim is the Schema for the parkeddomainclaims API. It lets
+// a namespace request a parked domain, which the operator provisions as a
+// ParkedDomain in its own namespace if the namespace's policy allows it.
+type ParkedDomainClaim struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ParkedDomainClaimSpec   `json:"spec,omitempty"`
+	Status ParkedDomainClaimStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ParkedDomainClaimList contains a list of ParkedDomainClaim.
+type ParkedDomainClaimList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ParkedDomainClaim `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ParkedDomainClaim{}, &ParkedDomainClaimList{})
+}
@@ -21,15 +21,131 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Alarm) DeepCopyInto(out *Alarm) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Alarm.
+func (in *Alarm) DeepCopy() *Alarm {
+	if in == nil {
+		return nil
+	}
+	out := new(Alarm)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AliasTarget) DeepCopyInto(out *AliasTarget) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AliasTarget.
+func (in *AliasTarget) DeepCopy() *AliasTarget {
+	if in == nil {
+		return nil
+	}
+	out := new(AliasTarget)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Encryption) DeepCopyInto(out *Encryption) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Encryption.
+func (in *Encryption) DeepCopy() *Encryption {
+	if in == nil {
+		return nil
+	}
+	out := new(Encryption)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LifecycleRule) DeepCopyInto(out *LifecycleRule) {
+	*out = *in
+	if in.ExpirationDays != nil {
+		in, out := &in.ExpirationDays, &out.ExpirationDays
+		*out = new(int32)
+		**out = **in
+	}
+	if in.NoncurrentVersionExpirationDays != nil {
+		in, out := &in.NoncurrentVersionExpirationDays, &out.NoncurrentVersionExpirationDays
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LifecycleRule.
+func (in *LifecycleRule) DeepCopy() *LifecycleRule {
+	if in == nil {
+		return nil
+	}
+	out := new(LifecycleRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaintenanceWindow) DeepCopyInto(out *MaintenanceWindow) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaintenanceWindow.
+func (in *MaintenanceWindow) DeepCopy() *MaintenanceWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(MaintenanceWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NameServersExport) DeepCopyInto(out *NameServersExport) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NameServersExport.
+func (in *NameServersExport) DeepCopy() *NameServersExport {
+	if in == nil {
+		return nil
+	}
+	out := new(NameServersExport)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ObjectLock) DeepCopyInto(out *ObjectLock) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectLock.
+func (in *ObjectLock) DeepCopy() *ObjectLock {
+	if in == nil {
+		return nil
+	}
+	out := new(ObjectLock)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ParkedDomain) DeepCopyInto(out *ParkedDomain) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
+	in.Spec.DeepCopyInto(&out.Spec)
 	in.Status.DeepCopyInto(&out.Status)
 }
 
@@ -51,6 +167,107 @@ func (in *ParkedDomain) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ParkedDomainClaim) DeepCopyInto(out *ParkedDomainClaim) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ParkedDomainClaim.
+func (in *ParkedDomainClaim) DeepCopy() *ParkedDomainClaim {
+	if in == nil {
+		return nil
+	}
+	out := new(ParkedDomainClaim)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ParkedDomainClaim) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ParkedDomainClaimList) DeepCopyInto(out *ParkedDomainClaimList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ParkedDomainClaim, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ParkedDomainClaimList.
+func (in *ParkedDomainClaimList) DeepCopy() *ParkedDomainClaimList {
+	if in == nil {
+		return nil
+	}
+	out := new(ParkedDomainClaimList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ParkedDomainClaimList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ParkedDomainClaimSpec) DeepCopyInto(out *ParkedDomainClaimSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ParkedDomainClaimSpec.
+func (in *ParkedDomainClaimSpec) DeepCopy() *ParkedDomainClaimSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ParkedDomainClaimSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ParkedDomainClaimStatus) DeepCopyInto(out *ParkedDomainClaimStatus) {
+	*out = *in
+	if in.NameServers != nil {
+		in, out := &in.NameServers, &out.NameServers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ParkedDomainClaimStatus.
+func (in *ParkedDomainClaimStatus) DeepCopy() *ParkedDomainClaimStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ParkedDomainClaimStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ParkedDomainList) DeepCopyInto(out *ParkedDomainList) {
 	*out = *in
@@ -86,6 +303,119 @@ func (in *ParkedDomainList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ParkedDomainSpec) DeepCopyInto(out *ParkedDomainSpec) {
 	*out = *in
+	if in.TemplateConfigMapRef != nil {
+		in, out := &in.TemplateConfigMapRef, &out.TemplateConfigMapRef
+		*out = new(TemplateConfigMapRef)
+		**out = **in
+	}
+	if in.TemplateSecretRef != nil {
+		in, out := &in.TemplateSecretRef, &out.TemplateSecretRef
+		*out = new(TemplateSecretRef)
+		**out = **in
+	}
+	if in.Locales != nil {
+		in, out := &in.Locales, &out.Locales
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ContentTypes != nil {
+		in, out := &in.ContentTypes, &out.ContentTypes
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.ObjectMetadata != nil {
+		in, out := &in.ObjectMetadata, &out.ObjectMetadata
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.LifecycleRules != nil {
+		in, out := &in.LifecycleRules, &out.LifecycleRules
+		*out = make([]LifecycleRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Encryption != nil {
+		in, out := &in.Encryption, &out.Encryption
+		*out = new(Encryption)
+		**out = **in
+	}
+	if in.ObjectLock != nil {
+		in, out := &in.ObjectLock, &out.ObjectLock
+		*out = new(ObjectLock)
+		**out = **in
+	}
+	if in.PrivateZone != nil {
+		in, out := &in.PrivateZone, &out.PrivateZone
+		*out = new(PrivateZone)
+		**out = **in
+	}
+	if in.AdditionalVPCs != nil {
+		in, out := &in.AdditionalVPCs, &out.AdditionalVPCs
+		*out = make([]VPCRef, len(*in))
+		copy(*out, *in)
+	}
+	if in.AliasTarget != nil {
+		in, out := &in.AliasTarget, &out.AliasTarget
+		*out = new(AliasTarget)
+		**out = **in
+	}
+	if in.QueryLogging != nil {
+		in, out := &in.QueryLogging, &out.QueryLogging
+		*out = new(QueryLogging)
+		**out = **in
+	}
+	if in.NameServersExport != nil {
+		in, out := &in.NameServersExport, &out.NameServersExport
+		*out = new(NameServersExport)
+		**out = **in
+	}
+	if in.SharedBucket != nil {
+		in, out := &in.SharedBucket, &out.SharedBucket
+		*out = new(SharedBucket)
+		**out = **in
+	}
+	if in.StorageEnabled != nil {
+		in, out := &in.StorageEnabled, &out.StorageEnabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.DNSEnabled != nil {
+		in, out := &in.DNSEnabled, &out.DNSEnabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.MaintenanceWindow != nil {
+		in, out := &in.MaintenanceWindow, &out.MaintenanceWindow
+		*out = new(MaintenanceWindow)
+		**out = **in
+	}
+	if in.TransferAcceleration != nil {
+		in, out := &in.TransferAcceleration, &out.TransferAcceleration
+		*out = new(bool)
+		**out = **in
+	}
+	if in.RequesterPays != nil {
+		in, out := &in.RequesterPays, &out.RequesterPays
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Alarm != nil {
+		in, out := &in.Alarm, &out.Alarm
+		*out = new(Alarm)
+		**out = **in
+	}
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ParkedDomainSpec.
@@ -106,6 +436,43 @@ func (in *ParkedDomainStatus) DeepCopyInto(out *ParkedDomainStatus) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.AssociatedVPCs != nil {
+		in, out := &in.AssociatedVPCs, &out.AssociatedVPCs
+		*out = make([]VPCRef, len(*in))
+		copy(*out, *in)
+	}
+	if in.TagKeys != nil {
+		in, out := &in.TagKeys, &out.TagKeys
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Locales != nil {
+		in, out := &in.Locales, &out.Locales
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.UsageUpdatedAt != nil {
+		in, out := &in.UsageUpdatedAt, &out.UsageUpdatedAt
+		*out = (*in).DeepCopy()
+	}
+	if in.DeferredUntil != nil {
+		in, out := &in.DeferredUntil, &out.DeferredUntil
+		*out = (*in).DeepCopy()
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.History != nil {
+		in, out := &in.History, &out.History
+		*out = make([]TransitionRecord, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ParkedDomainStatus.
@@ -117,3 +484,109 @@ func (in *ParkedDomainStatus) DeepCopy() *ParkedDomainStatus {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PrivateZone) DeepCopyInto(out *PrivateZone) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrivateZone.
+func (in *PrivateZone) DeepCopy() *PrivateZone {
+	if in == nil {
+		return nil
+	}
+	out := new(PrivateZone)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *QueryLogging) DeepCopyInto(out *QueryLogging) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QueryLogging.
+func (in *QueryLogging) DeepCopy() *QueryLogging {
+	if in == nil {
+		return nil
+	}
+	out := new(QueryLogging)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SharedBucket) DeepCopyInto(out *SharedBucket) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SharedBucket.
+func (in *SharedBucket) DeepCopy() *SharedBucket {
+	if in == nil {
+		return nil
+	}
+	out := new(SharedBucket)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TemplateConfigMapRef) DeepCopyInto(out *TemplateConfigMapRef) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemplateConfigMapRef.
+func (in *TemplateConfigMapRef) DeepCopy() *TemplateConfigMapRef {
+	if in == nil {
+		return nil
+	}
+	out := new(TemplateConfigMapRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TemplateSecretRef) DeepCopyInto(out *TemplateSecretRef) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemplateSecretRef.
+func (in *TemplateSecretRef) DeepCopy() *TemplateSecretRef {
+	if in == nil {
+		return nil
+	}
+	out := new(TemplateSecretRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TransitionRecord) DeepCopyInto(out *TransitionRecord) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TransitionRecord.
+func (in *TransitionRecord) DeepCopy() *TransitionRecord {
+	if in == nil {
+		return nil
+	}
+	out := new(TransitionRecord)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VPCRef) DeepCopyInto(out *VPCRef) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VPCRef.
+func (in *VPCRef) DeepCopy() *VPCRef {
+	if in == nil {
+		return nil
+	}
+	out := new(VPCRef)
+	in.DeepCopyInto(out)
+	return out
+}
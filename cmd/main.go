@@ -19,22 +19,37 @@ package main
 import (
 	"context"
 	"crypto/tls"
+	"errors"
 	"flag"
+	"fmt"
+	"net/url"
 	"os"
 	"path/filepath"
+	"slices"
+	"strings"
+	"time"
 
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
 	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/service/route53"
+	"github.com/aws/aws-sdk-go-v2/service/sns"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
 
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
 	// to ensure that exec-entrypoint and run can make use of them.
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
 
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/validation"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/certwatcher"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 	"sigs.k8s.io/controller-runtime/pkg/metrics/filters"
@@ -43,12 +58,15 @@ import (
 
 	parkingv1alpha1 "github.com/gminiba/parked-domain-operator/api/v1alpha1"
 	"github.com/gminiba/parked-domain-operator/internal/controller"
+	webhookv1alpha1 "github.com/gminiba/parked-domain-operator/internal/webhook/v1alpha1"
 	// +kubebuilder:scaffold:imports
 )
 
 var (
 	scheme   = runtime.NewScheme()
 	setupLog = ctrl.Log.WithName("setup")
+	// version is the operator version, set at build time with -ldflags "-X main.version=...".
+	version = "dev"
 )
 
 func init() {
@@ -67,6 +85,26 @@ func main() {
 	var probeAddr string
 	var secureMetrics bool
 	var enableHTTP2 bool
+	var notifyWebhookURL, notifySNSTopicARN string
+	var delegationSetID string
+	var logFormat string
+	var allowCrossNamespaceTemplates bool
+	var allowSecretTemplates bool
+	var bucketUsageInterval time.Duration
+	var managedLabel string
+	var s3Endpoint string
+	var s3ForcePathStyle bool
+	var requeueJitter float64
+	var awsCallTimeout time.Duration
+	var watchNamespaces string
+	var resyncOnStart bool
+	var resyncInterval time.Duration
+	var zoneVerifyInterval time.Duration
+	var claimNamespace string
+	var domainSuffixConfigMap string
+	var allowedRoles string
+	var allowedSharedBuckets string
+	var enableWebhook bool
 	var tlsOpts []func(*tls.Config)
 	flag.StringVar(&metricsAddr, "metrics-bind-address", "0", "The address the metrics endpoint binds to. "+
 		"Use :8443 for HTTPS or :8080 for HTTP, or leave as 0 to disable the metrics service.")
@@ -85,13 +123,74 @@ func main() {
 	flag.StringVar(&metricsCertKey, "metrics-cert-key", "tls.key", "The name of the metrics server key file.")
 	flag.BoolVar(&enableHTTP2, "enable-http2", false,
 		"If set, HTTP/2 will be enabled for the metrics and webhook servers")
+	flag.StringVar(&notifyWebhookURL, "notify-webhook-url", "",
+		"If set, a JSON notification is POSTed to this URL when a ParkedDomain is provisioned or fails.")
+	flag.StringVar(&notifySNSTopicARN, "notify-sns-topic-arn", "",
+		"If set, a JSON notification is published to this SNS topic when a ParkedDomain is provisioned or fails.")
+	flag.StringVar(&delegationSetID, "delegation-set-id", "",
+		"If set, new Hosted Zones use this reusable delegation set unless a ParkedDomain specifies its own.")
+	flag.DurationVar(&bucketUsageInterval, "bucket-usage-interval", time.Hour,
+		"How often each bucket's object count and size are counted into the ParkedDomain status. 0 disables counting.")
+	flag.BoolVar(&allowCrossNamespaceTemplates, "allow-cross-namespace-templates", false,
+		"If set, ParkedDomains may read templates from ConfigMaps in other namespaces via spec.templateConfigMapRef.")
+	flag.BoolVar(&allowSecretTemplates, "allow-secret-templates", false,
+		"If set, ParkedDomains may read templates via spec.templateSecretRef from Secrets labeled "+
+			parkingv1alpha1.GroupName+"/template=true.")
+	flag.StringVar(&managedLabel, "managed-label", parkingv1alpha1.GroupName+"/managed=true",
+		"Label, as key=value, set on every ParkedDomain the operator manages. Empty disables the label.")
+	flag.StringVar(&s3Endpoint, "s3-endpoint", "",
+		"If set, the URL of an S3-compatible service, e.g. MinIO, to create buckets in instead of AWS S3.")
+	flag.BoolVar(&s3ForcePathStyle, "s3-force-path-style", false,
+		"If set, buckets are addressed as <endpoint>/<bucket>, as most S3-compatible services require.")
+	flag.Float64Var(&requeueJitter, "requeue-jitter", 0.1,
+		"Largest fraction added at random to requeue and retry delays, so ParkedDomains queued together "+
+			"do not hit AWS at once. 0 disables jitter.")
+	flag.DurationVar(&awsCallTimeout, "aws-call-timeout", 30*time.Second,
+		"How long a single S3 or Route 53 call may take before it fails and the reconcile is retried. 0 disables the limit.")
+	flag.StringVar(&watchNamespaces, "namespaces", os.Getenv("WATCH_NAMESPACE"),
+		"Comma-separated namespaces whose ParkedDomains are reconciled, defaulting to the WATCH_NAMESPACE "+
+			"environment variable. Empty watches all namespaces.")
+	flag.BoolVar(&resyncOnStart, "resync-on-start", false,
+		"If set, every ParkedDomain is fully reconciled once at startup, repairing drift in AWS even where "+
+			"its status says it is up to date.")
+	flag.DurationVar(&resyncInterval, "resync-interval", time.Second,
+		"Delay between the ParkedDomains queued by --resync-on-start, to stay within AWS request limits.")
+	flag.DurationVar(&zoneVerifyInterval, "zone-verify-interval", 10*time.Minute,
+		"How long a Hosted Zone is trusted before GetHostedZone confirms it again and refreshes its nameservers. "+
+			"0 confirms it on every reconcile.")
+	flag.StringVar(&claimNamespace, "claim-namespace", "",
+		"If set, ParkedDomainClaims are reconciled into ParkedDomains in this namespace. Empty ignores claims.")
+	flag.StringVar(&domainSuffixConfigMap, "domain-suffix-configmap", "",
+		"If set, the namespace/name of a ConfigMap mapping each namespace to its allowed domain suffixes, "+
+			"enforced by the validating webhook for ParkedDomains. Implies --enable-webhook.")
+	flag.StringVar(&allowedRoles, "allowed-roles", "",
+		"Comma-separated IAM role ARNs, or account IDs allowing all roles of an account, that spec.dnsRoleARN "+
+			"and spec.storageRoleARN may name. \"*\" allows any role. Empty allows none.")
+	flag.StringVar(&allowedSharedBuckets, "allowed-shared-buckets", "",
+		"Comma-separated bucket names spec.sharedBucket may name. \"*\" allows any bucket. Empty allows none.")
+	flag.BoolVar(&enableWebhook, "enable-webhook", false,
+		"If set, serve the validating webhook for ParkedDomains, which keeps spec.domainName immutable. "+
+			"Requires a serving certificate, see --webhook-cert-path.")
+	flag.StringVar(&logFormat, "log-format", "",
+		"If set, the log output format, either console or json. Takes precedence over --zap-encoder.")
 	opts := zap.Options{
 		Development: true,
 	}
 	opts.BindFlags(flag.CommandLine)
 	flag.Parse()
 
-	ctrl.SetLogger(zap.New(zap.UseFlagOptions(&opts)))
+	loggerOpts := []zap.Opts{zap.UseFlagOptions(&opts)}
+	switch logFormat {
+	case "":
+	case "console":
+		loggerOpts = append(loggerOpts, zap.ConsoleEncoder())
+	case "json":
+		loggerOpts = append(loggerOpts, zap.JSONEncoder())
+	default:
+		fmt.Fprintf(os.Stderr, "invalid --log-format %q, must be console or json\n", logFormat)
+		os.Exit(1)
+	}
+	ctrl.SetLogger(zap.New(loggerOpts...))
 
 	// if the enable-http2 flag is false (the default), http/2 should be disabled
 	// due to its vulnerabilities. More specifically, disabling http/2 will
@@ -182,6 +281,47 @@ func main() {
 		})
 	}
 
+	var namespaces []string
+	for _, namespace := range strings.Split(watchNamespaces, ",") {
+		namespace = strings.TrimSpace(namespace)
+		if namespace == "" {
+			continue
+		}
+		if errs := validation.IsDNS1123Label(namespace); len(errs) > 0 {
+			setupLog.Error(errors.New(strings.Join(errs, "; ")), "invalid --namespaces", "namespace", namespace)
+			os.Exit(1)
+		}
+		namespaces = append(namespaces, namespace)
+	}
+	var suffixConfigMap types.NamespacedName
+	if domainSuffixConfigMap != "" {
+		namespace, name, ok := strings.Cut(domainSuffixConfigMap, "/")
+		if !ok || len(validation.IsDNS1123Label(namespace)) > 0 || len(validation.IsDNS1123Subdomain(name)) > 0 {
+			setupLog.Error(errors.New("must be namespace/name"), "invalid --domain-suffix-configmap",
+				"value", domainSuffixConfigMap)
+			os.Exit(1)
+		}
+		suffixConfigMap = types.NamespacedName{Namespace: namespace, Name: name}
+	}
+	if claimNamespace != "" && len(namespaces) > 0 && !slices.Contains(namespaces, claimNamespace) {
+		setupLog.Error(errors.New("the claim namespace must be watched"), "invalid --claim-namespace",
+			"namespace", claimNamespace)
+		os.Exit(1)
+	}
+	// Template Secrets are read directly, so the operator neither caches nor needs to
+	// list and watch every Secret in the cluster.
+	uncached := []client.Object{&corev1.Secret{}}
+	var cacheOptions cache.Options
+	if len(namespaces) > 0 {
+		cacheOptions.DefaultNamespaces = make(map[string]cache.Config, len(namespaces))
+		for _, namespace := range namespaces {
+			cacheOptions.DefaultNamespaces[namespace] = cache.Config{}
+		}
+		// The template ConfigMap usually lives in the operator's namespace, outside the
+		// watched ones, which the cache could not read it from.
+		uncached = append(uncached, &corev1.ConfigMap{})
+	}
+
 	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
 		Scheme:                 scheme,
 		Metrics:                metricsServerOptions,
@@ -189,6 +329,8 @@ func main() {
 		HealthProbeBindAddress: probeAddr,
 		LeaderElection:         enableLeaderElection,
 		LeaderElectionID:       "d4d0f255.minibaev.eu",
+		Cache:                  cacheOptions,
+		Client:                 client.Options{Cache: &client.CacheOptions{DisableFor: uncached}},
 		// LeaderElectionReleaseOnCancel defines if the leader should step down voluntarily
 		// when the Manager ends. This requires the binary to immediately end when the
 		// Manager is stopped, otherwise, this setting is unsafe. Setting this significantly
@@ -206,21 +348,119 @@ func main() {
 		os.Exit(1)
 	}
 
+	// A watched namespace that does not exist yet is most likely a typo, but not an error.
+	for _, namespace := range namespaces {
+		err := mgr.GetAPIReader().Get(context.TODO(), client.ObjectKey{Name: namespace}, &corev1.Namespace{})
+		switch {
+		case apierrors.IsNotFound(err):
+			setupLog.Info("Watched namespace does not exist, its ParkedDomains are reconciled once it is created",
+				"namespace", namespace)
+		case err != nil:
+			setupLog.Info("Unable to check that watched namespace exists", "namespace", namespace, "error", err.Error())
+		}
+	}
+
 	awsCfg, err := config.LoadDefaultConfig(context.TODO())
 	if err != nil {
 		setupLog.Error(err, "unable to load AWS config")
 		os.Exit(1)
 	}
+	controller.RecordCallRates(&awsCfg)
+
+	var managedLabelKey, managedLabelValue string
+	if managedLabel != "" {
+		managedLabelKey, managedLabelValue, _ = strings.Cut(managedLabel, "=")
+		if errs := append(validation.IsQualifiedName(managedLabelKey), validation.IsValidLabelValue(managedLabelValue)...); len(errs) > 0 {
+			setupLog.Error(errors.New(strings.Join(errs, "; ")), "invalid --managed-label")
+			os.Exit(1)
+		}
+	}
+	if requeueJitter < 0 {
+		setupLog.Error(fmt.Errorf("%v is negative", requeueJitter), "invalid --requeue-jitter")
+		os.Exit(1)
+	}
+	if awsCallTimeout < 0 {
+		setupLog.Error(fmt.Errorf("%v is negative", awsCallTimeout), "invalid --aws-call-timeout")
+		os.Exit(1)
+	}
+	if s3Endpoint != "" {
+		if u, err := url.Parse(s3Endpoint); err != nil || u.Host == "" || (u.Scheme != "http" && u.Scheme != "https") {
+			setupLog.Error(fmt.Errorf("%q is not an http or https URL", s3Endpoint), "invalid --s3-endpoint")
+			os.Exit(1)
+		}
+	}
+
+	var notifier controller.Notifier
+	switch {
+	case notifyWebhookURL != "" && notifySNSTopicARN != "":
+		setupLog.Error(nil, "only one of --notify-webhook-url and --notify-sns-topic-arn may be set")
+		os.Exit(1)
+	case notifyWebhookURL != "":
+		notifier = &controller.WebhookNotifier{URL: notifyWebhookURL}
+	case notifySNSTopicARN != "":
+		topicARN, err := arn.Parse(notifySNSTopicARN)
+		if err != nil {
+			setupLog.Error(err, "invalid --notify-sns-topic-arn")
+			os.Exit(1)
+		}
+		notifier = &controller.SNSNotifier{
+			TopicARN: notifySNSTopicARN,
+			Client: sns.NewFromConfig(awsCfg, func(o *sns.Options) {
+				o.Region = topicARN.Region
+			}),
+		}
+	}
 
 	if err = (&controller.ParkedDomainReconciler{
-		Client:          mgr.GetClient(),
-		Scheme:          mgr.GetScheme(),
-		S3ClientFactory: &controller.AWSS3ClientFactory{},
-		R53Client:       route53.NewFromConfig(awsCfg),
+		Client:                       mgr.GetClient(),
+		Scheme:                       mgr.GetScheme(),
+		S3ClientFactory:              &controller.AWSS3ClientFactory{UsePathStyle: s3ForcePathStyle},
+		S3Endpoint:                   s3Endpoint,
+		S3ForcePathStyle:             s3ForcePathStyle,
+		R53Client:                    route53.NewFromConfig(awsCfg),
+		STSClient:                    sts.NewFromConfig(awsCfg),
+		R53ClientFactory:             &controller.AWSR53ClientFactory{},
+		CloudWatchClientFactory:      &controller.AWSCloudWatchClientFactory{},
+		DNSFirewallClientFactory:     &controller.AWSDNSFirewallClientFactory{},
+		AllowedRoles:                 controller.SplitPolicyList(allowedRoles),
+		AllowedSharedBuckets:         controller.SplitPolicyList(allowedSharedBuckets),
+		Notifier:                     notifier,
+		Recorder:                     mgr.GetEventRecorderFor("parkeddomain-controller"),
+		DelegationSetID:              delegationSetID,
+		AllowCrossNamespaceTemplates: allowCrossNamespaceTemplates,
+		AllowSecretTemplates:         allowSecretTemplates,
+		BucketUsageInterval:          bucketUsageInterval,
+		ManagedLabelKey:              managedLabelKey,
+		ManagedLabelValue:            managedLabelValue,
+		OperatorVersion:              version,
+		RequeueJitter:                requeueJitter,
+		AWSCallTimeout:               awsCallTimeout,
+		WatchNamespaces:              namespaces,
+		ResyncOnStart:                resyncOnStart,
+		ResyncInterval:               resyncInterval,
+		ZoneVerifyInterval:           zoneVerifyInterval,
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "ParkedDomain")
 		os.Exit(1)
 	}
+	if claimNamespace != "" {
+		if err = (&controller.ParkedDomainClaimReconciler{
+			Client:         mgr.GetClient(),
+			Scheme:         mgr.GetScheme(),
+			Recorder:       mgr.GetEventRecorderFor("parkeddomainclaim-controller"),
+			ClaimNamespace: claimNamespace,
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "ParkedDomainClaim")
+			os.Exit(1)
+		}
+	}
+	if enableWebhook || domainSuffixConfigMap != "" {
+		if err = webhookv1alpha1.SetupParkedDomainWebhookWithManager(mgr, suffixConfigMap,
+			controller.SplitPolicyList(allowedRoles), controller.SplitPolicyList(allowedSharedBuckets)); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "ParkedDomain")
+			os.Exit(1)
+		}
+	}
 	// +kubebuilder:scaffold:builder
 
 	if metricsCertWatcher != nil {
@@ -0,0 +1,119 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Command validate checks ParkedDomain manifests offline, without a cluster.
+//
+// Usage:
+//
+//	validate [-allowed-roles LIST] [-allowed-shared-buckets LIST] FILE...
+//
+// Each file may contain several YAML documents; documents of other kinds are
+// skipped. The command exits non-zero if any ParkedDomain is invalid.
+// -allowed-roles takes the operator's --allowed-roles, so IAM roles the
+// operator would refuse to assume are reported too, and -allowed-shared-buckets
+// likewise its --allowed-shared-buckets.
+package main
+
+import (
+	"bufio"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"sigs.k8s.io/yaml"
+
+	parkingv1alpha1 "github.com/gminiba/parked-domain-operator/api/v1alpha1"
+	"github.com/gminiba/parked-domain-operator/internal/controller"
+)
+
+func main() {
+	allowedRoles := flag.String("allowed-roles", "",
+		"Comma-separated IAM role ARNs or account IDs ParkedDomains may name, as the operator's --allowed-roles. "+
+			"\"*\" allows any role. Empty allows none.")
+	allowedSharedBuckets := flag.String("allowed-shared-buckets", "",
+		"Comma-separated bucket names ParkedDomains may share, as the operator's --allowed-shared-buckets. "+
+			"\"*\" allows any bucket. Empty allows none.")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: %s [flags] FILE...\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	valid := true
+	for _, path := range flag.Args() {
+		fileValid, err := validateFile(path, controller.SplitPolicyList(*allowedRoles),
+			controller.SplitPolicyList(*allowedSharedBuckets), os.Stdout)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %v\n", path, err)
+			os.Exit(2)
+		}
+		valid = valid && fileValid
+	}
+	if !valid {
+		os.Exit(1)
+	}
+}
+
+// validateFile validates every ParkedDomain in the file at path, reporting problems to out.
+// IAM roles are checked against allowedRoles, shared buckets against allowedSharedBuckets.
+func validateFile(path string, allowedRoles, allowedSharedBuckets []string, out io.Writer) (bool, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return false, err
+	}
+	defer func() { _ = f.Close() }()
+
+	valid := true
+	reader := utilyaml.NewYAMLReader(bufio.NewReader(f))
+	for {
+		doc, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return valid, nil
+		}
+		if err != nil {
+			return false, err
+		}
+
+		var typeMeta metav1.TypeMeta
+		if err := yaml.Unmarshal(doc, &typeMeta); err != nil {
+			return false, err
+		}
+		if typeMeta.Kind != "ParkedDomain" || typeMeta.GroupVersionKind().Group != parkingv1alpha1.GroupVersion.Group {
+			continue
+		}
+
+		pd := &parkingv1alpha1.ParkedDomain{}
+		if err := yaml.UnmarshalStrict(doc, pd); err != nil {
+			fmt.Fprintf(out, "%s: %s: %v\n", path, pd.Name, err)
+			valid = false
+			continue
+		}
+		fieldErrs := append(controller.ValidateParkedDomain(pd), controller.ValidateAssumedRoles(pd, allowedRoles)...)
+		fieldErrs = append(fieldErrs, controller.ValidateSharedBucketAllowed(pd, allowedSharedBuckets)...)
+		for _, fieldErr := range fieldErrs {
+			fmt.Fprintf(out, "%s: %s: %v\n", path, pd.Name, fieldErr)
+			valid = false
+		}
+	}
+}
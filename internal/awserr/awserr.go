@@ -0,0 +1,99 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package awserr classifies errors returned by the AWS SDK, so callers react to
+// the kind of failure instead of matching SDK error types and codes themselves.
+// Every classifier looks through wrapped errors and is false for a nil error or
+// an error that did not come from an AWS API.
+package awserr
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws/retry"
+	"github.com/aws/smithy-go"
+)
+
+// Code returns the API error code of err, e.g. "NoSuchBucket", or "" when err
+// is not an AWS API error.
+func Code(err error) string {
+	var apiErr smithy.APIError
+	if !errors.As(err, &apiErr) {
+		return ""
+	}
+	return apiErr.ErrorCode()
+}
+
+// IsNotFound reports whether the resource the request addressed does not exist,
+// e.g. a missing bucket, Hosted Zone, delegation set or DNS Firewall association.
+func IsNotFound(err error) bool {
+	code := Code(err)
+	return code == "NotFound" || code == "ResourceNotFoundException" || strings.HasPrefix(code, "NoSuch")
+}
+
+// IsNoSuchWebsiteConfiguration reports whether a bucket has no static website
+// configuration, e.g. because it was removed outside the operator.
+func IsNoSuchWebsiteConfiguration(err error) bool {
+	return Code(err) == "NoSuchWebsiteConfiguration"
+}
+
+// IsThrottle reports whether the request was rejected for exceeding a rate or
+// request limit, and can succeed when retried later, e.g. Route 53's
+// PriorRequestNotComplete or S3's SlowDown. The codes are the ones the SDK's
+// own retryer treats as throttling.
+func IsThrottle(err error) bool {
+	_, ok := retry.DefaultThrottleErrorCodes[Code(err)]
+	return ok
+}
+
+// IsAccessDenied reports whether the caller's credentials lack permission for
+// the request. Such errors are permanent until the IAM policies change.
+func IsAccessDenied(err error) bool {
+	switch Code(err) {
+	case "AccessDenied", "AccessDeniedException", "Forbidden", "AllAccessDisabled":
+		return true
+	}
+	return false
+}
+
+// IsConflict reports whether the request collided with another operation on
+// the same resource, e.g. a concurrent change to a bucket's configuration.
+func IsConflict(err error) bool {
+	switch Code(err) {
+	case "OperationAborted", "ConditionalRequestConflict", "ConcurrentModification", "ConflictException":
+		return true
+	}
+	return false
+}
+
+// IsAlreadyExists reports whether a bucket could not be created because another
+// AWS account owns its globally unique name.
+func IsAlreadyExists(err error) bool {
+	return Code(err) == "BucketAlreadyExists"
+}
+
+// IsAlreadyOwnedByYou reports whether a bucket could not be created because
+// the caller already owns it.
+func IsAlreadyOwnedByYou(err error) bool {
+	return Code(err) == "BucketAlreadyOwnedByYou"
+}
+
+// IsNotImplemented reports whether an S3-compatible service rejected the
+// request because it does not support the feature.
+func IsNotImplemented(err error) bool {
+	return Code(err) == "NotImplemented"
+}
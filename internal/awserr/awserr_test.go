@@ -0,0 +1,131 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package awserr
+
+import (
+	"errors"
+	"fmt"
+
+	r53types "github.com/aws/aws-sdk-go-v2/service/route53/types"
+	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/aws/smithy-go"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func apiError(code string) error {
+	return &smithy.GenericAPIError{Code: code, Message: "test"}
+}
+
+var _ = Describe("AWS error classification", func() {
+	It("should return the code of wrapped API errors only", func() {
+		Expect(Code(fmt.Errorf("failed to create S3 bucket: %w", apiError("BucketAlreadyExists")))).To(Equal("BucketAlreadyExists"))
+		Expect(Code(errors.New("connection reset"))).To(BeEmpty())
+		Expect(Code(nil)).To(BeEmpty())
+	})
+
+	DescribeTable("IsNotFound",
+		func(err error, expected bool) {
+			Expect(IsNotFound(err)).To(Equal(expected))
+		},
+		Entry("HeadBucket on a missing bucket", &s3types.NotFound{}, true),
+		Entry("a missing bucket", &s3types.NoSuchBucket{}, true),
+		Entry("a missing Hosted Zone", &r53types.NoSuchHostedZone{}, true),
+		Entry("a missing delegation set", &r53types.NoSuchDelegationSet{}, true),
+		Entry("a missing bucket policy", apiError("NoSuchBucketPolicy"), true),
+		Entry("a missing DNS Firewall association", apiError("ResourceNotFoundException"), true),
+		Entry("a wrapped missing bucket", fmt.Errorf("list: %w", &s3types.NoSuchBucket{}), true),
+		Entry("access denied", apiError("AccessDenied"), false),
+		Entry("a non-API error", errors.New("NoSuchBucket"), false),
+		Entry("nil", nil, false),
+	)
+
+	DescribeTable("IsNoSuchWebsiteConfiguration",
+		func(err error, expected bool) {
+			Expect(IsNoSuchWebsiteConfiguration(err)).To(Equal(expected))
+		},
+		Entry("a bucket without website configuration", apiError("NoSuchWebsiteConfiguration"), true),
+		Entry("a wrapped missing website configuration", fmt.Errorf("get website: %w", apiError("NoSuchWebsiteConfiguration")), true),
+		Entry("a missing bucket", &s3types.NoSuchBucket{}, false),
+		Entry("nil", nil, false),
+	)
+
+	DescribeTable("IsThrottle",
+		func(err error, expected bool) {
+			Expect(IsThrottle(err)).To(Equal(expected))
+		},
+		Entry("Route 53 throttling", apiError("Throttling"), true),
+		Entry("Route 53 PriorRequestNotComplete", &r53types.PriorRequestNotComplete{}, true),
+		Entry("S3 SlowDown", apiError("SlowDown"), true),
+		Entry("STS throttling", apiError("ThrottlingException"), true),
+		Entry("a wrapped throttle", fmt.Errorf("create zone: %w", apiError("Throttling")), true),
+		Entry("a missing bucket", &s3types.NoSuchBucket{}, false),
+		Entry("nil", nil, false),
+	)
+
+	DescribeTable("IsAccessDenied",
+		func(err error, expected bool) {
+			Expect(IsAccessDenied(err)).To(Equal(expected))
+		},
+		Entry("S3 access denied", apiError("AccessDenied"), true),
+		Entry("STS access denied", apiError("AccessDeniedException"), true),
+		Entry("HeadBucket forbidden", apiError("Forbidden"), true),
+		Entry("a wrapped denial", fmt.Errorf("assume role: %w", apiError("AccessDenied")), true),
+		Entry("throttling", apiError("Throttling"), false),
+		Entry("nil", nil, false),
+	)
+
+	DescribeTable("IsConflict",
+		func(err error, expected bool) {
+			Expect(IsConflict(err)).To(Equal(expected))
+		},
+		Entry("a concurrent S3 operation", apiError("OperationAborted"), true),
+		Entry("a conflicting conditional request", apiError("ConditionalRequestConflict"), true),
+		Entry("a concurrent Route 53 change", &r53types.ConcurrentModification{}, true),
+		Entry("a bucket owned by someone else", &s3types.BucketAlreadyExists{}, false),
+		Entry("nil", nil, false),
+	)
+
+	DescribeTable("IsAlreadyExists",
+		func(err error, expected bool) {
+			Expect(IsAlreadyExists(err)).To(Equal(expected))
+		},
+		Entry("a bucket owned by someone else", &s3types.BucketAlreadyExists{}, true),
+		Entry("a wrapped bucket owned by someone else", fmt.Errorf("create: %w", &s3types.BucketAlreadyExists{}), true),
+		Entry("a bucket the caller owns", &s3types.BucketAlreadyOwnedByYou{}, false),
+		Entry("nil", nil, false),
+	)
+
+	DescribeTable("IsAlreadyOwnedByYou",
+		func(err error, expected bool) {
+			Expect(IsAlreadyOwnedByYou(err)).To(Equal(expected))
+		},
+		Entry("a bucket the caller owns", &s3types.BucketAlreadyOwnedByYou{}, true),
+		Entry("a wrapped bucket the caller owns", fmt.Errorf("create: %w", &s3types.BucketAlreadyOwnedByYou{}), true),
+		Entry("a bucket owned by someone else", &s3types.BucketAlreadyExists{}, false),
+		Entry("nil", nil, false),
+	)
+
+	DescribeTable("IsNotImplemented",
+		func(err error, expected bool) {
+			Expect(IsNotImplemented(err)).To(Equal(expected))
+		},
+		Entry("an unsupported feature", apiError("NotImplemented"), true),
+		Entry("access denied", apiError("AccessDenied"), false),
+		Entry("nil", nil, false),
+	)
+})
@@ -0,0 +1,138 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatch"
+	cwtypes "github.com/aws/aws-sdk-go-v2/service/cloudwatch/types"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
+	parkingv1alpha1 "github.com/gminiba/parked-domain-operator/api/v1alpha1"
+	"github.com/gminiba/parked-domain-operator/internal/awserr"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const (
+	// bucketMetricsID is the ID of the bucket's request metrics configuration, and the FilterId
+	// dimension of the metrics S3 publishes for it.
+	bucketMetricsID = "parked-domain-operator"
+	// defaultAlarmMetric and defaultAlarmThreshold are used when Spec.Alarm leaves them unset.
+	defaultAlarmMetric    = "5xxErrors"
+	defaultAlarmThreshold = 10
+	// alarmPeriodSeconds is the window error responses are summed over.
+	alarmPeriodSeconds = 300
+)
+
+// alarmNameFor returns the name of the ParkedDomain's CloudWatch alarm, unique per bucket.
+func alarmNameFor(pd *parkingv1alpha1.ParkedDomain) string {
+	return "parked-domain-operator-" + recordNameFor(pd)
+}
+
+// reconcileAlarm creates or updates the CloudWatch alarm in Spec.Alarm, enabling the bucket's
+// request metrics it watches, and deletes it again when Spec.Alarm is unset.
+func (r *ParkedDomainReconciler) reconcileAlarm(ctx context.Context, s3Client S3ClientAPI, pd *parkingv1alpha1.ParkedDomain, bucketName string) error {
+	alarm := pd.Spec.Alarm
+	if alarm == nil {
+		return r.deleteAlarm(ctx, s3Client, pd, bucketName)
+	}
+	if r.storageEndpointFor(pd) != "" {
+		return errors.New("S3-compatible services publish no CloudWatch metrics to alarm on; remove spec.alarm")
+	}
+	cwClient, err := r.cloudWatchClientFor(ctx, pd)
+	if err != nil {
+		return err
+	}
+
+	// S3 only publishes request metrics for buckets with a metrics configuration.
+	_, err = s3Client.PutBucketMetricsConfiguration(ctx, &s3.PutBucketMetricsConfigurationInput{
+		Bucket:               aws.String(bucketName),
+		Id:                   aws.String(bucketMetricsID),
+		MetricsConfiguration: &s3types.MetricsConfiguration{Id: aws.String(bucketMetricsID)},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to enable S3 request metrics: %w", err)
+	}
+
+	metric := alarm.Metric
+	if metric == "" {
+		metric = defaultAlarmMetric
+	}
+	threshold := alarm.Threshold
+	if threshold == 0 {
+		threshold = defaultAlarmThreshold
+	}
+	name := alarmNameFor(pd)
+	_, err = cwClient.PutMetricAlarm(ctx, &cloudwatch.PutMetricAlarmInput{
+		AlarmName:        aws.String(name),
+		AlarmDescription: aws.String(fmt.Sprintf("%s responses of the parked page of %s", metric, pd.Spec.DomainName)),
+		Namespace:        aws.String("AWS/S3"),
+		MetricName:       aws.String(metric),
+		Dimensions: []cwtypes.Dimension{
+			{Name: aws.String("BucketName"), Value: aws.String(bucketName)},
+			{Name: aws.String("FilterId"), Value: aws.String(bucketMetricsID)},
+		},
+		Statistic:          cwtypes.StatisticSum,
+		Period:             aws.Int32(alarmPeriodSeconds),
+		EvaluationPeriods:  aws.Int32(1),
+		Threshold:          aws.Float64(float64(threshold)),
+		ComparisonOperator: cwtypes.ComparisonOperatorGreaterThanOrEqualToThreshold,
+		// No requests means no errors, not an unknown state.
+		TreatMissingData: aws.String("notBreaching"),
+		AlarmActions:     []string{alarm.SNSTopicARN},
+		OKActions:        []string{alarm.SNSTopicARN},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create CloudWatch alarm %s: %w", name, err)
+	}
+	if pd.Status.AlarmName != name {
+		log.FromContext(ctx).Info("Created CloudWatch alarm", "alarm", name)
+	}
+	pd.Status.AlarmName = name
+	return nil
+}
+
+// deleteAlarm deletes the CloudWatch alarm in Status.AlarmName, if any, and the request metrics
+// configuration it watched.
+func (r *ParkedDomainReconciler) deleteAlarm(ctx context.Context, s3Client S3ClientAPI, pd *parkingv1alpha1.ParkedDomain, bucketName string) error {
+	name := pd.Status.AlarmName
+	if name == "" {
+		return nil
+	}
+	cwClient, err := r.cloudWatchClientFor(ctx, pd)
+	if err != nil {
+		return err
+	}
+	_, err = cwClient.DeleteAlarms(ctx, &cloudwatch.DeleteAlarmsInput{AlarmNames: []string{name}})
+	if err != nil && awserr.Code(err) != "ResourceNotFound" {
+		return fmt.Errorf("failed to delete CloudWatch alarm %s: %w", name, err)
+	}
+	_, err = s3Client.DeleteBucketMetricsConfiguration(ctx, &s3.DeleteBucketMetricsConfigurationInput{
+		Bucket: aws.String(bucketName),
+		Id:     aws.String(bucketMetricsID),
+	})
+	if err != nil && !awserr.IsNotFound(err) {
+		return fmt.Errorf("failed to disable S3 request metrics: %w", err)
+	}
+	log.FromContext(ctx).Info("Deleted CloudWatch alarm", "alarm", name)
+	pd.Status.AlarmName = ""
+	return nil
+}
@@ -0,0 +1,189 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatch"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/smithy-go"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	parkingv1alpha1 "github.com/gminiba/parked-domain-operator/api/v1alpha1"
+)
+
+// MockCloudWatchClient is a mock implementation of CloudWatchClientAPI.
+type MockCloudWatchClient struct {
+	PutMetricAlarmFunc func(ctx context.Context, params *cloudwatch.PutMetricAlarmInput, optFns ...func(*cloudwatch.Options)) (*cloudwatch.PutMetricAlarmOutput, error)
+	DeleteAlarmsFunc   func(ctx context.Context, params *cloudwatch.DeleteAlarmsInput, optFns ...func(*cloudwatch.Options)) (*cloudwatch.DeleteAlarmsOutput, error)
+}
+
+func (m *MockCloudWatchClient) PutMetricAlarm(ctx context.Context, params *cloudwatch.PutMetricAlarmInput, optFns ...func(*cloudwatch.Options)) (*cloudwatch.PutMetricAlarmOutput, error) {
+	if m.PutMetricAlarmFunc != nil {
+		return m.PutMetricAlarmFunc(ctx, params, optFns...)
+	}
+	return &cloudwatch.PutMetricAlarmOutput{}, nil
+}
+
+func (m *MockCloudWatchClient) DeleteAlarms(ctx context.Context, params *cloudwatch.DeleteAlarmsInput, optFns ...func(*cloudwatch.Options)) (*cloudwatch.DeleteAlarmsOutput, error) {
+	if m.DeleteAlarmsFunc != nil {
+		return m.DeleteAlarmsFunc(ctx, params, optFns...)
+	}
+	return &cloudwatch.DeleteAlarmsOutput{}, nil
+}
+
+// MockCloudWatchClientFactory records the region and role it was asked for and returns a fixed mock client.
+type MockCloudWatchClientFactory struct {
+	MockCloudWatch   CloudWatchClientAPI
+	RequestedRegion  string
+	RequestedRoleARN string
+}
+
+func (f *MockCloudWatchClientFactory) GetClient(ctx context.Context, region, roleARN string) (CloudWatchClientAPI, error) {
+	f.RequestedRegion = region
+	f.RequestedRoleARN = roleARN
+	return f.MockCloudWatch, nil
+}
+
+var _ = Describe("CloudWatch error alarm", func() {
+	const topicARN = "arn:aws:sns:eu-west-1:111111111111:parked-domains"
+	var pd *parkingv1alpha1.ParkedDomain
+
+	BeforeEach(func() {
+		pd = &parkingv1alpha1.ParkedDomain{
+			ObjectMeta: metav1.ObjectMeta{Name: "alarmed", Namespace: "default"},
+			Spec: parkingv1alpha1.ParkedDomainSpec{
+				DomainName:     "example.com",
+				Region:         "eu-west-1",
+				StorageRoleARN: "arn:aws:iam::222222222222:role/parked-storage",
+				Alarm:          &parkingv1alpha1.Alarm{SNSTopicARN: topicARN},
+			},
+		}
+	})
+
+	It("should enable request metrics and alarm on 5xx errors by default", func() {
+		var metricsConfig *s3.PutBucketMetricsConfigurationInput
+		var alarm *cloudwatch.PutMetricAlarmInput
+		s3Client := &MockS3Client{
+			PutBucketMetricsConfigurationFunc: func(ctx context.Context, params *s3.PutBucketMetricsConfigurationInput, optFns ...func(*s3.Options)) (*s3.PutBucketMetricsConfigurationOutput, error) {
+				metricsConfig = params
+				return &s3.PutBucketMetricsConfigurationOutput{}, nil
+			},
+		}
+		factory := &MockCloudWatchClientFactory{MockCloudWatch: &MockCloudWatchClient{
+			PutMetricAlarmFunc: func(ctx context.Context, params *cloudwatch.PutMetricAlarmInput, optFns ...func(*cloudwatch.Options)) (*cloudwatch.PutMetricAlarmOutput, error) {
+				alarm = params
+				return &cloudwatch.PutMetricAlarmOutput{}, nil
+			},
+		}}
+		r := &ParkedDomainReconciler{AllowedRoles: []string{"222222222222"}, CloudWatchClientFactory: factory}
+
+		Expect(r.reconcileAlarm(context.Background(), s3Client, pd, "example.com")).To(Succeed())
+		Expect(factory.RequestedRegion).To(Equal("eu-west-1"))
+		Expect(factory.RequestedRoleARN).To(Equal(pd.Spec.StorageRoleARN))
+		Expect(aws.ToString(metricsConfig.Bucket)).To(Equal("example.com"))
+		Expect(aws.ToString(metricsConfig.Id)).To(Equal(bucketMetricsID))
+
+		Expect(aws.ToString(alarm.AlarmName)).To(Equal("parked-domain-operator-example.com"))
+		Expect(aws.ToString(alarm.Namespace)).To(Equal("AWS/S3"))
+		Expect(aws.ToString(alarm.MetricName)).To(Equal("5xxErrors"))
+		Expect(aws.ToFloat64(alarm.Threshold)).To(Equal(float64(10)))
+		Expect(alarm.Dimensions).To(HaveLen(2))
+		Expect(aws.ToString(alarm.Dimensions[0].Value)).To(Equal("example.com"))
+		Expect(aws.ToString(alarm.Dimensions[1].Value)).To(Equal(bucketMetricsID))
+		Expect(alarm.AlarmActions).To(Equal([]string{topicARN}))
+		Expect(alarm.OKActions).To(Equal([]string{topicARN}))
+		Expect(pd.Status.AlarmName).To(Equal("parked-domain-operator-example.com"))
+	})
+
+	It("should alarm on the metric and threshold in the spec", func() {
+		pd.Spec.Alarm.Metric = "4xxErrors"
+		pd.Spec.Alarm.Threshold = 100
+		var alarm *cloudwatch.PutMetricAlarmInput
+		r := &ParkedDomainReconciler{AllowedRoles: []string{"222222222222"}, CloudWatchClientFactory: &MockCloudWatchClientFactory{MockCloudWatch: &MockCloudWatchClient{
+			PutMetricAlarmFunc: func(ctx context.Context, params *cloudwatch.PutMetricAlarmInput, optFns ...func(*cloudwatch.Options)) (*cloudwatch.PutMetricAlarmOutput, error) {
+				alarm = params
+				return &cloudwatch.PutMetricAlarmOutput{}, nil
+			},
+		}}}
+
+		Expect(r.reconcileAlarm(context.Background(), &MockS3Client{}, pd, "example.com")).To(Succeed())
+		Expect(aws.ToString(alarm.MetricName)).To(Equal("4xxErrors"))
+		Expect(aws.ToFloat64(alarm.Threshold)).To(Equal(float64(100)))
+	})
+
+	It("should delete the alarm and the metrics configuration once spec.alarm is removed", func() {
+		pd.Spec.Alarm = nil
+		pd.Status.AlarmName = "parked-domain-operator-example.com"
+		var deletedAlarms []string
+		var deletedConfig string
+		s3Client := &MockS3Client{
+			DeleteBucketMetricsConfigurationFunc: func(ctx context.Context, params *s3.DeleteBucketMetricsConfigurationInput, optFns ...func(*s3.Options)) (*s3.DeleteBucketMetricsConfigurationOutput, error) {
+				deletedConfig = aws.ToString(params.Id)
+				return &s3.DeleteBucketMetricsConfigurationOutput{}, nil
+			},
+		}
+		r := &ParkedDomainReconciler{AllowedRoles: []string{"222222222222"}, CloudWatchClientFactory: &MockCloudWatchClientFactory{MockCloudWatch: &MockCloudWatchClient{
+			DeleteAlarmsFunc: func(ctx context.Context, params *cloudwatch.DeleteAlarmsInput, optFns ...func(*cloudwatch.Options)) (*cloudwatch.DeleteAlarmsOutput, error) {
+				deletedAlarms = params.AlarmNames
+				return &cloudwatch.DeleteAlarmsOutput{}, nil
+			},
+		}}}
+
+		Expect(r.reconcileAlarm(context.Background(), s3Client, pd, "example.com")).To(Succeed())
+		Expect(deletedAlarms).To(Equal([]string{"parked-domain-operator-example.com"}))
+		Expect(deletedConfig).To(Equal(bucketMetricsID))
+		Expect(pd.Status.AlarmName).To(BeEmpty())
+	})
+
+	It("should not need a CloudWatch client when no alarm was ever created", func() {
+		pd.Spec.Alarm = nil
+		r := &ParkedDomainReconciler{}
+
+		Expect(r.reconcileAlarm(context.Background(), &MockS3Client{}, pd, "example.com")).To(Succeed())
+	})
+
+	It("should treat an alarm and metrics configuration deleted outside the operator as deleted", func() {
+		pd.Status.AlarmName = "parked-domain-operator-example.com"
+		s3Client := &MockS3Client{
+			DeleteBucketMetricsConfigurationFunc: func(ctx context.Context, params *s3.DeleteBucketMetricsConfigurationInput, optFns ...func(*s3.Options)) (*s3.DeleteBucketMetricsConfigurationOutput, error) {
+				return nil, &smithy.GenericAPIError{Code: "NoSuchConfiguration"}
+			},
+		}
+		r := &ParkedDomainReconciler{AllowedRoles: []string{"222222222222"}, CloudWatchClientFactory: &MockCloudWatchClientFactory{MockCloudWatch: &MockCloudWatchClient{
+			DeleteAlarmsFunc: func(ctx context.Context, params *cloudwatch.DeleteAlarmsInput, optFns ...func(*cloudwatch.Options)) (*cloudwatch.DeleteAlarmsOutput, error) {
+				return nil, &smithy.GenericAPIError{Code: "ResourceNotFound"}
+			},
+		}}}
+
+		Expect(r.deleteAlarm(context.Background(), s3Client, pd, "example.com")).To(Succeed())
+		Expect(pd.Status.AlarmName).To(BeEmpty())
+	})
+
+	It("should refuse to alarm on a bucket on an S3-compatible service", func() {
+		pd.Spec.StorageEndpoint = "https://minio.internal:9000"
+		r := &ParkedDomainReconciler{AllowedRoles: []string{"222222222222"}, CloudWatchClientFactory: &MockCloudWatchClientFactory{MockCloudWatch: &MockCloudWatchClient{}}}
+
+		err := r.reconcileAlarm(context.Background(), &MockS3Client{}, pd, "example.com")
+		Expect(err).To(MatchError(ContainSubstring("S3-compatible")))
+		Expect(pd.Status.AlarmName).To(BeEmpty())
+	})
+})
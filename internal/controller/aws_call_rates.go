@@ -0,0 +1,142 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsmiddleware "github.com/aws/aws-sdk-go-v2/aws/middleware"
+	"github.com/aws/smithy-go/middleware"
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+// callRateWindow is the sliding window AWS call rates are averaged over, counted in
+// one-second slots.
+const callRateWindow = 60
+
+// awsCallRateDesc describes the parkeddomain_aws_rate gauge.
+var awsCallRateDesc = prometheus.NewDesc(
+	"parkeddomain_aws_rate",
+	"Approximate AWS API requests per second, including retries, over the last minute.",
+	[]string{"region", "service"}, nil,
+)
+
+// awsCallRates counts the AWS requests of every client the operator creates.
+var awsCallRates = newCallRates(time.Now)
+
+func init() {
+	metrics.Registry.MustRegister(awsCallRates)
+}
+
+// callRateKey identifies the requests a rate is reported for.
+type callRateKey struct {
+	region, service string
+}
+
+// callWindow counts requests in one-second slots, each slot tagged with the Unix second it
+// counts, so slots left over from an earlier pass of the window are recognized as stale.
+type callWindow struct {
+	seconds [callRateWindow]int64
+	counts  [callRateWindow]uint32
+}
+
+// callRates counts AWS requests per region and service over a sliding window. Recording a
+// request only bumps a counter; the rates are computed when the metrics are scraped.
+type callRates struct {
+	mu      sync.Mutex
+	now     func() time.Time
+	windows map[callRateKey]*callWindow
+}
+
+func newCallRates(now func() time.Time) *callRates {
+	return &callRates{now: now, windows: map[callRateKey]*callWindow{}}
+}
+
+// record counts a request to service in region.
+func (c *callRates) record(region, service string) {
+	second := c.now().Unix()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	key := callRateKey{region: region, service: service}
+	w := c.windows[key]
+	if w == nil {
+		w = &callWindow{}
+		c.windows[key] = w
+	}
+	slot := second % callRateWindow
+	if w.seconds[slot] != second {
+		w.seconds[slot], w.counts[slot] = second, 0
+	}
+	w.counts[slot]++
+}
+
+// rate returns the requests per second to service in region over the last callRateWindow
+// seconds.
+func (c *callRates) rate(region, service string) float64 {
+	second := c.now().Unix()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	w := c.windows[callRateKey{region: region, service: service}]
+	if w == nil {
+		return 0
+	}
+	return w.rate(second)
+}
+
+func (w *callWindow) rate(now int64) float64 {
+	var total uint32
+	for slot, second := range w.seconds {
+		if now-second < callRateWindow {
+			total += w.counts[slot]
+		}
+	}
+	return float64(total) / callRateWindow
+}
+
+// Describe implements prometheus.Collector.
+func (c *callRates) Describe(ch chan<- *prometheus.Desc) {
+	ch <- awsCallRateDesc
+}
+
+// Collect implements prometheus.Collector.
+func (c *callRates) Collect(ch chan<- prometheus.Metric) {
+	second := c.now().Unix()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for key, w := range c.windows {
+		ch <- prometheus.MustNewConstMetric(awsCallRateDesc, prometheus.GaugeValue, w.rate(second), key.region, key.service)
+	}
+}
+
+// addMiddleware counts every request attempt of a client created from the config it is added to.
+func (c *callRates) addMiddleware(stack *middleware.Stack) error {
+	return stack.Finalize.Add(middleware.FinalizeMiddlewareFunc("ParkedDomainCallRate",
+		func(ctx context.Context, in middleware.FinalizeInput, next middleware.FinalizeHandler) (middleware.FinalizeOutput, middleware.Metadata, error) {
+			c.record(awsmiddleware.GetRegion(ctx), awsmiddleware.GetServiceID(ctx))
+			return next.HandleFinalize(ctx, in)
+		}), middleware.After)
+}
+
+// RecordCallRates makes the clients created from cfg count their requests into the
+// parkeddomain_aws_rate metric. The operator's own client factories already do.
+func RecordCallRates(cfg *aws.Config) {
+	cfg.APIOptions = append(cfg.APIOptions, awsCallRates.addMiddleware)
+}
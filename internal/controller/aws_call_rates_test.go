@@ -0,0 +1,100 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/smithy-go/middleware"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+// okHTTPClient answers every request with an empty 200 response.
+type okHTTPClient struct{}
+
+func (okHTTPClient) Do(req *http.Request) (*http.Response, error) {
+	return &http.Response{StatusCode: http.StatusOK, Header: http.Header{}, Body: io.NopCloser(strings.NewReader(""))}, nil
+}
+
+var _ = Describe("AWS call rates", func() {
+	var (
+		now   time.Time
+		rates *callRates
+	)
+
+	BeforeEach(func() {
+		now = time.Date(2026, time.March, 14, 12, 0, 0, 0, time.UTC)
+		rates = newCallRates(func() time.Time { return now })
+	})
+
+	It("should average the requests of the last minute per region and service", func() {
+		for range 30 {
+			rates.record("eu-west-1", "Route 53")
+		}
+		now = now.Add(30 * time.Second)
+		for range 90 {
+			rates.record("eu-west-1", "Route 53")
+		}
+		rates.record("us-east-1", "S3")
+
+		Expect(rates.rate("eu-west-1", "Route 53")).To(Equal(2.0))
+		Expect(rates.rate("us-east-1", "S3")).To(BeNumerically("~", 1.0/60))
+		Expect(rates.rate("us-east-1", "Route 53")).To(BeZero())
+
+		By("dropping the requests that slid out of the window")
+		now = now.Add(45 * time.Second)
+		Expect(rates.rate("eu-west-1", "Route 53")).To(Equal(1.5))
+		now = now.Add(time.Minute)
+		Expect(rates.rate("eu-west-1", "Route 53")).To(BeZero())
+	})
+
+	It("should count the requests of clients using the middleware", func() {
+		client := s3.New(s3.Options{
+			Region:      "eu-west-1",
+			Credentials: aws.AnonymousCredentials{},
+			HTTPClient:  okHTTPClient{},
+			APIOptions:  []func(*middleware.Stack) error{rates.addMiddleware},
+		})
+
+		for range 3 {
+			_, err := client.HeadBucket(context.Background(), &s3.HeadBucketInput{Bucket: aws.String("example.com")})
+			Expect(err).NotTo(HaveOccurred())
+		}
+		Expect(rates.rate("eu-west-1", "S3")).To(Equal(3.0 / 60))
+	})
+
+	It("should expose the rates as gauges", func() {
+		for range 6 {
+			rates.record("eu-west-1", "Route 53")
+		}
+
+		expected := `
+# HELP parkeddomain_aws_rate Approximate AWS API requests per second, including retries, over the last minute.
+# TYPE parkeddomain_aws_rate gauge
+parkeddomain_aws_rate{region="eu-west-1",service="Route 53"} 0.1
+`
+		Expect(testutil.CollectAndCompare(rates, strings.NewReader(expected))).To(Succeed())
+	})
+})
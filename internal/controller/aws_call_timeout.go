@@ -0,0 +1,340 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatch"
+	"github.com/aws/aws-sdk-go-v2/service/route53"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+)
+
+// withCallTimeout runs call with a context that expires after timeout. The context is derived
+// from ctx, so cancelling the reconcile still cancels the call.
+func withCallTimeout[T any](ctx context.Context, timeout time.Duration, call func(context.Context) (T, error)) (T, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	return call(ctx)
+}
+
+// timeoutS3Client bounds every call to the wrapped S3 client by timeout.
+type timeoutS3Client struct {
+	client  S3ClientAPI
+	timeout time.Duration
+}
+
+func (c *timeoutS3Client) HeadBucket(ctx context.Context, params *s3.HeadBucketInput, optFns ...func(*s3.Options)) (*s3.HeadBucketOutput, error) {
+	return withCallTimeout(ctx, c.timeout, func(ctx context.Context) (*s3.HeadBucketOutput, error) {
+		return c.client.HeadBucket(ctx, params, optFns...)
+	})
+}
+
+func (c *timeoutS3Client) CreateBucket(ctx context.Context, params *s3.CreateBucketInput, optFns ...func(*s3.Options)) (*s3.CreateBucketOutput, error) {
+	return withCallTimeout(ctx, c.timeout, func(ctx context.Context) (*s3.CreateBucketOutput, error) {
+		return c.client.CreateBucket(ctx, params, optFns...)
+	})
+}
+
+func (c *timeoutS3Client) PutObject(ctx context.Context, params *s3.PutObjectInput, optFns ...func(*s3.Options)) (*s3.PutObjectOutput, error) {
+	return withCallTimeout(ctx, c.timeout, func(ctx context.Context) (*s3.PutObjectOutput, error) {
+		return c.client.PutObject(ctx, params, optFns...)
+	})
+}
+
+func (c *timeoutS3Client) GetObject(ctx context.Context, params *s3.GetObjectInput, optFns ...func(*s3.Options)) (*s3.GetObjectOutput, error) {
+	return withCallTimeout(ctx, c.timeout, func(ctx context.Context) (*s3.GetObjectOutput, error) {
+		return c.client.GetObject(ctx, params, optFns...)
+	})
+}
+
+func (c *timeoutS3Client) PutBucketWebsite(ctx context.Context, params *s3.PutBucketWebsiteInput, optFns ...func(*s3.Options)) (*s3.PutBucketWebsiteOutput, error) {
+	return withCallTimeout(ctx, c.timeout, func(ctx context.Context) (*s3.PutBucketWebsiteOutput, error) {
+		return c.client.PutBucketWebsite(ctx, params, optFns...)
+	})
+}
+
+func (c *timeoutS3Client) GetBucketWebsite(ctx context.Context, params *s3.GetBucketWebsiteInput, optFns ...func(*s3.Options)) (*s3.GetBucketWebsiteOutput, error) {
+	return withCallTimeout(ctx, c.timeout, func(ctx context.Context) (*s3.GetBucketWebsiteOutput, error) {
+		return c.client.GetBucketWebsite(ctx, params, optFns...)
+	})
+}
+
+func (c *timeoutS3Client) GetPublicAccessBlock(ctx context.Context, params *s3.GetPublicAccessBlockInput, optFns ...func(*s3.Options)) (*s3.GetPublicAccessBlockOutput, error) {
+	return withCallTimeout(ctx, c.timeout, func(ctx context.Context) (*s3.GetPublicAccessBlockOutput, error) {
+		return c.client.GetPublicAccessBlock(ctx, params, optFns...)
+	})
+}
+
+func (c *timeoutS3Client) PutBucketPolicy(ctx context.Context, params *s3.PutBucketPolicyInput, optFns ...func(*s3.Options)) (*s3.PutBucketPolicyOutput, error) {
+	return withCallTimeout(ctx, c.timeout, func(ctx context.Context) (*s3.PutBucketPolicyOutput, error) {
+		return c.client.PutBucketPolicy(ctx, params, optFns...)
+	})
+}
+
+func (c *timeoutS3Client) DeleteBucket(ctx context.Context, params *s3.DeleteBucketInput, optFns ...func(*s3.Options)) (*s3.DeleteBucketOutput, error) {
+	return withCallTimeout(ctx, c.timeout, func(ctx context.Context) (*s3.DeleteBucketOutput, error) {
+		return c.client.DeleteBucket(ctx, params, optFns...)
+	})
+}
+
+func (c *timeoutS3Client) ListObjectsV2(ctx context.Context, params *s3.ListObjectsV2Input, optFns ...func(*s3.Options)) (*s3.ListObjectsV2Output, error) {
+	return withCallTimeout(ctx, c.timeout, func(ctx context.Context) (*s3.ListObjectsV2Output, error) {
+		return c.client.ListObjectsV2(ctx, params, optFns...)
+	})
+}
+
+func (c *timeoutS3Client) ListObjectVersions(ctx context.Context, params *s3.ListObjectVersionsInput, optFns ...func(*s3.Options)) (*s3.ListObjectVersionsOutput, error) {
+	return withCallTimeout(ctx, c.timeout, func(ctx context.Context) (*s3.ListObjectVersionsOutput, error) {
+		return c.client.ListObjectVersions(ctx, params, optFns...)
+	})
+}
+
+func (c *timeoutS3Client) PutObjectLockConfiguration(ctx context.Context, params *s3.PutObjectLockConfigurationInput, optFns ...func(*s3.Options)) (*s3.PutObjectLockConfigurationOutput, error) {
+	return withCallTimeout(ctx, c.timeout, func(ctx context.Context) (*s3.PutObjectLockConfigurationOutput, error) {
+		return c.client.PutObjectLockConfiguration(ctx, params, optFns...)
+	})
+}
+
+func (c *timeoutS3Client) DeleteObjects(ctx context.Context, params *s3.DeleteObjectsInput, optFns ...func(*s3.Options)) (*s3.DeleteObjectsOutput, error) {
+	return withCallTimeout(ctx, c.timeout, func(ctx context.Context) (*s3.DeleteObjectsOutput, error) {
+		return c.client.DeleteObjects(ctx, params, optFns...)
+	})
+}
+
+func (c *timeoutS3Client) PutBucketLifecycleConfiguration(ctx context.Context, params *s3.PutBucketLifecycleConfigurationInput, optFns ...func(*s3.Options)) (*s3.PutBucketLifecycleConfigurationOutput, error) {
+	return withCallTimeout(ctx, c.timeout, func(ctx context.Context) (*s3.PutBucketLifecycleConfigurationOutput, error) {
+		return c.client.PutBucketLifecycleConfiguration(ctx, params, optFns...)
+	})
+}
+
+func (c *timeoutS3Client) DeleteBucketLifecycle(ctx context.Context, params *s3.DeleteBucketLifecycleInput, optFns ...func(*s3.Options)) (*s3.DeleteBucketLifecycleOutput, error) {
+	return withCallTimeout(ctx, c.timeout, func(ctx context.Context) (*s3.DeleteBucketLifecycleOutput, error) {
+		return c.client.DeleteBucketLifecycle(ctx, params, optFns...)
+	})
+}
+
+func (c *timeoutS3Client) PutBucketOwnershipControls(ctx context.Context, params *s3.PutBucketOwnershipControlsInput, optFns ...func(*s3.Options)) (*s3.PutBucketOwnershipControlsOutput, error) {
+	return withCallTimeout(ctx, c.timeout, func(ctx context.Context) (*s3.PutBucketOwnershipControlsOutput, error) {
+		return c.client.PutBucketOwnershipControls(ctx, params, optFns...)
+	})
+}
+
+func (c *timeoutS3Client) GetBucketOwnershipControls(ctx context.Context, params *s3.GetBucketOwnershipControlsInput, optFns ...func(*s3.Options)) (*s3.GetBucketOwnershipControlsOutput, error) {
+	return withCallTimeout(ctx, c.timeout, func(ctx context.Context) (*s3.GetBucketOwnershipControlsOutput, error) {
+		return c.client.GetBucketOwnershipControls(ctx, params, optFns...)
+	})
+}
+
+func (c *timeoutS3Client) PutBucketAccelerateConfiguration(ctx context.Context, params *s3.PutBucketAccelerateConfigurationInput, optFns ...func(*s3.Options)) (*s3.PutBucketAccelerateConfigurationOutput, error) {
+	return withCallTimeout(ctx, c.timeout, func(ctx context.Context) (*s3.PutBucketAccelerateConfigurationOutput, error) {
+		return c.client.PutBucketAccelerateConfiguration(ctx, params, optFns...)
+	})
+}
+
+func (c *timeoutS3Client) PutBucketRequestPayment(ctx context.Context, params *s3.PutBucketRequestPaymentInput, optFns ...func(*s3.Options)) (*s3.PutBucketRequestPaymentOutput, error) {
+	return withCallTimeout(ctx, c.timeout, func(ctx context.Context) (*s3.PutBucketRequestPaymentOutput, error) {
+		return c.client.PutBucketRequestPayment(ctx, params, optFns...)
+	})
+}
+
+func (c *timeoutS3Client) PutBucketLogging(ctx context.Context, params *s3.PutBucketLoggingInput, optFns ...func(*s3.Options)) (*s3.PutBucketLoggingOutput, error) {
+	return withCallTimeout(ctx, c.timeout, func(ctx context.Context) (*s3.PutBucketLoggingOutput, error) {
+		return c.client.PutBucketLogging(ctx, params, optFns...)
+	})
+}
+
+func (c *timeoutS3Client) GetBucketTagging(ctx context.Context, params *s3.GetBucketTaggingInput, optFns ...func(*s3.Options)) (*s3.GetBucketTaggingOutput, error) {
+	return withCallTimeout(ctx, c.timeout, func(ctx context.Context) (*s3.GetBucketTaggingOutput, error) {
+		return c.client.GetBucketTagging(ctx, params, optFns...)
+	})
+}
+
+func (c *timeoutS3Client) PutBucketTagging(ctx context.Context, params *s3.PutBucketTaggingInput, optFns ...func(*s3.Options)) (*s3.PutBucketTaggingOutput, error) {
+	return withCallTimeout(ctx, c.timeout, func(ctx context.Context) (*s3.PutBucketTaggingOutput, error) {
+		return c.client.PutBucketTagging(ctx, params, optFns...)
+	})
+}
+
+func (c *timeoutS3Client) DeleteBucketTagging(ctx context.Context, params *s3.DeleteBucketTaggingInput, optFns ...func(*s3.Options)) (*s3.DeleteBucketTaggingOutput, error) {
+	return withCallTimeout(ctx, c.timeout, func(ctx context.Context) (*s3.DeleteBucketTaggingOutput, error) {
+		return c.client.DeleteBucketTagging(ctx, params, optFns...)
+	})
+}
+
+func (c *timeoutS3Client) PutBucketMetricsConfiguration(ctx context.Context, params *s3.PutBucketMetricsConfigurationInput, optFns ...func(*s3.Options)) (*s3.PutBucketMetricsConfigurationOutput, error) {
+	return withCallTimeout(ctx, c.timeout, func(ctx context.Context) (*s3.PutBucketMetricsConfigurationOutput, error) {
+		return c.client.PutBucketMetricsConfiguration(ctx, params, optFns...)
+	})
+}
+
+func (c *timeoutS3Client) DeleteBucketMetricsConfiguration(ctx context.Context, params *s3.DeleteBucketMetricsConfigurationInput, optFns ...func(*s3.Options)) (*s3.DeleteBucketMetricsConfigurationOutput, error) {
+	return withCallTimeout(ctx, c.timeout, func(ctx context.Context) (*s3.DeleteBucketMetricsConfigurationOutput, error) {
+		return c.client.DeleteBucketMetricsConfiguration(ctx, params, optFns...)
+	})
+}
+
+// timeoutR53Client bounds every call to the wrapped Route 53 client by timeout.
+type timeoutR53Client struct {
+	client  R53ClientAPI
+	timeout time.Duration
+}
+
+func (c *timeoutR53Client) CreateHostedZone(ctx context.Context, params *route53.CreateHostedZoneInput, optFns ...func(*route53.Options)) (*route53.CreateHostedZoneOutput, error) {
+	return withCallTimeout(ctx, c.timeout, func(ctx context.Context) (*route53.CreateHostedZoneOutput, error) {
+		return c.client.CreateHostedZone(ctx, params, optFns...)
+	})
+}
+
+func (c *timeoutR53Client) DeleteHostedZone(ctx context.Context, params *route53.DeleteHostedZoneInput, optFns ...func(*route53.Options)) (*route53.DeleteHostedZoneOutput, error) {
+	return withCallTimeout(ctx, c.timeout, func(ctx context.Context) (*route53.DeleteHostedZoneOutput, error) {
+		return c.client.DeleteHostedZone(ctx, params, optFns...)
+	})
+}
+
+func (c *timeoutR53Client) ChangeResourceRecordSets(ctx context.Context, params *route53.ChangeResourceRecordSetsInput, optFns ...func(*route53.Options)) (*route53.ChangeResourceRecordSetsOutput, error) {
+	return withCallTimeout(ctx, c.timeout, func(ctx context.Context) (*route53.ChangeResourceRecordSetsOutput, error) {
+		return c.client.ChangeResourceRecordSets(ctx, params, optFns...)
+	})
+}
+
+func (c *timeoutR53Client) ListResourceRecordSets(ctx context.Context, params *route53.ListResourceRecordSetsInput, optFns ...func(*route53.Options)) (*route53.ListResourceRecordSetsOutput, error) {
+	return withCallTimeout(ctx, c.timeout, func(ctx context.Context) (*route53.ListResourceRecordSetsOutput, error) {
+		return c.client.ListResourceRecordSets(ctx, params, optFns...)
+	})
+}
+
+func (c *timeoutR53Client) ListHostedZonesByName(ctx context.Context, params *route53.ListHostedZonesByNameInput, optFns ...func(*route53.Options)) (*route53.ListHostedZonesByNameOutput, error) {
+	return withCallTimeout(ctx, c.timeout, func(ctx context.Context) (*route53.ListHostedZonesByNameOutput, error) {
+		return c.client.ListHostedZonesByName(ctx, params, optFns...)
+	})
+}
+
+func (c *timeoutR53Client) GetHostedZone(ctx context.Context, params *route53.GetHostedZoneInput, optFns ...func(*route53.Options)) (*route53.GetHostedZoneOutput, error) {
+	return withCallTimeout(ctx, c.timeout, func(ctx context.Context) (*route53.GetHostedZoneOutput, error) {
+		return c.client.GetHostedZone(ctx, params, optFns...)
+	})
+}
+
+func (c *timeoutR53Client) GetChange(ctx context.Context, params *route53.GetChangeInput, optFns ...func(*route53.Options)) (*route53.GetChangeOutput, error) {
+	return withCallTimeout(ctx, c.timeout, func(ctx context.Context) (*route53.GetChangeOutput, error) {
+		return c.client.GetChange(ctx, params, optFns...)
+	})
+}
+
+func (c *timeoutR53Client) GetReusableDelegationSet(ctx context.Context, params *route53.GetReusableDelegationSetInput, optFns ...func(*route53.Options)) (*route53.GetReusableDelegationSetOutput, error) {
+	return withCallTimeout(ctx, c.timeout, func(ctx context.Context) (*route53.GetReusableDelegationSetOutput, error) {
+		return c.client.GetReusableDelegationSet(ctx, params, optFns...)
+	})
+}
+
+func (c *timeoutR53Client) CreateQueryLoggingConfig(ctx context.Context, params *route53.CreateQueryLoggingConfigInput, optFns ...func(*route53.Options)) (*route53.CreateQueryLoggingConfigOutput, error) {
+	return withCallTimeout(ctx, c.timeout, func(ctx context.Context) (*route53.CreateQueryLoggingConfigOutput, error) {
+		return c.client.CreateQueryLoggingConfig(ctx, params, optFns...)
+	})
+}
+
+func (c *timeoutR53Client) GetQueryLoggingConfig(ctx context.Context, params *route53.GetQueryLoggingConfigInput, optFns ...func(*route53.Options)) (*route53.GetQueryLoggingConfigOutput, error) {
+	return withCallTimeout(ctx, c.timeout, func(ctx context.Context) (*route53.GetQueryLoggingConfigOutput, error) {
+		return c.client.GetQueryLoggingConfig(ctx, params, optFns...)
+	})
+}
+
+func (c *timeoutR53Client) ListQueryLoggingConfigs(ctx context.Context, params *route53.ListQueryLoggingConfigsInput, optFns ...func(*route53.Options)) (*route53.ListQueryLoggingConfigsOutput, error) {
+	return withCallTimeout(ctx, c.timeout, func(ctx context.Context) (*route53.ListQueryLoggingConfigsOutput, error) {
+		return c.client.ListQueryLoggingConfigs(ctx, params, optFns...)
+	})
+}
+
+func (c *timeoutR53Client) DeleteQueryLoggingConfig(ctx context.Context, params *route53.DeleteQueryLoggingConfigInput, optFns ...func(*route53.Options)) (*route53.DeleteQueryLoggingConfigOutput, error) {
+	return withCallTimeout(ctx, c.timeout, func(ctx context.Context) (*route53.DeleteQueryLoggingConfigOutput, error) {
+		return c.client.DeleteQueryLoggingConfig(ctx, params, optFns...)
+	})
+}
+
+func (c *timeoutR53Client) ListTagsForResource(ctx context.Context, params *route53.ListTagsForResourceInput, optFns ...func(*route53.Options)) (*route53.ListTagsForResourceOutput, error) {
+	return withCallTimeout(ctx, c.timeout, func(ctx context.Context) (*route53.ListTagsForResourceOutput, error) {
+		return c.client.ListTagsForResource(ctx, params, optFns...)
+	})
+}
+
+func (c *timeoutR53Client) ChangeTagsForResource(ctx context.Context, params *route53.ChangeTagsForResourceInput, optFns ...func(*route53.Options)) (*route53.ChangeTagsForResourceOutput, error) {
+	return withCallTimeout(ctx, c.timeout, func(ctx context.Context) (*route53.ChangeTagsForResourceOutput, error) {
+		return c.client.ChangeTagsForResource(ctx, params, optFns...)
+	})
+}
+
+func (c *timeoutR53Client) AssociateVPCWithHostedZone(ctx context.Context, params *route53.AssociateVPCWithHostedZoneInput, optFns ...func(*route53.Options)) (*route53.AssociateVPCWithHostedZoneOutput, error) {
+	return withCallTimeout(ctx, c.timeout, func(ctx context.Context) (*route53.AssociateVPCWithHostedZoneOutput, error) {
+		return c.client.AssociateVPCWithHostedZone(ctx, params, optFns...)
+	})
+}
+
+func (c *timeoutR53Client) DisassociateVPCFromHostedZone(ctx context.Context, params *route53.DisassociateVPCFromHostedZoneInput, optFns ...func(*route53.Options)) (*route53.DisassociateVPCFromHostedZoneOutput, error) {
+	return withCallTimeout(ctx, c.timeout, func(ctx context.Context) (*route53.DisassociateVPCFromHostedZoneOutput, error) {
+		return c.client.DisassociateVPCFromHostedZone(ctx, params, optFns...)
+	})
+}
+
+// timeoutCloudWatchClient bounds every call to the wrapped CloudWatch client by timeout.
+type timeoutCloudWatchClient struct {
+	client  CloudWatchClientAPI
+	timeout time.Duration
+}
+
+func (c *timeoutCloudWatchClient) PutMetricAlarm(ctx context.Context, params *cloudwatch.PutMetricAlarmInput, optFns ...func(*cloudwatch.Options)) (*cloudwatch.PutMetricAlarmOutput, error) {
+	return withCallTimeout(ctx, c.timeout, func(ctx context.Context) (*cloudwatch.PutMetricAlarmOutput, error) {
+		return c.client.PutMetricAlarm(ctx, params, optFns...)
+	})
+}
+
+func (c *timeoutCloudWatchClient) DeleteAlarms(ctx context.Context, params *cloudwatch.DeleteAlarmsInput, optFns ...func(*cloudwatch.Options)) (*cloudwatch.DeleteAlarmsOutput, error) {
+	return withCallTimeout(ctx, c.timeout, func(ctx context.Context) (*cloudwatch.DeleteAlarmsOutput, error) {
+		return c.client.DeleteAlarms(ctx, params, optFns...)
+	})
+}
+
+// timeoutDNSFirewallClient bounds every call to the wrapped DNS Firewall client by timeout.
+type timeoutDNSFirewallClient struct {
+	client  DNSFirewallClientAPI
+	timeout time.Duration
+}
+
+func (c *timeoutDNSFirewallClient) AssociateFirewallRuleGroup(ctx context.Context, params *AssociateFirewallRuleGroupInput) (*AssociateFirewallRuleGroupOutput, error) {
+	return withCallTimeout(ctx, c.timeout, func(ctx context.Context) (*AssociateFirewallRuleGroupOutput, error) {
+		return c.client.AssociateFirewallRuleGroup(ctx, params)
+	})
+}
+
+func (c *timeoutDNSFirewallClient) GetFirewallRuleGroupAssociation(ctx context.Context, params *GetFirewallRuleGroupAssociationInput) (*GetFirewallRuleGroupAssociationOutput, error) {
+	return withCallTimeout(ctx, c.timeout, func(ctx context.Context) (*GetFirewallRuleGroupAssociationOutput, error) {
+		return c.client.GetFirewallRuleGroupAssociation(ctx, params)
+	})
+}
+
+func (c *timeoutDNSFirewallClient) ListFirewallRuleGroupAssociations(ctx context.Context, params *ListFirewallRuleGroupAssociationsInput) (*ListFirewallRuleGroupAssociationsOutput, error) {
+	return withCallTimeout(ctx, c.timeout, func(ctx context.Context) (*ListFirewallRuleGroupAssociationsOutput, error) {
+		return c.client.ListFirewallRuleGroupAssociations(ctx, params)
+	})
+}
+
+func (c *timeoutDNSFirewallClient) DisassociateFirewallRuleGroup(ctx context.Context, params *DisassociateFirewallRuleGroupInput) (*DisassociateFirewallRuleGroupOutput, error) {
+	return withCallTimeout(ctx, c.timeout, func(ctx context.Context) (*DisassociateFirewallRuleGroupOutput, error) {
+		return c.client.DisassociateFirewallRuleGroup(ctx, params)
+	})
+}
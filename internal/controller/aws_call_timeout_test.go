@@ -0,0 +1,95 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"os"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/route53"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	parkingv1alpha1 "github.com/gminiba/parked-domain-operator/api/v1alpha1"
+)
+
+var _ = Describe("AWS call timeout", func() {
+	// hang blocks until the call's context is done, like a request that never gets a response.
+	hang := func(ctx context.Context, params *s3.HeadBucketInput, optFns ...func(*s3.Options)) (*s3.HeadBucketOutput, error) {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+
+	It("should fail a hung S3 call once the timeout fires", func() {
+		s3Client := &timeoutS3Client{client: &MockS3Client{HeadBucketFunc: hang}, timeout: 10 * time.Millisecond}
+
+		_, err := s3Client.HeadBucket(context.Background(), &s3.HeadBucketInput{})
+		Expect(err).To(MatchError(context.DeadlineExceeded))
+	})
+
+	It("should fail a hung Route 53 call once the timeout fires", func() {
+		r53Client := &timeoutR53Client{client: &MockR53Client{
+			GetHostedZoneFunc: func(ctx context.Context, params *route53.GetHostedZoneInput, optFns ...func(*route53.Options)) (*route53.GetHostedZoneOutput, error) {
+				<-ctx.Done()
+				return nil, ctx.Err()
+			},
+		}, timeout: 10 * time.Millisecond}
+
+		_, err := r53Client.GetHostedZone(context.Background(), &route53.GetHostedZoneInput{})
+		Expect(err).To(MatchError(context.DeadlineExceeded))
+	})
+
+	It("should still cancel the call with the reconcile", func() {
+		s3Client := &timeoutS3Client{client: &MockS3Client{HeadBucketFunc: hang}, timeout: time.Hour}
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		_, err := s3Client.HeadBucket(ctx, &s3.HeadBucketInput{})
+		Expect(err).To(MatchError(context.Canceled))
+	})
+
+	It("should fail the bucket step on a hung call instead of blocking the reconcile", func() {
+		Expect(os.Setenv("TEMPLATE_CONFIGMAP_NAME", "parked-domain-templates")).To(Succeed())
+		DeferCleanup(os.Unsetenv, "TEMPLATE_CONFIGMAP_NAME")
+		ctx := context.Background()
+		pd := &parkingv1alpha1.ParkedDomain{
+			ObjectMeta: metav1.ObjectMeta{Name: "hung-domain", Namespace: "default"},
+			Spec:       parkingv1alpha1.ParkedDomainSpec{DomainName: "hung.example.com"},
+		}
+		templateCM := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "parked-domain-templates", Namespace: "default"},
+			Data:       map[string]string{"default.html": "<h1>{{DOMAIN_NAME}}</h1>"},
+		}
+		r := newTestReconciler(&MockR53Client{}, &MockS3Client{HeadBucketFunc: hang}, pd, templateCM)
+		r.AWSCallTimeout = 10 * time.Millisecond
+		req := ctrl.Request{NamespacedName: types.NamespacedName{Name: "hung-domain", Namespace: "default"}}
+
+		_, err := r.Reconcile(ctx, req)
+		Expect(err).To(MatchError(context.DeadlineExceeded))
+
+		failed := &parkingv1alpha1.ParkedDomain{}
+		Expect(r.Get(ctx, req.NamespacedName, failed)).To(Succeed())
+		Expect(meta.IsStatusConditionFalse(failed.Status.Conditions, parkingv1alpha1.ConditionBucketReady)).To(BeTrue())
+	})
+})
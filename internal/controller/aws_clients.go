@@ -3,18 +3,100 @@ package controller
 import (
 	"context"
 	"fmt"
+	"sync"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials/stscreds"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatch"
+	"github.com/aws/aws-sdk-go-v2/service/route53"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
 )
 
 // AWSS3ClientFactory creates real AWS S3 clients.
-type AWSS3ClientFactory struct{}
+type AWSS3ClientFactory struct {
+	// UsePathStyle addresses buckets as <endpoint>/<bucket> instead of <bucket>.<endpoint>,
+	// as most S3-compatible services expect.
+	UsePathStyle bool
+}
+
+func (f *AWSS3ClientFactory) GetClient(ctx context.Context, region, roleARN, endpoint string) (S3ClientAPI, error) {
+	cfg, err := loadAWSConfig(ctx, region, roleARN)
+	if err != nil {
+		return nil, err
+	}
+	return s3.NewFromConfig(cfg, func(o *s3.Options) {
+		if endpoint != "" {
+			o.BaseEndpoint = aws.String(endpoint)
+		}
+		o.UsePathStyle = f.UsePathStyle
+	}), nil
+}
+
+// AWSR53ClientFactory creates real AWS Route 53 clients.
+type AWSR53ClientFactory struct{}
+
+func (f *AWSR53ClientFactory) GetClient(ctx context.Context, region, roleARN string) (R53ClientAPI, error) {
+	cfg, err := loadAWSConfig(ctx, region, roleARN)
+	if err != nil {
+		return nil, err
+	}
+	return route53.NewFromConfig(cfg), nil
+}
+
+// AWSCloudWatchClientFactory creates real AWS CloudWatch clients.
+type AWSCloudWatchClientFactory struct{}
+
+func (f *AWSCloudWatchClientFactory) GetClient(ctx context.Context, region, roleARN string) (CloudWatchClientAPI, error) {
+	cfg, err := loadAWSConfig(ctx, region, roleARN)
+	if err != nil {
+		return nil, err
+	}
+	return cloudwatch.NewFromConfig(cfg), nil
+}
+
+// awsConfigKey identifies the configs loadAWSConfig caches.
+type awsConfigKey struct {
+	region  string
+	roleARN string
+}
+
+// awsConfigs caches the configs loadAWSConfig loaded. Their credentials caches are shared by
+// every client created from them, so a role is only assumed again once its credentials
+// are about to expire, not on every reconcile. mu only guards configs; loading holds the
+// lock of the config's key, so a slow load only delays loads of the same config.
+var awsConfigs = struct {
+	mu      sync.Mutex
+	loading keyedMutex
+	configs map[awsConfigKey]aws.Config
+}{configs: map[awsConfigKey]aws.Config{}}
+
+// loadAWSConfig loads the default AWS config for region, once per region and roleARN. When
+// roleARN is set, the returned config uses credentials from assuming that role with the
+// default credentials. Clients created from it count their requests into the
+// parkeddomain_aws_rate metric.
+func loadAWSConfig(ctx context.Context, region, roleARN string) (aws.Config, error) {
+	key := awsConfigKey{region: region, roleARN: roleARN}
+	unlock := awsConfigs.loading.Lock(region + " " + roleARN)
+	defer unlock()
+	awsConfigs.mu.Lock()
+	cfg, ok := awsConfigs.configs[key]
+	awsConfigs.mu.Unlock()
+	if ok {
+		return cfg.Copy(), nil
+	}
 
-func (f *AWSS3ClientFactory) GetClient(ctx context.Context, region string) (S3ClientAPI, error) {
 	cfg, err := config.LoadDefaultConfig(ctx, config.WithRegion(region))
 	if err != nil {
-		return nil, fmt.Errorf("failed to load AWS config for region %s: %w", region, err)
+		return aws.Config{}, fmt.Errorf("failed to load AWS config for region %s: %w", region, err)
+	}
+	if roleARN != "" {
+		cfg.Credentials = aws.NewCredentialsCache(stscreds.NewAssumeRoleProvider(sts.NewFromConfig(cfg), roleARN))
 	}
-	return s3.NewFromConfig(cfg), nil
+	RecordCallRates(&cfg)
+	awsConfigs.mu.Lock()
+	awsConfigs.configs[key] = cfg
+	awsConfigs.mu.Unlock()
+	return cfg.Copy(), nil
 }
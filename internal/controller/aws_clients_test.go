@@ -0,0 +1,57 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("AWS config loading", func() {
+	It("should share the credentials of a region and role across clients", func() {
+		ctx := context.Background()
+		roleARN := "arn:aws:iam::123456789012:role/parking"
+		first, err := loadAWSConfig(ctx, "eu-west-1", roleARN)
+		Expect(err).NotTo(HaveOccurred())
+		second, err := loadAWSConfig(ctx, "eu-west-1", roleARN)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(second.Credentials).To(BeIdenticalTo(first.Credentials))
+
+		By("keeping the configs of other regions and roles apart")
+		otherRegion, err := loadAWSConfig(ctx, "us-east-1", roleARN)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(otherRegion.Credentials).NotTo(BeIdenticalTo(first.Credentials))
+		otherRole, err := loadAWSConfig(ctx, "eu-west-1", "arn:aws:iam::123456789012:role/other")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(otherRole.Credentials).NotTo(BeIdenticalTo(first.Credentials))
+	})
+
+	It("should not make loads of other configs wait for a slow one", func() {
+		// A load of eu-west-1 stuck e.g. on the instance metadata service holds its key.
+		unlock := awsConfigs.loading.Lock("eu-west-1 ")
+		defer unlock()
+
+		loaded := make(chan error, 1)
+		go func() {
+			_, err := loadAWSConfig(context.Background(), "ap-southeast-2", "")
+			loaded <- err
+		}()
+		Eventually(loaded).Should(Receive(BeNil()))
+	})
+})
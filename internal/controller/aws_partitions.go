@@ -0,0 +1,153 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// awsPartition describes an AWS partition the operator can provision into.
+type awsPartition struct {
+	// ID is the partition identifier, as used in ARNs.
+	ID string
+	// DNSSuffix is the domain suffix of the partition's service endpoints.
+	DNSSuffix string
+	// Route53Region is the region Route 53 API calls are signed for in this partition.
+	Route53Region string
+}
+
+var (
+	partitionAWS      = awsPartition{ID: "aws", DNSSuffix: "amazonaws.com", Route53Region: "us-east-1"}
+	partitionAWSChina = awsPartition{ID: "aws-cn", DNSSuffix: "amazonaws.com.cn", Route53Region: "cn-northwest-1"}
+	partitionAWSGov   = awsPartition{ID: "aws-us-gov", DNSSuffix: "amazonaws.com", Route53Region: "us-gov-west-1"}
+)
+
+// s3WebsiteHostedZoneIDs maps regions to the canonical hosted zone ID of their S3 website endpoints.
+// Source: https://docs.aws.amazon.com/general/latest/gr/s3.html
+var s3WebsiteHostedZoneIDs = map[string]string{
+	"us-east-1": "Z3AQBSTGFYJSTF",
+	"us-east-2": "Z2O1EMRO9K5GLX",
+	"us-west-1": "Z2F56UZL2M1ACD",
+	"us-west-2": "Z3BJ6K6RIION7M",
+
+	"af-south-1": "Z83WF9RJE8B12",
+
+	"ap-east-1":      "ZNB98KWMFR0R6",
+	"ap-northeast-1": "Z2M4EHUR26P7ZW",
+	"ap-northeast-2": "Z3W03O7B5YMIYP",
+	"ap-northeast-3": "Z2YQB5RD63NC85",
+	"ap-south-1":     "Z11RGJOFQNVJUP",
+	"ap-south-2":     "Z02976202B4EZMXIPMXF7",
+	"ap-southeast-1": "Z3O0J2DXBE1FTB",
+	"ap-southeast-2": "Z1WCIGYICN2BYD",
+	"ap-southeast-3": "Z01846753K324LI26A3VV",
+	"ap-southeast-4": "Z0312387243XT5FE14WFO",
+
+	"ca-central-1": "Z1QDHH18159H29",
+
+	"eu-central-1": "Z21DNDUVLTQW6Q",
+	"eu-central-2": "Z030506016YDQGETNASS",
+	"eu-north-1":   "Z3BAZG2TWCNX0D",
+	"eu-south-1":   "Z30OZKI7KPW7MI",
+	"eu-south-2":   "Z0081959F7139GRJC19J",
+	"eu-west-1":    "Z1BKCTXD74EZPE",
+	"eu-west-2":    "Z3GKZC51ZF0DB4",
+	"eu-west-3":    "Z3R1K369G5AVDG",
+
+	"il-central-1": "Z09640613K4A3MN55U7GU",
+	"me-central-1": "Z06143092I8HRXZRUZROF",
+	"me-south-1":   "Z1MPMWCPA7YB62",
+
+	"sa-east-1": "Z7KQH4QJS55SO",
+
+	"cn-north-1":     "Z5CN8UMXT92WN",
+	"cn-northwest-1": "Z282HJ1KT0DH03",
+
+	"us-gov-east-1": "Z2NIFVYYW2VKV1",
+	"us-gov-west-1": "Z31GFT0UA1I2HV",
+}
+
+// cloudFrontHostedZoneID is the hosted zone ID of every CloudFront distribution.
+const cloudFrontHostedZoneID = "Z2FDTNDATAQYW2"
+
+// dashedWebsiteRegions are the regions whose S3 website endpoints use the legacy
+// "s3-website-<region>" form instead of "s3-website.<region>".
+var dashedWebsiteRegions = map[string]bool{
+	"us-east-1":      true,
+	"us-west-1":      true,
+	"us-west-2":      true,
+	"ap-southeast-1": true,
+	"ap-southeast-2": true,
+	"ap-northeast-1": true,
+	"eu-west-1":      true,
+	"sa-east-1":      true,
+	"us-gov-west-1":  true,
+}
+
+// partitionForRegion returns the AWS partition a region belongs to. Isolated
+// partitions (ISO, ISOB, ISOE, ...) are not supported.
+func partitionForRegion(region string) (awsPartition, error) {
+	switch {
+	case strings.HasPrefix(region, "cn-"):
+		return partitionAWSChina, nil
+	case strings.HasPrefix(region, "us-gov-"):
+		return partitionAWSGov, nil
+	case strings.Contains(region, "-iso"):
+		return awsPartition{}, fmt.Errorf("region %s belongs to an unsupported AWS partition", region)
+	default:
+		return partitionAWS, nil
+	}
+}
+
+// getS3WebsiteHostedZoneID returns the canonical hosted zone ID for S3 website endpoints for a given
+// region, or an error for regions without S3 website endpoints Route 53 can alias.
+func getS3WebsiteHostedZoneID(region string) (string, error) {
+	if id, ok := s3WebsiteHostedZoneIDs[region]; ok {
+		return id, nil
+	}
+	return "", fmt.Errorf("region %s has no S3 website endpoint Route 53 can alias, supported regions are %s",
+		region, strings.Join(supportedRegions(), ", "))
+}
+
+// customWebsiteEndpoint returns the endpoint and page URL of a bucket on an S3-compatible
+// service. Such services have no separate website endpoints, so the page is read through
+// the S3 API, which the bucket policy opens for anonymous reads.
+func customWebsiteEndpoint(endpoint, bucketName string, pathStyle bool) (string, string, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil || u.Host == "" || (u.Scheme != "http" && u.Scheme != "https") {
+		return "", "", fmt.Errorf("invalid S3 endpoint %q", endpoint)
+	}
+	address := bucketName + "." + u.Host
+	if pathStyle {
+		address = u.Host + "/" + bucketName
+	}
+	return address, u.Scheme + "://" + address + "/" + indexDocument, nil
+}
+
+// s3WebsiteEndpoint returns the static website endpoint of a bucket in the given region.
+func s3WebsiteEndpoint(bucketName, region string) (string, error) {
+	partition, err := partitionForRegion(region)
+	if err != nil {
+		return "", err
+	}
+	if dashedWebsiteRegions[region] {
+		return fmt.Sprintf("%s.s3-website-%s.%s", bucketName, region, partition.DNSSuffix), nil
+	}
+	return fmt.Sprintf("%s.s3-website.%s.%s", bucketName, region, partition.DNSSuffix), nil
+}
@@ -0,0 +1,124 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	parkingv1alpha1 "github.com/gminiba/parked-domain-operator/api/v1alpha1"
+)
+
+// MockR53ClientFactory records the region and role it was asked for and returns a fixed mock client.
+type MockR53ClientFactory struct {
+	MockR53          R53ClientAPI
+	RequestedRegion  string
+	RequestedRoleARN string
+}
+
+func (f *MockR53ClientFactory) GetClient(ctx context.Context, region, roleARN string) (R53ClientAPI, error) {
+	f.RequestedRegion = region
+	f.RequestedRoleARN = roleARN
+	return f.MockR53, nil
+}
+
+// regionEntries returns a table entry for every region with a known S3 website hosted zone.
+func regionEntries() []TableEntry {
+	var entries []TableEntry
+	for _, region := range supportedRegions() {
+		entries = append(entries, Entry(region, region))
+	}
+	return entries
+}
+
+var _ = Describe("AWS partitions", func() {
+	DescribeTable("resolving the S3 website endpoint of a bucket",
+		func(region, partitionID, endpoint string) {
+			partition, err := partitionForRegion(region)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(partition.ID).To(Equal(partitionID))
+
+			Expect(s3WebsiteEndpoint("parked.example.com", region)).To(Equal(endpoint))
+			Expect(getS3WebsiteHostedZoneID(region)).NotTo(BeEmpty())
+		},
+		Entry("standard partition", "eu-central-1", "aws", "parked.example.com.s3-website.eu-central-1.amazonaws.com"),
+		Entry("standard partition with legacy endpoint", "us-east-1", "aws", "parked.example.com.s3-website-us-east-1.amazonaws.com"),
+		Entry("standard partition with legacy endpoint outside the US", "ap-southeast-1", "aws", "parked.example.com.s3-website-ap-southeast-1.amazonaws.com"),
+		Entry("China partition", "cn-north-1", "aws-cn", "parked.example.com.s3-website.cn-north-1.amazonaws.com.cn"),
+		Entry("GovCloud partition", "us-gov-west-1", "aws-us-gov", "parked.example.com.s3-website-us-gov-west-1.amazonaws.com"),
+	)
+
+	DescribeTable("resolving the S3 website hosted zone of every supported region",
+		func(region string) {
+			id, err := getS3WebsiteHostedZoneID(region)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(id).To(MatchRegexp(`^Z[0-9A-Z]+$`))
+			Expect(s3WebsiteEndpoint("parked.example.com", region)).NotTo(BeEmpty())
+		},
+		regionEntries(),
+	)
+
+	It("should support the commonly used regions", func() {
+		Expect(supportedRegions()).To(ContainElements("us-east-2", "ap-south-1", "ap-southeast-1", "ca-central-1", "eu-north-1", "eu-west-2", "eu-west-3"))
+	})
+
+	It("should name the supported regions for a region without S3 website endpoints", func() {
+		_, err := getS3WebsiteHostedZoneID("mars-1")
+		Expect(err).To(MatchError(And(ContainSubstring("mars-1"), ContainSubstring("eu-central-1"))))
+	})
+
+	DescribeTable("resolving the page of a bucket on an S3-compatible service",
+		func(endpoint string, pathStyle bool, address, pageURL string) {
+			gotAddress, gotURL, err := customWebsiteEndpoint(endpoint, "parked.example.com", pathStyle)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(gotAddress).To(Equal(address))
+			Expect(gotURL).To(Equal(pageURL))
+		},
+		Entry("path-style", "http://minio.internal:9000", true,
+			"minio.internal:9000/parked.example.com", "http://minio.internal:9000/parked.example.com/index.html"),
+		Entry("virtual-hosted", "https://s3.wasabisys.com/", false,
+			"parked.example.com.s3.wasabisys.com", "https://parked.example.com.s3.wasabisys.com/index.html"),
+	)
+
+	It("should reject S3 endpoints that are not http or https URLs", func() {
+		_, _, err := customWebsiteEndpoint("minio.internal:9000", "parked.example.com", true)
+		Expect(err).To(MatchError(ContainSubstring("invalid S3 endpoint")))
+	})
+
+	It("should reject regions in isolated partitions", func() {
+		_, err := partitionForRegion("us-iso-east-1")
+		Expect(err).To(MatchError(ContainSubstring("unsupported AWS partition")))
+	})
+
+	It("should use a partition-specific Route 53 client outside the standard partition", func() {
+		partitionR53 := &MockR53Client{}
+		factory := &MockR53ClientFactory{MockR53: partitionR53}
+		r := &ParkedDomainReconciler{R53Client: &MockR53Client{}, R53ClientFactory: factory}
+		pd := &parkingv1alpha1.ParkedDomain{
+			ObjectMeta: metav1.ObjectMeta{Name: "china", Namespace: "default"},
+			Spec:       parkingv1alpha1.ParkedDomainSpec{DomainName: "parked.example.cn", Region: "cn-north-1"},
+		}
+
+		r53Client, err := r.r53ClientFor(context.Background(), pd)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(r53Client).To(BeIdenticalTo(partitionR53))
+		Expect(factory.RequestedRegion).To(Equal("cn-northwest-1"))
+	})
+})
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
 	"strings"
 	"time"
 
@@ -11,165 +12,807 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/route53"
 	r53types "github.com/aws/aws-sdk-go-v2/service/route53/types"
 	parkingv1alpha1 "github.com/gminiba/parked-domain-operator/api/v1alpha1"
+	"github.com/gminiba/parked-domain-operator/internal/awserr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 )
 
-// getS3WebsiteHostedZoneID returns the canonical hosted zone ID for S3 website endpoints for a given region.
-func getS3WebsiteHostedZoneID(region string) string {
-	// Source: https://docs.aws.amazon.com/general/latest/gr/s3.html
-	s3HostedZoneIDs := map[string]string{
-		"us-east-1": "Z3AQBSTGFYJSTF",
-		"us-west-1": "Z2F56UZL2M1ACD",
-		"us-west-2": "Z3BJ6K6RIION7M",
-		"eu-west-1": "Z1BKCTXD74EZPE",
+const (
+	// managedComment marks Hosted Zones and change batches created by the operator.
+	managedComment = "Managed by ParkedDomain Operator"
+	// maxChangeCommentLength is the longest comment Route 53 accepts on a change batch.
+	maxChangeCommentLength = 256
+	// managedCallerReferencePrefix starts the caller reference of every Hosted Zone the
+	// operator creates. Zones created before managedComment was set carry only this.
+	managedCallerReferencePrefix = "parkeddomain-operator-"
+	// recordCleanupBatchSize bounds the number of record deletions sent in one change batch.
+	recordCleanupBatchSize = 10
+	// placeholderTTL is the TTL, in seconds, of the record pointing at Spec.PlaceholderAddress,
+	// short so resolvers pick up the alias record soon after it replaces it.
+	placeholderTTL = 60
+	// placeholderReason marks a RecordReady condition whose record points at Spec.PlaceholderAddress.
+	placeholderReason = "PlaceholderAddress"
+	// parentDelegationTTL is the TTL, in seconds, of the NS records delegating a domain from its parent zone.
+	parentDelegationTTL = 172800
+	// changeSyncPollDelay is how soon cleanup checks again whether a change batch is INSYNC.
+	changeSyncPollDelay = 10 * time.Second
+	// conflictListSize bounds the records listed at a name when looking for the ones in the way
+	// of an upsert.
+	conflictListSize = 10
+)
 
-		"eu-central-1": "Z21DNDUVLTQW6Q",
-		// ... add other regions as needed
-	}
-	return s3HostedZoneIDs[region]
-}
+// errRecordConflict is returned when Route 53 rejects the ParkedDomain's records because of
+// records at their names that the operator could not replace.
+var errRecordConflict = errors.New("records in the Hosted Zone conflict with the parked page records")
 
-// reconcileRoute53Zone ensures the Hosted Zone exists and returns its ID and nameservers.
+// reconcileRoute53ARecord points the domain's A record at the website endpoint or Spec.AliasTarget.
+// While the bucket has no website endpoint yet, the record points at Spec.PlaceholderAddress
+// if one is set, and is swapped for the alias record once the endpoint exists.
 func (r *ParkedDomainReconciler) reconcileRoute53ARecord(ctx context.Context, pd *parkingv1alpha1.ParkedDomain, zoneID, s3Endpoint string) error {
 	logger := log.FromContext(ctx)
 
-	region := pd.Spec.Region
-	if region == "" {
-		region = DefaultRegion
+	r53Client, err := r.r53ClientFor(ctx, pd)
+	if err != nil {
+		return err
 	}
 
-	s3HostedZoneID := getS3WebsiteHostedZoneID(region)
-	if s3HostedZoneID == "" {
-		return fmt.Errorf("unsupported S3 website region for alias record: %s", region)
+	placeholder := s3Endpoint == "" && storageEnabled(pd) && pd.Spec.PlaceholderAddress != ""
+	record := r53types.ResourceRecordSet{Type: r53types.RRTypeA}
+	if placeholder {
+		record.TTL = aws.Int64(placeholderTTL)
+		record.ResourceRecords = []r53types.ResourceRecord{{Value: aws.String(pd.Spec.PlaceholderAddress)}}
+	} else {
+		record.AliasTarget, err = r.aliasTargetFor(pd, s3Endpoint)
+		if err != nil {
+			return err
+		}
 	}
 
-	changeBatch := &r53types.ChangeBatch{
-		Comment: aws.String("Managed by ParkedDomain Operator"),
-		Changes: []r53types.Change{
-			{
-				Action: r53types.ChangeActionUpsert,
-				ResourceRecordSet: &r53types.ResourceRecordSet{
-					Name: aws.String(pd.Spec.DomainName),
-					Type: "A",
-					AliasTarget: &r53types.AliasTarget{
-						HostedZoneId:         aws.String(s3HostedZoneID),
-						DNSName:              aws.String(s3Endpoint),
-						EvaluateTargetHealth: false,
-					},
-				},
-			},
-		},
+	names := []string{recordNameFor(pd)}
+	if pd.Spec.Wildcard {
+		names = append(names, wildcardRecordNameFor(pd))
+	} else if err := deleteParkedPageRecordNamed(ctx, r53Client, pd, wildcardRecordNameFor(pd)); err != nil {
+		return err
+	}
+	changeBatch := &r53types.ChangeBatch{Comment: aws.String(changeComment(pd))}
+	for _, name := range names {
+		recordSet := record
+		recordSet.Name = aws.String(name)
+		owner := ownerRecordFor(pd, name)
+		changeBatch.Changes = append(changeBatch.Changes,
+			r53types.Change{Action: r53types.ChangeActionUpsert, ResourceRecordSet: &recordSet},
+			r53types.Change{Action: r53types.ChangeActionUpsert, ResourceRecordSet: &owner},
+		)
+	}
+
+	if err := r.upsertRecords(ctx, r53Client, pd, zoneID, changeBatch); err != nil {
+		return fmt.Errorf("failed to create/update A record: %w", err)
+	}
+	if placeholder {
+		logger.Info("Pointed Route 53 A record at the placeholder address until the bucket is ready", "address", pd.Spec.PlaceholderAddress)
+		return nil
+	}
+
+	// S3 website endpoints only serve plain HTTP, API Gateway and CloudFront serve HTTPS.
+	scheme := "http://"
+	if at := pd.Spec.AliasTarget; at != nil && at.Type != parkingv1alpha1.AliasTargetLoadBalancer {
+		scheme = "https://"
 	}
+	pd.Status.WebsiteURL = scheme + recordNameFor(pd)
+
+	logger.Info("Successfully reconciled Route 53 A record")
+	return nil
+}
 
-	_, err := r.R53Client.ChangeResourceRecordSets(ctx, &route53.ChangeResourceRecordSetsInput{
+// upsertRecords applies changeBatch. When Route 53 rejects it because records at the upserted
+// names cannot coexist with them, e.g. a CNAME left from before the domain was parked, the batch
+// is sent again with those records deleted first, so the names are never left without a record.
+func (r *ParkedDomainReconciler) upsertRecords(ctx context.Context, r53Client R53ClientAPI, pd *parkingv1alpha1.ParkedDomain, zoneID string, changeBatch *r53types.ChangeBatch) error {
+	_, err := r53Client.ChangeResourceRecordSets(ctx, &route53.ChangeResourceRecordSetsInput{
 		HostedZoneId: aws.String(zoneID),
 		ChangeBatch:  changeBatch,
 	})
-	if err != nil {
-		return fmt.Errorf("failed to create/update A record: %w", err)
+	var icb *r53types.InvalidChangeBatch
+	if !errors.As(err, &icb) {
+		return err
 	}
 
-	logger.Info("Successfully reconciled Route 53 A record", "DomainName", pd.Spec.DomainName)
+	conflicts, listErr := conflictingRecords(ctx, r53Client, zoneID, changeBatch.Changes)
+	if listErr != nil {
+		return errors.Join(err, listErr)
+	}
+	if len(conflicts) == 0 {
+		return fmt.Errorf("%w: %w", errRecordConflict, err)
+	}
+	retry := &r53types.ChangeBatch{Comment: changeBatch.Comment}
+	for i := range conflicts {
+		retry.Changes = append(retry.Changes, r53types.Change{Action: r53types.ChangeActionDelete, ResourceRecordSet: &conflicts[i]})
+	}
+	retry.Changes = append(retry.Changes, changeBatch.Changes...)
+	if _, err := r53Client.ChangeResourceRecordSets(ctx, &route53.ChangeResourceRecordSetsInput{
+		HostedZoneId: aws.String(zoneID),
+		ChangeBatch:  retry,
+	}); err != nil {
+		return fmt.Errorf("%w: replacing %s: %w", errRecordConflict, describeRecords(conflicts), err)
+	}
+
+	message := fmt.Sprintf("Replaced %s, which conflicted with the parked page records", describeRecords(conflicts))
+	log.FromContext(ctx).Info(message)
+	r.recordEvent(pd, corev1.EventTypeWarning, "ConflictingRecordsReplaced", message)
 	return nil
 }
 
-// cleanupRoute53Zone cleans up records and deletes the Hosted Zone.
-func (r *ParkedDomainReconciler) cleanupRoute53Zone(ctx context.Context, pd *parkingv1alpha1.ParkedDomain) error {
+// conflictingRecords returns the records at the names changes upsert that Route 53 does not let
+// them coexist with: a CNAME, which allows no other record at its name, and records of the same
+// type with a routing policy, which a simple record cannot join.
+func conflictingRecords(ctx context.Context, r53Client R53ClientAPI, zoneID string, changes []r53types.Change) ([]r53types.ResourceRecordSet, error) {
+	var conflicts []r53types.ResourceRecordSet
+	for _, change := range changes {
+		upserted := change.ResourceRecordSet
+		name := aws.ToString(upserted.Name)
+		listOutput, err := r53Client.ListResourceRecordSets(ctx, &route53.ListResourceRecordSetsInput{
+			HostedZoneId:    aws.String(zoneID),
+			StartRecordName: aws.String(name),
+			MaxItems:        aws.Int32(conflictListSize),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list records in Hosted Zone: %w", err)
+		}
+		for _, record := range listOutput.ResourceRecordSets {
+			if !sameRecordName(aws.ToString(record.Name), name) {
+				break
+			}
+			if record.Type == r53types.RRTypeCname || (record.Type == upserted.Type && record.SetIdentifier != nil) {
+				conflicts = append(conflicts, record)
+			}
+		}
+	}
+	return conflicts, nil
+}
+
+// describeRecords returns the types and names of records, e.g. "CNAME record shop.example.com.".
+func describeRecords(records []r53types.ResourceRecordSet) string {
+	described := make([]string, 0, len(records))
+	for _, record := range records {
+		described = append(described, fmt.Sprintf("%s record %s", record.Type, aws.ToString(record.Name)))
+	}
+	return strings.Join(described, ", ")
+}
+
+// changeComment returns the comment of the ParkedDomain's change batches: managedComment,
+// followed by Spec.RecordComment when set.
+func changeComment(pd *parkingv1alpha1.ParkedDomain) string {
+	if pd.Spec.RecordComment == "" {
+		return managedComment
+	}
+	return managedComment + ": " + pd.Spec.RecordComment
+}
+
+// aliasTargetFor returns the target of the ParkedDomain's alias record: Spec.AliasTarget when
+// set, otherwise the bucket's S3 website endpoint.
+func (r *ParkedDomainReconciler) aliasTargetFor(pd *parkingv1alpha1.ParkedDomain, s3Endpoint string) (*r53types.AliasTarget, error) {
+	if at := pd.Spec.AliasTarget; at != nil {
+		return &r53types.AliasTarget{
+			HostedZoneId:         aws.String(at.HostedZoneID),
+			DNSName:              aws.String(at.DNSName),
+			EvaluateTargetHealth: at.Type == parkingv1alpha1.AliasTargetLoadBalancer,
+		}, nil
+	}
+
+	if endpoint := r.storageEndpointFor(pd); endpoint != "" {
+		return nil, fmt.Errorf("alias records cannot target the S3-compatible endpoint %s; set spec.dnsEnabled to false", endpoint)
+	}
+	region := regionFor(pd)
+	s3HostedZoneID, err := getS3WebsiteHostedZoneID(region)
+	if err != nil {
+		return nil, err
+	}
+	return &r53types.AliasTarget{
+		HostedZoneId:         aws.String(s3HostedZoneID),
+		DNSName:              aws.String(s3Endpoint),
+		EvaluateTargetHealth: false,
+	}, nil
+}
+
+// cleanupRoute53Zone cleans up records and deletes the Hosted Zone. It returns false while a
+// batch of record deletions is not INSYNC yet, with its change in Status.PendingChangeID, so
+// the caller can requeue instead of blocking the worker until Route 53 applies it.
+func (r *ParkedDomainReconciler) cleanupRoute53Zone(ctx context.Context, pd *parkingv1alpha1.ParkedDomain) (bool, error) {
 	logger := log.FromContext(ctx)
 	zoneID := pd.Status.ZoneID
 	if zoneID == "" {
 		logger.Info("ZoneID is empty, skipping Route 53 cleanup")
-		return nil
+		return true, nil
+	}
+	r.zones.forget(zoneID)
+
+	r53Client, err := r.r53ClientFor(ctx, pd)
+	if err != nil {
+		return false, err
+	}
+
+	if changeID := pd.Status.PendingChangeID; changeID != "" {
+		inSync, err := changeInSync(ctx, r53Client, changeID)
+		if err != nil {
+			return false, err
+		}
+		if !inSync {
+			logger.V(1).Info("Waiting for record deletions to become INSYNC", "changeID", changeID)
+			return false, nil
+		}
+		pd.Status.PendingChangeID = ""
+	}
+
+	// Only delete zones the operator created. An adopted or foreign zone keeps
+	// everything but the records the ParkedDomain created.
+	getZoneOutput, err := r53Client.GetHostedZone(ctx, &route53.GetHostedZoneInput{Id: aws.String(zoneID)})
+	if err != nil {
+		if awserr.IsNotFound(err) {
+			logger.Info("Hosted Zone not found, cleanup is considered successful.")
+			return true, nil
+		}
+		return false, fmt.Errorf("failed to get details for hosted zone: %w", err)
+	}
+	managed := isManagedZone(getZoneOutput.HostedZone)
+
+	// The operator created the query logging configuration even in a zone it did not.
+	if err := deleteQueryLogging(ctx, r53Client, pd); err != nil {
+		return false, err
 	}
 
-	logger.Info("Starting Route 53 Hosted Zone cleanup", "ZoneID", zoneID)
-	paginator := route53.NewListResourceRecordSetsPaginator(r.R53Client, &route53.ListResourceRecordSetsInput{HostedZoneId: aws.String(zoneID)})
-	var changes []r53types.Change
+	logger.Info("Starting Route 53 Hosted Zone cleanup", "managed", managed)
+	paginator := route53.NewListResourceRecordSetsPaginator(r53Client, &route53.ListResourceRecordSetsInput{HostedZoneId: aws.String(zoneID)})
+	var listed []r53types.ResourceRecordSet
 	for paginator.HasMorePages() {
 		page, err := paginator.NextPage(ctx)
+		if awserr.IsNotFound(err) {
+			// Deleted since GetHostedZone, e.g. by a concurrent cleanup or by hand.
+			logger.Info("Hosted Zone disappeared while listing its records, cleanup is considered successful.")
+			return true, nil
+		}
 		if err != nil {
-			return fmt.Errorf("failed to list records in Hosted Zone: %w", err)
+			return false, fmt.Errorf("failed to list records in Hosted Zone: %w", err)
 		}
 		for _, record := range page.ResourceRecordSets {
 			if record.Type != "NS" && record.Type != "SOA" {
-				changes = append(changes, r53types.Change{
-					Action:            r53types.ChangeActionDelete,
-					ResourceRecordSet: &record,
-				})
+				listed = append(listed, record)
 			}
 		}
 	}
 
-	if len(changes) > 0 {
-		_, err := r.R53Client.ChangeResourceRecordSets(ctx, &route53.ChangeResourceRecordSetsInput{
-			HostedZoneId: aws.String(zoneID),
-			ChangeBatch:  &r53types.ChangeBatch{Changes: changes},
-		})
-		if err != nil {
-			return fmt.Errorf("failed to delete records from Hosted Zone: %w", err)
+	// Records are the ParkedDomain's when an owner record marks their name, or, for records
+	// created before owner records were, when they point where the ParkedDomain points.
+	owned := map[string]bool{}
+	for _, record := range listed {
+		if isOwnerRecord(record, pd) {
+			owned[normalizeRecordName(ownedRecordName(record))] = true
+		}
+	}
+	var records []r53types.ResourceRecordSet
+	foreign := 0
+	for _, record := range listed {
+		ownedName := record.Type == r53types.RRTypeA && owned[normalizeRecordName(aws.ToString(record.Name))]
+		if isOwnerRecord(record, pd) || ownedName || isParkedPageRecord(record, pd) {
+			records = append(records, record)
+		} else {
+			foreign++
+		}
+	}
+
+	changeID, err := deleteRecordsInBatches(ctx, r53Client, zoneID, records)
+	if err != nil {
+		return false, err
+	}
+	if changeID != "" {
+		logger.Info("Deleted a batch of records, waiting for it to become INSYNC", "changeID", changeID)
+		pd.Status.PendingChangeID = changeID
+		return false, nil
+	}
+
+	if !managed || foreign > 0 {
+		if err := disassociateVPCs(ctx, r53Client, pd); err != nil {
+			return false, err
 		}
+		message := fmt.Sprintf("Hosted Zone %s was not created by the operator, removed the parked page record and kept the zone", zoneID)
+		if managed {
+			message = fmt.Sprintf("Hosted Zone %s holds %d records the operator did not create, removed the parked page record and kept the zone", zoneID, foreign)
+		}
+		logger.Info(message)
+		r.recordEvent(pd, corev1.EventTypeWarning, "HostedZoneRetained", message)
+		return true, nil
+	}
+
+	if err := r.deleteParentDelegation(ctx, pd); err != nil {
+		return false, err
 	}
 
-	_, err := r.R53Client.DeleteHostedZone(ctx, &route53.DeleteHostedZoneInput{Id: aws.String(zoneID)})
+	_, err = r53Client.DeleteHostedZone(ctx, &route53.DeleteHostedZoneInput{Id: aws.String(zoneID)})
 	if err != nil {
-		var nshze *r53types.NoSuchHostedZone
-		if !errors.As(err, &nshze) {
-			return fmt.Errorf("failed to delete Hosted Zone: %w", err)
+		if !awserr.IsNotFound(err) {
+			return false, fmt.Errorf("failed to delete Hosted Zone: %w", err)
 		}
 	}
 
-	logger.Info("Route 53 Hosted Zone cleanup complete", "ZoneID", zoneID)
+	logger.Info("Route 53 Hosted Zone cleanup complete")
+	return true, nil
+}
+
+// deleteParkedPageRecord deletes the alias records pointing at the bucket's website endpoint,
+// including the wildcard one, if they exist, leaving the rest of the zone untouched.
+func (r *ParkedDomainReconciler) deleteParkedPageRecord(ctx context.Context, pd *parkingv1alpha1.ParkedDomain) error {
+	r53Client, err := r.r53ClientFor(ctx, pd)
+	if err != nil {
+		return err
+	}
+	for _, name := range []string{recordNameFor(pd), wildcardRecordNameFor(pd)} {
+		if err := deleteParkedPageRecordNamed(ctx, r53Client, pd, name); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
+// deleteParkedPageRecordNamed deletes the parked page alias record called name and its owner
+// record, if they exist.
+func deleteParkedPageRecordNamed(ctx context.Context, r53Client R53ClientAPI, pd *parkingv1alpha1.ParkedDomain, name string) error {
+	if err := deleteOwnerRecord(ctx, r53Client, pd, name); err != nil {
+		return err
+	}
+	listOutput, err := r53Client.ListResourceRecordSets(ctx, &route53.ListResourceRecordSetsInput{
+		HostedZoneId:    aws.String(pd.Status.ZoneID),
+		StartRecordName: aws.String(name),
+		StartRecordType: r53types.RRTypeA,
+		MaxItems:        aws.Int32(1),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list records in Hosted Zone: %w", err)
+	}
+	for _, record := range listOutput.ResourceRecordSets {
+		if isParkedPageRecord(record, pd) && sameRecordName(aws.ToString(record.Name), name) {
+			_, err := deleteRecords(ctx, r53Client, pd.Status.ZoneID, record)
+			return err
+		}
+	}
+	return nil
+}
+
+// reconcileParentDelegation points the NS records for the domain in its parent zone at the
+// nameservers of its Hosted Zone.
+func (r *ParkedDomainReconciler) reconcileParentDelegation(ctx context.Context, pd *parkingv1alpha1.ParkedDomain) error {
+	if !delegatesToParent(pd) || len(pd.Status.NameServers) == 0 {
+		return nil
+	}
+	r53Client, err := r.r53ClientFor(ctx, pd)
+	if err != nil {
+		return err
+	}
+	parentZoneID, err := parentZoneFor(ctx, r53Client, pd)
+	if err != nil {
+		return err
+	}
+	if parentZoneID == "" {
+		return fmt.Errorf("no public Hosted Zone found for a parent domain of %s", pd.Spec.DomainName)
+	}
+
+	records := make([]r53types.ResourceRecord, 0, len(pd.Status.NameServers))
+	for _, ns := range pd.Status.NameServers {
+		records = append(records, r53types.ResourceRecord{Value: aws.String(ns)})
+	}
+	_, err = r53Client.ChangeResourceRecordSets(ctx, &route53.ChangeResourceRecordSetsInput{
+		HostedZoneId: aws.String(parentZoneID),
+		ChangeBatch: &r53types.ChangeBatch{
+			Comment: aws.String(changeComment(pd)),
+			Changes: []r53types.Change{{
+				Action: r53types.ChangeActionUpsert,
+				ResourceRecordSet: &r53types.ResourceRecordSet{
+					Name:            aws.String(pd.Spec.DomainName),
+					Type:            r53types.RRTypeNs,
+					TTL:             aws.Int64(parentDelegationTTL),
+					ResourceRecords: records,
+				},
+			}},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delegate from parent zone %s: %w", parentZoneID, err)
+	}
+	log.FromContext(ctx).Info("Delegated domain from parent zone", "parentZoneID", parentZoneID)
+	return nil
+}
+
+// deleteParentDelegation deletes the NS records for the domain from its parent zone, if they exist.
+func (r *ParkedDomainReconciler) deleteParentDelegation(ctx context.Context, pd *parkingv1alpha1.ParkedDomain) error {
+	if !delegatesToParent(pd) {
+		return nil
+	}
+	r53Client, err := r.r53ClientFor(ctx, pd)
+	if err != nil {
+		return err
+	}
+	parentZoneID, err := parentZoneFor(ctx, r53Client, pd)
+	if err != nil || parentZoneID == "" {
+		return err
+	}
+
+	listOutput, err := r53Client.ListResourceRecordSets(ctx, &route53.ListResourceRecordSetsInput{
+		HostedZoneId:    aws.String(parentZoneID),
+		StartRecordName: aws.String(pd.Spec.DomainName),
+		StartRecordType: r53types.RRTypeNs,
+		MaxItems:        aws.Int32(1),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list records in parent zone %s: %w", parentZoneID, err)
+	}
+	for _, record := range listOutput.ResourceRecordSets {
+		if record.Type == r53types.RRTypeNs &&
+			strings.EqualFold(strings.TrimSuffix(aws.ToString(record.Name), "."), strings.TrimSuffix(pd.Spec.DomainName, ".")) {
+			if _, err := deleteRecords(ctx, r53Client, parentZoneID, record); err != nil {
+				return fmt.Errorf("failed to remove delegation from parent zone %s: %w", parentZoneID, err)
+			}
+		}
+	}
+	return nil
+}
+
+// delegatesToParent reports whether the domain is delegated from a parent zone.
+func delegatesToParent(pd *parkingv1alpha1.ParkedDomain) bool {
+	return pd.Spec.ParentZoneID != "" || pd.Spec.DelegateInParentZone
+}
+
+// parentZoneFor returns Spec.ParentZoneID or, with Spec.DelegateInParentZone, the ID of the
+// public Hosted Zone of the closest parent domain. It returns "" when no such zone exists.
+func parentZoneFor(ctx context.Context, r53Client R53ClientAPI, pd *parkingv1alpha1.ParkedDomain) (string, error) {
+	if pd.Spec.ParentZoneID != "" {
+		return pd.Spec.ParentZoneID, nil
+	}
+	name := strings.TrimSuffix(pd.Spec.DomainName, ".")
+	for {
+		_, parent, found := strings.Cut(name, ".")
+		if !found || !strings.Contains(parent, ".") {
+			return "", nil
+		}
+		name = parent
+
+		output, err := r53Client.ListHostedZonesByName(ctx, &route53.ListHostedZonesByNameInput{DNSName: aws.String(name)})
+		if err != nil {
+			return "", fmt.Errorf("failed to look up the parent zone %s: %w", name, err)
+		}
+		for _, zone := range output.HostedZones {
+			if !strings.EqualFold(aws.ToString(zone.Name), name+".") {
+				break
+			}
+			if zone.Config == nil || !zone.Config.PrivateZone {
+				return aws.ToString(zone.Id), nil
+			}
+		}
+	}
+}
+
+// isManagedZone reports whether the operator created the Hosted Zone, judged by its comment or,
+// for zones created before the comment was set, by its caller reference.
+func isManagedZone(zone *r53types.HostedZone) bool {
+	if zone == nil {
+		return false
+	}
+	if zone.Config != nil && aws.ToString(zone.Config.Comment) == managedComment {
+		return true
+	}
+	return strings.HasPrefix(aws.ToString(zone.CallerReference), managedCallerReferencePrefix)
+}
+
+// isParkedPageRecord reports whether record is an alias record the operator points at the
+// bucket's website endpoint or Spec.AliasTarget, or the record pointing at
+// Spec.PlaceholderAddress, at the zone apex or Spec.RecordName, or at the wildcard below it.
+func isParkedPageRecord(record r53types.ResourceRecordSet, pd *parkingv1alpha1.ParkedDomain) bool {
+	if record.Type != r53types.RRTypeA {
+		return false
+	}
+	name := aws.ToString(record.Name)
+	if !sameRecordName(name, recordNameFor(pd)) && !sameRecordName(name, wildcardRecordNameFor(pd)) {
+		return false
+	}
+	if record.AliasTarget == nil {
+		return pd.Spec.PlaceholderAddress != "" && len(record.ResourceRecords) == 1 &&
+			aws.ToString(record.ResourceRecords[0].Value) == pd.Spec.PlaceholderAddress
+	}
+	target := strings.TrimSuffix(aws.ToString(record.AliasTarget.DNSName), ".")
+	if pd.Status.Endpoint != "" && strings.EqualFold(target, pd.Status.Endpoint) {
+		return true
+	}
+	return pd.Spec.AliasTarget != nil && strings.EqualFold(target, strings.TrimSuffix(pd.Spec.AliasTarget.DNSName, "."))
+}
+
+// wildcardRecordNameFor returns the name of the wildcard record parking every host below
+// the ParkedDomain's record.
+func wildcardRecordNameFor(pd *parkingv1alpha1.ParkedDomain) string {
+	return "*." + recordNameFor(pd)
+}
+
+// sameRecordName reports whether two record names are equal, ignoring case, a trailing dot
+// and Route 53 returning the * of wildcard records escaped as \052.
+func sameRecordName(a, b string) bool {
+	return normalizeRecordName(a) == normalizeRecordName(b)
+}
+
+// normalizeRecordName returns name lower-cased, without a trailing dot and with the * of a
+// wildcard unescaped.
+func normalizeRecordName(name string) string {
+	return strings.ToLower(strings.TrimSuffix(strings.Replace(name, `\052`, "*", 1), "."))
+}
+
+// deleteRecordsInBatches deletes records in small change batches until Route 53 reports one
+// still PENDING, and returns the ID of that change, so the caller sends the next batch once it
+// is INSYNC. It returns "" once all records are deleted. When Route 53 rejects a batch, its
+// records are retried one at a time so the error names the offending record. Records deleted
+// before a failure stay deleted, so the next attempt only lists and retries what is left.
+func deleteRecordsInBatches(ctx context.Context, r53Client R53ClientAPI, zoneID string, records []r53types.ResourceRecordSet) (string, error) {
+	for start := 0; start < len(records); start += recordCleanupBatchSize {
+		batch := records[start:min(start+recordCleanupBatchSize, len(records))]
+		changeID, err := deleteRecords(ctx, r53Client, zoneID, batch...)
+		if err == nil {
+			if changeID != "" {
+				return changeID, nil
+			}
+			continue
+		}
+
+		var icb *r53types.InvalidChangeBatch
+		if !errors.As(err, &icb) {
+			return "", fmt.Errorf("failed to delete records from Hosted Zone: %w", err)
+		}
+		if len(batch) == 1 {
+			return "", fmt.Errorf("failed to delete %s record %s from Hosted Zone: %w", batch[0].Type, aws.ToString(batch[0].Name), err)
+		}
+		for _, record := range batch {
+			changeID, err := deleteRecords(ctx, r53Client, zoneID, record)
+			if err != nil {
+				return "", fmt.Errorf("failed to delete %s record %s from Hosted Zone: %w", record.Type, aws.ToString(record.Name), err)
+			}
+			if changeID != "" {
+				return changeID, nil
+			}
+		}
+	}
+	return "", nil
+}
+
+// changeInSync reports whether the Route 53 change changeID is INSYNC. A change Route 53 no
+// longer knows was applied long ago.
+func changeInSync(ctx context.Context, r53Client R53ClientAPI, changeID string) (bool, error) {
+	output, err := r53Client.GetChange(ctx, &route53.GetChangeInput{Id: aws.String(changeID)})
+	if awserr.IsNotFound(err) {
+		return true, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to get the status of Route 53 change %s: %w", changeID, err)
+	}
+	return output.ChangeInfo == nil || output.ChangeInfo.Status == r53types.ChangeStatusInsync, nil
+}
+
+// deleteRecords deletes records in a single change batch. It returns the ID of the change if
+// Route 53 has not applied it yet, without waiting for it.
+func deleteRecords(ctx context.Context, r53Client R53ClientAPI, zoneID string, records ...r53types.ResourceRecordSet) (string, error) {
+	changes := make([]r53types.Change, 0, len(records))
+	for i := range records {
+		changes = append(changes, r53types.Change{
+			Action:            r53types.ChangeActionDelete,
+			ResourceRecordSet: &records[i],
+		})
+	}
+
+	output, err := r53Client.ChangeResourceRecordSets(ctx, &route53.ChangeResourceRecordSetsInput{
+		HostedZoneId: aws.String(zoneID),
+		ChangeBatch:  &r53types.ChangeBatch{Changes: changes},
+	})
+	if err != nil {
+		return "", err
+	}
+	if output.ChangeInfo == nil || output.ChangeInfo.Status == r53types.ChangeStatusInsync {
+		return "", nil
+	}
+	return aws.ToString(output.ChangeInfo.Id), nil
+}
+
 func (r *ParkedDomainReconciler) reconcileRoute53Zone(ctx context.Context, pd *parkingv1alpha1.ParkedDomain) (string, []string, error) {
 	logger := log.FromContext(ctx)
 	domainName := pd.Spec.DomainName
 
+	// The zone in the status was confirmed by refreshNameServers, so it need not be found again.
+	if zoneID := pd.Status.ZoneID; zoneID != "" && r.zones.matches(zoneID, pd) {
+		logger.V(1).Info("Using the cached Hosted Zone", "zoneID", zoneID)
+		return zoneID, pd.Status.NameServers, nil
+	}
+
+	r53Client, err := r.r53ClientFor(ctx, pd)
+	if err != nil {
+		return "", nil, err
+	}
+
 	// Check if the Hosted Zone already exists.
 	listInput := &route53.ListHostedZonesByNameInput{
 		DNSName: aws.String(domainName),
 	}
-	listOutput, err := r.R53Client.ListHostedZonesByName(ctx, listInput)
+	listOutput, err := r53Client.ListHostedZonesByName(ctx, listInput)
 	if err != nil {
 		return "", nil, fmt.Errorf("failed to list hosted zones: %w", err)
 	}
 
-	// If a zone with the exact name is found, adopt it.
-	if len(listOutput.HostedZones) > 0 && *listOutput.HostedZones[0].Name == domainName+"." {
-		existingZone := listOutput.HostedZones[0]
+	// If a zone with the exact name and visibility is found, adopt it.
+	for _, existingZone := range listOutput.HostedZones {
+		if aws.ToString(existingZone.Name) != domainName+"." {
+			break
+		}
+		isPrivate := existingZone.Config != nil && existingZone.Config.PrivateZone
+		if isPrivate != (pd.Spec.PrivateZone != nil) {
+			continue
+		}
+
 		zoneID := strings.Replace(*existingZone.Id, "/hostedzone/", "", 1)
-		logger.Info("Found existing Route 53 Hosted Zone, adopting it.", "ZoneID", zoneID)
+		// The zone in the status had its nameservers refreshed at the start of this reconcile,
+		// so only a zone adopted for the first time needs another API call to get them.
+		if zoneID == pd.Status.ZoneID && len(pd.Status.NameServers) > 0 {
+			logger.V(1).Info("Hosted Zone already adopted, keeping its nameservers", "zoneID", zoneID)
+			return zoneID, pd.Status.NameServers, nil
+		}
 
 		// To get the nameservers for an existing zone, we need another API call.
-		getZoneOutput, err := r.R53Client.GetHostedZone(ctx, &route53.GetHostedZoneInput{Id: existingZone.Id})
+		getZoneOutput, err := r53Client.GetHostedZone(ctx, &route53.GetHostedZoneInput{Id: existingZone.Id})
 		if err != nil {
 			return "", nil, fmt.Errorf("failed to get details for existing hosted zone: %w", err)
 		}
+		if pd.Spec.PrivateZone != nil && !zoneHasVPC(getZoneOutput.VPCs, pd.Spec.PrivateZone.VPCID) {
+			continue
+		}
 
-		var nameservers []string
-		nameservers = append(nameservers, getZoneOutput.DelegationSet.NameServers...)
-
-		return zoneID, nameservers, nil
+		logger.Info("Found existing Route 53 Hosted Zone, adopting it.", "zoneID", zoneID)
+		r.zones.store(zoneID, isPrivate, getZoneOutput.VPCs)
+		return zoneID, delegationSetNameServers(getZoneOutput.DelegationSet), nil
 	}
 
 	// If no zone was found, proceed to create it.
 	logger.Info("No existing Hosted Zone found, creating a new one.")
-	callerReference := fmt.Sprintf("parkeddomain-operator-%s-%d", pd.Name, time.Now().Unix())
+	callerReference := fmt.Sprintf("%s%s-%d", managedCallerReferencePrefix, pd.Name, time.Now().Unix())
 	createZoneInput := &route53.CreateHostedZoneInput{
-		Name:            aws.String(domainName),
-		CallerReference: aws.String(callerReference),
+		Name:             aws.String(domainName),
+		CallerReference:  aws.String(callerReference),
+		HostedZoneConfig: &r53types.HostedZoneConfig{Comment: aws.String(managedComment)},
+	}
+	if privateZone := pd.Spec.PrivateZone; privateZone != nil {
+		vpcRegion := privateZone.VPCRegion
+		if vpcRegion == "" {
+			vpcRegion = regionFor(pd)
+		}
+		createZoneInput.HostedZoneConfig.PrivateZone = true
+		createZoneInput.VPC = &r53types.VPC{VPCId: aws.String(privateZone.VPCID), VPCRegion: r53types.VPCRegion(vpcRegion)}
+	} else if delegationSetID := r.delegationSetFor(pd); delegationSetID != "" {
+		if err := checkDelegationSet(ctx, r53Client, delegationSetID); err != nil {
+			return "", nil, err
+		}
+		createZoneInput.DelegationSetId = aws.String(delegationSetID)
 	}
 
-	createOutput, err := r.R53Client.CreateHostedZone(ctx, createZoneInput)
+	createOutput, err := r53Client.CreateHostedZone(ctx, createZoneInput)
 	if err != nil {
 		return "", nil, fmt.Errorf("failed to create Route 53 Hosted Zone: %w", err)
 	}
 
 	zoneID := strings.Replace(*createOutput.HostedZone.Id, "/hostedzone/", "", 1)
+	logger.Info("Successfully created Route 53 Hosted Zone", "zoneID", zoneID)
+	var vpcs []r53types.VPC
+	if createZoneInput.VPC != nil {
+		vpcs = append(vpcs, *createZoneInput.VPC)
+	}
+	r.zones.store(zoneID, createZoneInput.VPC != nil, vpcs)
+	return zoneID, delegationSetNameServers(createOutput.DelegationSet), nil
+}
+
+// delegationSetNameServers returns a copy of the delegation set's nameservers. Private zones
+// have no delegation set, so they have no nameservers.
+func delegationSetNameServers(delegationSet *r53types.DelegationSet) []string {
+	if delegationSet == nil {
+		return nil
+	}
 	var nameservers []string
-	nameservers = append(nameservers, createOutput.DelegationSet.NameServers...)
+	return append(nameservers, delegationSet.NameServers...)
+}
+
+// zoneHasVPC reports whether vpcID is among the VPCs a private zone is associated with.
+func zoneHasVPC(vpcs []r53types.VPC, vpcID string) bool {
+	for _, vpc := range vpcs {
+		if aws.ToString(vpc.VPCId) == vpcID {
+			return true
+		}
+	}
+	return false
+}
+
+// delegationSetFor returns the reusable delegation set to create the zone with, without the
+// "/delegationset/" prefix, or "" to let Route 53 assign nameservers.
+func (r *ParkedDomainReconciler) delegationSetFor(pd *parkingv1alpha1.ParkedDomain) string {
+	delegationSetID := pd.Spec.DelegationSetID
+	if delegationSetID == "" {
+		delegationSetID = r.DelegationSetID
+	}
+	return strings.TrimPrefix(delegationSetID, "/delegationset/")
+}
 
-	logger.Info("Successfully created Route 53 Hosted Zone", "ZoneID", zoneID)
-	return zoneID, nameservers, nil
+// checkDelegationSet verifies the reusable delegation set exists, so a typo is reported as such
+// instead of as a generic Hosted Zone creation failure.
+func checkDelegationSet(ctx context.Context, r53Client R53ClientAPI, delegationSetID string) error {
+	_, err := r53Client.GetReusableDelegationSet(ctx, &route53.GetReusableDelegationSetInput{Id: aws.String(delegationSetID)})
+	if err != nil {
+		if awserr.IsNotFound(err) {
+			return fmt.Errorf("reusable delegation set %s does not exist: %w", delegationSetID, err)
+		}
+		return fmt.Errorf("failed to get reusable delegation set %s: %w", delegationSetID, err)
+	}
+	return nil
+}
+
+// refreshNameServers compares the stored nameservers with the zone's current delegation set
+// and reports whether the status changed. A zone deleted outside the operator resets the zone
+// and record steps, so the zone is recreated and the change reported by the zone step.
+func (r *ParkedDomainReconciler) refreshNameServers(ctx context.Context, pd *parkingv1alpha1.ParkedDomain) (bool, error) {
+	if r.zones.fresh(pd.Status.ZoneID, r.ZoneVerifyInterval) {
+		return false, nil
+	}
+	r53Client, err := r.r53ClientFor(ctx, pd)
+	if err != nil {
+		return false, err
+	}
+
+	getZoneOutput, err := r53Client.GetHostedZone(ctx, &route53.GetHostedZoneInput{Id: aws.String(pd.Status.ZoneID)})
+	if err != nil {
+		if awserr.IsNotFound(err) {
+			log.FromContext(ctx).Info("Hosted Zone no longer exists, it will be recreated")
+			r.zones.forget(pd.Status.ZoneID)
+			pd.Status.ZoneID = ""
+			meta.RemoveStatusCondition(&pd.Status.Conditions, parkingv1alpha1.ConditionZoneReady)
+			meta.RemoveStatusCondition(&pd.Status.Conditions, parkingv1alpha1.ConditionRecordReady)
+			return true, nil
+		}
+		return false, fmt.Errorf("failed to get details for hosted zone: %w", err)
+	}
+	zone := getZoneOutput.HostedZone
+	r.zones.store(pd.Status.ZoneID, zone != nil && zone.Config != nil && zone.Config.PrivateZone, getZoneOutput.VPCs)
+
+	return r.updateNameServers(pd, delegationSetNameServers(getZoneOutput.DelegationSet)), nil
+}
+
+// updateNameServers stores nameservers in the status and reports whether they differ from the
+// ones stored before. A change is surfaced as the NameServersChanged condition and a warning
+// event, because the registrar still delegates to the old nameservers.
+func (r *ParkedDomainReconciler) updateNameServers(pd *parkingv1alpha1.ParkedDomain, nameservers []string) bool {
+	previous := pd.Status.NameServers
+	pd.Status.NameServers = nameservers
+	if len(previous) == 0 || sameNameServers(previous, nameservers) {
+		return false
+	}
+
+	message := fmt.Sprintf("Name servers changed from %s to %s, update the delegation at the registrar",
+		strings.Join(previous, ", "), strings.Join(nameservers, ", "))
+	meta.SetStatusCondition(&pd.Status.Conditions, metav1.Condition{
+		Type:               parkingv1alpha1.ConditionNameServersChanged,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: pd.Generation,
+		Reason:             "DelegationSetChanged",
+		Message:            message,
+	})
+	r.recordEvent(pd, corev1.EventTypeWarning, "NameServersChanged", message)
+	return true
+}
+
+// sameNameServers reports whether a and b hold the same nameservers, ignoring order and case.
+func sameNameServers(a, b []string) bool {
+	normalize := func(nameservers []string) []string {
+		out := make([]string, 0, len(nameservers))
+		for _, ns := range nameservers {
+			out = append(out, strings.ToLower(strings.TrimSuffix(ns, ".")))
+		}
+		slices.Sort(out)
+		return out
+	}
+	return slices.Equal(normalize(a), normalize(b))
 }
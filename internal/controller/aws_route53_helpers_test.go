@@ -0,0 +1,915 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/route53"
+	r53types "github.com/aws/aws-sdk-go-v2/service/route53/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	parkingv1alpha1 "github.com/gminiba/parked-domain-operator/api/v1alpha1"
+)
+
+// zoneRecords returns the default NS/SOA records plus count A records named
+// record-<i>.cleanup.example.com, each followed by its owner record when owner is set.
+func zoneRecords(count int, owner *parkingv1alpha1.ParkedDomain) []r53types.ResourceRecordSet {
+	records := []r53types.ResourceRecordSet{{Type: "NS"}, {Type: "SOA"}}
+	for i := 0; i < count; i++ {
+		name := fmt.Sprintf("record-%d.cleanup.example.com.", i)
+		records = append(records, r53types.ResourceRecordSet{Name: aws.String(name), Type: r53types.RRTypeA})
+		if owner != nil {
+			records = append(records, ownerRecordFor(owner, name))
+		}
+	}
+	return records
+}
+
+var _ = Describe("Route 53 helpers", func() {
+	var pd *parkingv1alpha1.ParkedDomain
+
+	BeforeEach(func() {
+		pd = &parkingv1alpha1.ParkedDomain{
+			ObjectMeta: metav1.ObjectMeta{Name: "cleanup", Namespace: "default"},
+			Spec:       parkingv1alpha1.ParkedDomainSpec{DomainName: "cleanup.example.com"},
+			Status:     parkingv1alpha1.ParkedDomainStatus{ZoneID: "CLEANUPZONE"},
+		}
+	})
+
+	Context("When cleaning up a Hosted Zone", func() {
+		It("should delete records in batches and resume once each batch is in sync", func() {
+			deleted := map[string]bool{}
+			var batchSizes []int
+			status := r53types.ChangeStatusPending
+			zoneDeleted := false
+			r53 := &MockR53Client{
+				ListResourceRecordSetsFunc: func(ctx context.Context, params *route53.ListResourceRecordSetsInput, optFns ...func(*route53.Options)) (*route53.ListResourceRecordSetsOutput, error) {
+					records := slices.DeleteFunc(zoneRecords(recordCleanupBatchSize/2+1, pd), func(record r53types.ResourceRecordSet) bool {
+						return deleted[aws.ToString(record.Name)]
+					})
+					return &route53.ListResourceRecordSetsOutput{ResourceRecordSets: records}, nil
+				},
+				ChangeResourceRecordSetsFunc: func(ctx context.Context, params *route53.ChangeResourceRecordSetsInput, optFns ...func(*route53.Options)) (*route53.ChangeResourceRecordSetsOutput, error) {
+					batchSizes = append(batchSizes, len(params.ChangeBatch.Changes))
+					for _, change := range params.ChangeBatch.Changes {
+						deleted[aws.ToString(change.ResourceRecordSet.Name)] = true
+					}
+					id := fmt.Sprintf("C%d", len(batchSizes))
+					return &route53.ChangeResourceRecordSetsOutput{ChangeInfo: &r53types.ChangeInfo{Id: aws.String(id), Status: r53types.ChangeStatusPending}}, nil
+				},
+				GetChangeFunc: func(ctx context.Context, params *route53.GetChangeInput, optFns ...func(*route53.Options)) (*route53.GetChangeOutput, error) {
+					return &route53.GetChangeOutput{ChangeInfo: &r53types.ChangeInfo{Id: params.Id, Status: status}}, nil
+				},
+				DeleteHostedZoneFunc: func(ctx context.Context, params *route53.DeleteHostedZoneInput, optFns ...func(*route53.Options)) (*route53.DeleteHostedZoneOutput, error) {
+					zoneDeleted = true
+					return &route53.DeleteHostedZoneOutput{}, nil
+				},
+			}
+			r := &ParkedDomainReconciler{R53Client: r53}
+			ctx := context.Background()
+
+			By("sending the first batch and returning without waiting for it")
+			done, err := r.cleanupRoute53Zone(ctx, pd)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(done).To(BeFalse())
+			Expect(batchSizes).To(Equal([]int{recordCleanupBatchSize}))
+			Expect(pd.Status.PendingChangeID).To(Equal("C1"))
+
+			By("sending nothing more while the batch is PENDING")
+			done, err = r.cleanupRoute53Zone(ctx, pd)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(done).To(BeFalse())
+			Expect(batchSizes).To(HaveLen(1))
+
+			By("sending the next batch once the first is INSYNC")
+			status = r53types.ChangeStatusInsync
+			done, err = r.cleanupRoute53Zone(ctx, pd)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(done).To(BeFalse())
+			Expect(batchSizes).To(Equal([]int{recordCleanupBatchSize, 2}))
+			Expect(pd.Status.PendingChangeID).To(Equal("C2"))
+			Expect(zoneDeleted).To(BeFalse())
+
+			By("deleting the zone once the last batch is INSYNC")
+			Expect(r.cleanupRoute53Zone(ctx, pd)).To(BeTrue())
+			Expect(pd.Status.PendingChangeID).To(BeEmpty())
+			Expect(zoneDeleted).To(BeTrue())
+		})
+
+		It("should requeue the finalizer while record deletions are PENDING", func() {
+			pd.Finalizers = []string{finalizerName}
+			pd.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+			remaining := zoneRecords(1, pd)
+			r53 := &MockR53Client{
+				ListResourceRecordSetsFunc: func(ctx context.Context, params *route53.ListResourceRecordSetsInput, optFns ...func(*route53.Options)) (*route53.ListResourceRecordSetsOutput, error) {
+					return &route53.ListResourceRecordSetsOutput{ResourceRecordSets: remaining}, nil
+				},
+				ChangeResourceRecordSetsFunc: func(ctx context.Context, params *route53.ChangeResourceRecordSetsInput, optFns ...func(*route53.Options)) (*route53.ChangeResourceRecordSetsOutput, error) {
+					remaining = nil
+					return &route53.ChangeResourceRecordSetsOutput{ChangeInfo: &r53types.ChangeInfo{Id: aws.String("C1"), Status: r53types.ChangeStatusPending}}, nil
+				},
+				GetChangeFunc: func(ctx context.Context, params *route53.GetChangeInput, optFns ...func(*route53.Options)) (*route53.GetChangeOutput, error) {
+					Fail("the change should not be polled within the same reconcile")
+					return nil, nil
+				},
+			}
+			r := newTestReconciler(r53, &MockS3Client{}, pd)
+			req := ctrl.Request{NamespacedName: types.NamespacedName{Name: pd.Name, Namespace: pd.Namespace}}
+
+			result, err := r.Reconcile(context.Background(), req)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).To(Equal(changeSyncPollDelay))
+
+			pending := &parkingv1alpha1.ParkedDomain{}
+			Expect(r.Get(context.Background(), req.NamespacedName, pending)).To(Succeed())
+			Expect(pending.Status.PendingChangeID).To(Equal("C1"))
+			Expect(pending.Finalizers).To(ContainElement(finalizerName))
+		})
+
+		It("should name the record Route 53 refuses to delete", func() {
+			zoneDeleted := false
+			r53 := &MockR53Client{
+				ListResourceRecordSetsFunc: func(ctx context.Context, params *route53.ListResourceRecordSetsInput, optFns ...func(*route53.Options)) (*route53.ListResourceRecordSetsOutput, error) {
+					return &route53.ListResourceRecordSetsOutput{ResourceRecordSets: zoneRecords(3, pd)}, nil
+				},
+				ChangeResourceRecordSetsFunc: func(ctx context.Context, params *route53.ChangeResourceRecordSetsInput, optFns ...func(*route53.Options)) (*route53.ChangeResourceRecordSetsOutput, error) {
+					for _, change := range params.ChangeBatch.Changes {
+						if aws.ToString(change.ResourceRecordSet.Name) == "record-1.cleanup.example.com." {
+							return nil, &r53types.InvalidChangeBatch{Message: aws.String("record is a dependency of another record")}
+						}
+					}
+					return &route53.ChangeResourceRecordSetsOutput{}, nil
+				},
+				DeleteHostedZoneFunc: func(ctx context.Context, params *route53.DeleteHostedZoneInput, optFns ...func(*route53.Options)) (*route53.DeleteHostedZoneOutput, error) {
+					zoneDeleted = true
+					return &route53.DeleteHostedZoneOutput{}, nil
+				},
+			}
+			r := &ParkedDomainReconciler{R53Client: r53}
+
+			_, err := r.cleanupRoute53Zone(context.Background(), pd)
+			Expect(err).To(MatchError(ContainSubstring("A record record-1.cleanup.example.com.")))
+			var icb *r53types.InvalidChangeBatch
+			Expect(errors.As(err, &icb)).To(BeTrue())
+			Expect(zoneDeleted).To(BeFalse())
+		})
+
+		It("should keep a zone the operator did not create and remove only the parked page record", func() {
+			pd.Status.Endpoint = "cleanup.example.com.s3-website.eu-central-1.amazonaws.com"
+			var deleted []string
+			zoneDeleted := false
+			r53 := &MockR53Client{
+				GetHostedZoneFunc: func(ctx context.Context, params *route53.GetHostedZoneInput, optFns ...func(*route53.Options)) (*route53.GetHostedZoneOutput, error) {
+					return &route53.GetHostedZoneOutput{HostedZone: &r53types.HostedZone{
+						Id:              params.Id,
+						CallerReference: aws.String("terraform-20240101"),
+						Config:          &r53types.HostedZoneConfig{Comment: aws.String("Company apex zone")},
+					}}, nil
+				},
+				ListResourceRecordSetsFunc: func(ctx context.Context, params *route53.ListResourceRecordSetsInput, optFns ...func(*route53.Options)) (*route53.ListResourceRecordSetsOutput, error) {
+					records := append(zoneRecords(2, nil), r53types.ResourceRecordSet{
+						Name: aws.String("cleanup.example.com."),
+						Type: r53types.RRTypeA,
+						AliasTarget: &r53types.AliasTarget{
+							HostedZoneId: aws.String("Z21DNDUVLTQW6Q"),
+							DNSName:      aws.String("cleanup.example.com.s3-website.eu-central-1.amazonaws.com."),
+						},
+					})
+					return &route53.ListResourceRecordSetsOutput{ResourceRecordSets: records}, nil
+				},
+				ChangeResourceRecordSetsFunc: func(ctx context.Context, params *route53.ChangeResourceRecordSetsInput, optFns ...func(*route53.Options)) (*route53.ChangeResourceRecordSetsOutput, error) {
+					for _, change := range params.ChangeBatch.Changes {
+						deleted = append(deleted, aws.ToString(change.ResourceRecordSet.Name))
+					}
+					return &route53.ChangeResourceRecordSetsOutput{}, nil
+				},
+				DeleteHostedZoneFunc: func(ctx context.Context, params *route53.DeleteHostedZoneInput, optFns ...func(*route53.Options)) (*route53.DeleteHostedZoneOutput, error) {
+					zoneDeleted = true
+					return &route53.DeleteHostedZoneOutput{}, nil
+				},
+			}
+			recorder := record.NewFakeRecorder(10)
+			r := &ParkedDomainReconciler{R53Client: r53, Recorder: recorder}
+
+			Expect(r.cleanupRoute53Zone(context.Background(), pd)).To(BeTrue())
+			Expect(deleted).To(Equal([]string{"cleanup.example.com."}))
+			Expect(zoneDeleted).To(BeFalse())
+			Expect(recorder.Events).To(Receive(ContainSubstring("HostedZoneRetained")))
+		})
+
+		It("should delete only its own records and keep a zone that holds records of others", func() {
+			other := pd.DeepCopy()
+			other.Name = "other"
+			records := append(zoneRecords(1, pd),
+				r53types.ResourceRecordSet{Name: aws.String("mail.cleanup.example.com."), Type: r53types.RRTypeMx},
+				r53types.ResourceRecordSet{Name: aws.String("shop.cleanup.example.com."), Type: r53types.RRTypeA},
+				ownerRecordFor(other, "shop.cleanup.example.com."),
+			)
+			var deleted []string
+			zoneDeleted := false
+			r53 := &MockR53Client{
+				ListResourceRecordSetsFunc: func(ctx context.Context, params *route53.ListResourceRecordSetsInput, optFns ...func(*route53.Options)) (*route53.ListResourceRecordSetsOutput, error) {
+					return &route53.ListResourceRecordSetsOutput{ResourceRecordSets: records}, nil
+				},
+				ChangeResourceRecordSetsFunc: func(ctx context.Context, params *route53.ChangeResourceRecordSetsInput, optFns ...func(*route53.Options)) (*route53.ChangeResourceRecordSetsOutput, error) {
+					for _, change := range params.ChangeBatch.Changes {
+						deleted = append(deleted, aws.ToString(change.ResourceRecordSet.Name))
+					}
+					return &route53.ChangeResourceRecordSetsOutput{}, nil
+				},
+				DeleteHostedZoneFunc: func(ctx context.Context, params *route53.DeleteHostedZoneInput, optFns ...func(*route53.Options)) (*route53.DeleteHostedZoneOutput, error) {
+					zoneDeleted = true
+					return &route53.DeleteHostedZoneOutput{}, nil
+				},
+			}
+			recorder := record.NewFakeRecorder(10)
+			r := &ParkedDomainReconciler{R53Client: r53, Recorder: recorder}
+
+			Expect(r.cleanupRoute53Zone(context.Background(), pd)).To(BeTrue())
+			Expect(deleted).To(Equal([]string{"record-0.cleanup.example.com.", "_pdo-owner.record-0.cleanup.example.com."}))
+			Expect(zoneDeleted).To(BeFalse())
+			Expect(recorder.Events).To(Receive(ContainSubstring("holds 3 records the operator did not create")))
+		})
+
+		It("should delete a zone created before the managed comment was set", func() {
+			zoneDeleted := false
+			r53 := &MockR53Client{
+				GetHostedZoneFunc: func(ctx context.Context, params *route53.GetHostedZoneInput, optFns ...func(*route53.Options)) (*route53.GetHostedZoneOutput, error) {
+					return &route53.GetHostedZoneOutput{HostedZone: &r53types.HostedZone{
+						Id:              params.Id,
+						CallerReference: aws.String("parkeddomain-operator-cleanup-1700000000"),
+					}}, nil
+				},
+				DeleteHostedZoneFunc: func(ctx context.Context, params *route53.DeleteHostedZoneInput, optFns ...func(*route53.Options)) (*route53.DeleteHostedZoneOutput, error) {
+					zoneDeleted = true
+					return &route53.DeleteHostedZoneOutput{}, nil
+				},
+			}
+			r := &ParkedDomainReconciler{R53Client: r53}
+
+			Expect(r.cleanupRoute53Zone(context.Background(), pd)).To(BeTrue())
+			Expect(zoneDeleted).To(BeTrue())
+		})
+
+		It("should succeed when the zone disappears before its records are listed", func() {
+			r53 := &MockR53Client{
+				ListResourceRecordSetsFunc: func(ctx context.Context, params *route53.ListResourceRecordSetsInput, optFns ...func(*route53.Options)) (*route53.ListResourceRecordSetsOutput, error) {
+					return nil, &r53types.NoSuchHostedZone{}
+				},
+				ChangeResourceRecordSetsFunc: func(ctx context.Context, params *route53.ChangeResourceRecordSetsInput, optFns ...func(*route53.Options)) (*route53.ChangeResourceRecordSetsOutput, error) {
+					Fail("records of a deleted zone should not be changed")
+					return nil, nil
+				},
+				DeleteHostedZoneFunc: func(ctx context.Context, params *route53.DeleteHostedZoneInput, optFns ...func(*route53.Options)) (*route53.DeleteHostedZoneOutput, error) {
+					Fail("a deleted zone should not be deleted again")
+					return nil, nil
+				},
+			}
+			r := &ParkedDomainReconciler{R53Client: r53}
+
+			Expect(r.cleanupRoute53Zone(context.Background(), pd)).To(BeTrue())
+		})
+	})
+
+	Context("When the page is served at a host within the zone", func() {
+		BeforeEach(func() {
+			pd.Spec = parkingv1alpha1.ParkedDomainSpec{DomainName: "example.com", RecordName: "shop.example.com"}
+			pd.Status.Endpoint = "shop.example.com.s3-website.eu-central-1.amazonaws.com"
+		})
+
+		It("should create the alias record at the host and mark it as the ParkedDomain's", func() {
+			var upserted, owner *r53types.ResourceRecordSet
+			r53 := &MockR53Client{
+				ChangeResourceRecordSetsFunc: func(ctx context.Context, params *route53.ChangeResourceRecordSetsInput, optFns ...func(*route53.Options)) (*route53.ChangeResourceRecordSetsOutput, error) {
+					upserted, owner = params.ChangeBatch.Changes[0].ResourceRecordSet, params.ChangeBatch.Changes[1].ResourceRecordSet
+					return &route53.ChangeResourceRecordSetsOutput{}, nil
+				},
+			}
+			r := &ParkedDomainReconciler{R53Client: r53}
+
+			Expect(r.reconcileRoute53ARecord(context.Background(), pd, "CLEANUPZONE", pd.Status.Endpoint)).To(Succeed())
+			Expect(aws.ToString(upserted.Name)).To(Equal("shop.example.com"))
+			Expect(aws.ToString(owner.Name)).To(Equal("_pdo-owner.shop.example.com"))
+			Expect(owner.Type).To(Equal(r53types.RRTypeTxt))
+			Expect(aws.ToString(owner.ResourceRecords[0].Value)).To(Equal(`"heritage=parked-domain-operator,owner=default/cleanup"`))
+			Expect(aws.ToString(upserted.AliasTarget.DNSName)).To(Equal("shop.example.com.s3-website.eu-central-1.amazonaws.com"))
+			Expect(pd.Status.WebsiteURL).To(Equal("http://shop.example.com"))
+		})
+
+		It("should follow the managed comment with the record comment", func() {
+			pd.Spec.RecordComment = "team-web, CHG-1234"
+			var comment string
+			r53 := &MockR53Client{
+				ChangeResourceRecordSetsFunc: func(ctx context.Context, params *route53.ChangeResourceRecordSetsInput, optFns ...func(*route53.Options)) (*route53.ChangeResourceRecordSetsOutput, error) {
+					comment = aws.ToString(params.ChangeBatch.Comment)
+					return &route53.ChangeResourceRecordSetsOutput{}, nil
+				},
+			}
+			r := &ParkedDomainReconciler{R53Client: r53}
+
+			Expect(r.reconcileRoute53ARecord(context.Background(), pd, "CLEANUPZONE", pd.Status.Endpoint)).To(Succeed())
+			Expect(comment).To(Equal("Managed by ParkedDomain Operator: team-web, CHG-1234"))
+		})
+
+		It("should remove only the host's record from a zone it did not create", func() {
+			alias := func(name, target string) r53types.ResourceRecordSet {
+				return r53types.ResourceRecordSet{
+					Name:        aws.String(name),
+					Type:        r53types.RRTypeA,
+					AliasTarget: &r53types.AliasTarget{HostedZoneId: aws.String("Z21DNDUVLTQW6Q"), DNSName: aws.String(target)},
+				}
+			}
+			var deleted []string
+			r53 := &MockR53Client{
+				GetHostedZoneFunc: func(ctx context.Context, params *route53.GetHostedZoneInput, optFns ...func(*route53.Options)) (*route53.GetHostedZoneOutput, error) {
+					return &route53.GetHostedZoneOutput{HostedZone: &r53types.HostedZone{Id: params.Id, CallerReference: aws.String("terraform-20240101")}}, nil
+				},
+				ListResourceRecordSetsFunc: func(ctx context.Context, params *route53.ListResourceRecordSetsInput, optFns ...func(*route53.Options)) (*route53.ListResourceRecordSetsOutput, error) {
+					return &route53.ListResourceRecordSetsOutput{ResourceRecordSets: []r53types.ResourceRecordSet{
+						alias("example.com.", "example.com.s3-website.eu-central-1.amazonaws.com."),
+						alias("shop.example.com.", "shop.example.com.s3-website.eu-central-1.amazonaws.com."),
+						alias("blog.example.com.", "blog.example.com.s3-website.eu-central-1.amazonaws.com."),
+					}}, nil
+				},
+				ChangeResourceRecordSetsFunc: func(ctx context.Context, params *route53.ChangeResourceRecordSetsInput, optFns ...func(*route53.Options)) (*route53.ChangeResourceRecordSetsOutput, error) {
+					for _, change := range params.ChangeBatch.Changes {
+						deleted = append(deleted, aws.ToString(change.ResourceRecordSet.Name))
+					}
+					return &route53.ChangeResourceRecordSetsOutput{}, nil
+				},
+			}
+			r := &ParkedDomainReconciler{R53Client: r53, Recorder: record.NewFakeRecorder(10)}
+
+			Expect(r.cleanupRoute53Zone(context.Background(), pd)).To(BeTrue())
+			Expect(deleted).To(Equal([]string{"shop.example.com."}))
+		})
+	})
+
+	Context("When records are in the way of the parked page record", func() {
+		var cname r53types.ResourceRecordSet
+
+		BeforeEach(func() {
+			pd.Status.Endpoint = "cleanup.example.com.s3-website.eu-central-1.amazonaws.com"
+			cname = r53types.ResourceRecordSet{
+				Name:            aws.String("cleanup.example.com."),
+				Type:            r53types.RRTypeCname,
+				TTL:             aws.Int64(300),
+				ResourceRecords: []r53types.ResourceRecord{{Value: aws.String("old-host.example.net")}},
+			}
+		})
+
+		It("should replace a CNAME record at the record's name", func() {
+			var batches [][]r53types.Change
+			zone := []r53types.ResourceRecordSet{cname}
+			recorder := record.NewFakeRecorder(10)
+			r53 := &MockR53Client{
+				ListResourceRecordSetsFunc: func(ctx context.Context, params *route53.ListResourceRecordSetsInput, optFns ...func(*route53.Options)) (*route53.ListResourceRecordSetsOutput, error) {
+					return &route53.ListResourceRecordSetsOutput{ResourceRecordSets: zone}, nil
+				},
+				ChangeResourceRecordSetsFunc: func(ctx context.Context, params *route53.ChangeResourceRecordSetsInput, optFns ...func(*route53.Options)) (*route53.ChangeResourceRecordSetsOutput, error) {
+					batches = append(batches, params.ChangeBatch.Changes)
+					if params.ChangeBatch.Changes[0].Action != r53types.ChangeActionDelete && len(zone) > 0 {
+						return nil, &r53types.InvalidChangeBatch{Message: aws.String("RRSet of type A with DNS name cleanup.example.com. is not permitted because a conflicting RRSet of type CNAME with the same DNS name already exists")}
+					}
+					zone = nil
+					return &route53.ChangeResourceRecordSetsOutput{}, nil
+				},
+			}
+			r := &ParkedDomainReconciler{R53Client: r53, Recorder: recorder}
+
+			Expect(r.reconcileRoute53ARecord(context.Background(), pd, "CLEANUPZONE", pd.Status.Endpoint)).To(Succeed())
+			Expect(batches).To(HaveLen(2))
+			retry := batches[1]
+			Expect(retry).To(HaveLen(3))
+			Expect(retry[0].Action).To(Equal(r53types.ChangeActionDelete))
+			Expect(*retry[0].ResourceRecordSet).To(Equal(cname))
+			Expect(retry[1:]).To(Equal(batches[0]))
+			Expect(drainEvents(recorder)).To(ContainElement(ContainSubstring("ConflictingRecordsReplaced")))
+			Expect(pd.Status.WebsiteURL).To(Equal("http://cleanup.example.com"))
+		})
+
+		It("should report a conflict it cannot resolve", func() {
+			r53 := &MockR53Client{
+				ChangeResourceRecordSetsFunc: func(ctx context.Context, params *route53.ChangeResourceRecordSetsInput, optFns ...func(*route53.Options)) (*route53.ChangeResourceRecordSetsOutput, error) {
+					return nil, &r53types.InvalidChangeBatch{Message: aws.String("conflicting record")}
+				},
+			}
+			r := &ParkedDomainReconciler{R53Client: r53}
+
+			err := r.reconcileRoute53ARecord(context.Background(), pd, "CLEANUPZONE", pd.Status.Endpoint)
+			Expect(err).To(MatchError(errRecordConflict))
+			Expect(err).To(MatchError(ContainSubstring("conflicting record")))
+			Expect(failureReason(err)).To(Equal("RecordConflict"))
+		})
+	})
+
+	Context("When parking every host below the domain", func() {
+		var wildcard r53types.ResourceRecordSet
+
+		BeforeEach(func() {
+			pd.Spec = parkingv1alpha1.ParkedDomainSpec{DomainName: "example.com", Wildcard: true}
+			pd.Status.Endpoint = "example.com.s3-website.eu-central-1.amazonaws.com"
+			// Route 53 returns the * of wildcard records escaped.
+			wildcard = r53types.ResourceRecordSet{
+				Name: aws.String(`\052.example.com.`),
+				Type: r53types.RRTypeA,
+				AliasTarget: &r53types.AliasTarget{
+					HostedZoneId: aws.String("Z21DNDUVLTQW6Q"),
+					DNSName:      aws.String("example.com.s3-website.eu-central-1.amazonaws.com."),
+				},
+			}
+		})
+
+		It("should create a wildcard alias record next to the domain's", func() {
+			var changes []r53types.Change
+			r53 := &MockR53Client{
+				ChangeResourceRecordSetsFunc: func(ctx context.Context, params *route53.ChangeResourceRecordSetsInput, optFns ...func(*route53.Options)) (*route53.ChangeResourceRecordSetsOutput, error) {
+					changes = params.ChangeBatch.Changes
+					return &route53.ChangeResourceRecordSetsOutput{}, nil
+				},
+			}
+			r := &ParkedDomainReconciler{R53Client: r53}
+
+			Expect(r.reconcileRoute53ARecord(context.Background(), pd, "CLEANUPZONE", pd.Status.Endpoint)).To(Succeed())
+			Expect(changes).To(HaveLen(4))
+			Expect(aws.ToString(changes[0].ResourceRecordSet.Name)).To(Equal("example.com"))
+			Expect(aws.ToString(changes[2].ResourceRecordSet.Name)).To(Equal("*.example.com"))
+			Expect(aws.ToString(changes[3].ResourceRecordSet.Name)).To(Equal("_pdo-owner.*.example.com"))
+			Expect(changes[2].Action).To(Equal(r53types.ChangeActionUpsert))
+			Expect(changes[2].ResourceRecordSet.AliasTarget).To(Equal(changes[0].ResourceRecordSet.AliasTarget))
+		})
+
+		It("should remove the wildcard record once wildcards are turned off", func() {
+			pd.Spec.Wildcard = false
+			var deleted []string
+			r53 := &MockR53Client{
+				ListResourceRecordSetsFunc: func(ctx context.Context, params *route53.ListResourceRecordSetsInput, optFns ...func(*route53.Options)) (*route53.ListResourceRecordSetsOutput, error) {
+					if aws.ToString(params.StartRecordName) != "*.example.com" {
+						return &route53.ListResourceRecordSetsOutput{}, nil
+					}
+					return &route53.ListResourceRecordSetsOutput{ResourceRecordSets: []r53types.ResourceRecordSet{wildcard}}, nil
+				},
+				ChangeResourceRecordSetsFunc: func(ctx context.Context, params *route53.ChangeResourceRecordSetsInput, optFns ...func(*route53.Options)) (*route53.ChangeResourceRecordSetsOutput, error) {
+					for _, change := range params.ChangeBatch.Changes {
+						if change.Action == r53types.ChangeActionDelete {
+							deleted = append(deleted, aws.ToString(change.ResourceRecordSet.Name))
+						}
+					}
+					return &route53.ChangeResourceRecordSetsOutput{}, nil
+				},
+			}
+			r := &ParkedDomainReconciler{R53Client: r53}
+
+			Expect(r.reconcileRoute53ARecord(context.Background(), pd, "CLEANUPZONE", pd.Status.Endpoint)).To(Succeed())
+			Expect(deleted).To(Equal([]string{`\052.example.com.`}))
+		})
+
+		It("should remove the wildcard record from a zone it did not create", func() {
+			var deleted []string
+			r53 := &MockR53Client{
+				GetHostedZoneFunc: func(ctx context.Context, params *route53.GetHostedZoneInput, optFns ...func(*route53.Options)) (*route53.GetHostedZoneOutput, error) {
+					return &route53.GetHostedZoneOutput{HostedZone: &r53types.HostedZone{Id: params.Id, CallerReference: aws.String("terraform-20240101")}}, nil
+				},
+				ListResourceRecordSetsFunc: func(ctx context.Context, params *route53.ListResourceRecordSetsInput, optFns ...func(*route53.Options)) (*route53.ListResourceRecordSetsOutput, error) {
+					return &route53.ListResourceRecordSetsOutput{ResourceRecordSets: append(zoneRecords(1, nil), wildcard)}, nil
+				},
+				ChangeResourceRecordSetsFunc: func(ctx context.Context, params *route53.ChangeResourceRecordSetsInput, optFns ...func(*route53.Options)) (*route53.ChangeResourceRecordSetsOutput, error) {
+					for _, change := range params.ChangeBatch.Changes {
+						deleted = append(deleted, aws.ToString(change.ResourceRecordSet.Name))
+					}
+					return &route53.ChangeResourceRecordSetsOutput{}, nil
+				},
+			}
+			r := &ParkedDomainReconciler{R53Client: r53, Recorder: record.NewFakeRecorder(10)}
+
+			Expect(r.cleanupRoute53Zone(context.Background(), pd)).To(BeTrue())
+			Expect(deleted).To(Equal([]string{`\052.example.com.`}))
+		})
+	})
+
+	Context("When creating a Hosted Zone", func() {
+		var (
+			r53            *MockR53Client
+			createdWithSet *string
+		)
+
+		BeforeEach(func() {
+			createdWithSet = nil
+			r53 = &MockR53Client{
+				CreateHostedZoneFunc: func(ctx context.Context, params *route53.CreateHostedZoneInput, optFns ...func(*route53.Options)) (*route53.CreateHostedZoneOutput, error) {
+					createdWithSet = params.DelegationSetId
+					Expect(aws.ToString(params.HostedZoneConfig.Comment)).To(Equal(managedComment))
+					return &route53.CreateHostedZoneOutput{
+						HostedZone:    &r53types.HostedZone{Id: aws.String("/hostedzone/SHAREDZONE")},
+						DelegationSet: &r53types.DelegationSet{NameServers: []string{"ns-shared.awsdns.com"}},
+					}, nil
+				},
+			}
+		})
+
+		It("should use the operator's reusable delegation set by default", func() {
+			r := &ParkedDomainReconciler{R53Client: r53, DelegationSetID: "N1OPERATOR"}
+
+			_, nameservers, err := r.reconcileRoute53Zone(context.Background(), pd)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(aws.ToString(createdWithSet)).To(Equal("N1OPERATOR"))
+			Expect(nameservers).To(Equal([]string{"ns-shared.awsdns.com"}))
+		})
+
+		It("should prefer the delegation set named in the spec", func() {
+			r := &ParkedDomainReconciler{R53Client: r53, DelegationSetID: "N1OPERATOR"}
+			pd.Spec.DelegationSetID = "/delegationset/N2SPEC"
+
+			_, _, err := r.reconcileRoute53Zone(context.Background(), pd)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(aws.ToString(createdWithSet)).To(Equal("N2SPEC"))
+		})
+
+		It("should not create the zone when the delegation set does not exist", func() {
+			r53.GetReusableDelegationSetFunc = func(ctx context.Context, params *route53.GetReusableDelegationSetInput, optFns ...func(*route53.Options)) (*route53.GetReusableDelegationSetOutput, error) {
+				return nil, &r53types.NoSuchDelegationSet{Message: aws.String("not found")}
+			}
+			r := &ParkedDomainReconciler{R53Client: r53}
+			pd.Spec.DelegationSetID = "N3TYPO"
+
+			_, _, err := r.reconcileRoute53Zone(context.Background(), pd)
+			Expect(err).To(MatchError(ContainSubstring("reusable delegation set N3TYPO does not exist")))
+			Expect(createdWithSet).To(BeNil())
+		})
+	})
+
+	Context("When adopting an existing Hosted Zone", func() {
+		var (
+			r53        *MockR53Client
+			getZoneIDs []string
+		)
+
+		BeforeEach(func() {
+			getZoneIDs = nil
+			r53 = &MockR53Client{
+				ListHostedZonesByNameFunc: func(ctx context.Context, params *route53.ListHostedZonesByNameInput, optFns ...func(*route53.Options)) (*route53.ListHostedZonesByNameOutput, error) {
+					return &route53.ListHostedZonesByNameOutput{HostedZones: []r53types.HostedZone{
+						{Id: aws.String("/hostedzone/ZADOPTED"), Name: aws.String(pd.Spec.DomainName + ".")},
+					}}, nil
+				},
+				GetHostedZoneFunc: func(ctx context.Context, params *route53.GetHostedZoneInput, optFns ...func(*route53.Options)) (*route53.GetHostedZoneOutput, error) {
+					getZoneIDs = append(getZoneIDs, aws.ToString(params.Id))
+					return &route53.GetHostedZoneOutput{
+						HostedZone:    &r53types.HostedZone{Id: params.Id},
+						DelegationSet: &r53types.DelegationSet{NameServers: []string{"ns-adopted.awsdns.com"}},
+					}, nil
+				},
+			}
+		})
+
+		It("should read the nameservers of a zone adopted for the first time", func() {
+			r := &ParkedDomainReconciler{R53Client: r53}
+
+			zoneID, nameservers, err := r.reconcileRoute53Zone(context.Background(), pd)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(zoneID).To(Equal("ZADOPTED"))
+			Expect(nameservers).To(Equal([]string{"ns-adopted.awsdns.com"}))
+			Expect(getZoneIDs).To(HaveLen(1))
+		})
+
+		It("should keep the nameservers in the status for the zone already adopted", func() {
+			pd.Status.ZoneID = "ZADOPTED"
+			pd.Status.NameServers = []string{"ns-known.awsdns.com"}
+			r := &ParkedDomainReconciler{R53Client: r53}
+
+			zoneID, nameservers, err := r.reconcileRoute53Zone(context.Background(), pd)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(zoneID).To(Equal("ZADOPTED"))
+			Expect(nameservers).To(Equal([]string{"ns-known.awsdns.com"}))
+			Expect(getZoneIDs).To(BeEmpty())
+		})
+	})
+})
+
+var _ = Describe("Route 53 private zones", func() {
+	var pd *parkingv1alpha1.ParkedDomain
+
+	BeforeEach(func() {
+		pd = &parkingv1alpha1.ParkedDomain{
+			ObjectMeta: metav1.ObjectMeta{Name: "internal", Namespace: "default"},
+			Spec: parkingv1alpha1.ParkedDomainSpec{
+				DomainName:  "internal.example.com",
+				Region:      "eu-west-1",
+				PrivateZone: &parkingv1alpha1.PrivateZone{VPCID: "vpc-0abc"},
+			},
+		}
+	})
+
+	It("should create a private zone associated with the VPC", func() {
+		var created *route53.CreateHostedZoneInput
+		r53 := &MockR53Client{
+			CreateHostedZoneFunc: func(ctx context.Context, params *route53.CreateHostedZoneInput, optFns ...func(*route53.Options)) (*route53.CreateHostedZoneOutput, error) {
+				created = params
+				return &route53.CreateHostedZoneOutput{
+					HostedZone: &r53types.HostedZone{Id: aws.String("/hostedzone/PRIVATEZONE")},
+					VPC:        params.VPC,
+				}, nil
+			},
+		}
+		r := &ParkedDomainReconciler{R53Client: r53, DelegationSetID: "N1OPERATOR"}
+
+		zoneID, nameservers, err := r.reconcileRoute53Zone(context.Background(), pd)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(zoneID).To(Equal("PRIVATEZONE"))
+		Expect(nameservers).To(BeEmpty())
+		Expect(created.HostedZoneConfig.PrivateZone).To(BeTrue())
+		Expect(aws.ToString(created.VPC.VPCId)).To(Equal("vpc-0abc"))
+		Expect(created.VPC.VPCRegion).To(Equal(r53types.VPCRegionEuWest1))
+		Expect(created.DelegationSetId).To(BeNil())
+	})
+
+	It("should adopt only a private zone associated with the VPC", func() {
+		r53 := &MockR53Client{
+			ListHostedZonesByNameFunc: func(ctx context.Context, params *route53.ListHostedZonesByNameInput, optFns ...func(*route53.Options)) (*route53.ListHostedZonesByNameOutput, error) {
+				return &route53.ListHostedZonesByNameOutput{HostedZones: []r53types.HostedZone{
+					{Id: aws.String("/hostedzone/PUBLIC"), Name: aws.String("internal.example.com.")},
+					{Id: aws.String("/hostedzone/OTHERVPC"), Name: aws.String("internal.example.com."), Config: &r53types.HostedZoneConfig{PrivateZone: true}},
+					{Id: aws.String("/hostedzone/OURVPC"), Name: aws.String("internal.example.com."), Config: &r53types.HostedZoneConfig{PrivateZone: true}},
+				}}, nil
+			},
+			GetHostedZoneFunc: func(ctx context.Context, params *route53.GetHostedZoneInput, optFns ...func(*route53.Options)) (*route53.GetHostedZoneOutput, error) {
+				vpcID := "vpc-0other"
+				if aws.ToString(params.Id) == "/hostedzone/OURVPC" {
+					vpcID = "vpc-0abc"
+				}
+				return &route53.GetHostedZoneOutput{
+					HostedZone: &r53types.HostedZone{Id: params.Id},
+					VPCs:       []r53types.VPC{{VPCId: aws.String(vpcID)}},
+				}, nil
+			},
+			CreateHostedZoneFunc: func(ctx context.Context, params *route53.CreateHostedZoneInput, optFns ...func(*route53.Options)) (*route53.CreateHostedZoneOutput, error) {
+				Fail("an existing private zone should be adopted")
+				return nil, nil
+			},
+		}
+		r := &ParkedDomainReconciler{R53Client: r53}
+
+		zoneID, _, err := r.reconcileRoute53Zone(context.Background(), pd)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(zoneID).To(Equal("OURVPC"))
+	})
+})
+
+var _ = Describe("Route 53 nameserver drift", func() {
+	var (
+		pd         *parkingv1alpha1.ParkedDomain
+		templateCM *corev1.ConfigMap
+		req        ctrl.Request
+	)
+
+	BeforeEach(func() {
+		Expect(os.Setenv("TEMPLATE_CONFIGMAP_NAME", "parked-domain-templates")).To(Succeed())
+		pd = &parkingv1alpha1.ParkedDomain{
+			ObjectMeta: metav1.ObjectMeta{Name: "drift", Namespace: "default"},
+			Spec:       parkingv1alpha1.ParkedDomainSpec{DomainName: "drift.example.com"},
+		}
+		templateCM = &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "parked-domain-templates", Namespace: "default"},
+			Data:       map[string]string{"default.html": "<h1>{{DOMAIN_NAME}}</h1>"},
+		}
+		req = ctrl.Request{NamespacedName: types.NamespacedName{Name: "drift", Namespace: "default"}}
+	})
+
+	AfterEach(func() {
+		Expect(os.Unsetenv("TEMPLATE_CONFIGMAP_NAME")).To(Succeed())
+	})
+
+	It("should refresh the nameservers and warn when the delegation set changes", func() {
+		ctx := context.Background()
+		r53 := &MockR53Client{}
+		recorder := record.NewFakeRecorder(10)
+		r := newTestReconciler(r53, &MockS3Client{}, pd, templateCM)
+		r.Recorder = recorder
+
+		_, err := r.Reconcile(ctx, req)
+		Expect(err).NotTo(HaveOccurred())
+
+		By("rotating the zone's delegation set")
+		r53.GetHostedZoneFunc = func(ctx context.Context, params *route53.GetHostedZoneInput, optFns ...func(*route53.Options)) (*route53.GetHostedZoneOutput, error) {
+			return &route53.GetHostedZoneOutput{
+				HostedZone:    &r53types.HostedZone{Id: params.Id},
+				DelegationSet: &r53types.DelegationSet{NameServers: []string{"ns-3.awsdns.com", "ns-4.awsdns.com"}},
+			}, nil
+		}
+		_, err = r.Reconcile(ctx, req)
+		Expect(err).NotTo(HaveOccurred())
+
+		drifted := &parkingv1alpha1.ParkedDomain{}
+		Expect(r.Get(ctx, req.NamespacedName, drifted)).To(Succeed())
+		Expect(drifted.Status.NameServers).To(Equal([]string{"ns-3.awsdns.com", "ns-4.awsdns.com"}))
+		Expect(meta.IsStatusConditionTrue(drifted.Status.Conditions, parkingv1alpha1.ConditionNameServersChanged)).To(BeTrue())
+		Expect(recorder.Events).To(Receive(ContainSubstring("NameServersChanged")))
+	})
+
+	It("should ignore nameservers reported in a different order", func() {
+		r := newTestReconciler(&MockR53Client{}, &MockS3Client{})
+		pd.Status.NameServers = []string{"ns-2.awsdns.com", "NS-1.awsdns.com."}
+
+		Expect(r.updateNameServers(pd, []string{"ns-1.awsdns.com", "ns-2.awsdns.com"})).To(BeFalse())
+		Expect(meta.FindStatusCondition(pd.Status.Conditions, parkingv1alpha1.ConditionNameServersChanged)).To(BeNil())
+	})
+})
+
+var _ = Describe("Route 53 cross-account delegation", func() {
+	const (
+		dnsRole     = "arn:aws:iam::111111111111:role/parked-dns"
+		storageRole = "arn:aws:iam::222222222222:role/parked-storage"
+	)
+	var (
+		pd         *parkingv1alpha1.ParkedDomain
+		templateCM *corev1.ConfigMap
+		req        ctrl.Request
+	)
+
+	BeforeEach(func() {
+		Expect(os.Setenv("TEMPLATE_CONFIGMAP_NAME", "parked-domain-templates")).To(Succeed())
+		pd = &parkingv1alpha1.ParkedDomain{
+			ObjectMeta: metav1.ObjectMeta{Name: "spoke", Namespace: "default"},
+			Spec: parkingv1alpha1.ParkedDomainSpec{
+				DomainName:     "spoke.example.com",
+				ParentZoneID:   "PARENTZONE",
+				DNSRoleARN:     dnsRole,
+				StorageRoleARN: storageRole,
+			},
+		}
+		templateCM = &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "parked-domain-templates", Namespace: "default"},
+			Data:       map[string]string{"default.html": "<h1>{{DOMAIN_NAME}}</h1>"},
+		}
+		req = ctrl.Request{NamespacedName: types.NamespacedName{Name: "spoke", Namespace: "default"}}
+	})
+
+	AfterEach(func() {
+		Expect(os.Unsetenv("TEMPLATE_CONFIGMAP_NAME")).To(Succeed())
+	})
+
+	It("should assume the DNS and storage roles and delegate from the parent zone", func() {
+		ctx := context.Background()
+		var delegation *r53types.ResourceRecordSet
+		dnsR53 := &MockR53Client{
+			ChangeResourceRecordSetsFunc: func(ctx context.Context, params *route53.ChangeResourceRecordSetsInput, optFns ...func(*route53.Options)) (*route53.ChangeResourceRecordSetsOutput, error) {
+				if aws.ToString(params.HostedZoneId) == "PARENTZONE" {
+					delegation = params.ChangeBatch.Changes[0].ResourceRecordSet
+				}
+				return &route53.ChangeResourceRecordSetsOutput{}, nil
+			},
+		}
+		s3Factory := &MockS3ClientFactory{MockS3: &MockS3Client{}}
+		r53Factory := &MockR53ClientFactory{MockR53: dnsR53}
+		r := newTestReconciler(&MockR53Client{}, &MockS3Client{}, pd, templateCM)
+		r.S3ClientFactory = s3Factory
+		r.R53ClientFactory = r53Factory
+		r.AllowedRoles = []string{dnsRole, storageRole}
+
+		_, err := r.Reconcile(ctx, req)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(r53Factory.RequestedRoleARN).To(Equal(dnsRole))
+		Expect(r53Factory.RequestedRegion).To(Equal("us-east-1"))
+		Expect(s3Factory.RequestedRoleARN).To(Equal(storageRole))
+
+		Expect(delegation).NotTo(BeNil())
+		Expect(aws.ToString(delegation.Name)).To(Equal("spoke.example.com"))
+		Expect(delegation.Type).To(Equal(r53types.RRTypeNs))
+		var values []string
+		for _, rr := range delegation.ResourceRecords {
+			values = append(values, aws.ToString(rr.Value))
+		}
+		Expect(values).To(Equal([]string{"ns-1.awsdns.com", "ns-2.awsdns.com"}))
+	})
+
+	It("should not assume a role the operator does not allow", func() {
+		ctx := context.Background()
+		s3Factory := &MockS3ClientFactory{MockS3: &MockS3Client{}}
+		r53Factory := &MockR53ClientFactory{MockR53: &MockR53Client{}}
+		r := newTestReconciler(&MockR53Client{}, &MockS3Client{}, pd, templateCM)
+		r.S3ClientFactory = s3Factory
+		r.R53ClientFactory = r53Factory
+		r.AllowedRoles = []string{dnsRole}
+
+		_, err := r.Reconcile(ctx, req)
+		Expect(err).To(MatchError(errRoleNotAllowed))
+		Expect(errors.Is(err, reconcile.TerminalError(nil))).To(BeTrue())
+		Expect(s3Factory.RequestedRoleARN).To(BeEmpty())
+
+		failed := &parkingv1alpha1.ParkedDomain{}
+		Expect(r.Get(ctx, req.NamespacedName, failed)).To(Succeed())
+		bucket := meta.FindStatusCondition(failed.Status.Conditions, parkingv1alpha1.ConditionBucketReady)
+		Expect(bucket).NotTo(BeNil())
+		Expect(bucket.Reason).To(Equal("RoleNotAllowed"))
+		Expect(bucket.Message).To(ContainSubstring(storageRole))
+	})
+
+	It("should remove the delegation when the zone is deleted", func() {
+		var deletedFrom []string
+		r53 := &MockR53Client{
+			ListResourceRecordSetsFunc: func(ctx context.Context, params *route53.ListResourceRecordSetsInput, optFns ...func(*route53.Options)) (*route53.ListResourceRecordSetsOutput, error) {
+				if aws.ToString(params.HostedZoneId) == "PARENTZONE" {
+					return &route53.ListResourceRecordSetsOutput{ResourceRecordSets: []r53types.ResourceRecordSet{
+						{Name: aws.String("spoke.example.com."), Type: r53types.RRTypeNs},
+					}}, nil
+				}
+				return &route53.ListResourceRecordSetsOutput{ResourceRecordSets: zoneRecords(0, nil)}, nil
+			},
+			ChangeResourceRecordSetsFunc: func(ctx context.Context, params *route53.ChangeResourceRecordSetsInput, optFns ...func(*route53.Options)) (*route53.ChangeResourceRecordSetsOutput, error) {
+				deletedFrom = append(deletedFrom, aws.ToString(params.HostedZoneId))
+				return &route53.ChangeResourceRecordSetsOutput{}, nil
+			},
+		}
+		pd.Spec.DNSRoleARN = ""
+		pd.Status.ZoneID = "SPOKEZONE"
+		r := newTestReconciler(r53, &MockS3Client{})
+
+		Expect(r.cleanupRoute53Zone(context.Background(), pd)).To(BeTrue())
+		Expect(deletedFrom).To(Equal([]string{"PARENTZONE"}))
+	})
+})
+
+var _ = Describe("Route 53 parent zone lookup", func() {
+	var (
+		queried []string
+		r53     *MockR53Client
+	)
+
+	BeforeEach(func() {
+		queried = nil
+		r53 = &MockR53Client{
+			ListHostedZonesByNameFunc: func(ctx context.Context, params *route53.ListHostedZonesByNameInput, optFns ...func(*route53.Options)) (*route53.ListHostedZonesByNameOutput, error) {
+				queried = append(queried, aws.ToString(params.DNSName))
+				if aws.ToString(params.DNSName) != "example.com" {
+					return &route53.ListHostedZonesByNameOutput{HostedZones: []r53types.HostedZone{
+						{Id: aws.String("/hostedzone/OTHER"), Name: aws.String("example.net.")},
+					}}, nil
+				}
+				return &route53.ListHostedZonesByNameOutput{HostedZones: []r53types.HostedZone{
+					{Id: aws.String("/hostedzone/PRIVATE"), Name: aws.String("example.com."), Config: &r53types.HostedZoneConfig{PrivateZone: true}},
+					{Id: aws.String("/hostedzone/PUBLIC"), Name: aws.String("example.com."), Config: &r53types.HostedZoneConfig{}},
+				}}, nil
+			},
+		}
+	})
+
+	It("should find the public zone of the closest parent domain", func() {
+		pd := &parkingv1alpha1.ParkedDomain{Spec: parkingv1alpha1.ParkedDomainSpec{DomainName: "promo.shop.example.com", DelegateInParentZone: true}}
+
+		zoneID, err := parentZoneFor(context.Background(), r53, pd)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(zoneID).To(Equal("/hostedzone/PUBLIC"))
+		Expect(queried).To(Equal([]string{"shop.example.com", "example.com"}))
+	})
+
+	It("should prefer an explicit parent zone", func() {
+		pd := &parkingv1alpha1.ParkedDomain{Spec: parkingv1alpha1.ParkedDomainSpec{DomainName: "promo.example.com", DelegateInParentZone: true, ParentZoneID: "EXPLICIT"}}
+
+		Expect(parentZoneFor(context.Background(), r53, pd)).To(Equal("EXPLICIT"))
+		Expect(queried).To(BeEmpty())
+	})
+
+	It("should fail the delegation when no parent zone exists", func() {
+		pd := &parkingv1alpha1.ParkedDomain{
+			Spec:   parkingv1alpha1.ParkedDomainSpec{DomainName: "promo.example.org", DelegateInParentZone: true},
+			Status: parkingv1alpha1.ParkedDomainStatus{NameServers: []string{"ns-1.awsdns.com"}},
+		}
+		r := newTestReconciler(r53, &MockS3Client{})
+
+		err := r.reconcileParentDelegation(context.Background(), pd)
+		Expect(err).To(MatchError(ContainSubstring("no public Hosted Zone found")))
+		Expect(queried).To(Equal([]string{"example.org"}))
+
+		By("skipping cleanup of a delegation that cannot exist")
+		Expect(r.deleteParentDelegation(context.Background(), pd)).To(Succeed())
+	})
+})
@@ -2,182 +2,1089 @@ package controller
 
 import (
 	"bytes"
+	"compress/gzip"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"errors"
 	"fmt"
-	"os"
+	"maps"
+	"slices"
 	"strings"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
 	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
 	parkingv1alpha1 "github.com/gminiba/parked-domain-operator/api/v1alpha1"
+	"github.com/gminiba/parked-domain-operator/internal/awserr"
 	corev1 "k8s.io/api/core/v1"
-	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
+// bucketNameSuffixLength is the number of hex digits of the suffix Spec.AutoSuffixBucket appends.
+const bucketNameSuffixLength = 8
+
+// indexDocument is the object key the rendered page is uploaded to and served from.
+const indexDocument = "index.html"
+
+// minCompressSize is the smallest page gzip-compressed with Spec.Compress. Smaller pages fit a
+// single packet either way, so compressing them only costs the client a decompression.
+const minCompressSize = 1024
+
+const (
+	// s3CleanupDeadlineMargin is kept free before the reconcile deadline when emptying a bucket.
+	s3CleanupDeadlineMargin = 10 * time.Second
+	// s3CleanupResumeDelay is how soon a paused bucket cleanup is resumed.
+	s3CleanupResumeDelay = time.Second
+)
+
+// s3CleanupBudget bounds how long one reconcile spends emptying a bucket.
+var s3CleanupBudget = 2 * time.Minute
+
+// bucketPolicyBackoff bounds the retries of a bucket policy rejected while a new
+// bucket's public access settings are still propagating.
+var bucketPolicyBackoff = wait.Backoff{Steps: 4, Duration: time.Second, Factor: 2}
+
+// websiteConfigurationMissingReason marks a BucketReady condition reset because the bucket's
+// website configuration was removed, so the next bucket step applies its full state again.
+const websiteConfigurationMissingReason = "WebsiteConfigurationMissing"
+
+// errBucketNameTaken is returned when a bucket cannot be created because another AWS account
+// owns its name. Bucket names are global, so retrying only helps once that bucket is deleted.
+var errBucketNameTaken = errors.New("S3 bucket name is taken by another AWS account")
+
+// errPublicAccessBlocked is returned when S3 Block Public Access prevents the bucket's public
+// read policy and the ParkedDomain does not opt into a CDN. Retrying only helps once the block
+// is lifted, which an organization-wide enforcement does not allow.
+var errPublicAccessBlocked = errors.New("S3 Block Public Access prevents the public bucket policy")
+
+// errInvalidRegion is returned when the ParkedDomain's region has no S3 website endpoint. Only
+// a change to the spec fixes it, so it is returned as a terminal error that is not retried.
+var errInvalidRegion = errors.New("region has no S3 website endpoint")
+
+// errSharedBucketWithoutCDN is returned when a ParkedDomain publishes into a shared bucket
+// without a CloudFront alias target, as nothing would serve its pages. It is terminal too.
+var errSharedBucketWithoutCDN = errors.New("a shared bucket requires a CloudFront alias target")
+
+// errSharedBucketObjectLock is returned when a ParkedDomain sets Object Lock on a shared
+// bucket, which belongs to its owner and holds the pages of other ParkedDomains. It is terminal.
+var errSharedBucketObjectLock = errors.New("objectLock cannot be set on a shared bucket")
+
+// errObjectLockRetention is returned when a bucket cannot be emptied because S3 Object Lock
+// still retains some of its object versions. Retrying only helps once their retention expires.
+var errObjectLockRetention = errors.New("S3 Object Lock still retains object versions")
+
 // reconcileS3Bucket ensures the S3 bucket is correctly configured and returns its website endpoint.
 func (r *ParkedDomainReconciler) reconcileS3Bucket(ctx context.Context, pd *parkingv1alpha1.ParkedDomain) (string, error) {
 	logger := log.FromContext(ctx)
-	bucketName := pd.Spec.DomainName
+	bucketName := bucketNameFor(pd)
 
-	// Use the region from the CR spec, or default to eu-central-1.
-	region := pd.Spec.Region
-	if region == "" {
-		region = "eu-central-1"
+	region := regionFor(pd)
+	// Catch a mistyped region before CreateBucket fails on it deep in AWS. S3-compatible
+	// services name their regions as they like.
+	if r.storageEndpointFor(pd) == "" {
+		if _, err := getS3WebsiteHostedZoneID(region); err != nil {
+			r.recordEvent(pd, corev1.EventTypeWarning, "InvalidRegion", err.Error())
+			return "", reconcile.TerminalError(fmt.Errorf("%w: %w", errInvalidRegion, err))
+		}
+	}
+
+	shared := pd.Spec.SharedBucket != nil
+	if shared && (pd.Spec.AliasTarget == nil || pd.Spec.AliasTarget.Type != parkingv1alpha1.AliasTargetCloudFront) {
+		r.recordEvent(pd, corev1.EventTypeWarning, "SharedBucketWithoutCDN", errSharedBucketWithoutCDN.Error())
+		return "", reconcile.TerminalError(errSharedBucketWithoutCDN)
+	}
+	if shared && pd.Spec.ObjectLock != nil {
+		r.recordEvent(pd, corev1.EventTypeWarning, "SharedBucketObjectLock", errSharedBucketObjectLock.Error())
+		return "", reconcile.TerminalError(errSharedBucketObjectLock)
+	}
+	if err := r.checkSharedBucket(pd); err != nil {
+		r.recordEvent(pd, corev1.EventTypeWarning, "SharedBucketNotAllowed", err.Error())
+		return "", err
 	}
-	logger = logger.WithValues("region", region)
 
 	// Get a region-specific client from the factory.
-	s3Client, err := r.S3ClientFactory.GetClient(ctx, region)
+	s3Client, err := r.s3ClientFor(ctx, pd)
+	if err != nil {
+		return "", err
+	}
+	owner, err := r.bucketOwnerFor(ctx, pd)
 	if err != nil {
 		return "", err
 	}
 
-	// 1. Check if bucket exists and create if not.
-	_, err = s3Client.HeadBucket(ctx, &s3.HeadBucketInput{Bucket: aws.String(bucketName)})
+	// 1. Check if bucket exists and is ours, and create it if not.
+	created := false
+	if owner != "" {
+		err = headOwnedBucket(ctx, s3Client, bucketName, owner)
+	} else {
+		_, err = s3Client.HeadBucket(ctx, &s3.HeadBucketInput{Bucket: aws.String(bucketName)})
+	}
+	if errors.Is(err, errNotBucketOwner) {
+		r.recordEvent(pd, corev1.EventTypeWarning, "NotBucketOwner",
+			fmt.Sprintf("S3 bucket %s exists but is not owned by AWS account %s, leaving it unchanged", bucketName, owner))
+		return "", err
+	}
+	// Every further call is rejected should the bucket change hands meanwhile.
+	s3Client = withExpectedOwner(s3Client, owner)
 	if err != nil {
-		var nfe *s3types.NotFound
-		if errors.As(err, &nfe) {
+		if awserr.IsNotFound(err) {
 			logger.Info("S3 bucket not found, creating it")
-			createBucketInput := &s3.CreateBucketInput{Bucket: aws.String(bucketName)}
-			if region != "us-east-1" {
-				createBucketInput.CreateBucketConfiguration = &s3types.CreateBucketConfiguration{
-					LocationConstraint: s3types.BucketLocationConstraint(region),
+			_, createErr := s3Client.CreateBucket(ctx, createBucketInput(bucketName, region, pd.Spec.ObjectLock != nil))
+			if awserr.IsAlreadyExists(createErr) && pd.Spec.AutoSuffixBucket && pd.Status.BucketName == "" {
+				suffixed := suffixedBucketName(pd)
+				logger.Info("S3 bucket name is taken, creating the bucket with a suffix", "taken", bucketName, "bucket", suffixed)
+				_, createErr = s3Client.CreateBucket(ctx, createBucketInput(suffixed, region, pd.Spec.ObjectLock != nil))
+				if createErr == nil || awserr.IsAlreadyOwnedByYou(createErr) {
+					message := fmt.Sprintf("S3 bucket name %s belongs to another AWS account, using %s", bucketName, suffixed)
+					r.recordEvent(pd, corev1.EventTypeNormal, "BucketNameSuffixed", message)
 				}
+				bucketName = suffixed
 			}
-			if _, createErr := s3Client.CreateBucket(ctx, createBucketInput); createErr != nil {
+			switch {
+			case awserr.IsAlreadyExists(createErr):
+				message := fmt.Sprintf("S3 bucket %s cannot be created, its name belongs to another AWS account", bucketName)
+				r.recordEvent(pd, corev1.EventTypeWarning, "BucketNameTaken", message)
+				return "", fmt.Errorf("%w: %s: %w", errBucketNameTaken, bucketName, createErr)
+			case awserr.IsAlreadyOwnedByYou(createErr):
+				// Created since HeadBucket, e.g. by a concurrent reconcile. It may not be
+				// configured yet, so it is configured like a new bucket.
+				logger.Info("S3 bucket was created concurrently, configuring it")
+			case createErr != nil:
 				return "", fmt.Errorf("failed to create S3 bucket: %w", createErr)
 			}
+			created = true
+			if bucketName != recordNameFor(pd) && !shared {
+				pd.Status.BucketName = bucketName
+			}
+			if createErr == nil && pd.Status.Endpoint != "" {
+				// The bucket was provisioned before, so it was deleted outside the operator.
+				// Being new, it gets the full configuration below regardless of the last-applied hash.
+				message := fmt.Sprintf("S3 bucket %s was deleted outside the operator and was recreated", bucketName)
+				r.recordEvent(pd, corev1.EventTypeWarning, "BucketRecreated", message)
+				meta.SetStatusCondition(&pd.Status.Conditions, metav1.Condition{
+					Type:               parkingv1alpha1.ConditionDriftRepaired,
+					Status:             metav1.ConditionTrue,
+					ObservedGeneration: pd.Generation,
+					Reason:             "BucketRecreated",
+					Message:            message,
+				})
+			}
 		} else {
 			return "", fmt.Errorf("failed to check S3 bucket existence: %w", err)
 		}
 	}
 
-	// 2. Fetch, replace, and upload template from a ConfigMap.
-	cmName := os.Getenv("TEMPLATE_CONFIGMAP_NAME")
-	if cmName == "" {
-		return "", errors.New("TEMPLATE_CONFIGMAP_NAME environment variable must be set")
+	if pd.Spec.CreateAccessLogBucket && pd.Spec.AccessLogBucket != "" {
+		if err := ensureAccessLogBucket(ctx, s3Client, pd.Spec.AccessLogBucket, pd.Spec.AccessLogPrefix, bucketName, region); err != nil {
+			return "", err
+		}
 	}
 
-	cmNamespace := os.Getenv("TEMPLATE_CONFIGMAP_NAMESPACE")
-	if cmNamespace == "" {
-		cmNamespace = pd.Namespace // Default to the CR's namespace.
+	// 2. Fetch and render the template.
+	templateContent, localeTemplates, err := r.loadTemplates(ctx, pd)
+	if errors.Is(err, errTemplateConfigMapMissing) {
+		return "", contentFailed(pd, "TemplateConfigMapMissing", err)
+	}
+	if err != nil {
+		return "", contentFailed(pd, "TemplateUnavailable", err)
+	}
+	meta.RemoveStatusCondition(&pd.Status.Conditions, parkingv1alpha1.ConditionContentSourceMissing)
+	if strings.TrimSpace(templateContent) == "" {
+		return "", contentFailed(pd, "TemplateEmpty", errors.New("template is empty, refusing to publish a blank page"))
+	}
+	finalContent, err := renderTemplate(templateContent, pd)
+	if err != nil {
+		return "", contentFailed(pd, "TemplateInvalid", err)
+	}
+	localePages, err := renderLocalePages(pd, localeTemplates)
+	if err != nil {
+		return "", err
 	}
+	markStep(pd, parkingv1alpha1.ConditionContentReady, "Template rendered")
 
-	templateName := pd.Spec.TemplateName
-	if templateName == "" {
-		templateName = "default.html" // Default template key in the ConfigMap.
+	// Skip the S3 mutations when nothing changed since they were last applied.
+	desired := desiredBucketState(pd, finalContent)
+	desired.LocaleContent = localePages
+	desiredHash, err := desired.hash()
+	if err != nil {
+		return "", err
+	}
+	// A bucket that lost its website configuration matches the last-applied hash, so it is
+	// configured in full like a new bucket.
+	websiteMissing := false
+	if cond := meta.FindStatusCondition(pd.Status.Conditions, parkingv1alpha1.ConditionBucketReady); cond != nil {
+		websiteMissing = cond.Status == metav1.ConditionFalse && cond.Reason == websiteConfigurationMissingReason
+	}
+	if websiteMissing {
+		message := fmt.Sprintf("S3 bucket %s lost its website configuration outside the operator and was reconfigured", bucketName)
+		r.recordEvent(pd, corev1.EventTypeWarning, "WebsiteConfigurationRestored", message)
+		meta.SetStatusCondition(&pd.Status.Conditions, metav1.Condition{
+			Type:               parkingv1alpha1.ConditionDriftRepaired,
+			Status:             metav1.ConditionTrue,
+			ObservedGeneration: pd.Generation,
+			Reason:             "WebsiteConfigurationRestored",
+			Message:            message,
+		})
+	}
+	// A resync re-applies the state, repairing changes made outside the operator.
+	resyncing := r.resync.has(client.ObjectKeyFromObject(pd))
+	desired.Reupload = resyncing || websiteMissing
+	if !created && !websiteMissing && !resyncing && pd.Annotations[lastAppliedHashAnnotation] == desiredHash {
+		logger.V(1).Info("S3 bucket state unchanged, skipping updates")
+	} else if shared {
+		// The shared bucket is configured by whoever runs the distribution in front of it, so
+		// only the pages are uploaded.
+		if err := putPages(ctx, s3Client, bucketName, desired); err != nil {
+			return "", err
+		}
+		if err := r.setLastAppliedHash(ctx, pd, desiredHash); err != nil {
+			return "", fmt.Errorf("failed to record the applied S3 bucket state: %w", err)
+		}
+	} else {
+		applied := desired
+		if err := r.reconcilePublicAccess(ctx, s3Client, pd, bucketName, &applied); err != nil {
+			return "", err
+		}
+		if err := applyBucketState(ctx, s3Client, bucketName, applied); err != nil {
+			return "", err
+		}
+		if err := r.setLastAppliedHash(ctx, pd, desiredHash); err != nil {
+			return "", fmt.Errorf("failed to record the applied S3 bucket state: %w", err)
+		}
+	}
+	if err := deleteRemovedLocalePages(ctx, s3Client, pd, bucketName); err != nil {
+		return "", err
+	}
+	// The tags of a shared bucket are not any one ParkedDomain's to manage.
+	if !shared {
+		if err := reconcileBucketTags(ctx, s3Client, pd, bucketName); err != nil {
+			return "", err
+		}
+	}
+	if err := r.reconcileAlarm(ctx, s3Client, pd, bucketName); err != nil {
+		return "", err
 	}
 
-	templateCM := &corev1.ConfigMap{}
-	err = r.Get(ctx, types.NamespacedName{Name: cmName, Namespace: cmNamespace}, templateCM)
+	// 3. Construct the S3 website endpoint URL.
+	var s3Endpoint string
+	if shared {
+		// The distribution serves the pages, so it is the endpoint to check.
+		s3Endpoint = pd.Spec.AliasTarget.DNSName
+	} else if endpoint := r.storageEndpointFor(pd); endpoint != "" {
+		s3Endpoint, pd.Status.WebsiteURL, err = customWebsiteEndpoint(endpoint, bucketName, r.S3ForcePathStyle)
+	} else {
+		s3Endpoint, err = s3WebsiteEndpoint(bucketName, region)
+	}
 	if err != nil {
-		return "", fmt.Errorf("failed to get template ConfigMap '%s' in namespace '%s': %w", cmName, cmNamespace, err)
+		return "", err
 	}
+	pd.Status.Endpoint = s3Endpoint
+
+	logger.Info("Successfully reconciled S3 bucket", "endpoint", s3Endpoint)
+	return s3Endpoint, nil
+}
 
-	templateContent, ok := templateCM.Data[templateName]
-	if !ok {
-		return "", fmt.Errorf("template key '%s' not found in ConfigMap '%s'", templateName, cmName)
+// suffixedBucketName returns the bucket name Spec.AutoSuffixBucket falls back to: the host name
+// followed by a hash of the ParkedDomain's namespace and name, so a retried reconcile, or a
+// ParkedDomain recreated under the same name, picks the same bucket again.
+func suffixedBucketName(pd *parkingv1alpha1.ParkedDomain) string {
+	sum := sha256.Sum256([]byte(pd.Namespace + "/" + pd.Name))
+	suffix := "-" + hex.EncodeToString(sum[:])[:bucketNameSuffixLength]
+	name := recordNameFor(pd)
+	if len(name)+len(suffix) > maxBucketNameLength {
+		// Bucket names may not have a label end in a dot or hyphen.
+		name = strings.TrimRight(name[:maxBucketNameLength-len(suffix)], ".-")
 	}
+	return name + suffix
+}
 
-	finalContent := strings.ReplaceAll(templateContent, "{{DOMAIN_NAME}}", pd.Spec.DomainName)
+// bucketState is the desired configuration and content of a bucket, hashed to detect changes.
+type bucketState struct {
+	Content              string                          `json:"content"`
+	LocaleContent        map[string]string               `json:"localeContent,omitempty"`
+	ContentType          string                          `json:"contentType"`
+	Metadata             map[string]string               `json:"metadata,omitempty"`
+	Compress             bool                            `json:"compress,omitempty"`
+	StorageClass         string                          `json:"storageClass,omitempty"`
+	Encryption           *parkingv1alpha1.Encryption     `json:"encryption,omitempty"`
+	ObjectLock           *parkingv1alpha1.ObjectLock     `json:"objectLock,omitempty"`
+	IndexDocument        string                          `json:"indexDocument"`
+	ErrorDocument        string                          `json:"errorDocument,omitempty"`
+	KeyPrefix            string                          `json:"keyPrefix,omitempty"`
+	ManifestKey          string                          `json:"manifestKey,omitempty"`
+	Policy               string                          `json:"policy"`
+	ObjectOwnership      string                          `json:"objectOwnership,omitempty"`
+	TransferAcceleration *bool                           `json:"transferAcceleration,omitempty"`
+	RequesterPays        *bool                           `json:"requesterPays,omitempty"`
+	LifecycleRules       []parkingv1alpha1.LifecycleRule `json:"lifecycleRules,omitempty"`
+	AccessLogBucket      string                          `json:"accessLogBucket,omitempty"`
+	AccessLogPrefix      string                          `json:"accessLogPrefix,omitempty"`
+	RequireCDN           bool                            `json:"requireCDN,omitempty"`
+	// PublicRead is whether the page is readable by anyone. It follows from Policy, so it is
+	// not hashed.
+	PublicRead bool `json:"-"`
+	// ObjectACL is the canned ACL pages are uploaded with, found by applyBucketState.
+	ObjectACL s3types.ObjectCannedACL `json:"-"`
+	// Reupload uploads every page, even those the manifest lists as unchanged.
+	Reupload bool `json:"-"`
+}
 
-	_, err = s3Client.PutObject(ctx, &s3.PutObjectInput{
-		Bucket:      aws.String(bucketName),
-		Key:         aws.String("index.html"),
-		Body:        bytes.NewReader([]byte(finalContent)),
-		ContentType: aws.String("text/html"),
-	})
+// desiredBucketState returns the state pd's bucket should have when serving content.
+func desiredBucketState(pd *parkingv1alpha1.ParkedDomain, content string) bucketState {
+	state := bucketState{
+		Content:              content,
+		ContentType:          contentTypeFor(indexDocument, pd.Spec.ContentTypes),
+		Metadata:             pd.Spec.ObjectMetadata,
+		Compress:             pd.Spec.Compress,
+		StorageClass:         pd.Spec.StorageClass,
+		Encryption:           pd.Spec.Encryption,
+		ObjectLock:           pd.Spec.ObjectLock,
+		IndexDocument:        indexDocument,
+		KeyPrefix:            keyPrefixFor(pd),
+		ManifestKey:          pd.Spec.ManifestKey,
+		Policy:               bucketReadPolicy(bucketNameFor(pd), pd.Spec.PrivateZone, pd.Spec.ManifestKey),
+		ObjectOwnership:      pd.Spec.ObjectOwnership,
+		TransferAcceleration: pd.Spec.TransferAcceleration,
+		RequesterPays:        pd.Spec.RequesterPays,
+		LifecycleRules:       pd.Spec.LifecycleRules,
+		AccessLogBucket:      pd.Spec.AccessLogBucket,
+		AccessLogPrefix:      pd.Spec.AccessLogPrefix,
+		RequireCDN:           pd.Spec.RequireCDNWhenBPAEnforced,
+		PublicRead:           pd.Spec.PrivateZone == nil,
+	}
+	if pd.Spec.SPAFallback {
+		state.ErrorDocument = indexDocument
+	}
+	if pd.Spec.SharedBucket != nil {
+		// A shared bucket stays private behind its distribution.
+		state.Policy, state.PublicRead = "", false
+	}
+	return state
+}
+
+// hash returns a hex-encoded SHA-256 digest of the state.
+func (s bucketState) hash() (string, error) {
+	data, err := json.Marshal(s)
 	if err != nil {
-		return "", fmt.Errorf("failed to upload final index.html: %w", err)
+		return "", fmt.Errorf("failed to hash S3 bucket state: %w", err)
 	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
 
-	// 3. Enable static website hosting.
-	_, err = s3Client.PutBucketWebsite(ctx, &s3.PutBucketWebsiteInput{
+// applyBucketState configures the bucket and uploads its content.
+func applyBucketState(ctx context.Context, s3Client S3ClientAPI, bucketName string, state bucketState) error {
+	if err := reconcileBucketOwnership(ctx, s3Client, bucketName, state.ObjectOwnership); err != nil {
+		return err
+	}
+	if err := reconcileBucketTransferSettings(ctx, s3Client, bucketName, state.TransferAcceleration, state.RequesterPays); err != nil {
+		return err
+	}
+	// The default retention applies to the pages uploaded after it, so it is set first.
+	if err := reconcileBucketObjectLock(ctx, s3Client, bucketName, state.ObjectLock); err != nil {
+		return err
+	}
+	if state.PublicRead {
+		aclsEnabled, err := bucketACLsEnabled(ctx, s3Client, bucketName, state.ObjectOwnership)
+		if err != nil {
+			return err
+		}
+		if aclsEnabled {
+			// The bucket policy only covers objects the bucket owner owns. With ACLs enabled, an
+			// object may be owned by its writer, so each page is made public by its own ACL too,
+			// unless Block Public Access would reject the upload for it.
+			blocked, err := publicACLsBlocked(ctx, s3Client, bucketName)
+			if err != nil {
+				return err
+			}
+			if !blocked {
+				state.ObjectACL = s3types.ObjectCannedACLPublicRead
+			}
+		}
+	}
+
+	if err := putPages(ctx, s3Client, bucketName, state); err != nil {
+		return err
+	}
+
+	// Enable static website hosting.
+	websiteConfig := &s3types.WebsiteConfiguration{IndexDocument: &s3types.IndexDocument{Suffix: aws.String(state.IndexDocument)}}
+	if state.ErrorDocument != "" {
+		websiteConfig.ErrorDocument = &s3types.ErrorDocument{Key: aws.String(state.ErrorDocument)}
+	}
+	_, err := s3Client.PutBucketWebsite(ctx, &s3.PutBucketWebsiteInput{
 		Bucket:               aws.String(bucketName),
-		WebsiteConfiguration: &s3types.WebsiteConfiguration{IndexDocument: &s3types.IndexDocument{Suffix: aws.String("index.html")}},
+		WebsiteConfiguration: websiteConfig,
 	})
+	if err != nil && !awserr.IsNotImplemented(err) {
+		return fmt.Errorf("failed to enable S3 static website hosting: %w", err)
+	}
+
+	// Apply a read bucket policy, public or limited to the private zone's VPC. A bucket kept
+	// private for a CDN has none.
+	if state.Policy != "" {
+		if err := putBucketPolicy(ctx, s3Client, bucketName, state.Policy); err != nil {
+			return fmt.Errorf("failed to apply S3 bucket policy: %w", err)
+		}
+	}
+
+	if err := reconcileBucketLifecycle(ctx, s3Client, bucketName, state.LifecycleRules); err != nil {
+		return err
+	}
+	return reconcileBucketLogging(ctx, s3Client, bucketName, state.AccessLogBucket, state.AccessLogPrefix)
+}
+
+// putPages uploads the index page and the locale pages of state under its key prefix.
+func putPages(ctx context.Context, s3Client S3ClientAPI, bucketName string, state bucketState) error {
+	if state.ManifestKey != "" {
+		return syncPages(ctx, s3Client, bucketName, state)
+	}
+	if err := putPage(ctx, s3Client, bucketName, state.KeyPrefix+indexDocument, state.Content, state); err != nil {
+		return err
+	}
+	for _, locale := range slices.Sorted(maps.Keys(state.LocaleContent)) {
+		if err := putPage(ctx, s3Client, bucketName, state.KeyPrefix+localePageKey(locale), state.LocaleContent[locale], state); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// putPage uploads a rendered page to key with the content type, metadata, compression, storage
+// class and encryption of state.
+func putPage(ctx context.Context, s3Client S3ClientAPI, bucketName, key, content string, state bucketState) error {
+	body := []byte(content)
+	var contentEncoding *string
+	if state.Compress {
+		compressed, err := gzipContent(body)
+		if err != nil {
+			return err
+		}
+		if compressed != nil {
+			body, contentEncoding = compressed, aws.String("gzip")
+		}
+	}
+	put := &s3.PutObjectInput{
+		Bucket:          aws.String(bucketName),
+		Key:             aws.String(key),
+		Body:            bytes.NewReader(body),
+		ContentType:     aws.String(state.ContentType),
+		ContentEncoding: contentEncoding,
+		Metadata:        state.Metadata,
+		StorageClass:    storageClassFor(state.StorageClass),
+		ACL:             state.ObjectACL,
+	}
+	if enc := state.Encryption; enc != nil {
+		put.ServerSideEncryption = s3types.ServerSideEncryption(enc.Algorithm)
+		if enc.KMSKeyID != "" {
+			put.SSEKMSKeyId = aws.String(enc.KMSKeyID)
+		}
+	}
+	if _, err := s3Client.PutObject(ctx, put); err != nil {
+		return fmt.Errorf("failed to upload final %s: %w", key, err)
+	}
+	return nil
+}
+
+// gzipContent returns content gzip-compressed, or nil when it is smaller than minCompressSize or
+// does not shrink.
+func gzipContent(content []byte) ([]byte, error) {
+	if len(content) < minCompressSize {
+		return nil, nil
+	}
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write(content); err != nil {
+		return nil, fmt.Errorf("failed to compress index.html: %w", err)
+	}
+	if err := zw.Close(); err != nil {
+		return nil, fmt.Errorf("failed to compress index.html: %w", err)
+	}
+	if buf.Len() >= len(content) {
+		return nil, nil
+	}
+	return buf.Bytes(), nil
+}
+
+// storageClassFor returns the S3 storage class for the page, STANDARD when none is set.
+func storageClassFor(storageClass string) s3types.StorageClass {
+	if storageClass == "" {
+		return s3types.StorageClassStandard
+	}
+	return s3types.StorageClass(storageClass)
+}
+
+// setLastAppliedHash stores hash in pd's lastAppliedHashAnnotation. Only the annotation is
+// patched, so the status built up during this reconcile is kept in pd.
+func (r *ParkedDomainReconciler) setLastAppliedHash(ctx context.Context, pd *parkingv1alpha1.ParkedDomain, hash string) error {
+	if pd.Annotations[lastAppliedHashAnnotation] == hash {
+		return nil
+	}
+	patched := pd.DeepCopy()
+	if patched.Annotations == nil {
+		patched.Annotations = map[string]string{}
+	}
+	patched.Annotations[lastAppliedHashAnnotation] = hash
+	if err := r.Patch(ctx, patched, client.MergeFrom(pd)); err != nil {
+		return err
+	}
+	pd.Annotations = patched.Annotations
+	pd.ResourceVersion = patched.ResourceVersion
+	return nil
+}
+
+// contentFailed records why the page content could not be produced in the ContentReady condition
+// and returns err.
+func contentFailed(pd *parkingv1alpha1.ParkedDomain, reason string, err error) error {
+	meta.SetStatusCondition(&pd.Status.Conditions, metav1.Condition{
+		Type:               parkingv1alpha1.ConditionContentReady,
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: pd.Generation,
+		Reason:             reason,
+		Message:            err.Error(),
+	})
+	return err
+}
+
+// bucketReadPolicy returns the policy letting the website endpoint serve the bucket's objects.
+// For a private zone, reads are only allowed from its VPC, which requires an S3 gateway endpoint.
+// The manifest at manifestKey, if any, is denied to the anonymous readers the website serves.
+func bucketReadPolicy(bucketName string, privateZone *parkingv1alpha1.PrivateZone, manifestKey string) string {
+	statement := fmt.Sprintf(`{"Sid":"PublicReadGetObject","Effect":"Allow","Principal":"*","Action":"s3:GetObject","Resource":"arn:aws:s3:::%s/*"}`, bucketName)
+	if privateZone != nil {
+		statement = fmt.Sprintf(`{"Sid":"VPCReadGetObject","Effect":"Allow","Principal":"*","Action":"s3:GetObject","Resource":"arn:aws:s3:::%s/*","Condition":{"StringEquals":{"aws:SourceVpc":"%s"}}}`, bucketName, privateZone.VPCID)
+	}
+	if manifestKey != "" {
+		statement += fmt.Sprintf(`,{"Sid":"DenyAnonymousManifest","Effect":"Deny","Principal":"*","Action":"s3:GetObject","Resource":"arn:aws:s3:::%s/%s","Condition":{"StringEquals":{"aws:PrincipalType":"Anonymous"}}}`, bucketName, manifestKey)
+	}
+	return `{"Version":"2012-10-17","Statement":[` + statement + `]}`
+}
+
+// createBucketInput returns the request creating bucketName in region.
+func createBucketInput(bucketName, region string, objectLock bool) *s3.CreateBucketInput {
+	input := &s3.CreateBucketInput{Bucket: aws.String(bucketName)}
+	if objectLock {
+		input.ObjectLockEnabledForBucket = aws.Bool(true)
+	}
+	if region != "us-east-1" {
+		input.CreateBucketConfiguration = &s3types.CreateBucketConfiguration{
+			LocationConstraint: s3types.BucketLocationConstraint(region),
+		}
+	}
+	return input
+}
+
+// accessLogDeliveryPolicy lets the S3 logging service write sourceBucket's access logs under
+// prefix in logBucket.
+func accessLogDeliveryPolicy(logBucket, prefix, sourceBucket string) string {
+	return fmt.Sprintf(`{"Version":"2012-10-17","Statement":[{"Sid":"S3ServerAccessLogsPolicy","Effect":"Allow","Principal":{"Service":"logging.s3.amazonaws.com"},"Action":"s3:PutObject","Resource":"arn:aws:s3:::%s/%s*","Condition":{"ArnLike":{"aws:SourceArn":"arn:aws:s3:::%s"}}}]}`, logBucket, prefix, sourceBucket)
+}
+
+// ensureAccessLogBucket creates logBucket in region when it does not exist, with ownership
+// enforced and a policy letting S3 deliver sourceBucket's access logs to it. An existing
+// bucket is left as it is, as it may collect the logs of other buckets too.
+func ensureAccessLogBucket(ctx context.Context, s3Client S3ClientAPI, logBucket, prefix, sourceBucket, region string) error {
+	_, err := s3Client.HeadBucket(ctx, &s3.HeadBucketInput{Bucket: aws.String(logBucket)})
+	if err == nil {
+		return nil
+	}
+	if !awserr.IsNotFound(err) {
+		return fmt.Errorf("failed to check access log bucket existence: %w", err)
+	}
+
+	log.FromContext(ctx).Info("Access log bucket not found, creating it", "accessLogBucket", logBucket)
+	_, err = s3Client.CreateBucket(ctx, createBucketInput(logBucket, region, false))
+	switch {
+	case awserr.IsAlreadyExists(err):
+		return fmt.Errorf("%w: access log bucket %s: %w", errBucketNameTaken, logBucket, err)
+	case awserr.IsAlreadyOwnedByYou(err):
+		// Created since HeadBucket, so it is left to whoever created it like any existing bucket.
+		return nil
+	case err != nil:
+		return fmt.Errorf("failed to create access log bucket: %w", err)
+	}
+	if err := reconcileBucketOwnership(ctx, s3Client, logBucket, string(s3types.ObjectOwnershipBucketOwnerEnforced)); err != nil {
+		return err
+	}
+	if err := putBucketPolicy(ctx, s3Client, logBucket, accessLogDeliveryPolicy(logBucket, prefix, sourceBucket)); err != nil {
+		return fmt.Errorf("failed to apply access log bucket policy: %w", err)
+	}
+	return nil
+}
+
+// reconcileBucketLogging delivers the bucket's access logs to logBucket, or turns access
+// logging off when logBucket is empty.
+func reconcileBucketLogging(ctx context.Context, s3Client S3ClientAPI, bucketName, logBucket, prefix string) error {
+	status := &s3types.BucketLoggingStatus{}
+	if logBucket != "" {
+		status.LoggingEnabled = &s3types.LoggingEnabled{
+			TargetBucket: aws.String(logBucket),
+			TargetPrefix: aws.String(prefix),
+		}
+	}
+	_, err := s3Client.PutBucketLogging(ctx, &s3.PutBucketLoggingInput{
+		Bucket:              aws.String(bucketName),
+		BucketLoggingStatus: status,
+	})
+	if err != nil && !awserr.IsNotImplemented(err) {
+		return fmt.Errorf("failed to apply S3 bucket access logging: %w", err)
+	}
+	return nil
+}
+
+// reconcileBucketOwnership applies the desired object ownership controls. An empty
+// ownership leaves the bucket's current setting untouched.
+func reconcileBucketOwnership(ctx context.Context, s3Client S3ClientAPI, bucketName, ownership string) error {
+	if ownership == "" {
+		return nil
+	}
+	_, err := s3Client.PutBucketOwnershipControls(ctx, &s3.PutBucketOwnershipControlsInput{
+		Bucket: aws.String(bucketName),
+		OwnershipControls: &s3types.OwnershipControls{
+			Rules: []s3types.OwnershipControlsRule{{ObjectOwnership: s3types.ObjectOwnership(ownership)}},
+		},
+	})
+	if err != nil && !awserr.IsNotImplemented(err) {
+		return fmt.Errorf("failed to apply S3 bucket ownership controls: %w", err)
+	}
+	return nil
+}
+
+// bucketACLsEnabled reports whether the bucket's object ownership leaves ACLs enabled, as for
+// buckets created with ObjectWriter ownership or before S3 disabled ACLs by default. ownership,
+// when set, was just applied and is used without reading it back.
+func bucketACLsEnabled(ctx context.Context, s3Client S3ClientAPI, bucketName, ownership string) (bool, error) {
+	if ownership == "" {
+		out, err := s3Client.GetBucketOwnershipControls(ctx, &s3.GetBucketOwnershipControlsInput{Bucket: aws.String(bucketName)})
+		switch {
+		case awserr.Code(err) == "OwnershipControlsNotFoundError":
+			// Buckets without ownership controls predate them and have ACLs enabled.
+			return true, nil
+		case awserr.IsNotImplemented(err):
+			return false, nil
+		case err != nil:
+			return false, fmt.Errorf("failed to get S3 bucket ownership controls: %w", err)
+		}
+		if out.OwnershipControls == nil || len(out.OwnershipControls.Rules) == 0 {
+			return false, nil
+		}
+		ownership = string(out.OwnershipControls.Rules[0].ObjectOwnership)
+	}
+	return ownership != string(s3types.ObjectOwnershipBucketOwnerEnforced), nil
+}
+
+// reconcileBucketTransferSettings enforces the desired transfer acceleration and requester-pays
+// settings. A nil setting is not checked, to avoid an API call for buckets that don't care.
+func reconcileBucketTransferSettings(ctx context.Context, s3Client S3ClientAPI, bucketName string, acceleration, requesterPays *bool) error {
+	if acceleration != nil {
+		status := s3types.BucketAccelerateStatusSuspended
+		if *acceleration {
+			status = s3types.BucketAccelerateStatusEnabled
+		}
+		_, err := s3Client.PutBucketAccelerateConfiguration(ctx, &s3.PutBucketAccelerateConfigurationInput{
+			Bucket:                  aws.String(bucketName),
+			AccelerateConfiguration: &s3types.AccelerateConfiguration{Status: status},
+		})
+		if err != nil {
+			return fmt.Errorf("failed to apply S3 bucket transfer acceleration: %w", err)
+		}
+	}
+
+	if requesterPays != nil {
+		payer := s3types.PayerBucketOwner
+		if *requesterPays {
+			payer = s3types.PayerRequester
+		}
+		_, err := s3Client.PutBucketRequestPayment(ctx, &s3.PutBucketRequestPaymentInput{
+			Bucket:                      aws.String(bucketName),
+			RequestPaymentConfiguration: &s3types.RequestPaymentConfiguration{Payer: payer},
+		})
+		if err != nil {
+			return fmt.Errorf("failed to apply S3 bucket request payment: %w", err)
+		}
+	}
+	return nil
+}
+
+// reconcilePublicAccess checks whether S3 Block Public Access prevents the public read policy in
+// state, before any content is uploaded. A blocked ParkedDomain requiring a CDN keeps its bucket
+// private by dropping the policy from state; any other blocked ParkedDomain fails with
+// errPublicAccessBlocked instead of serving a page that only answers 403.
+func (r *ParkedDomainReconciler) reconcilePublicAccess(ctx context.Context, s3Client S3ClientAPI, pd *parkingv1alpha1.ParkedDomain, bucketName string, state *bucketState) error {
+	// Private zone policies are limited to a VPC, which Block Public Access allows, and
+	// S3-compatible services have no such setting.
+	if pd.Spec.PrivateZone != nil || r.storageEndpointFor(pd) != "" {
+		meta.RemoveStatusCondition(&pd.Status.Conditions, parkingv1alpha1.ConditionPublicAccessBlocked)
+		return nil
+	}
+	blocked, err := publicAccessBlocked(ctx, s3Client, bucketName)
+	if err != nil {
+		return err
+	}
+	if !blocked {
+		meta.RemoveStatusCondition(&pd.Status.Conditions, parkingv1alpha1.ConditionPublicAccessBlocked)
+		return nil
+	}
+
+	if pd.Spec.RequireCDNWhenBPAEnforced {
+		log.FromContext(ctx).Info("S3 Block Public Access is enforced, keeping the bucket private for a CDN")
+		state.Policy = ""
+		state.PublicRead = false
+		meta.SetStatusCondition(&pd.Status.Conditions, metav1.Condition{
+			Type:               parkingv1alpha1.ConditionPublicAccessBlocked,
+			Status:             metav1.ConditionTrue,
+			ObservedGeneration: pd.Generation,
+			Reason:             "CDNRequired",
+			Message: fmt.Sprintf("S3 Block Public Access prevents a public policy on bucket %s, so it is kept private. "+
+				"Serve it through a CDN, e.g. a CloudFront distribution with an origin access control set as spec.aliasTarget", bucketName),
+		})
+		return nil
+	}
+
+	message := fmt.Sprintf("S3 Block Public Access prevents a public policy on bucket %s, so the page would not be served. "+
+		"Lift the block for the bucket and account, or set spec.requireCDNWhenBPAEnforced and serve the bucket through a CDN", bucketName)
+	meta.SetStatusCondition(&pd.Status.Conditions, metav1.Condition{
+		Type:               parkingv1alpha1.ConditionPublicAccessBlocked,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: pd.Generation,
+		Reason:             "PublicPolicyBlocked",
+		Message:            message,
+	})
+	r.recordEvent(pd, corev1.EventTypeWarning, "PublicAccessBlocked", message)
+	return fmt.Errorf("%w: %s", errPublicAccessBlocked, bucketName)
+}
+
+// publicAccessBlocked reports whether the bucket's Block Public Access settings reject or
+// neutralise a public bucket policy. A bucket without such settings is not blocked.
+func publicAccessBlocked(ctx context.Context, s3Client S3ClientAPI, bucketName string) (bool, error) {
+	out, err := s3Client.GetPublicAccessBlock(ctx, &s3.GetPublicAccessBlockInput{Bucket: aws.String(bucketName)})
+	if awserr.IsNotFound(err) || awserr.IsNotImplemented(err) {
+		return false, nil
+	}
 	if err != nil {
-		return "", fmt.Errorf("failed to enable S3 static website hosting: %w", err)
+		return false, fmt.Errorf("failed to get S3 bucket public access block: %w", err)
 	}
+	cfg := out.PublicAccessBlockConfiguration
+	return cfg != nil && (aws.ToBool(cfg.BlockPublicPolicy) || aws.ToBool(cfg.RestrictPublicBuckets)), nil
+}
+
+// publicACLsBlocked reports whether the bucket's Block Public Access settings reject or ignore
+// public object ACLs. A bucket without such settings is not blocked.
+func publicACLsBlocked(ctx context.Context, s3Client S3ClientAPI, bucketName string) (bool, error) {
+	out, err := s3Client.GetPublicAccessBlock(ctx, &s3.GetPublicAccessBlockInput{Bucket: aws.String(bucketName)})
+	if awserr.IsNotFound(err) || awserr.IsNotImplemented(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to get S3 bucket public access block: %w", err)
+	}
+	cfg := out.PublicAccessBlockConfiguration
+	return cfg != nil && (aws.ToBool(cfg.BlockPublicAcls) || aws.ToBool(cfg.IgnorePublicAcls)), nil
+}
+
+// putBucketPolicy applies policy to the bucket. Right after the bucket or its public access
+// block is created, S3 may still reject a public policy with MalformedPolicy or AccessDenied,
+// so those errors are retried a bounded number of times before giving up.
+func putBucketPolicy(ctx context.Context, s3Client S3ClientAPI, bucketName, policy string) error {
+	return retry.OnError(bucketPolicyBackoff, isTransientPolicyError, func() error {
+		_, err := s3Client.PutBucketPolicy(ctx, &s3.PutBucketPolicyInput{
+			Bucket: aws.String(bucketName),
+			Policy: aws.String(policy),
+		})
+		return err
+	})
+}
+
+// isTransientPolicyError reports whether a PutBucketPolicy error is likely caused by
+// public access settings that have not propagated yet.
+func isTransientPolicyError(err error) bool {
+	return awserr.Code(err) == "MalformedPolicy" || awserr.IsAccessDenied(err)
+}
 
-	// 4. Apply a public-read bucket policy.
-	policy := fmt.Sprintf(`{"Version":"2012-10-17","Statement":[{"Sid":"PublicReadGetObject","Effect":"Allow","Principal":"*","Action":"s3:GetObject","Resource":"arn:aws:s3:::%s/*"}]}`, bucketName)
-	_, err = s3Client.PutBucketPolicy(ctx, &s3.PutBucketPolicyInput{
+// reconcileBucketObjectLock sets the bucket's default Object Lock retention. Object Lock cannot
+// be turned off again, so without lock the bucket is left as it is.
+func reconcileBucketObjectLock(ctx context.Context, s3Client S3ClientAPI, bucketName string, lock *parkingv1alpha1.ObjectLock) error {
+	if lock == nil {
+		return nil
+	}
+	_, err := s3Client.PutObjectLockConfiguration(ctx, &s3.PutObjectLockConfigurationInput{
 		Bucket: aws.String(bucketName),
-		Policy: aws.String(policy),
+		ObjectLockConfiguration: &s3types.ObjectLockConfiguration{
+			ObjectLockEnabled: s3types.ObjectLockEnabledEnabled,
+			Rule: &s3types.ObjectLockRule{DefaultRetention: &s3types.DefaultRetention{
+				Mode: s3types.ObjectLockRetentionMode(lock.Mode),
+				Days: aws.Int32(lock.Days),
+			}},
+		},
 	})
 	if err != nil {
-		return "", fmt.Errorf("failed to apply S3 bucket policy: %w", err)
+		return fmt.Errorf("failed to set S3 Object Lock retention: %w", err)
 	}
+	return nil
+}
 
-	// 5. Construct the S3 website endpoint URL.
-	s3Endpoint := fmt.Sprintf("%s.s3-website.%s.amazonaws.com", bucketName, region)
+// reconcileBucketLifecycle replaces the bucket lifecycle configuration with the desired rules,
+// or removes it when no rules are desired.
+func reconcileBucketLifecycle(ctx context.Context, s3Client S3ClientAPI, bucketName string, rules []parkingv1alpha1.LifecycleRule) error {
+	if len(rules) == 0 {
+		_, err := s3Client.DeleteBucketLifecycle(ctx, &s3.DeleteBucketLifecycleInput{Bucket: aws.String(bucketName)})
+		if err != nil {
+			return fmt.Errorf("failed to remove S3 bucket lifecycle configuration: %w", err)
+		}
+		return nil
+	}
 
-	logger.Info("Successfully reconciled S3 bucket", "BucketName", bucketName, "Endpoint", s3Endpoint)
-	return s3Endpoint, nil
+	s3Rules := make([]s3types.LifecycleRule, 0, len(rules))
+	for _, rule := range rules {
+		s3Rule := s3types.LifecycleRule{
+			ID:     aws.String(rule.ID),
+			Status: s3types.ExpirationStatusEnabled,
+			Filter: &s3types.LifecycleRuleFilter{Prefix: aws.String(rule.Prefix)},
+		}
+		if rule.ExpirationDays != nil {
+			s3Rule.Expiration = &s3types.LifecycleExpiration{Days: rule.ExpirationDays}
+		}
+		if rule.NoncurrentVersionExpirationDays != nil {
+			s3Rule.NoncurrentVersionExpiration = &s3types.NoncurrentVersionExpiration{NoncurrentDays: rule.NoncurrentVersionExpirationDays}
+		}
+		s3Rules = append(s3Rules, s3Rule)
+	}
+
+	_, err := s3Client.PutBucketLifecycleConfiguration(ctx, &s3.PutBucketLifecycleConfigurationInput{
+		Bucket:                 aws.String(bucketName),
+		LifecycleConfiguration: &s3types.BucketLifecycleConfiguration{Rules: s3Rules},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to apply S3 bucket lifecycle configuration: %w", err)
+	}
+	return nil
 }
 
-// cleanupS3Bucket empties and deletes the S3 bucket in the correct region.
-func (r *ParkedDomainReconciler) cleanupS3Bucket(ctx context.Context, pd *parkingv1alpha1.ParkedDomain) error {
+// cleanupS3Bucket empties and deletes the S3 bucket in the correct region, or only deletes the
+// ParkedDomain's prefix of a shared bucket. Emptying a large bucket may not fit in one
+// reconcile: when the time budget runs out it stops after the current page and returns false,
+// and the next call resumes with the objects still listed.
+func (r *ParkedDomainReconciler) cleanupS3Bucket(ctx context.Context, pd *parkingv1alpha1.ParkedDomain) (bool, error) {
 	logger := log.FromContext(ctx)
-	bucketName := pd.Spec.DomainName
-
-	// Use the region from the CR spec, or default to eu-central-1.
-	region := pd.Spec.Region
-	if region == "" {
-		region = "eu-central-1"
+	bucketName := bucketNameFor(pd)
+	if r.checkSharedBucket(pd) != nil {
+		// Nothing was published into a bucket that is not allowed.
+		logger.Info("Shared S3 bucket is not allowed, leaving it alone", "bucket", bucketName)
+		return true, nil
 	}
-	logger = logger.WithValues("region", region)
 
 	// Get a region-specific client from the factory for cleanup.
-	s3Client, err := r.S3ClientFactory.GetClient(ctx, region)
+	s3Client, err := r.s3ClientFor(ctx, pd)
 	if err != nil {
-		return err
+		return false, err
 	}
+	owner, err := r.bucketOwnerFor(ctx, pd)
+	if err != nil {
+		return false, err
+	}
+	if owner != "" {
+		// A bucket of another account was never configured, so there is nothing to clean up.
+		if err := headOwnedBucket(ctx, s3Client, bucketName, owner); errors.Is(err, errNotBucketOwner) {
+			logger.Info("S3 bucket is owned by another AWS account, leaving it alone", "owner", owner)
+			return true, nil
+		}
+	}
+	s3Client = withExpectedOwner(s3Client, owner)
+
+	logger.Info("Starting S3 bucket cleanup")
 
-	logger.Info("Starting S3 bucket cleanup", "BucketName", bucketName)
+	if err := r.deleteAlarm(ctx, s3Client, pd, bucketName); err != nil {
+		return false, err
+	}
+
+	batchSize, err := cleanupBatchSizeFor(pd)
+	if err != nil {
+		logger.Info("Ignoring invalid cleanup batch size", "error", err.Error())
+		r.recordEvent(pd, corev1.EventTypeWarning, "InvalidCleanupBatchSize", fmt.Sprintf("%s, deleting %d objects per call", err, batchSize))
+	}
 
-	// Empty the bucket before deletion.
-	paginator := s3.NewListObjectsV2Paginator(s3Client, &s3.ListObjectsV2Input{Bucket: aws.String(bucketName)})
+	// Empty the bucket before deletion. Deleted objects drop out of the listing,
+	// so the bucket itself records how far an interrupted cleanup got.
+	deadline := s3CleanupDeadline(ctx)
+	if pd.Spec.ObjectLock != nil {
+		done, err := emptyVersionedBucket(ctx, s3Client, bucketName, keyPrefixFor(pd), batchSize, deadline)
+		if awserr.IsNotFound(err) {
+			logger.Info("S3 bucket not found during list, cleanup is considered successful.")
+			return true, nil
+		}
+		if err != nil || !done {
+			return false, err
+		}
+		if pd.Spec.SharedBucket != nil {
+			// Admission rejects Object Lock on a shared bucket, but a ParkedDomain created
+			// before that must still leave the other ParkedDomains' pages alone.
+			logger.Info("Shared S3 bucket prefix cleanup complete")
+			return true, nil
+		}
+		return deleteBucket(ctx, s3Client, bucketName)
+	}
+	deleted := 0
+	// Each page is deleted in one call, so the page size is the batch size.
+	listInput := listObjectsInput(bucketName, keyPrefixFor(pd))
+	listInput.MaxKeys = aws.Int32(batchSize)
+	paginator := s3.NewListObjectsV2Paginator(s3Client, listInput)
 	for paginator.HasMorePages() {
 		page, err := paginator.NextPage(ctx)
 		if err != nil {
 			// If the bucket doesn't exist, cleanup is successful.
-			var nsb *s3types.NoSuchBucket
-			if errors.As(err, &nsb) {
-				logger.Info("S3 bucket not found during list, cleanup is considered successful.", "BucketName", bucketName)
-				return nil
+			if awserr.IsNotFound(err) {
+				logger.Info("S3 bucket not found during list, cleanup is considered successful.")
+				return true, nil
 			}
-			return fmt.Errorf("failed to list objects in S3 bucket for deletion: %w", err)
+			return false, fmt.Errorf("failed to list objects in S3 bucket for deletion: %w", err)
 		}
 		if len(page.Contents) > 0 {
-			var objectsToDelete []s3types.ObjectIdentifier
-			for _, obj := range page.Contents {
-				objectsToDelete = append(objectsToDelete, s3types.ObjectIdentifier{Key: obj.Key})
+			if err := deleteObjects(ctx, s3Client, bucketName, page.Contents); err != nil {
+				return false, err
 			}
-			_, err := s3Client.DeleteObjects(ctx, &s3.DeleteObjectsInput{
-				Bucket: aws.String(bucketName),
-				Delete: &s3types.Delete{Objects: objectsToDelete},
-			})
+			deleted += len(page.Contents)
+		}
+		if paginator.HasMorePages() && time.Now().After(deadline) {
+			logger.Info("S3 bucket cleanup paused, resuming on the next reconcile", "deletedObjects", deleted)
+			return false, nil
+		}
+	}
+
+	if pd.Spec.SharedBucket != nil {
+		// Other ParkedDomains keep publishing into the shared bucket.
+		logger.Info("Shared S3 bucket prefix cleanup complete")
+		return true, nil
+	}
+
+	return deleteBucket(ctx, s3Client, bucketName)
+}
+
+// deleteBucket deletes an emptied bucket. A bucket that is already gone counts as deleted.
+func deleteBucket(ctx context.Context, s3Client S3ClientAPI, bucketName string) (bool, error) {
+	_, err := s3Client.DeleteBucket(ctx, &s3.DeleteBucketInput{Bucket: aws.String(bucketName)})
+	if err != nil && !awserr.IsNotFound(err) {
+		return false, fmt.Errorf("failed to delete S3 bucket: %w", err)
+	}
+	log.FromContext(ctx).Info("S3 Bucket cleanup complete")
+	return true, nil
+}
+
+// emptyVersionedBucket deletes every object version and delete marker under prefix of an
+// Object Lock bucket, as deleting its current objects would only hide them behind delete
+// markers. An empty prefix empties the whole bucket. It returns false when the time budget
+// ran out, and errObjectLockRetention when versions under retention were left. At most
+// batchSize versions are deleted per call.
+func emptyVersionedBucket(ctx context.Context, s3Client S3ClientAPI, bucketName, prefix string, batchSize int32, deadline time.Time) (bool, error) {
+	retained := 0
+	input := &s3.ListObjectVersionsInput{Bucket: aws.String(bucketName), MaxKeys: aws.Int32(batchSize)}
+	if prefix != "" {
+		input.Prefix = aws.String(prefix)
+	}
+	paginator := s3.NewListObjectVersionsPaginator(s3Client, input)
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return false, fmt.Errorf("failed to list object versions in S3 bucket for deletion: %w", err)
+		}
+		identifiers := make([]s3types.ObjectIdentifier, 0, len(page.Versions)+len(page.DeleteMarkers))
+		for _, version := range page.Versions {
+			identifiers = append(identifiers, s3types.ObjectIdentifier{Key: version.Key, VersionId: version.VersionId})
+		}
+		for _, marker := range page.DeleteMarkers {
+			identifiers = append(identifiers, s3types.ObjectIdentifier{Key: marker.Key, VersionId: marker.VersionId})
+		}
+		if len(identifiers) > 0 {
+			n, err := deleteObjectVersions(ctx, s3Client, bucketName, identifiers)
 			if err != nil {
-				return fmt.Errorf("failed to delete objects from S3 bucket: %w", err)
+				return false, err
 			}
+			retained += n
+		}
+		if paginator.HasMorePages() && time.Now().After(deadline) {
+			log.FromContext(ctx).Info("S3 bucket cleanup paused, resuming on the next reconcile")
+			return false, nil
 		}
 	}
+	if retained > 0 {
+		return false, fmt.Errorf("%w: %d versions in S3 bucket %s cannot be deleted until their retention expires",
+			errObjectLockRetention, retained, bucketName)
+	}
+	return true, nil
+}
 
-	// Delete the bucket.
-	_, err = s3Client.DeleteBucket(ctx, &s3.DeleteBucketInput{Bucket: aws.String(bucketName)})
+// deleteObjectVersions deletes a page of listed object versions and returns how many of them
+// Object Lock retains. Versions that are already gone count as deleted.
+func deleteObjectVersions(ctx context.Context, s3Client S3ClientAPI, bucketName string, identifiers []s3types.ObjectIdentifier) (int, error) {
+	output, err := s3Client.DeleteObjects(ctx, &s3.DeleteObjectsInput{
+		Bucket: aws.String(bucketName),
+		Delete: &s3types.Delete{Objects: identifiers, Quiet: aws.Bool(true)},
+	})
 	if err != nil {
-		// If the bucket doesn't exist, cleanup is successful.
-		var nsb *s3types.NoSuchBucket
-		if !errors.As(err, &nsb) {
-			return fmt.Errorf("failed to delete S3 bucket: %w", err)
+		return 0, fmt.Errorf("failed to delete object versions from S3 bucket: %w", err)
+	}
+	retained := 0
+	for _, objErr := range output.Errors {
+		switch aws.ToString(objErr.Code) {
+		case "NoSuchKey", "NoSuchVersion":
+		case "AccessDenied":
+			// S3 denies deleting a version under retention.
+			retained++
+		default:
+			return 0, fmt.Errorf("failed to delete object %s version %s from S3 bucket: %s: %s", aws.ToString(objErr.Key),
+				aws.ToString(objErr.VersionId), aws.ToString(objErr.Code), aws.ToString(objErr.Message))
 		}
 	}
+	return retained, nil
+}
 
-	logger.Info("S3 Bucket cleanup complete", "BucketName", bucketName)
+// listObjectsInput lists the objects of a bucket whose keys start with prefix, or all of them
+// when prefix is empty.
+func listObjectsInput(bucketName, prefix string) *s3.ListObjectsV2Input {
+	input := &s3.ListObjectsV2Input{Bucket: aws.String(bucketName)}
+	if prefix != "" {
+		input.Prefix = aws.String(prefix)
+	}
+	return input
+}
+
+// s3CleanupDeadline returns when emptying a bucket should pause: after s3CleanupBudget, or
+// s3CleanupDeadlineMargin before ctx's deadline if that comes first.
+func s3CleanupDeadline(ctx context.Context) time.Time {
+	deadline := time.Now().Add(s3CleanupBudget)
+	if ctxDeadline, ok := ctx.Deadline(); ok && ctxDeadline.Add(-s3CleanupDeadlineMargin).Before(deadline) {
+		deadline = ctxDeadline.Add(-s3CleanupDeadlineMargin)
+	}
+	return deadline
+}
+
+// deleteObjects deletes a page of listed objects. Objects that are already gone count as deleted,
+// so a cleanup re-run after an interruption does not fail on them.
+func deleteObjects(ctx context.Context, s3Client S3ClientAPI, bucketName string, objects []s3types.Object) error {
+	identifiers := make([]s3types.ObjectIdentifier, 0, len(objects))
+	for _, obj := range objects {
+		identifiers = append(identifiers, s3types.ObjectIdentifier{Key: obj.Key})
+	}
+	output, err := s3Client.DeleteObjects(ctx, &s3.DeleteObjectsInput{
+		Bucket: aws.String(bucketName),
+		Delete: &s3types.Delete{Objects: identifiers, Quiet: aws.Bool(true)},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete objects from S3 bucket: %w", err)
+	}
+	for _, objErr := range output.Errors {
+		if aws.ToString(objErr.Code) == "NoSuchKey" {
+			continue
+		}
+		return fmt.Errorf("failed to delete object %s from S3 bucket: %s: %s", aws.ToString(objErr.Key), aws.ToString(objErr.Code), aws.ToString(objErr.Message))
+	}
 	return nil
 }
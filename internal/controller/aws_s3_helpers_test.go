@@ -0,0 +1,985 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/aws/smithy-go"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	parkingv1alpha1 "github.com/gminiba/parked-domain-operator/api/v1alpha1"
+)
+
+var _ = Describe("S3 helpers", func() {
+	Context("When reconciling bucket lifecycle rules", func() {
+		It("should replace the lifecycle configuration with the desired rules", func() {
+			var applied *s3.PutBucketLifecycleConfigurationInput
+			s3Client := &MockS3Client{
+				PutBucketLifecycleConfigurationFunc: func(ctx context.Context, params *s3.PutBucketLifecycleConfigurationInput, optFns ...func(*s3.Options)) (*s3.PutBucketLifecycleConfigurationOutput, error) {
+					applied = params
+					return &s3.PutBucketLifecycleConfigurationOutput{}, nil
+				},
+			}
+			rules := []parkingv1alpha1.LifecycleRule{
+				{ID: "expire-logs", Prefix: "logs/", ExpirationDays: aws.Int32(30)},
+				{ID: "expire-noncurrent", NoncurrentVersionExpirationDays: aws.Int32(7)},
+			}
+
+			Expect(reconcileBucketLifecycle(context.Background(), s3Client, "lifecycle.example.com", rules)).To(Succeed())
+			Expect(applied).NotTo(BeNil())
+			Expect(aws.ToString(applied.Bucket)).To(Equal("lifecycle.example.com"))
+			Expect(applied.LifecycleConfiguration.Rules).To(HaveLen(2))
+
+			logs := applied.LifecycleConfiguration.Rules[0]
+			Expect(aws.ToString(logs.ID)).To(Equal("expire-logs"))
+			Expect(logs.Status).To(Equal(s3types.ExpirationStatusEnabled))
+			Expect(aws.ToString(logs.Filter.Prefix)).To(Equal("logs/"))
+			Expect(aws.ToInt32(logs.Expiration.Days)).To(Equal(int32(30)))
+			Expect(logs.NoncurrentVersionExpiration).To(BeNil())
+
+			noncurrent := applied.LifecycleConfiguration.Rules[1]
+			Expect(noncurrent.Expiration).To(BeNil())
+			Expect(aws.ToInt32(noncurrent.NoncurrentVersionExpiration.NoncurrentDays)).To(Equal(int32(7)))
+		})
+
+		It("should remove the lifecycle configuration when no rules are desired", func() {
+			deleted := false
+			s3Client := &MockS3Client{
+				DeleteBucketLifecycleFunc: func(ctx context.Context, params *s3.DeleteBucketLifecycleInput, optFns ...func(*s3.Options)) (*s3.DeleteBucketLifecycleOutput, error) {
+					deleted = true
+					return &s3.DeleteBucketLifecycleOutput{}, nil
+				},
+			}
+
+			Expect(reconcileBucketLifecycle(context.Background(), s3Client, "lifecycle.example.com", nil)).To(Succeed())
+			Expect(deleted).To(BeTrue())
+		})
+	})
+
+	Context("When reconciling bucket ownership controls", func() {
+		It("should apply the desired object ownership", func() {
+			var applied *s3.PutBucketOwnershipControlsInput
+			s3Client := &MockS3Client{
+				PutBucketOwnershipControlsFunc: func(ctx context.Context, params *s3.PutBucketOwnershipControlsInput, optFns ...func(*s3.Options)) (*s3.PutBucketOwnershipControlsOutput, error) {
+					applied = params
+					return &s3.PutBucketOwnershipControlsOutput{}, nil
+				},
+			}
+
+			Expect(reconcileBucketOwnership(context.Background(), s3Client, "owner.example.com", "ObjectWriter")).To(Succeed())
+			Expect(applied).NotTo(BeNil())
+			Expect(applied.OwnershipControls.Rules).To(ConsistOf(s3types.OwnershipControlsRule{ObjectOwnership: s3types.ObjectOwnershipObjectWriter}))
+		})
+
+		It("should leave ownership untouched when unset", func() {
+			s3Client := &MockS3Client{
+				PutBucketOwnershipControlsFunc: func(ctx context.Context, params *s3.PutBucketOwnershipControlsInput, optFns ...func(*s3.Options)) (*s3.PutBucketOwnershipControlsOutput, error) {
+					Fail("ownership controls should not be applied")
+					return nil, nil
+				},
+			}
+
+			Expect(reconcileBucketOwnership(context.Background(), s3Client, "owner.example.com", "")).To(Succeed())
+		})
+	})
+	Context("When reconciling bucket transfer settings", func() {
+		It("should turn off acceleration and requester pays when set to false", func() {
+			var accelerate *s3.PutBucketAccelerateConfigurationInput
+			var payment *s3.PutBucketRequestPaymentInput
+			s3Client := &MockS3Client{
+				PutBucketAccelerateConfigurationFunc: func(ctx context.Context, params *s3.PutBucketAccelerateConfigurationInput, optFns ...func(*s3.Options)) (*s3.PutBucketAccelerateConfigurationOutput, error) {
+					accelerate = params
+					return &s3.PutBucketAccelerateConfigurationOutput{}, nil
+				},
+				PutBucketRequestPaymentFunc: func(ctx context.Context, params *s3.PutBucketRequestPaymentInput, optFns ...func(*s3.Options)) (*s3.PutBucketRequestPaymentOutput, error) {
+					payment = params
+					return &s3.PutBucketRequestPaymentOutput{}, nil
+				},
+			}
+
+			Expect(reconcileBucketTransferSettings(context.Background(), s3Client, "transfer.example.com", aws.Bool(false), aws.Bool(false))).To(Succeed())
+			Expect(accelerate.AccelerateConfiguration.Status).To(Equal(s3types.BucketAccelerateStatusSuspended))
+			Expect(payment.RequestPaymentConfiguration.Payer).To(Equal(s3types.PayerBucketOwner))
+		})
+
+		It("should make no calls when unset", func() {
+			s3Client := &MockS3Client{
+				PutBucketAccelerateConfigurationFunc: func(ctx context.Context, params *s3.PutBucketAccelerateConfigurationInput, optFns ...func(*s3.Options)) (*s3.PutBucketAccelerateConfigurationOutput, error) {
+					Fail("transfer acceleration should not be applied")
+					return nil, nil
+				},
+				PutBucketRequestPaymentFunc: func(ctx context.Context, params *s3.PutBucketRequestPaymentInput, optFns ...func(*s3.Options)) (*s3.PutBucketRequestPaymentOutput, error) {
+					Fail("request payment should not be applied")
+					return nil, nil
+				},
+			}
+
+			Expect(reconcileBucketTransferSettings(context.Background(), s3Client, "transfer.example.com", nil, nil)).To(Succeed())
+		})
+	})
+
+	Context("When compressing the uploaded page", func() {
+		var uploaded *s3.PutObjectInput
+		var body []byte
+		var s3Client *MockS3Client
+
+		BeforeEach(func() {
+			uploaded, body = nil, nil
+			s3Client = &MockS3Client{
+				PutObjectFunc: func(ctx context.Context, params *s3.PutObjectInput, optFns ...func(*s3.Options)) (*s3.PutObjectOutput, error) {
+					uploaded = params
+					var err error
+					body, err = io.ReadAll(params.Body)
+					return &s3.PutObjectOutput{}, err
+				},
+			}
+		})
+
+		It("should upload a gzip-compressed page with its content type", func() {
+			content := "<h1>" + strings.Repeat("parked ", 500) + "</h1>"
+			state := bucketState{Content: content, ContentType: "text/html", Compress: true, Policy: "{}"}
+
+			Expect(applyBucketState(context.Background(), s3Client, "gzip.example.com", state)).To(Succeed())
+			Expect(aws.ToString(uploaded.ContentEncoding)).To(Equal("gzip"))
+			Expect(aws.ToString(uploaded.ContentType)).To(Equal("text/html"))
+			Expect(len(body)).To(BeNumerically("<", len(content)))
+			zr, err := gzip.NewReader(bytes.NewReader(body))
+			Expect(err).NotTo(HaveOccurred())
+			decompressed, err := io.ReadAll(zr)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(decompressed)).To(Equal(content))
+		})
+
+		It("should upload a page too small to benefit as it is", func() {
+			state := bucketState{Content: "<h1>parked</h1>", ContentType: "text/html", Compress: true, Policy: "{}"}
+
+			Expect(applyBucketState(context.Background(), s3Client, "gzip.example.com", state)).To(Succeed())
+			Expect(uploaded.ContentEncoding).To(BeNil())
+			Expect(string(body)).To(Equal("<h1>parked</h1>"))
+		})
+	})
+
+	Context("When applying the bucket policy", func() {
+		BeforeEach(func() {
+			saved := bucketPolicyBackoff
+			bucketPolicyBackoff = wait.Backoff{Steps: 3, Duration: time.Millisecond}
+			DeferCleanup(func() { bucketPolicyBackoff = saved })
+		})
+
+		It("should retry a policy rejected while public access settings propagate", func() {
+			calls := 0
+			s3Client := &MockS3Client{
+				PutBucketPolicyFunc: func(ctx context.Context, params *s3.PutBucketPolicyInput, optFns ...func(*s3.Options)) (*s3.PutBucketPolicyOutput, error) {
+					calls++
+					if calls == 1 {
+						return nil, &smithy.GenericAPIError{Code: "AccessDenied", Message: "public policies are blocked"}
+					}
+					return &s3.PutBucketPolicyOutput{}, nil
+				},
+			}
+
+			Expect(putBucketPolicy(context.Background(), s3Client, "example.com", "{}")).To(Succeed())
+			Expect(calls).To(Equal(2))
+		})
+
+		It("should give up after the retry cap", func() {
+			calls := 0
+			s3Client := &MockS3Client{
+				PutBucketPolicyFunc: func(ctx context.Context, params *s3.PutBucketPolicyInput, optFns ...func(*s3.Options)) (*s3.PutBucketPolicyOutput, error) {
+					calls++
+					return nil, &smithy.GenericAPIError{Code: "MalformedPolicy", Message: "invalid principal"}
+				},
+			}
+
+			Expect(putBucketPolicy(context.Background(), s3Client, "example.com", "{}")).NotTo(Succeed())
+			Expect(calls).To(Equal(3))
+		})
+
+		It("should not retry other errors", func() {
+			calls := 0
+			s3Client := &MockS3Client{
+				PutBucketPolicyFunc: func(ctx context.Context, params *s3.PutBucketPolicyInput, optFns ...func(*s3.Options)) (*s3.PutBucketPolicyOutput, error) {
+					calls++
+					return nil, &s3types.NoSuchBucket{}
+				},
+			}
+
+			Expect(putBucketPolicy(context.Background(), s3Client, "example.com", "{}")).NotTo(Succeed())
+			Expect(calls).To(Equal(1))
+		})
+	})
+
+	Context("When the desired bucket state is unchanged", func() {
+		BeforeEach(func() {
+			Expect(os.Setenv("TEMPLATE_CONFIGMAP_NAME", "parked-domain-templates")).To(Succeed())
+		})
+
+		AfterEach(func() {
+			Expect(os.Unsetenv("TEMPLATE_CONFIGMAP_NAME")).To(Succeed())
+		})
+
+		It("should skip the S3 mutations until the state changes", func() {
+			ctx := context.Background()
+			mutations := 0
+			s3Client := &MockS3Client{
+				HeadBucketFunc: func(ctx context.Context, params *s3.HeadBucketInput, optFns ...func(*s3.Options)) (*s3.HeadBucketOutput, error) {
+					return &s3.HeadBucketOutput{}, nil
+				},
+				PutObjectFunc: func(ctx context.Context, params *s3.PutObjectInput, optFns ...func(*s3.Options)) (*s3.PutObjectOutput, error) {
+					mutations++
+					return &s3.PutObjectOutput{}, nil
+				},
+				PutBucketWebsiteFunc: func(ctx context.Context, params *s3.PutBucketWebsiteInput, optFns ...func(*s3.Options)) (*s3.PutBucketWebsiteOutput, error) {
+					mutations++
+					return &s3.PutBucketWebsiteOutput{}, nil
+				},
+				PutBucketPolicyFunc: func(ctx context.Context, params *s3.PutBucketPolicyInput, optFns ...func(*s3.Options)) (*s3.PutBucketPolicyOutput, error) {
+					mutations++
+					return &s3.PutBucketPolicyOutput{}, nil
+				},
+			}
+			templateCM := &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: "parked-domain-templates", Namespace: "default"},
+				Data:       map[string]string{"default.html": "<h1>{{DOMAIN_NAME}}</h1>"},
+			}
+			pd := &parkingv1alpha1.ParkedDomain{
+				ObjectMeta: metav1.ObjectMeta{Name: "hash", Namespace: "default"},
+				Spec:       parkingv1alpha1.ParkedDomainSpec{DomainName: "hash.example.com"},
+			}
+			r := newTestReconciler(&MockR53Client{}, s3Client, pd, templateCM)
+
+			By("applying the state once")
+			_, err := r.reconcileS3Bucket(ctx, pd)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(mutations).To(Equal(3))
+			stored := &parkingv1alpha1.ParkedDomain{}
+			Expect(r.Get(ctx, client.ObjectKeyFromObject(pd), stored)).To(Succeed())
+			Expect(stored.Annotations).To(HaveKeyWithValue(lastAppliedHashAnnotation, pd.Annotations[lastAppliedHashAnnotation]))
+
+			By("skipping a no-op reconcile")
+			endpoint, err := r.reconcileS3Bucket(ctx, pd)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(endpoint).To(Equal("hash.example.com.s3-website.eu-central-1.amazonaws.com"))
+			Expect(mutations).To(Equal(3))
+
+			By("applying a changed template")
+			templateCM.Data["default.html"] = "<h1>{{DOMAIN_NAME}} is parked</h1>"
+			Expect(r.Update(ctx, templateCM)).To(Succeed())
+			_, err = r.reconcileS3Bucket(ctx, pd)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(mutations).To(Equal(6))
+		})
+
+		It("should publish an edited template ConfigMap on a reconciled ParkedDomain", func() {
+			ctx := context.Background()
+			var pages []string
+			s3Client := &MockS3Client{
+				HeadBucketFunc: func(ctx context.Context, params *s3.HeadBucketInput, optFns ...func(*s3.Options)) (*s3.HeadBucketOutput, error) {
+					return &s3.HeadBucketOutput{}, nil
+				},
+				PutObjectFunc: func(ctx context.Context, params *s3.PutObjectInput, optFns ...func(*s3.Options)) (*s3.PutObjectOutput, error) {
+					body, err := io.ReadAll(params.Body)
+					Expect(err).NotTo(HaveOccurred())
+					pages = append(pages, string(body))
+					return &s3.PutObjectOutput{}, nil
+				},
+			}
+			templateCM := &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: "parked-domain-templates", Namespace: "default"},
+				Data:       map[string]string{"default.html": "<h1>{{DOMAIN_NAME}}</h1>"},
+			}
+			pd := &parkingv1alpha1.ParkedDomain{
+				ObjectMeta: metav1.ObjectMeta{Name: "edited", Namespace: "default"},
+				Spec:       parkingv1alpha1.ParkedDomainSpec{DomainName: "edited.example.com"},
+			}
+			req := ctrl.Request{NamespacedName: client.ObjectKeyFromObject(pd)}
+			r := newTestReconciler(&MockR53Client{}, s3Client, pd, templateCM)
+			_, err := r.Reconcile(ctx, req)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(pages).To(ConsistOf(ContainSubstring("<h1>edited.example.com</h1>")))
+
+			By("uploading the page rendered from the edited template")
+			pages = nil
+			templateCM.Data["default.html"] = "<h1>{{DOMAIN_NAME}} is for sale</h1>"
+			Expect(r.Update(ctx, templateCM)).To(Succeed())
+			_, err = r.Reconcile(ctx, req)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(pages).To(ConsistOf(ContainSubstring("<h1>edited.example.com is for sale</h1>")))
+
+			By("uploading nothing when the edit leaves the page unchanged")
+			pages = nil
+			templateCM.Labels = map[string]string{"team": "web"}
+			Expect(r.Update(ctx, templateCM)).To(Succeed())
+			_, err = r.Reconcile(ctx, req)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(pages).To(BeEmpty())
+		})
+	})
+
+	Context("When uploading the page", func() {
+		It("should store the object metadata with it", func() {
+			var uploaded *s3.PutObjectInput
+			s3Client := &MockS3Client{
+				PutObjectFunc: func(ctx context.Context, params *s3.PutObjectInput, optFns ...func(*s3.Options)) (*s3.PutObjectOutput, error) {
+					uploaded = params
+					return &s3.PutObjectOutput{}, nil
+				},
+			}
+			pd := &parkingv1alpha1.ParkedDomain{Spec: parkingv1alpha1.ParkedDomainSpec{
+				DomainName:     "meta.example.com",
+				ObjectMetadata: map[string]string{"campaign": "spring"},
+			}}
+
+			Expect(applyBucketState(context.Background(), s3Client, "meta.example.com", desiredBucketState(pd, "<h1>parked</h1>"))).To(Succeed())
+			Expect(uploaded.Metadata).To(Equal(map[string]string{"campaign": "spring"}))
+			Expect(uploaded.StorageClass).To(Equal(s3types.StorageClassStandard))
+		})
+
+		It("should upload with the requested storage class", func() {
+			var uploaded *s3.PutObjectInput
+			s3Client := &MockS3Client{
+				PutObjectFunc: func(ctx context.Context, params *s3.PutObjectInput, optFns ...func(*s3.Options)) (*s3.PutObjectOutput, error) {
+					uploaded = params
+					return &s3.PutObjectOutput{}, nil
+				},
+			}
+			pd := &parkingv1alpha1.ParkedDomain{Spec: parkingv1alpha1.ParkedDomainSpec{
+				DomainName:   "cold.example.com",
+				StorageClass: "INTELLIGENT_TIERING",
+			}}
+
+			Expect(applyBucketState(context.Background(), s3Client, "cold.example.com", desiredBucketState(pd, "<h1>parked</h1>"))).To(Succeed())
+			Expect(uploaded.StorageClass).To(Equal(s3types.StorageClassIntelligentTiering))
+		})
+
+		It("should encrypt the page explicitly when encryption is set", func() {
+			var uploaded *s3.PutObjectInput
+			s3Client := &MockS3Client{
+				PutObjectFunc: func(ctx context.Context, params *s3.PutObjectInput, optFns ...func(*s3.Options)) (*s3.PutObjectOutput, error) {
+					uploaded = params
+					return &s3.PutObjectOutput{}, nil
+				},
+			}
+			keyARN := "arn:aws:kms:eu-central-1:111111111111:key/1234abcd-12ab-34cd-56ef-1234567890ab"
+			pd := &parkingv1alpha1.ParkedDomain{Spec: parkingv1alpha1.ParkedDomainSpec{
+				DomainName: "sealed.example.com",
+				Encryption: &parkingv1alpha1.Encryption{Algorithm: "aws:kms", KMSKeyID: keyARN},
+			}}
+
+			Expect(applyBucketState(context.Background(), s3Client, "sealed.example.com", desiredBucketState(pd, "<h1>parked</h1>"))).To(Succeed())
+			Expect(uploaded.ServerSideEncryption).To(Equal(s3types.ServerSideEncryptionAwsKms))
+			Expect(uploaded.SSEKMSKeyId).To(HaveValue(Equal(keyARN)))
+
+			By("leaving encryption to the bucket default when unset")
+			pd.Spec.Encryption = nil
+			Expect(applyBucketState(context.Background(), s3Client, "sealed.example.com", desiredBucketState(pd, "<h1>parked</h1>"))).To(Succeed())
+			Expect(uploaded.ServerSideEncryption).To(BeEmpty())
+			Expect(uploaded.SSEKMSKeyId).To(BeNil())
+		})
+	})
+
+	Context("When serving a single-page app", func() {
+		It("should answer every path with the index page", func() {
+			var website *s3types.WebsiteConfiguration
+			s3Client := &MockS3Client{
+				PutBucketWebsiteFunc: func(ctx context.Context, params *s3.PutBucketWebsiteInput, optFns ...func(*s3.Options)) (*s3.PutBucketWebsiteOutput, error) {
+					website = params.WebsiteConfiguration
+					return &s3.PutBucketWebsiteOutput{}, nil
+				},
+			}
+			pd := &parkingv1alpha1.ParkedDomain{Spec: parkingv1alpha1.ParkedDomainSpec{DomainName: "app.example.com", SPAFallback: true}}
+
+			Expect(applyBucketState(context.Background(), s3Client, "app.example.com", desiredBucketState(pd, "<div id=app></div>"))).To(Succeed())
+			Expect(aws.ToString(website.IndexDocument.Suffix)).To(Equal("index.html"))
+			Expect(website.ErrorDocument).NotTo(BeNil())
+			Expect(aws.ToString(website.ErrorDocument.Key)).To(Equal("index.html"))
+
+			By("leaving the error document unset otherwise")
+			pd.Spec.SPAFallback = false
+			Expect(applyBucketState(context.Background(), s3Client, "app.example.com", desiredBucketState(pd, "<div id=app></div>"))).To(Succeed())
+			Expect(website.ErrorDocument).To(BeNil())
+		})
+	})
+
+	Context("When the bucket has ACLs enabled", func() {
+		var (
+			uploaded *s3.PutObjectInput
+			s3Client *MockS3Client
+			pd       *parkingv1alpha1.ParkedDomain
+		)
+
+		BeforeEach(func() {
+			uploaded = nil
+			s3Client = &MockS3Client{
+				GetBucketOwnershipControlsFunc: func(ctx context.Context, params *s3.GetBucketOwnershipControlsInput, optFns ...func(*s3.Options)) (*s3.GetBucketOwnershipControlsOutput, error) {
+					return &s3.GetBucketOwnershipControlsOutput{OwnershipControls: &s3types.OwnershipControls{
+						Rules: []s3types.OwnershipControlsRule{{ObjectOwnership: s3types.ObjectOwnershipObjectWriter}},
+					}}, nil
+				},
+				PutObjectFunc: func(ctx context.Context, params *s3.PutObjectInput, optFns ...func(*s3.Options)) (*s3.PutObjectOutput, error) {
+					uploaded = params
+					return &s3.PutObjectOutput{}, nil
+				},
+			}
+			pd = &parkingv1alpha1.ParkedDomain{Spec: parkingv1alpha1.ParkedDomainSpec{DomainName: "legacy.example.com"}}
+		})
+
+		It("should make the page public by its ACL too", func() {
+			Expect(applyBucketState(context.Background(), s3Client, "legacy.example.com", desiredBucketState(pd, "<h1>parked</h1>"))).To(Succeed())
+			Expect(uploaded.ACL).To(Equal(s3types.ObjectCannedACLPublicRead))
+		})
+
+		It("should treat a bucket predating ownership controls as having ACLs enabled", func() {
+			s3Client.GetBucketOwnershipControlsFunc = func(ctx context.Context, params *s3.GetBucketOwnershipControlsInput, optFns ...func(*s3.Options)) (*s3.GetBucketOwnershipControlsOutput, error) {
+				return nil, &smithy.GenericAPIError{Code: "OwnershipControlsNotFoundError"}
+			}
+
+			Expect(applyBucketState(context.Background(), s3Client, "legacy.example.com", desiredBucketState(pd, "<h1>parked</h1>"))).To(Succeed())
+			Expect(uploaded.ACL).To(Equal(s3types.ObjectCannedACLPublicRead))
+		})
+
+		It("should upload without an ACL when Block Public Access rejects public ACLs", func() {
+			s3Client.GetPublicAccessBlockFunc = func(ctx context.Context, params *s3.GetPublicAccessBlockInput, optFns ...func(*s3.Options)) (*s3.GetPublicAccessBlockOutput, error) {
+				return &s3.GetPublicAccessBlockOutput{PublicAccessBlockConfiguration: &s3types.PublicAccessBlockConfiguration{
+					BlockPublicAcls: aws.Bool(true),
+				}}, nil
+			}
+
+			Expect(applyBucketState(context.Background(), s3Client, "legacy.example.com", desiredBucketState(pd, "<h1>parked</h1>"))).To(Succeed())
+			Expect(uploaded.ACL).To(BeEmpty())
+		})
+
+		It("should not make the page of a private zone public", func() {
+			pd.Spec.PrivateZone = &parkingv1alpha1.PrivateZone{VPCID: "vpc-0abc"}
+
+			Expect(applyBucketState(context.Background(), s3Client, "legacy.example.com", desiredBucketState(pd, "<h1>parked</h1>"))).To(Succeed())
+			Expect(uploaded.ACL).To(BeEmpty())
+		})
+
+		It("should upload without an ACL once ownership is enforced", func() {
+			pd.Spec.ObjectOwnership = string(s3types.ObjectOwnershipBucketOwnerEnforced)
+			s3Client.GetBucketOwnershipControlsFunc = func(ctx context.Context, params *s3.GetBucketOwnershipControlsInput, optFns ...func(*s3.Options)) (*s3.GetBucketOwnershipControlsOutput, error) {
+				Fail("the ownership just applied should not be read back")
+				return nil, nil
+			}
+
+			Expect(applyBucketState(context.Background(), s3Client, "legacy.example.com", desiredBucketState(pd, "<h1>parked</h1>"))).To(Succeed())
+			Expect(uploaded.ACL).To(BeEmpty())
+		})
+	})
+
+	Context("When the bucket lives on an S3-compatible service", func() {
+		BeforeEach(func() {
+			Expect(os.Setenv("TEMPLATE_CONFIGMAP_NAME", "parked-domain-templates")).To(Succeed())
+		})
+
+		AfterEach(func() {
+			Expect(os.Unsetenv("TEMPLATE_CONFIGMAP_NAME")).To(Succeed())
+		})
+
+		It("should use the endpoint and skip settings the service does not implement", func() {
+			ctx := context.Background()
+			notImplemented := &smithy.GenericAPIError{Code: "NotImplemented", Message: "A header you provided implies functionality that is not implemented"}
+			s3Client := &MockS3Client{
+				PutBucketWebsiteFunc: func(ctx context.Context, params *s3.PutBucketWebsiteInput, optFns ...func(*s3.Options)) (*s3.PutBucketWebsiteOutput, error) {
+					return nil, notImplemented
+				},
+				PutBucketOwnershipControlsFunc: func(ctx context.Context, params *s3.PutBucketOwnershipControlsInput, optFns ...func(*s3.Options)) (*s3.PutBucketOwnershipControlsOutput, error) {
+					return nil, notImplemented
+				},
+			}
+			templateCM := &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: "parked-domain-templates", Namespace: "default"},
+				Data:       map[string]string{"default.html": "<h1>{{DOMAIN_NAME}}</h1>"},
+			}
+			pd := &parkingv1alpha1.ParkedDomain{
+				ObjectMeta: metav1.ObjectMeta{Name: "minio", Namespace: "default"},
+				Spec: parkingv1alpha1.ParkedDomainSpec{
+					DomainName:      "minio.example.com",
+					ObjectOwnership: "BucketOwnerEnforced",
+					StorageEndpoint: "https://minio.internal:9000",
+				},
+			}
+			r := newTestReconciler(&MockR53Client{}, s3Client, pd, templateCM)
+			r.S3Endpoint = "https://s3.wasabisys.com"
+			r.S3ForcePathStyle = true
+
+			endpoint, err := r.reconcileS3Bucket(ctx, pd)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(r.S3ClientFactory.(*MockS3ClientFactory).RequestedEndpoint).To(Equal("https://minio.internal:9000"))
+			Expect(endpoint).To(Equal("minio.internal:9000/minio.example.com"))
+			Expect(pd.Status.WebsiteURL).To(Equal("https://minio.internal:9000/minio.example.com/index.html"))
+		})
+
+		It("should not create alias records for the endpoint", func() {
+			pd := &parkingv1alpha1.ParkedDomain{Spec: parkingv1alpha1.ParkedDomainSpec{DomainName: "minio.example.com"}}
+			r := newTestReconciler(&MockR53Client{}, &MockS3Client{})
+			r.S3Endpoint = "http://minio.internal:9000"
+
+			err := r.reconcileRoute53ARecord(context.Background(), pd, "Z1", "minio.example.com.minio.internal:9000")
+			Expect(err).To(MatchError(ContainSubstring("set spec.dnsEnabled to false")))
+		})
+	})
+
+	Context("When publishing into a shared bucket", func() {
+		var pd *parkingv1alpha1.ParkedDomain
+
+		BeforeEach(func() {
+			pd = &parkingv1alpha1.ParkedDomain{
+				ObjectMeta: metav1.ObjectMeta{Name: "shared", Namespace: "default"},
+				Spec: parkingv1alpha1.ParkedDomainSpec{
+					DomainName:     "shared.example.com",
+					InlineTemplate: "<h1>{{DOMAIN_NAME}}</h1>",
+					SharedBucket:   &parkingv1alpha1.SharedBucket{Name: "parked-pages"},
+					AliasTarget: &parkingv1alpha1.AliasTarget{
+						HostedZoneID: cloudFrontHostedZoneID,
+						DNSName:      "d111111abcdef8.cloudfront.net",
+						Type:         parkingv1alpha1.AliasTargetCloudFront,
+					},
+				},
+			}
+		})
+
+		It("should upload the page under the host name and leave the bucket's configuration alone", func() {
+			ctx := context.Background()
+			var created string
+			var keys []string
+			s3Client := &MockS3Client{
+				CreateBucketFunc: func(ctx context.Context, params *s3.CreateBucketInput, optFns ...func(*s3.Options)) (*s3.CreateBucketOutput, error) {
+					created = aws.ToString(params.Bucket)
+					return &s3.CreateBucketOutput{}, nil
+				},
+				PutObjectFunc: func(ctx context.Context, params *s3.PutObjectInput, optFns ...func(*s3.Options)) (*s3.PutObjectOutput, error) {
+					Expect(aws.ToString(params.Bucket)).To(Equal("parked-pages"))
+					Expect(params.ACL).To(BeEmpty())
+					keys = append(keys, aws.ToString(params.Key))
+					return &s3.PutObjectOutput{}, nil
+				},
+				PutBucketWebsiteFunc: func(ctx context.Context, params *s3.PutBucketWebsiteInput, optFns ...func(*s3.Options)) (*s3.PutBucketWebsiteOutput, error) {
+					Fail("a shared bucket should serve no website")
+					return nil, nil
+				},
+				PutBucketPolicyFunc: func(ctx context.Context, params *s3.PutBucketPolicyInput, optFns ...func(*s3.Options)) (*s3.PutBucketPolicyOutput, error) {
+					Fail("a shared bucket should keep its owner's policy")
+					return nil, nil
+				},
+				PutBucketTaggingFunc: func(ctx context.Context, params *s3.PutBucketTaggingInput, optFns ...func(*s3.Options)) (*s3.PutBucketTaggingOutput, error) {
+					Fail("a shared bucket should keep its owner's tags")
+					return nil, nil
+				},
+			}
+			pd.Spec.Tags = map[string]string{"team": "web"}
+			r := newTestReconciler(&MockR53Client{}, s3Client, pd)
+			r.AllowedSharedBuckets = []string{"parked-pages"}
+
+			endpoint, err := r.reconcileS3Bucket(ctx, pd)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(created).To(Equal("parked-pages"))
+			Expect(keys).To(Equal([]string{"shared.example.com/index.html"}))
+			Expect(endpoint).To(Equal("d111111abcdef8.cloudfront.net"))
+			Expect(pd.Status.BucketName).To(BeEmpty())
+		})
+
+		It("should refuse to publish without a CloudFront distribution", func() {
+			pd.Spec.AliasTarget = nil
+			r := newTestReconciler(&MockR53Client{}, &MockS3Client{}, pd)
+
+			_, err := r.reconcileS3Bucket(context.Background(), pd)
+			Expect(err).To(MatchError(errSharedBucketWithoutCDN))
+		})
+
+		It("should refuse a bucket the operator does not allow, and leave it alone on cleanup", func() {
+			s3Client := &MockS3Client{
+				CreateBucketFunc: func(ctx context.Context, params *s3.CreateBucketInput, optFns ...func(*s3.Options)) (*s3.CreateBucketOutput, error) {
+					Fail("no bucket should be created for a shared bucket that is not allowed")
+					return nil, nil
+				},
+				ListObjectsV2Func: func(ctx context.Context, params *s3.ListObjectsV2Input, optFns ...func(*s3.Options)) (*s3.ListObjectsV2Output, error) {
+					Fail("a shared bucket that is not allowed should not be emptied")
+					return nil, nil
+				},
+			}
+			recorder := record.NewFakeRecorder(10)
+			r := newTestReconciler(&MockR53Client{}, s3Client, pd)
+			r.Recorder = recorder
+			r.AllowedSharedBuckets = []string{"other-pages"}
+
+			_, err := r.reconcileS3Bucket(context.Background(), pd)
+			Expect(err).To(MatchError(errSharedBucketNotAllowed))
+			Expect(errors.Is(err, reconcile.TerminalError(nil))).To(BeTrue())
+			Expect(failureReason(err)).To(Equal("SharedBucketNotAllowed"))
+			Expect(drainEvents(recorder)).To(ContainElement(ContainSubstring("Warning SharedBucketNotAllowed")))
+
+			done, err := r.cleanupS3Bucket(context.Background(), pd)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(done).To(BeTrue())
+		})
+
+		It("should refuse Object Lock on the shared bucket", func() {
+			pd.Spec.ObjectLock = &parkingv1alpha1.ObjectLock{Mode: "COMPLIANCE", Days: 30}
+			r := newTestReconciler(&MockR53Client{}, &MockS3Client{}, pd)
+
+			_, err := r.reconcileS3Bucket(context.Background(), pd)
+			Expect(err).To(MatchError(errSharedBucketObjectLock))
+			Expect(errors.Is(err, reconcile.TerminalError(nil))).To(BeTrue())
+		})
+
+		It("should delete only the ParkedDomain's versions of an Object Lock shared bucket on cleanup", func() {
+			pd.Spec.ObjectLock = &parkingv1alpha1.ObjectLock{Mode: "COMPLIANCE", Days: 30}
+			var deleted []string
+			s3Client := &MockS3Client{
+				ListObjectVersionsFunc: func(ctx context.Context, params *s3.ListObjectVersionsInput, optFns ...func(*s3.Options)) (*s3.ListObjectVersionsOutput, error) {
+					Expect(aws.ToString(params.Prefix)).To(Equal("shared.example.com/"))
+					return &s3.ListObjectVersionsOutput{Versions: []s3types.ObjectVersion{
+						{Key: aws.String("shared.example.com/index.html"), VersionId: aws.String("v1")},
+					}}, nil
+				},
+				DeleteObjectsFunc: func(ctx context.Context, params *s3.DeleteObjectsInput, optFns ...func(*s3.Options)) (*s3.DeleteObjectsOutput, error) {
+					for _, obj := range params.Delete.Objects {
+						deleted = append(deleted, aws.ToString(obj.Key)+"@"+aws.ToString(obj.VersionId))
+					}
+					return &s3.DeleteObjectsOutput{}, nil
+				},
+				DeleteBucketFunc: func(ctx context.Context, params *s3.DeleteBucketInput, optFns ...func(*s3.Options)) (*s3.DeleteBucketOutput, error) {
+					Fail("a shared bucket should outlive the ParkedDomain")
+					return nil, nil
+				},
+			}
+			r := newTestReconciler(&MockR53Client{}, s3Client, pd)
+			r.AllowedSharedBuckets = []string{"parked-pages"}
+
+			done, err := r.cleanupS3Bucket(context.Background(), pd)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(done).To(BeTrue())
+			Expect(deleted).To(Equal([]string{"shared.example.com/index.html@v1"}))
+		})
+
+		It("should delete only the ParkedDomain's prefix on cleanup", func() {
+			var deleted []string
+			s3Client := &MockS3Client{
+				ListObjectsV2Func: func(ctx context.Context, params *s3.ListObjectsV2Input, optFns ...func(*s3.Options)) (*s3.ListObjectsV2Output, error) {
+					Expect(aws.ToString(params.Prefix)).To(Equal("shared.example.com/"))
+					return &s3.ListObjectsV2Output{Contents: []s3types.Object{
+						{Key: aws.String("shared.example.com/index.html")},
+						{Key: aws.String("shared.example.com/index.de.html")},
+					}}, nil
+				},
+				DeleteObjectsFunc: func(ctx context.Context, params *s3.DeleteObjectsInput, optFns ...func(*s3.Options)) (*s3.DeleteObjectsOutput, error) {
+					for _, obj := range params.Delete.Objects {
+						deleted = append(deleted, aws.ToString(obj.Key))
+					}
+					return &s3.DeleteObjectsOutput{}, nil
+				},
+				DeleteBucketFunc: func(ctx context.Context, params *s3.DeleteBucketInput, optFns ...func(*s3.Options)) (*s3.DeleteBucketOutput, error) {
+					Fail("the shared bucket should be left for the other ParkedDomains")
+					return nil, nil
+				},
+			}
+			r := newTestReconciler(&MockR53Client{}, s3Client, pd)
+			r.AllowedSharedBuckets = []string{"parked-pages"}
+
+			done, err := r.cleanupS3Bucket(context.Background(), pd)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(done).To(BeTrue())
+			Expect(deleted).To(ConsistOf("shared.example.com/index.html", "shared.example.com/index.de.html"))
+		})
+	})
+
+	Context("When the bucket has Object Lock", func() {
+		var pd *parkingv1alpha1.ParkedDomain
+
+		BeforeEach(func() {
+			pd = &parkingv1alpha1.ParkedDomain{
+				ObjectMeta: metav1.ObjectMeta{Name: "locked", Namespace: "default", Finalizers: []string{finalizerName}},
+				Spec: parkingv1alpha1.ParkedDomainSpec{
+					DomainName:     "locked.example.com",
+					DNSEnabled:     aws.Bool(false),
+					InlineTemplate: "<h1>{{DOMAIN_NAME}}</h1>",
+					ObjectLock:     &parkingv1alpha1.ObjectLock{Mode: "COMPLIANCE", Days: 30},
+				},
+			}
+		})
+
+		It("should create the bucket with Object Lock and retain the pages by default", func() {
+			var calls []string
+			s3Client := &MockS3Client{
+				CreateBucketFunc: func(ctx context.Context, params *s3.CreateBucketInput, optFns ...func(*s3.Options)) (*s3.CreateBucketOutput, error) {
+					Expect(aws.ToBool(params.ObjectLockEnabledForBucket)).To(BeTrue())
+					return &s3.CreateBucketOutput{}, nil
+				},
+				PutObjectLockConfigurationFunc: func(ctx context.Context, params *s3.PutObjectLockConfigurationInput, optFns ...func(*s3.Options)) (*s3.PutObjectLockConfigurationOutput, error) {
+					retention := params.ObjectLockConfiguration.Rule.DefaultRetention
+					Expect(retention.Mode).To(Equal(s3types.ObjectLockRetentionModeCompliance))
+					Expect(aws.ToInt32(retention.Days)).To(Equal(int32(30)))
+					calls = append(calls, "PutObjectLockConfiguration")
+					return &s3.PutObjectLockConfigurationOutput{}, nil
+				},
+				PutObjectFunc: func(ctx context.Context, params *s3.PutObjectInput, optFns ...func(*s3.Options)) (*s3.PutObjectOutput, error) {
+					calls = append(calls, "PutObject")
+					return &s3.PutObjectOutput{}, nil
+				},
+			}
+			r := newTestReconciler(&MockR53Client{}, s3Client, pd)
+
+			_, err := r.reconcileS3Bucket(context.Background(), pd)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(calls).To(Equal([]string{"PutObjectLockConfiguration", "PutObject"}))
+		})
+
+		It("should delete every version and delete marker before the bucket", func() {
+			var deleted []string
+			bucketDeleted := false
+			s3Client := &MockS3Client{
+				ListObjectVersionsFunc: func(ctx context.Context, params *s3.ListObjectVersionsInput, optFns ...func(*s3.Options)) (*s3.ListObjectVersionsOutput, error) {
+					return &s3.ListObjectVersionsOutput{
+						Versions:      []s3types.ObjectVersion{{Key: aws.String("index.html"), VersionId: aws.String("v2")}, {Key: aws.String("index.html"), VersionId: aws.String("v1")}},
+						DeleteMarkers: []s3types.DeleteMarkerEntry{{Key: aws.String("index.de.html"), VersionId: aws.String("m1")}},
+					}, nil
+				},
+				DeleteObjectsFunc: func(ctx context.Context, params *s3.DeleteObjectsInput, optFns ...func(*s3.Options)) (*s3.DeleteObjectsOutput, error) {
+					for _, obj := range params.Delete.Objects {
+						deleted = append(deleted, aws.ToString(obj.Key)+"@"+aws.ToString(obj.VersionId))
+					}
+					return &s3.DeleteObjectsOutput{}, nil
+				},
+				DeleteBucketFunc: func(ctx context.Context, params *s3.DeleteBucketInput, optFns ...func(*s3.Options)) (*s3.DeleteBucketOutput, error) {
+					bucketDeleted = true
+					return &s3.DeleteBucketOutput{}, nil
+				},
+			}
+			r := newTestReconciler(&MockR53Client{}, s3Client, pd)
+
+			done, err := r.cleanupS3Bucket(context.Background(), pd)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(done).To(BeTrue())
+			Expect(deleted).To(Equal([]string{"index.html@v2", "index.html@v1", "index.de.html@m1"}))
+			Expect(bucketDeleted).To(BeTrue())
+		})
+
+		It("should report versions under retention when the ParkedDomain is deleted", func() {
+			ctx := context.Background()
+			now := metav1.Now()
+			pd.DeletionTimestamp = &now
+			s3Client := &MockS3Client{
+				ListObjectVersionsFunc: func(ctx context.Context, params *s3.ListObjectVersionsInput, optFns ...func(*s3.Options)) (*s3.ListObjectVersionsOutput, error) {
+					return &s3.ListObjectVersionsOutput{Versions: []s3types.ObjectVersion{
+						{Key: aws.String("index.html"), VersionId: aws.String("v1")},
+						{Key: aws.String("index.html"), VersionId: aws.String("v2")},
+					}}, nil
+				},
+				DeleteObjectsFunc: func(ctx context.Context, params *s3.DeleteObjectsInput, optFns ...func(*s3.Options)) (*s3.DeleteObjectsOutput, error) {
+					var errs []s3types.Error
+					for _, obj := range params.Delete.Objects {
+						errs = append(errs, s3types.Error{Key: obj.Key, VersionId: obj.VersionId, Code: aws.String("AccessDenied")})
+					}
+					return &s3.DeleteObjectsOutput{Errors: errs}, nil
+				},
+				DeleteBucketFunc: func(ctx context.Context, params *s3.DeleteBucketInput, optFns ...func(*s3.Options)) (*s3.DeleteBucketOutput, error) {
+					Fail("a bucket holding retained versions cannot be deleted")
+					return nil, nil
+				},
+			}
+			r := newTestReconciler(&MockR53Client{}, s3Client, pd)
+
+			_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: client.ObjectKeyFromObject(pd)})
+			Expect(err).To(MatchError(errObjectLockRetention))
+
+			blocked := &parkingv1alpha1.ParkedDomain{}
+			Expect(r.Get(ctx, client.ObjectKeyFromObject(pd), blocked)).To(Succeed())
+			cond := meta.FindStatusCondition(blocked.Status.Conditions, parkingv1alpha1.ConditionCleanupFailed)
+			Expect(cond).NotTo(BeNil())
+			Expect(cond.Reason).To(Equal("ObjectLockRetention"))
+			Expect(cond.Message).To(ContainSubstring("2 versions in S3 bucket locked.example.com"))
+		})
+	})
+
+	Context("When cleaning up a large bucket", func() {
+		var (
+			pd       *parkingv1alpha1.ParkedDomain
+			s3Client *MockS3Client
+			batches  []int
+		)
+
+		BeforeEach(func() {
+			pd = &parkingv1alpha1.ParkedDomain{
+				ObjectMeta: metav1.ObjectMeta{Name: "large", Namespace: "default"},
+				Spec:       parkingv1alpha1.ParkedDomainSpec{DomainName: "large.example.com"},
+			}
+			keys := []string{"a.html", "b.html", "c.html", "d.html", "e.html"}
+			batches = nil
+			s3Client = &MockS3Client{
+				ListObjectsV2Func: func(ctx context.Context, params *s3.ListObjectsV2Input, optFns ...func(*s3.Options)) (*s3.ListObjectsV2Output, error) {
+					start := 0
+					if token := aws.ToString(params.ContinuationToken); token != "" {
+						start = slices.Index(keys, token)
+					}
+					end := min(start+int(aws.ToInt32(params.MaxKeys)), len(keys))
+					output := &s3.ListObjectsV2Output{}
+					for _, key := range keys[start:end] {
+						output.Contents = append(output.Contents, s3types.Object{Key: aws.String(key)})
+					}
+					if end < len(keys) {
+						output.IsTruncated, output.NextContinuationToken = aws.Bool(true), aws.String(keys[end])
+					}
+					return output, nil
+				},
+				DeleteObjectsFunc: func(ctx context.Context, params *s3.DeleteObjectsInput, optFns ...func(*s3.Options)) (*s3.DeleteObjectsOutput, error) {
+					batches = append(batches, len(params.Delete.Objects))
+					return &s3.DeleteObjectsOutput{}, nil
+				},
+			}
+		})
+
+		It("should delete as many objects per call as the annotation allows", func() {
+			pd.Annotations = map[string]string{cleanupBatchSizeAnnotation: "2"}
+			r := newTestReconciler(&MockR53Client{}, s3Client, pd)
+
+			done, err := r.cleanupS3Bucket(context.Background(), pd)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(done).To(BeTrue())
+			Expect(batches).To(Equal([]int{2, 2, 1}))
+		})
+
+		It("should fall back to the S3 limit when the annotation is invalid", func() {
+			pd.Annotations = map[string]string{cleanupBatchSizeAnnotation: "5000"}
+			recorder := record.NewFakeRecorder(10)
+			r := newTestReconciler(&MockR53Client{}, s3Client, pd)
+			r.Recorder = recorder
+
+			done, err := r.cleanupS3Bucket(context.Background(), pd)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(done).To(BeTrue())
+			Expect(batches).To(Equal([]int{5}))
+			Expect(drainEvents(recorder)).To(ContainElement(ContainSubstring("InvalidCleanupBatchSize")))
+		})
+	})
+
+	Context("When delivering access logs", func() {
+		BeforeEach(func() {
+			Expect(os.Setenv("TEMPLATE_CONFIGMAP_NAME", "parked-domain-templates")).To(Succeed())
+		})
+
+		AfterEach(func() {
+			Expect(os.Unsetenv("TEMPLATE_CONFIGMAP_NAME")).To(Succeed())
+		})
+
+		newLoggingMock := func(existing map[string]bool) (*MockS3Client, *[]string, map[string]string, **s3.PutBucketLoggingInput) {
+			var created []string
+			policies := map[string]string{}
+			var logging *s3.PutBucketLoggingInput
+			return &MockS3Client{
+				HeadBucketFunc: func(ctx context.Context, params *s3.HeadBucketInput, optFns ...func(*s3.Options)) (*s3.HeadBucketOutput, error) {
+					if existing[aws.ToString(params.Bucket)] {
+						return &s3.HeadBucketOutput{}, nil
+					}
+					return nil, &s3types.NotFound{}
+				},
+				CreateBucketFunc: func(ctx context.Context, params *s3.CreateBucketInput, optFns ...func(*s3.Options)) (*s3.CreateBucketOutput, error) {
+					created = append(created, aws.ToString(params.Bucket))
+					return &s3.CreateBucketOutput{}, nil
+				},
+				PutBucketPolicyFunc: func(ctx context.Context, params *s3.PutBucketPolicyInput, optFns ...func(*s3.Options)) (*s3.PutBucketPolicyOutput, error) {
+					policies[aws.ToString(params.Bucket)] = aws.ToString(params.Policy)
+					return &s3.PutBucketPolicyOutput{}, nil
+				},
+				PutBucketLoggingFunc: func(ctx context.Context, params *s3.PutBucketLoggingInput, optFns ...func(*s3.Options)) (*s3.PutBucketLoggingOutput, error) {
+					logging = params
+					return &s3.PutBucketLoggingOutput{}, nil
+				},
+			}, &created, policies, &logging
+		}
+
+		loggingDomain := func() (*parkingv1alpha1.ParkedDomain, *corev1.ConfigMap) {
+			return &parkingv1alpha1.ParkedDomain{
+				ObjectMeta: metav1.ObjectMeta{Name: "logged", Namespace: "default"},
+				Spec: parkingv1alpha1.ParkedDomainSpec{
+					DomainName:            "logged.example.com",
+					AccessLogBucket:       "parked-access-logs",
+					AccessLogPrefix:       "logged/",
+					CreateAccessLogBucket: true,
+				},
+			}, &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: "parked-domain-templates", Namespace: "default"},
+				Data:       map[string]string{"default.html": "<h1>{{DOMAIN_NAME}}</h1>"},
+			}
+		}
+
+		It("should create a missing log bucket that S3 can deliver to", func() {
+			s3Client, created, policies, logging := newLoggingMock(map[string]bool{})
+			pd, templateCM := loggingDomain()
+			r := newTestReconciler(&MockR53Client{}, s3Client, pd, templateCM)
+
+			_, err := r.reconcileS3Bucket(context.Background(), pd)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(*created).To(ConsistOf("logged.example.com", "parked-access-logs"))
+			Expect(policies["parked-access-logs"]).To(And(
+				ContainSubstring(`"Service":"logging.s3.amazonaws.com"`),
+				ContainSubstring(`"Resource":"arn:aws:s3:::parked-access-logs/logged/*"`),
+				ContainSubstring(`"aws:SourceArn":"arn:aws:s3:::logged.example.com"`),
+			))
+			Expect(aws.ToString((*logging).BucketLoggingStatus.LoggingEnabled.TargetBucket)).To(Equal("parked-access-logs"))
+			Expect(aws.ToString((*logging).BucketLoggingStatus.LoggingEnabled.TargetPrefix)).To(Equal("logged/"))
+		})
+
+		It("should leave an existing log bucket alone", func() {
+			s3Client, created, policies, _ := newLoggingMock(map[string]bool{"parked-access-logs": true})
+			pd, templateCM := loggingDomain()
+			r := newTestReconciler(&MockR53Client{}, s3Client, pd, templateCM)
+
+			_, err := r.reconcileS3Bucket(context.Background(), pd)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(*created).To(ConsistOf("logged.example.com"))
+			Expect(policies).NotTo(HaveKey("parked-access-logs"))
+		})
+
+		It("should turn access logging off when no log bucket is set", func() {
+			s3Client, _, _, logging := newLoggingMock(map[string]bool{})
+
+			Expect(reconcileBucketLogging(context.Background(), s3Client, "logged.example.com", "", "")).To(Succeed())
+			Expect((*logging).BucketLoggingStatus.LoggingEnabled).To(BeNil())
+		})
+	})
+
+	Context("When building the bucket policy", func() {
+		It("should limit reads to the VPC of a private zone", func() {
+			privateZone := &parkingv1alpha1.PrivateZone{VPCID: "vpc-0abc"}
+			Expect(bucketReadPolicy("internal.example.com", privateZone, "")).To(ContainSubstring(`"aws:SourceVpc":"vpc-0abc"`))
+			Expect(bucketReadPolicy("public.example.com", nil, "")).NotTo(ContainSubstring("aws:SourceVpc"))
+		})
+	})
+})
@@ -0,0 +1,258 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+	parkingv1alpha1 "github.com/gminiba/parked-domain-operator/api/v1alpha1"
+	"github.com/gminiba/parked-domain-operator/internal/awserr"
+)
+
+// errNotBucketOwner is returned when the bucket a ParkedDomain would configure exists but
+// belongs to another AWS account, which may have granted access to it. Retrying only helps
+// once that bucket is deleted or the ParkedDomain names another one.
+var errNotBucketOwner = errors.New("S3 bucket is owned by another AWS account")
+
+// callerAccount caches the AWS account of the operator's own credentials, which do not change
+// while it runs.
+type callerAccount struct {
+	mu sync.Mutex
+	id string
+}
+
+// bucketOwnerFor returns the AWS account pd's bucket must be owned by: the account of
+// Spec.StorageRoleARN, or else the operator's own. It returns "" when ownership is not
+// checked: for shared buckets, which may belong to whoever runs their distribution, for
+// S3-compatible services, and when no STSClient is configured to look up the operator's account.
+func (r *ParkedDomainReconciler) bucketOwnerFor(ctx context.Context, pd *parkingv1alpha1.ParkedDomain) (string, error) {
+	if pd.Spec.SharedBucket != nil || r.storageEndpointFor(pd) != "" {
+		return "", nil
+	}
+	if roleARN := pd.Spec.StorageRoleARN; roleARN != "" {
+		parsed, err := arn.Parse(roleARN)
+		if err != nil {
+			return "", fmt.Errorf("failed to parse storage role ARN %s: %w", roleARN, err)
+		}
+		return parsed.AccountID, nil
+	}
+	if r.STSClient == nil {
+		return "", nil
+	}
+	r.callerAccount.mu.Lock()
+	defer r.callerAccount.mu.Unlock()
+	if r.callerAccount.id != "" {
+		return r.callerAccount.id, nil
+	}
+	getCallerIdentity := func(ctx context.Context) (*sts.GetCallerIdentityOutput, error) {
+		return r.STSClient.GetCallerIdentity(ctx, &sts.GetCallerIdentityInput{})
+	}
+	var output *sts.GetCallerIdentityOutput
+	var err error
+	if r.AWSCallTimeout > 0 {
+		output, err = withCallTimeout(ctx, r.AWSCallTimeout, getCallerIdentity)
+	} else {
+		output, err = getCallerIdentity(ctx)
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to look up the operator's AWS account: %w", err)
+	}
+	r.callerAccount.id = aws.ToString(output.Account)
+	return r.callerAccount.id, nil
+}
+
+// headOwnedBucket checks that bucketName exists and is owned by owner. S3 rejects a request
+// whose ExpectedBucketOwner does not match with 403, like one the caller may not make at all,
+// so a rejected check is told apart by asking again without it.
+func headOwnedBucket(ctx context.Context, s3Client S3ClientAPI, bucketName, owner string) error {
+	_, err := s3Client.HeadBucket(ctx, &s3.HeadBucketInput{Bucket: aws.String(bucketName), ExpectedBucketOwner: aws.String(owner)})
+	if !awserr.IsAccessDenied(err) {
+		return err
+	}
+	if _, headErr := s3Client.HeadBucket(ctx, &s3.HeadBucketInput{Bucket: aws.String(bucketName)}); headErr == nil {
+		return fmt.Errorf("%w: %s is not owned by account %s", errNotBucketOwner, bucketName, owner)
+	}
+	return err
+}
+
+// withExpectedOwner returns s3Client with every call expecting the bucket to be owned by
+// owner, or s3Client itself when owner is "".
+func withExpectedOwner(s3Client S3ClientAPI, owner string) S3ClientAPI {
+	if owner == "" {
+		return s3Client
+	}
+	return &expectedOwnerS3Client{S3ClientAPI: s3Client, owner: owner}
+}
+
+// expectedOwnerS3Client sets ExpectedBucketOwner on every call to the wrapped client, so S3
+// rejects any call addressing a bucket owned by another account. CreateBucket has no such
+// parameter; a bucket it creates is owned by the caller.
+type expectedOwnerS3Client struct {
+	S3ClientAPI
+	owner string
+}
+
+func (c *expectedOwnerS3Client) HeadBucket(ctx context.Context, params *s3.HeadBucketInput, optFns ...func(*s3.Options)) (*s3.HeadBucketOutput, error) {
+	input := *params
+	input.ExpectedBucketOwner = aws.String(c.owner)
+	return c.S3ClientAPI.HeadBucket(ctx, &input, optFns...)
+}
+
+func (c *expectedOwnerS3Client) PutObject(ctx context.Context, params *s3.PutObjectInput, optFns ...func(*s3.Options)) (*s3.PutObjectOutput, error) {
+	input := *params
+	input.ExpectedBucketOwner = aws.String(c.owner)
+	return c.S3ClientAPI.PutObject(ctx, &input, optFns...)
+}
+
+func (c *expectedOwnerS3Client) GetObject(ctx context.Context, params *s3.GetObjectInput, optFns ...func(*s3.Options)) (*s3.GetObjectOutput, error) {
+	input := *params
+	input.ExpectedBucketOwner = aws.String(c.owner)
+	return c.S3ClientAPI.GetObject(ctx, &input, optFns...)
+}
+
+func (c *expectedOwnerS3Client) PutBucketWebsite(ctx context.Context, params *s3.PutBucketWebsiteInput, optFns ...func(*s3.Options)) (*s3.PutBucketWebsiteOutput, error) {
+	input := *params
+	input.ExpectedBucketOwner = aws.String(c.owner)
+	return c.S3ClientAPI.PutBucketWebsite(ctx, &input, optFns...)
+}
+
+func (c *expectedOwnerS3Client) GetBucketWebsite(ctx context.Context, params *s3.GetBucketWebsiteInput, optFns ...func(*s3.Options)) (*s3.GetBucketWebsiteOutput, error) {
+	input := *params
+	input.ExpectedBucketOwner = aws.String(c.owner)
+	return c.S3ClientAPI.GetBucketWebsite(ctx, &input, optFns...)
+}
+
+func (c *expectedOwnerS3Client) GetPublicAccessBlock(ctx context.Context, params *s3.GetPublicAccessBlockInput, optFns ...func(*s3.Options)) (*s3.GetPublicAccessBlockOutput, error) {
+	input := *params
+	input.ExpectedBucketOwner = aws.String(c.owner)
+	return c.S3ClientAPI.GetPublicAccessBlock(ctx, &input, optFns...)
+}
+
+func (c *expectedOwnerS3Client) PutBucketPolicy(ctx context.Context, params *s3.PutBucketPolicyInput, optFns ...func(*s3.Options)) (*s3.PutBucketPolicyOutput, error) {
+	input := *params
+	input.ExpectedBucketOwner = aws.String(c.owner)
+	return c.S3ClientAPI.PutBucketPolicy(ctx, &input, optFns...)
+}
+
+func (c *expectedOwnerS3Client) DeleteBucket(ctx context.Context, params *s3.DeleteBucketInput, optFns ...func(*s3.Options)) (*s3.DeleteBucketOutput, error) {
+	input := *params
+	input.ExpectedBucketOwner = aws.String(c.owner)
+	return c.S3ClientAPI.DeleteBucket(ctx, &input, optFns...)
+}
+
+func (c *expectedOwnerS3Client) ListObjectsV2(ctx context.Context, params *s3.ListObjectsV2Input, optFns ...func(*s3.Options)) (*s3.ListObjectsV2Output, error) {
+	input := *params
+	input.ExpectedBucketOwner = aws.String(c.owner)
+	return c.S3ClientAPI.ListObjectsV2(ctx, &input, optFns...)
+}
+
+func (c *expectedOwnerS3Client) DeleteObjects(ctx context.Context, params *s3.DeleteObjectsInput, optFns ...func(*s3.Options)) (*s3.DeleteObjectsOutput, error) {
+	input := *params
+	input.ExpectedBucketOwner = aws.String(c.owner)
+	return c.S3ClientAPI.DeleteObjects(ctx, &input, optFns...)
+}
+
+func (c *expectedOwnerS3Client) ListObjectVersions(ctx context.Context, params *s3.ListObjectVersionsInput, optFns ...func(*s3.Options)) (*s3.ListObjectVersionsOutput, error) {
+	input := *params
+	input.ExpectedBucketOwner = aws.String(c.owner)
+	return c.S3ClientAPI.ListObjectVersions(ctx, &input, optFns...)
+}
+
+func (c *expectedOwnerS3Client) PutObjectLockConfiguration(ctx context.Context, params *s3.PutObjectLockConfigurationInput, optFns ...func(*s3.Options)) (*s3.PutObjectLockConfigurationOutput, error) {
+	input := *params
+	input.ExpectedBucketOwner = aws.String(c.owner)
+	return c.S3ClientAPI.PutObjectLockConfiguration(ctx, &input, optFns...)
+}
+
+func (c *expectedOwnerS3Client) PutBucketLifecycleConfiguration(ctx context.Context, params *s3.PutBucketLifecycleConfigurationInput, optFns ...func(*s3.Options)) (*s3.PutBucketLifecycleConfigurationOutput, error) {
+	input := *params
+	input.ExpectedBucketOwner = aws.String(c.owner)
+	return c.S3ClientAPI.PutBucketLifecycleConfiguration(ctx, &input, optFns...)
+}
+
+func (c *expectedOwnerS3Client) DeleteBucketLifecycle(ctx context.Context, params *s3.DeleteBucketLifecycleInput, optFns ...func(*s3.Options)) (*s3.DeleteBucketLifecycleOutput, error) {
+	input := *params
+	input.ExpectedBucketOwner = aws.String(c.owner)
+	return c.S3ClientAPI.DeleteBucketLifecycle(ctx, &input, optFns...)
+}
+
+func (c *expectedOwnerS3Client) PutBucketOwnershipControls(ctx context.Context, params *s3.PutBucketOwnershipControlsInput, optFns ...func(*s3.Options)) (*s3.PutBucketOwnershipControlsOutput, error) {
+	input := *params
+	input.ExpectedBucketOwner = aws.String(c.owner)
+	return c.S3ClientAPI.PutBucketOwnershipControls(ctx, &input, optFns...)
+}
+
+func (c *expectedOwnerS3Client) GetBucketOwnershipControls(ctx context.Context, params *s3.GetBucketOwnershipControlsInput, optFns ...func(*s3.Options)) (*s3.GetBucketOwnershipControlsOutput, error) {
+	input := *params
+	input.ExpectedBucketOwner = aws.String(c.owner)
+	return c.S3ClientAPI.GetBucketOwnershipControls(ctx, &input, optFns...)
+}
+
+func (c *expectedOwnerS3Client) PutBucketAccelerateConfiguration(ctx context.Context, params *s3.PutBucketAccelerateConfigurationInput, optFns ...func(*s3.Options)) (*s3.PutBucketAccelerateConfigurationOutput, error) {
+	input := *params
+	input.ExpectedBucketOwner = aws.String(c.owner)
+	return c.S3ClientAPI.PutBucketAccelerateConfiguration(ctx, &input, optFns...)
+}
+
+func (c *expectedOwnerS3Client) PutBucketRequestPayment(ctx context.Context, params *s3.PutBucketRequestPaymentInput, optFns ...func(*s3.Options)) (*s3.PutBucketRequestPaymentOutput, error) {
+	input := *params
+	input.ExpectedBucketOwner = aws.String(c.owner)
+	return c.S3ClientAPI.PutBucketRequestPayment(ctx, &input, optFns...)
+}
+
+func (c *expectedOwnerS3Client) PutBucketLogging(ctx context.Context, params *s3.PutBucketLoggingInput, optFns ...func(*s3.Options)) (*s3.PutBucketLoggingOutput, error) {
+	input := *params
+	input.ExpectedBucketOwner = aws.String(c.owner)
+	return c.S3ClientAPI.PutBucketLogging(ctx, &input, optFns...)
+}
+
+func (c *expectedOwnerS3Client) GetBucketTagging(ctx context.Context, params *s3.GetBucketTaggingInput, optFns ...func(*s3.Options)) (*s3.GetBucketTaggingOutput, error) {
+	input := *params
+	input.ExpectedBucketOwner = aws.String(c.owner)
+	return c.S3ClientAPI.GetBucketTagging(ctx, &input, optFns...)
+}
+
+func (c *expectedOwnerS3Client) PutBucketTagging(ctx context.Context, params *s3.PutBucketTaggingInput, optFns ...func(*s3.Options)) (*s3.PutBucketTaggingOutput, error) {
+	input := *params
+	input.ExpectedBucketOwner = aws.String(c.owner)
+	return c.S3ClientAPI.PutBucketTagging(ctx, &input, optFns...)
+}
+
+func (c *expectedOwnerS3Client) DeleteBucketTagging(ctx context.Context, params *s3.DeleteBucketTaggingInput, optFns ...func(*s3.Options)) (*s3.DeleteBucketTaggingOutput, error) {
+	input := *params
+	input.ExpectedBucketOwner = aws.String(c.owner)
+	return c.S3ClientAPI.DeleteBucketTagging(ctx, &input, optFns...)
+}
+
+func (c *expectedOwnerS3Client) PutBucketMetricsConfiguration(ctx context.Context, params *s3.PutBucketMetricsConfigurationInput, optFns ...func(*s3.Options)) (*s3.PutBucketMetricsConfigurationOutput, error) {
+	input := *params
+	input.ExpectedBucketOwner = aws.String(c.owner)
+	return c.S3ClientAPI.PutBucketMetricsConfiguration(ctx, &input, optFns...)
+}
+
+func (c *expectedOwnerS3Client) DeleteBucketMetricsConfiguration(ctx context.Context, params *s3.DeleteBucketMetricsConfigurationInput, optFns ...func(*s3.Options)) (*s3.DeleteBucketMetricsConfigurationOutput, error) {
+	input := *params
+	input.ExpectedBucketOwner = aws.String(c.owner)
+	return c.S3ClientAPI.DeleteBucketMetricsConfiguration(ctx, &input, optFns...)
+}
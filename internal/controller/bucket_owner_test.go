@@ -0,0 +1,180 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+	"github.com/aws/smithy-go"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	parkingv1alpha1 "github.com/gminiba/parked-domain-operator/api/v1alpha1"
+)
+
+// MockSTSClient is a mock implementation of STSClientAPI.
+type MockSTSClient struct {
+	GetCallerIdentityFunc func(ctx context.Context, params *sts.GetCallerIdentityInput, optFns ...func(*sts.Options)) (*sts.GetCallerIdentityOutput, error)
+}
+
+func (m *MockSTSClient) GetCallerIdentity(ctx context.Context, params *sts.GetCallerIdentityInput, optFns ...func(*sts.Options)) (*sts.GetCallerIdentityOutput, error) {
+	if m.GetCallerIdentityFunc != nil {
+		return m.GetCallerIdentityFunc(ctx, params, optFns...)
+	}
+	return &sts.GetCallerIdentityOutput{Account: aws.String("111111111111")}, nil
+}
+
+var _ = Describe("Bucket ownership", func() {
+	// ownedBy simulates S3 for a bucket owned by account: a request expecting another
+	// owner is rejected with 403.
+	ownedBy := func(account string) func(ctx context.Context, params *s3.HeadBucketInput, optFns ...func(*s3.Options)) (*s3.HeadBucketOutput, error) {
+		return func(ctx context.Context, params *s3.HeadBucketInput, optFns ...func(*s3.Options)) (*s3.HeadBucketOutput, error) {
+			if owner := aws.ToString(params.ExpectedBucketOwner); owner != "" && owner != account {
+				return nil, &smithy.GenericAPIError{Code: "Forbidden"}
+			}
+			return &s3.HeadBucketOutput{}, nil
+		}
+	}
+	newDomain := func(name string) *parkingv1alpha1.ParkedDomain {
+		return &parkingv1alpha1.ParkedDomain{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec: parkingv1alpha1.ParkedDomainSpec{
+				DomainName:     name + ".example.com",
+				InlineTemplate: "<h1>{{DOMAIN_NAME}}</h1>",
+			},
+		}
+	}
+
+	It("should not configure a bucket owned by another account", func() {
+		ctx := context.Background()
+		configured := false
+		s3Client := &MockS3Client{
+			HeadBucketFunc: ownedBy("999999999999"),
+			PutBucketWebsiteFunc: func(ctx context.Context, params *s3.PutBucketWebsiteInput, optFns ...func(*s3.Options)) (*s3.PutBucketWebsiteOutput, error) {
+				configured = true
+				return &s3.PutBucketWebsiteOutput{}, nil
+			},
+		}
+		pd := newDomain("foreign")
+		pd.Spec.StorageRoleARN = "arn:aws:iam::222222222222:role/parking"
+		r := newTestReconciler(&MockR53Client{}, s3Client, pd)
+		r.AllowedRoles = []string{"222222222222"}
+
+		_, err := r.reconcileS3Bucket(ctx, pd)
+		Expect(err).To(MatchError(errNotBucketOwner))
+		Expect(failureReason(err)).To(Equal("NotBucketOwner"))
+		Expect(configured).To(BeFalse())
+	})
+
+	It("should expect the operator's account to own the bucket on every call", func() {
+		ctx := context.Background()
+		var owners []string
+		s3Client := &MockS3Client{
+			HeadBucketFunc: ownedBy("111111111111"),
+			PutBucketPolicyFunc: func(ctx context.Context, params *s3.PutBucketPolicyInput, optFns ...func(*s3.Options)) (*s3.PutBucketPolicyOutput, error) {
+				owners = append(owners, aws.ToString(params.ExpectedBucketOwner))
+				return &s3.PutBucketPolicyOutput{}, nil
+			},
+			PutObjectFunc: func(ctx context.Context, params *s3.PutObjectInput, optFns ...func(*s3.Options)) (*s3.PutObjectOutput, error) {
+				owners = append(owners, aws.ToString(params.ExpectedBucketOwner))
+				return &s3.PutObjectOutput{}, nil
+			},
+		}
+		lookups := 0
+		pd := newDomain("owned")
+		r := newTestReconciler(&MockR53Client{}, s3Client, pd)
+		r.STSClient = &MockSTSClient{
+			GetCallerIdentityFunc: func(ctx context.Context, params *sts.GetCallerIdentityInput, optFns ...func(*sts.Options)) (*sts.GetCallerIdentityOutput, error) {
+				lookups++
+				return &sts.GetCallerIdentityOutput{Account: aws.String("111111111111")}, nil
+			},
+		}
+
+		_, err := r.reconcileS3Bucket(ctx, pd)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(owners).NotTo(BeEmpty())
+		Expect(owners).To(HaveEach("111111111111"))
+
+		By("looking up the operator's account only once")
+		_, err = r.bucketOwnerFor(ctx, pd)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(lookups).To(Equal(1))
+	})
+
+	It("should expect the operator's account to own the bucket when emptying and counting it", func() {
+		ctx := context.Background()
+		var owners []string
+		s3Client := &MockS3Client{
+			HeadBucketFunc: ownedBy("111111111111"),
+			ListObjectsV2Func: func(ctx context.Context, params *s3.ListObjectsV2Input, optFns ...func(*s3.Options)) (*s3.ListObjectsV2Output, error) {
+				owners = append(owners, aws.ToString(params.ExpectedBucketOwner))
+				return &s3.ListObjectsV2Output{}, nil
+			},
+			DeleteBucketFunc: func(ctx context.Context, params *s3.DeleteBucketInput, optFns ...func(*s3.Options)) (*s3.DeleteBucketOutput, error) {
+				owners = append(owners, aws.ToString(params.ExpectedBucketOwner))
+				return &s3.DeleteBucketOutput{}, nil
+			},
+		}
+		pd := newDomain("owned-cleanup")
+		r := newTestReconciler(&MockR53Client{}, s3Client, pd)
+		r.STSClient = &MockSTSClient{}
+
+		r.refreshBucketUsage(ctx, pd)
+		Expect(owners).To(HaveLen(1))
+
+		done, err := r.cleanupS3Bucket(ctx, pd)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(done).To(BeTrue())
+		Expect(len(owners)).To(BeNumerically(">", 1))
+		Expect(owners).To(HaveEach("111111111111"))
+	})
+
+	It("should not check the owner of a bucket it cannot read at all", func() {
+		s3Client := &MockS3Client{
+			HeadBucketFunc: func(ctx context.Context, params *s3.HeadBucketInput, optFns ...func(*s3.Options)) (*s3.HeadBucketOutput, error) {
+				return nil, &smithy.GenericAPIError{Code: "Forbidden"}
+			},
+		}
+		err := headOwnedBucket(context.Background(), s3Client, "locked.example.com", "111111111111")
+		Expect(err).To(HaveOccurred())
+		Expect(err).NotTo(MatchError(errNotBucketOwner))
+	})
+
+	It("should leave a bucket owned by another account alone on deletion", func() {
+		ctx := context.Background()
+		deleted := false
+		s3Client := &MockS3Client{
+			HeadBucketFunc: ownedBy("999999999999"),
+			DeleteBucketFunc: func(ctx context.Context, params *s3.DeleteBucketInput, optFns ...func(*s3.Options)) (*s3.DeleteBucketOutput, error) {
+				deleted = true
+				return &s3.DeleteBucketOutput{}, nil
+			},
+		}
+		pd := newDomain("foreign-cleanup")
+		r := newTestReconciler(&MockR53Client{}, s3Client, pd)
+		r.STSClient = &MockSTSClient{}
+
+		done, err := r.cleanupS3Bucket(ctx, pd)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(done).To(BeTrue())
+		Expect(deleted).To(BeFalse())
+	})
+})
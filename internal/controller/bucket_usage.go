@@ -0,0 +1,125 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	parkingv1alpha1 "github.com/gminiba/parked-domain-operator/api/v1alpha1"
+	"github.com/gminiba/parked-domain-operator/internal/awserr"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// usageRefreshDue reports whether the bucket's object count and size should be counted again.
+func (r *ParkedDomainReconciler) usageRefreshDue(pd *parkingv1alpha1.ParkedDomain) bool {
+	return r.nextUsageRefresh(pd) == 0 && r.BucketUsageInterval > 0 && storageEnabled(pd) && pd.Status.Endpoint != ""
+}
+
+// nextUsageRefresh returns how long until the bucket usage is due to be counted again, or 0
+// when it is due now or usage is not tracked.
+func (r *ParkedDomainReconciler) nextUsageRefresh(pd *parkingv1alpha1.ParkedDomain) time.Duration {
+	if r.BucketUsageInterval <= 0 || !storageEnabled(pd) || pd.Status.UsageUpdatedAt == nil {
+		return 0
+	}
+	return max(r.BucketUsageInterval-time.Since(pd.Status.UsageUpdatedAt.Time), 0)
+}
+
+// refreshBucketUsage counts the bucket's objects and their total size into the status. Usage is
+// informational only, so a failure is logged and the previous counts are kept. It returns false
+// when the bucket no longer exists or lost its website configuration, after marking the
+// BucketReady step for repair.
+func (r *ParkedDomainReconciler) refreshBucketUsage(ctx context.Context, pd *parkingv1alpha1.ParkedDomain) bool {
+	logger := log.FromContext(ctx)
+	s3Client, err := r.s3ClientFor(ctx, pd)
+	if err != nil {
+		logger.Error(err, "Failed to count bucket usage")
+		return true
+	}
+	owner, err := r.bucketOwnerFor(ctx, pd)
+	if err != nil {
+		logger.Error(err, "Failed to count bucket usage")
+		return true
+	}
+	s3Client = withExpectedOwner(s3Client, owner)
+	count, size, err := bucketUsage(ctx, s3Client, bucketNameFor(pd), keyPrefixFor(pd))
+	if err != nil {
+		if awserr.IsNotFound(err) {
+			logger.Info("S3 bucket was deleted outside the operator")
+			meta.SetStatusCondition(&pd.Status.Conditions, metav1.Condition{
+				Type:               parkingv1alpha1.ConditionBucketReady,
+				Status:             metav1.ConditionFalse,
+				ObservedGeneration: pd.Generation,
+				Reason:             "BucketMissing",
+				Message:            "The bucket no longer exists",
+			})
+			return false
+		}
+		logger.Error(err, "Failed to count bucket usage")
+		return true
+	}
+	pd.Status.ObjectCount = count
+	pd.Status.TotalSizeBytes = size
+	now := metav1.Now()
+	pd.Status.UsageUpdatedAt = &now
+	pd.Status.EstimatedMonthlyCostUSD = r.estimateMonthlyCost(pd)
+
+	// S3-compatible services serve the page without a website configuration, and a shared
+	// bucket serves it through its distribution.
+	if r.storageEndpointFor(pd) != "" || pd.Spec.SharedBucket != nil {
+		return true
+	}
+	_, err = s3Client.GetBucketWebsite(ctx, &s3.GetBucketWebsiteInput{Bucket: aws.String(bucketNameFor(pd))})
+	if awserr.IsNoSuchWebsiteConfiguration(err) {
+		logger.Info("S3 bucket website configuration was removed outside the operator")
+		meta.SetStatusCondition(&pd.Status.Conditions, metav1.Condition{
+			Type:               parkingv1alpha1.ConditionBucketReady,
+			Status:             metav1.ConditionFalse,
+			ObservedGeneration: pd.Generation,
+			Reason:             websiteConfigurationMissingReason,
+			Message:            "The bucket has no website configuration",
+		})
+		return false
+	}
+	if err != nil {
+		logger.Error(err, "Failed to check bucket website configuration")
+	}
+	return true
+}
+
+// bucketUsage returns the number of objects under prefix in the bucket and their total size in
+// bytes.
+func bucketUsage(ctx context.Context, s3Client S3ClientAPI, bucketName, prefix string) (int64, int64, error) {
+	var count, size int64
+	paginator := s3.NewListObjectsV2Paginator(s3Client, listObjectsInput(bucketName, prefix))
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return 0, 0, fmt.Errorf("failed to list objects in S3 bucket: %w", err)
+		}
+		for _, obj := range page.Contents {
+			count++
+			size += aws.ToInt64(obj.Size)
+		}
+	}
+	return count, size, nil
+}
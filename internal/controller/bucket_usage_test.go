@@ -0,0 +1,117 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"os"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	parkingv1alpha1 "github.com/gminiba/parked-domain-operator/api/v1alpha1"
+)
+
+var _ = Describe("Bucket usage", func() {
+	It("should count objects and bytes across pages", func() {
+		s3Client := &MockS3Client{
+			ListObjectsV2Func: func(ctx context.Context, params *s3.ListObjectsV2Input, optFns ...func(*s3.Options)) (*s3.ListObjectsV2Output, error) {
+				if params.ContinuationToken == nil {
+					return &s3.ListObjectsV2Output{
+						Contents:              []s3types.Object{{Key: aws.String("index.html"), Size: aws.Int64(512)}},
+						IsTruncated:           aws.Bool(true),
+						NextContinuationToken: aws.String("next"),
+					}, nil
+				}
+				return &s3.ListObjectsV2Output{Contents: []s3types.Object{
+					{Key: aws.String("logo.png"), Size: aws.Int64(2048)},
+					{Key: aws.String("style.css"), Size: aws.Int64(256)},
+				}}, nil
+			},
+		}
+
+		count, size, err := bucketUsage(context.Background(), s3Client, "usage.example.com", "")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(count).To(Equal(int64(3)))
+		Expect(size).To(Equal(int64(2816)))
+	})
+
+	Context("When reconciling", func() {
+		req := ctrl.Request{NamespacedName: types.NamespacedName{Name: "usage", Namespace: "default"}}
+
+		BeforeEach(func() {
+			Expect(os.Setenv("TEMPLATE_CONFIGMAP_NAME", "parked-domain-templates")).To(Succeed())
+		})
+
+		AfterEach(func() {
+			Expect(os.Unsetenv("TEMPLATE_CONFIGMAP_NAME")).To(Succeed())
+		})
+
+		It("should only count the bucket once per interval", func() {
+			ctx := context.Background()
+			listings := 0
+			s3Client := &MockS3Client{
+				ListObjectsV2Func: func(ctx context.Context, params *s3.ListObjectsV2Input, optFns ...func(*s3.Options)) (*s3.ListObjectsV2Output, error) {
+					listings++
+					return &s3.ListObjectsV2Output{Contents: []s3types.Object{{Key: aws.String("index.html"), Size: aws.Int64(100)}}}, nil
+				},
+			}
+			pd := &parkingv1alpha1.ParkedDomain{
+				ObjectMeta: metav1.ObjectMeta{Name: "usage", Namespace: "default"},
+				Spec:       parkingv1alpha1.ParkedDomainSpec{DomainName: "usage.example.com"},
+			}
+			templateCM := &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: "parked-domain-templates", Namespace: "default"},
+				Data:       map[string]string{"default.html": "<h1>{{DOMAIN_NAME}}</h1>"},
+			}
+			r := newTestReconciler(&MockR53Client{}, s3Client, pd, templateCM)
+			r.BucketUsageInterval = time.Hour
+
+			result, err := r.Reconcile(ctx, req)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).To(BeNumerically("~", time.Hour, time.Minute))
+			Expect(listings).To(Equal(1))
+			counted := &parkingv1alpha1.ParkedDomain{}
+			Expect(r.Get(ctx, req.NamespacedName, counted)).To(Succeed())
+			Expect(counted.Status.ObjectCount).To(Equal(int64(1)))
+			Expect(counted.Status.TotalSizeBytes).To(Equal(int64(100)))
+			Expect(counted.Status.EstimatedMonthlyCostUSD).To(Equal("0.50"))
+
+			By("skipping the count before the interval elapsed")
+			result, err = r.Reconcile(ctx, req)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).To(BeNumerically(">", 0))
+			Expect(listings).To(Equal(1))
+
+			By("counting again once the interval elapsed")
+			Expect(r.Get(ctx, req.NamespacedName, counted)).To(Succeed())
+			counted.Status.UsageUpdatedAt = &metav1.Time{Time: time.Now().Add(-2 * time.Hour)}
+			Expect(r.Status().Update(ctx, counted)).To(Succeed())
+			_, err = r.Reconcile(ctx, req)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(listings).To(Equal(2))
+		})
+	})
+})
@@ -0,0 +1,87 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	parkingv1alpha1 "github.com/gminiba/parked-domain-operator/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// allowedDomainsAnnotation lists, comma-separated, the domains the ParkedDomainClaims of a
+	// namespace may park. An entry allows the domain and every subdomain, "*" allows any domain.
+	// A namespace without it may not claim any domain.
+	allowedDomainsAnnotation = parkingv1alpha1.GroupName + "/allowed-domains"
+	// allowedRegionsAnnotation lists, comma-separated, the regions the ParkedDomainClaims of a
+	// namespace may use. A namespace without it may use any region.
+	allowedRegionsAnnotation = parkingv1alpha1.GroupName + "/allowed-regions"
+)
+
+const (
+	// claimReasonDomainNotAllowed is the Bound reason of a claim for a domain its namespace may not park.
+	claimReasonDomainNotAllowed = "DomainNotAllowed"
+	// claimReasonRegionNotAllowed is the Bound reason of a claim for a region its namespace may not use.
+	claimReasonRegionNotAllowed = "RegionNotAllowed"
+)
+
+// claimPolicyViolation is why a namespace's policy does not allow a claim.
+type claimPolicyViolation struct {
+	reason  string
+	message string
+}
+
+// checkClaimPolicy returns why namespace's policy does not allow claim, or nil if it does.
+func checkClaimPolicy(namespace *corev1.Namespace, claim *parkingv1alpha1.ParkedDomainClaim) *claimPolicyViolation {
+	domains := SplitPolicyList(namespace.Annotations[allowedDomainsAnnotation])
+	if !slices.ContainsFunc(domains, func(allowed string) bool { return DomainAllowed(allowed, claim.Spec.DomainName) }) {
+		return &claimPolicyViolation{
+			reason:  claimReasonDomainNotAllowed,
+			message: fmt.Sprintf("domain %q is not allowed by annotation %s of namespace %s", claim.Spec.DomainName, allowedDomainsAnnotation, namespace.Name),
+		}
+	}
+	regions, restricted := namespace.Annotations[allowedRegionsAnnotation]
+	if restricted && claim.Spec.Region != "" && !slices.Contains(SplitPolicyList(regions), claim.Spec.Region) {
+		return &claimPolicyViolation{
+			reason:  claimReasonRegionNotAllowed,
+			message: fmt.Sprintf("region %q is not allowed by annotation %s of namespace %s", claim.Spec.Region, allowedRegionsAnnotation, namespace.Name),
+		}
+	}
+	return nil
+}
+
+// DomainAllowed reports whether the policy entry allowed, a domain suffix or "*", covers domain.
+// A suffix covers the domain itself and every subdomain, ignoring case and a trailing dot.
+func DomainAllowed(allowed, domain string) bool {
+	allowed = strings.TrimSuffix(strings.ToLower(allowed), ".")
+	domain = strings.TrimSuffix(strings.ToLower(domain), ".")
+	return allowed == "*" || domain == allowed || strings.HasSuffix(domain, "."+allowed)
+}
+
+// SplitPolicyList splits a comma-separated list of policy entries, dropping empty ones.
+func SplitPolicyList(value string) []string {
+	var entries []string
+	for _, entry := range strings.Split(value, ",") {
+		if entry = strings.TrimSpace(entry); entry != "" {
+			entries = append(entries, entry)
+		}
+	}
+	return entries
+}
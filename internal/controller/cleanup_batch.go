@@ -0,0 +1,55 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"strconv"
+
+	parkingv1alpha1 "github.com/gminiba/parked-domain-operator/api/v1alpha1"
+)
+
+const (
+	// cleanupBatchSizeAnnotation sets how many objects a bucket cleanup deletes per DeleteObjects
+	// call, so the cleanup of a large bucket can be slowed to leave API capacity to others.
+	cleanupBatchSizeAnnotation = parkingv1alpha1.GroupName + "/cleanup-batch-size"
+	// maxCleanupBatchSize is the most keys S3 accepts in one DeleteObjects call, and the default.
+	maxCleanupBatchSize = 1000
+)
+
+// cleanupBatchSizeFor returns the number of objects pd's bucket cleanup deletes per call. An
+// invalid annotation is reported and the default used, so a typo never blocks a deletion.
+func cleanupBatchSizeFor(pd *parkingv1alpha1.ParkedDomain) (int32, error) {
+	value, ok := pd.Annotations[cleanupBatchSizeAnnotation]
+	if !ok {
+		return maxCleanupBatchSize, nil
+	}
+	size, err := parseCleanupBatchSize(value)
+	if err != nil {
+		return maxCleanupBatchSize, err
+	}
+	return size, nil
+}
+
+// parseCleanupBatchSize parses the value of cleanupBatchSizeAnnotation.
+func parseCleanupBatchSize(value string) (int32, error) {
+	size, err := strconv.ParseInt(value, 10, 32)
+	if err != nil || size < 1 || size > maxCleanupBatchSize {
+		return 0, fmt.Errorf("annotation %s must be a number from 1 to %d, got %q", cleanupBatchSizeAnnotation, maxCleanupBatchSize, value)
+	}
+	return int32(size), nil
+}
@@ -0,0 +1,60 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"path"
+	"strings"
+)
+
+// defaultContentType is used for objects whose extension has no known content type.
+const defaultContentType = "application/octet-stream"
+
+// defaultContentTypes maps file extensions to the content types objects are uploaded with.
+var defaultContentTypes = map[string]string{
+	".html": "text/html",
+	".htm":  "text/html",
+	".css":  "text/css",
+	".js":   "text/javascript",
+	".json": "application/json",
+	".txt":  "text/plain",
+	".png":  "image/png",
+	".jpg":  "image/jpeg",
+	".jpeg": "image/jpeg",
+	".gif":  "image/gif",
+	".svg":  "image/svg+xml",
+	".ico":  "image/x-icon",
+	".webp": "image/webp",
+}
+
+// contentTypeFor returns the content type to upload key with. Overrides are keyed by extension,
+// with or without the leading dot, and take precedence over defaultContentTypes.
+func contentTypeFor(key string, overrides map[string]string) string {
+	ext := strings.ToLower(path.Ext(key))
+	if ext == "" {
+		return defaultContentType
+	}
+	for overrideExt, contentType := range overrides {
+		if "."+strings.TrimPrefix(strings.ToLower(overrideExt), ".") == ext {
+			return contentType
+		}
+	}
+	if contentType, ok := defaultContentTypes[ext]; ok {
+		return contentType
+	}
+	return defaultContentType
+}
@@ -0,0 +1,38 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Content types", func() {
+	DescribeTable("picks the content type for an object key",
+		func(key string, overrides map[string]string, expected string) {
+			Expect(contentTypeFor(key, overrides)).To(Equal(expected))
+		},
+		Entry("html", "index.html", nil, "text/html"),
+		Entry("upper-case extension", "LOGO.PNG", nil, "image/png"),
+		Entry("svg", "assets/logo.svg", nil, "image/svg+xml"),
+		Entry("favicon", "favicon.ico", nil, "image/x-icon"),
+		Entry("unknown extension", "archive.tar.zst", nil, "application/octet-stream"),
+		Entry("no extension", "LICENSE", nil, "application/octet-stream"),
+		Entry("override without dot", "index.html", map[string]string{"html": "text/html; charset=utf-8"}, "text/html; charset=utf-8"),
+		Entry("override with dot", "font.woff2", map[string]string{".woff2": "font/woff2"}, "font/woff2"),
+	)
+})
@@ -0,0 +1,59 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+
+	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
+	parkingv1alpha1 "github.com/gminiba/parked-domain-operator/api/v1alpha1"
+)
+
+// Monthly us-east-1 list prices, in US dollars, of the resources a ParkedDomain keeps. Only
+// fixed and storage charges are listed; traffic-based ones depend on visitors the operator
+// knows nothing about.
+const (
+	hostedZoneMonthlyCost = 0.50
+	alarmMonthlyCost      = 0.10
+)
+
+// storageGBMonthlyCost is the price of storing one GB for a month, per S3 storage class.
+var storageGBMonthlyCost = map[s3types.StorageClass]float64{
+	s3types.StorageClassStandard:           0.023,
+	s3types.StorageClassIntelligentTiering: 0.023,
+	s3types.StorageClassStandardIa:         0.0125,
+	s3types.StorageClassOnezoneIa:          0.01,
+	s3types.StorageClassGlacierIr:          0.004,
+}
+
+// estimateMonthlyCost returns a rough monthly cost of pd's resources in US dollars, formatted
+// for Status.EstimatedMonthlyCostUSD. The bucket's size is taken from the last usage count.
+// S3-compatible services have prices of their own and are not counted.
+func (r *ParkedDomainReconciler) estimateMonthlyCost(pd *parkingv1alpha1.ParkedDomain) string {
+	var cost float64
+	if pd.Status.ZoneID != "" {
+		cost += hostedZoneMonthlyCost
+	}
+	if storageEnabled(pd) && r.storageEndpointFor(pd) == "" {
+		const bytesPerGB = 1 << 30
+		cost += float64(pd.Status.TotalSizeBytes) / bytesPerGB * storageGBMonthlyCost[storageClassFor(pd.Spec.StorageClass)]
+	}
+	if pd.Status.AlarmName != "" {
+		cost += alarmMonthlyCost
+	}
+	return fmt.Sprintf("%.2f", cost)
+}
@@ -0,0 +1,45 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	parkingv1alpha1 "github.com/gminiba/parked-domain-operator/api/v1alpha1"
+)
+
+var _ = Describe("Monthly cost estimate", func() {
+	const gb = 1 << 30
+
+	DescribeTable("estimating the monthly cost",
+		func(spec parkingv1alpha1.ParkedDomainSpec, status parkingv1alpha1.ParkedDomainStatus, expected string) {
+			r := &ParkedDomainReconciler{}
+			pd := &parkingv1alpha1.ParkedDomain{Spec: spec, Status: status}
+			Expect(r.estimateMonthlyCost(pd)).To(Equal(expected))
+		},
+		Entry("nothing provisioned yet", parkingv1alpha1.ParkedDomainSpec{}, parkingv1alpha1.ParkedDomainStatus{}, "0.00"),
+		Entry("a Hosted Zone and a small page",
+			parkingv1alpha1.ParkedDomainSpec{}, parkingv1alpha1.ParkedDomainStatus{ZoneID: "Z1", TotalSizeBytes: 4096}, "0.50"),
+		Entry("a Hosted Zone, 100 GB and an alarm",
+			parkingv1alpha1.ParkedDomainSpec{}, parkingv1alpha1.ParkedDomainStatus{ZoneID: "Z1", TotalSizeBytes: 100 * gb, AlarmName: "a"}, "2.90"),
+		Entry("100 GB in STANDARD_IA",
+			parkingv1alpha1.ParkedDomainSpec{StorageClass: "STANDARD_IA"}, parkingv1alpha1.ParkedDomainStatus{TotalSizeBytes: 100 * gb}, "1.25"),
+		Entry("a bucket on an S3-compatible service",
+			parkingv1alpha1.ParkedDomainSpec{StorageEndpoint: "https://minio.internal:9000"}, parkingv1alpha1.ParkedDomainStatus{TotalSizeBytes: 100 * gb}, "0.00"),
+	)
+})
@@ -0,0 +1,158 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	parkingv1alpha1 "github.com/gminiba/parked-domain-operator/api/v1alpha1"
+	"github.com/gminiba/parked-domain-operator/internal/awserr"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// dnsFirewallPriority is the priority Spec.DNSFirewallRuleGroupID is associated with. It
+// leaves the priorities below it to rule groups associated outside the operator.
+const dnsFirewallPriority = 9000
+
+// reconcileDNSFirewall associates Spec.DNSFirewallRuleGroupID with the private zone's VPC,
+// replacing an association of another rule group, and removes the association when the
+// field is cleared. The association's ID is kept in Status.DNSFirewallAssociationID.
+func (r *ParkedDomainReconciler) reconcileDNSFirewall(ctx context.Context, pd *parkingv1alpha1.ParkedDomain) error {
+	if pd.Spec.DNSFirewallRuleGroupID == "" || pd.Spec.PrivateZone == nil {
+		return r.cleanupDNSFirewall(ctx, pd)
+	}
+	fwClient, err := r.dnsFirewallClientFor(ctx, pd)
+	if err != nil {
+		return err
+	}
+	ruleGroupID, vpcID := pd.Spec.DNSFirewallRuleGroupID, pd.Spec.PrivateZone.VPCID
+
+	if id := pd.Status.DNSFirewallAssociationID; id != "" {
+		getOutput, err := fwClient.GetFirewallRuleGroupAssociation(ctx, &GetFirewallRuleGroupAssociationInput{FirewallRuleGroupAssociationID: id})
+		switch {
+		case awserr.IsNotFound(err):
+			// Removed outside the operator, so associate it again below.
+			pd.Status.DNSFirewallAssociationID = ""
+		case err != nil:
+			return fmt.Errorf("failed to get DNS Firewall association %s: %w", id, err)
+		case getOutput.FirewallRuleGroupAssociation.FirewallRuleGroupID == ruleGroupID &&
+			getOutput.FirewallRuleGroupAssociation.VPCID == vpcID:
+			return nil
+		default:
+			if err := r.deleteDNSFirewallAssociation(ctx, fwClient, pd); err != nil {
+				return err
+			}
+		}
+	}
+
+	associateOutput, err := fwClient.AssociateFirewallRuleGroup(ctx, &AssociateFirewallRuleGroupInput{
+		CreatorRequestID:    fmt.Sprintf("%s%s-%d", managedCallerReferencePrefix, pd.UID, time.Now().Unix()),
+		FirewallRuleGroupID: ruleGroupID,
+		VPCID:               vpcID,
+		Priority:            dnsFirewallPriority,
+		Name:                managedComment,
+	})
+	switch {
+	case awserr.IsConflict(err):
+		// E.g. associated by an earlier reconcile whose status write was lost, or for
+		// another ParkedDomain in the same VPC.
+		return adoptDNSFirewallAssociation(ctx, fwClient, pd, ruleGroupID, vpcID)
+	case err != nil:
+		return fmt.Errorf("failed to associate DNS Firewall rule group %s with VPC %s: %w", ruleGroupID, vpcID, err)
+	}
+	pd.Status.DNSFirewallAssociationID = associateOutput.FirewallRuleGroupAssociation.ID
+	log.FromContext(ctx).Info("Associated DNS Firewall rule group with VPC", "ruleGroupID", ruleGroupID, "vpcID", vpcID)
+	return nil
+}
+
+// adoptDNSFirewallAssociation records the existing association of ruleGroupID with vpcID. An
+// association conflict without one means another rule group holds dnsFirewallPriority.
+func adoptDNSFirewallAssociation(ctx context.Context, fwClient DNSFirewallClientAPI, pd *parkingv1alpha1.ParkedDomain, ruleGroupID, vpcID string) error {
+	input := &ListFirewallRuleGroupAssociationsInput{FirewallRuleGroupID: ruleGroupID, VPCID: vpcID}
+	for {
+		listOutput, err := fwClient.ListFirewallRuleGroupAssociations(ctx, input)
+		if err != nil {
+			return fmt.Errorf("failed to list DNS Firewall associations: %w", err)
+		}
+		for _, association := range listOutput.FirewallRuleGroupAssociations {
+			if association.FirewallRuleGroupID == ruleGroupID && association.VPCID == vpcID {
+				pd.Status.DNSFirewallAssociationID = association.ID
+				return nil
+			}
+		}
+		if listOutput.NextToken == "" {
+			break
+		}
+		input.NextToken = listOutput.NextToken
+	}
+	return fmt.Errorf("VPC %s already has a DNS Firewall rule group at priority %d; disassociate it to associate %s",
+		vpcID, dnsFirewallPriority, ruleGroupID)
+}
+
+// cleanupDNSFirewall removes the association in Status.DNSFirewallAssociationID, if any.
+func (r *ParkedDomainReconciler) cleanupDNSFirewall(ctx context.Context, pd *parkingv1alpha1.ParkedDomain) error {
+	if pd.Status.DNSFirewallAssociationID == "" {
+		return nil
+	}
+	fwClient, err := r.dnsFirewallClientFor(ctx, pd)
+	if err != nil {
+		return err
+	}
+	return r.deleteDNSFirewallAssociation(ctx, fwClient, pd)
+}
+
+// deleteDNSFirewallAssociation removes the association in Status.DNSFirewallAssociationID,
+// unless another ParkedDomain in the same VPC still records it.
+func (r *ParkedDomainReconciler) deleteDNSFirewallAssociation(ctx context.Context, fwClient DNSFirewallClientAPI, pd *parkingv1alpha1.ParkedDomain) error {
+	id := pd.Status.DNSFirewallAssociationID
+	shared, err := r.dnsFirewallAssociationShared(ctx, pd)
+	if err != nil {
+		return err
+	}
+	if shared {
+		log.FromContext(ctx).Info("Keeping DNS Firewall association used by another ParkedDomain", "associationID", id)
+		pd.Status.DNSFirewallAssociationID = ""
+		return nil
+	}
+	_, err = fwClient.DisassociateFirewallRuleGroup(ctx, &DisassociateFirewallRuleGroupInput{FirewallRuleGroupAssociationID: id})
+	if err != nil && !awserr.IsNotFound(err) {
+		return fmt.Errorf("failed to remove DNS Firewall association %s: %w", id, err)
+	}
+	log.FromContext(ctx).Info("Removed DNS Firewall association", "associationID", id)
+	pd.Status.DNSFirewallAssociationID = ""
+	return nil
+}
+
+// dnsFirewallAssociationShared reports whether a ParkedDomain other than pd records pd's DNS
+// Firewall association.
+func (r *ParkedDomainReconciler) dnsFirewallAssociationShared(ctx context.Context, pd *parkingv1alpha1.ParkedDomain) (bool, error) {
+	var domains parkingv1alpha1.ParkedDomainList
+	if err := r.List(ctx, &domains); err != nil {
+		return false, fmt.Errorf("failed to list ParkedDomains: %w", err)
+	}
+	for _, other := range domains.Items {
+		if other.Namespace == pd.Namespace && other.Name == pd.Name {
+			continue
+		}
+		if other.Status.DNSFirewallAssociationID == pd.Status.DNSFirewallAssociationID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,258 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/retry"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+	awshttp "github.com/aws/aws-sdk-go-v2/aws/transport/http"
+	"github.com/aws/smithy-go"
+	smithyhttp "github.com/aws/smithy-go/transport/http"
+)
+
+// dnsFirewallService is the signing name and endpoint prefix of the Route 53 Resolver API,
+// which manages DNS Firewall.
+const dnsFirewallService = "route53resolver"
+
+// FirewallRuleGroupAssociation is the association of a DNS Firewall rule group with a VPC.
+type FirewallRuleGroupAssociation struct {
+	ID                  string `json:"Id"`
+	FirewallRuleGroupID string `json:"FirewallRuleGroupId"`
+	VPCID               string `json:"VpcId"`
+	Name                string `json:"Name"`
+	Priority            int32  `json:"Priority"`
+	Status              string `json:"Status"`
+}
+
+// AssociateFirewallRuleGroupInput are the parameters of AssociateFirewallRuleGroup.
+type AssociateFirewallRuleGroupInput struct {
+	// CreatorRequestID makes retries of the same association idempotent.
+	CreatorRequestID    string `json:"CreatorRequestId"`
+	FirewallRuleGroupID string `json:"FirewallRuleGroupId"`
+	VPCID               string `json:"VpcId"`
+	Priority            int32  `json:"Priority"`
+	Name                string `json:"Name"`
+}
+
+// AssociateFirewallRuleGroupOutput is the result of AssociateFirewallRuleGroup.
+type AssociateFirewallRuleGroupOutput struct {
+	FirewallRuleGroupAssociation *FirewallRuleGroupAssociation `json:"FirewallRuleGroupAssociation"`
+}
+
+// GetFirewallRuleGroupAssociationInput are the parameters of GetFirewallRuleGroupAssociation.
+type GetFirewallRuleGroupAssociationInput struct {
+	FirewallRuleGroupAssociationID string `json:"FirewallRuleGroupAssociationId"`
+}
+
+// GetFirewallRuleGroupAssociationOutput is the result of GetFirewallRuleGroupAssociation.
+type GetFirewallRuleGroupAssociationOutput struct {
+	FirewallRuleGroupAssociation *FirewallRuleGroupAssociation `json:"FirewallRuleGroupAssociation"`
+}
+
+// ListFirewallRuleGroupAssociationsInput are the parameters of
+// ListFirewallRuleGroupAssociations. Empty filters are omitted.
+type ListFirewallRuleGroupAssociationsInput struct {
+	FirewallRuleGroupID string `json:"FirewallRuleGroupId,omitempty"`
+	VPCID               string `json:"VpcId,omitempty"`
+	NextToken           string `json:"NextToken,omitempty"`
+}
+
+// ListFirewallRuleGroupAssociationsOutput is a page of ListFirewallRuleGroupAssociations.
+type ListFirewallRuleGroupAssociationsOutput struct {
+	FirewallRuleGroupAssociations []FirewallRuleGroupAssociation `json:"FirewallRuleGroupAssociations"`
+	NextToken                     string                         `json:"NextToken"`
+}
+
+// DisassociateFirewallRuleGroupInput are the parameters of DisassociateFirewallRuleGroup.
+type DisassociateFirewallRuleGroupInput struct {
+	FirewallRuleGroupAssociationID string `json:"FirewallRuleGroupAssociationId"`
+}
+
+// DisassociateFirewallRuleGroupOutput is the result of DisassociateFirewallRuleGroup.
+type DisassociateFirewallRuleGroupOutput struct {
+	FirewallRuleGroupAssociation *FirewallRuleGroupAssociation `json:"FirewallRuleGroupAssociation"`
+}
+
+// AWSDNSFirewallClientFactory creates real Route 53 Resolver DNS Firewall clients.
+type AWSDNSFirewallClientFactory struct{}
+
+func (f *AWSDNSFirewallClientFactory) GetClient(ctx context.Context, region, roleARN string) (DNSFirewallClientAPI, error) {
+	cfg, err := loadAWSConfig(ctx, region, roleARN)
+	if err != nil {
+		return nil, err
+	}
+	partition, err := partitionForRegion(region)
+	if err != nil {
+		return nil, err
+	}
+	endpoint := fmt.Sprintf("https://%s.%s.%s", dnsFirewallService, region, partition.DNSSuffix)
+	if cfg.BaseEndpoint != nil {
+		endpoint = aws.ToString(cfg.BaseEndpoint)
+	}
+	var retryer aws.Retryer = retry.NewStandard()
+	if cfg.Retryer != nil {
+		retryer = cfg.Retryer()
+	}
+	return &dnsFirewallClient{
+		cfg:      cfg,
+		endpoint: endpoint,
+		signer:   v4.NewSigner(),
+		retryer:  retryer,
+	}, nil
+}
+
+// dnsFirewallClient calls the four Route 53 Resolver operations the operator needs over the
+// service's JSON protocol, signed with the credentials of cfg and retried like the SDK's
+// clients retry, throttling included. The SDK's route53resolver module is not a dependency
+// of the operator for just these calls.
+type dnsFirewallClient struct {
+	cfg      aws.Config
+	endpoint string
+	signer   *v4.Signer
+	retryer  aws.Retryer
+}
+
+func (c *dnsFirewallClient) AssociateFirewallRuleGroup(ctx context.Context, params *AssociateFirewallRuleGroupInput) (*AssociateFirewallRuleGroupOutput, error) {
+	output := &AssociateFirewallRuleGroupOutput{}
+	return output, c.call(ctx, "AssociateFirewallRuleGroup", params, output)
+}
+
+func (c *dnsFirewallClient) GetFirewallRuleGroupAssociation(ctx context.Context, params *GetFirewallRuleGroupAssociationInput) (*GetFirewallRuleGroupAssociationOutput, error) {
+	output := &GetFirewallRuleGroupAssociationOutput{}
+	return output, c.call(ctx, "GetFirewallRuleGroupAssociation", params, output)
+}
+
+func (c *dnsFirewallClient) ListFirewallRuleGroupAssociations(ctx context.Context, params *ListFirewallRuleGroupAssociationsInput) (*ListFirewallRuleGroupAssociationsOutput, error) {
+	output := &ListFirewallRuleGroupAssociationsOutput{}
+	return output, c.call(ctx, "ListFirewallRuleGroupAssociations", params, output)
+}
+
+func (c *dnsFirewallClient) DisassociateFirewallRuleGroup(ctx context.Context, params *DisassociateFirewallRuleGroupInput) (*DisassociateFirewallRuleGroupOutput, error) {
+	output := &DisassociateFirewallRuleGroupOutput{}
+	return output, c.call(ctx, "DisassociateFirewallRuleGroup", params, output)
+}
+
+// call sends params to operation and decodes the response into output, retrying the
+// failures c.retryer deems retryable with its backoff. An error response is returned as a
+// smithy.APIError, so the awserr classifiers work on it.
+func (c *dnsFirewallClient) call(ctx context.Context, operation string, params, output any) error {
+	body, err := json.Marshal(params)
+	if err != nil {
+		return fmt.Errorf("failed to encode %s request: %w", operation, err)
+	}
+	for attempt := 1; ; attempt++ {
+		err = c.send(ctx, operation, body, output)
+		if err == nil || attempt >= c.retryer.MaxAttempts() || !c.retryer.IsErrorRetryable(err) {
+			return err
+		}
+		// The retry token bucket stops retry storms, e.g. during a long throttling episode.
+		if _, tokenErr := c.retryer.GetRetryToken(ctx, err); tokenErr != nil {
+			return err
+		}
+		delay, delayErr := c.retryer.RetryDelay(attempt, err)
+		if delayErr != nil {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(delay):
+		}
+	}
+}
+
+// send makes a single attempt of call.
+func (c *dnsFirewallClient) send(ctx context.Context, operation string, body []byte, output any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-amz-json-1.1")
+	req.Header.Set("X-Amz-Target", "Route53Resolver."+operation)
+
+	creds, err := c.cfg.Credentials.Retrieve(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to retrieve AWS credentials: %w", err)
+	}
+	payloadHash := sha256.Sum256(body)
+	if err := c.signer.SignHTTP(ctx, creds, req, hex.EncodeToString(payloadHash[:]), dnsFirewallService, c.cfg.Region, time.Now()); err != nil {
+		return fmt.Errorf("failed to sign %s request: %w", operation, err)
+	}
+
+	awsCallRates.record(c.cfg.Region, "Route53Resolver")
+	var httpClient aws.HTTPClient = c.cfg.HTTPClient
+	if httpClient == nil {
+		httpClient = awshttp.NewBuildableClient()
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("%s request failed: %w", operation, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read %s response: %w", operation, err)
+	}
+	if resp.StatusCode >= http.StatusMultipleChoices {
+		// Wrapped like the SDK's errors, so the retryer sees the status code, e.g. a 503.
+		return &awshttp.ResponseError{
+			ResponseError: &smithyhttp.ResponseError{
+				Response: &smithyhttp.Response{Response: resp},
+				Err:      decodeDNSFirewallError(resp.StatusCode, respBody),
+			},
+			RequestID: resp.Header.Get("X-Amzn-Requestid"),
+		}
+	}
+	if err := json.Unmarshal(respBody, output); err != nil {
+		return fmt.Errorf("failed to decode %s response: %w", operation, err)
+	}
+	return nil
+}
+
+// decodeDNSFirewallError turns the JSON error body of a failed request into an API error
+// carrying its code, e.g. ResourceNotFoundException. The message field is matched
+// case-insensitively, as the service spells it either way.
+func decodeDNSFirewallError(statusCode int, body []byte) error {
+	var payload struct {
+		Type    string `json:"__type"`
+		Message string `json:"message"`
+	}
+	_ = json.Unmarshal(body, &payload)
+	apiErr := &smithy.GenericAPIError{Code: payload.Type, Message: payload.Message, Fault: smithy.FaultClient}
+	if i := strings.LastIndex(apiErr.Code, "#"); i >= 0 {
+		apiErr.Code = apiErr.Code[i+1:]
+	}
+	if apiErr.Code == "" {
+		apiErr.Code = http.StatusText(statusCode)
+	}
+	if statusCode >= http.StatusInternalServerError {
+		apiErr.Fault = smithy.FaultServer
+	}
+	return apiErr
+}
@@ -0,0 +1,371 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/retry"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/smithy-go"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	parkingv1alpha1 "github.com/gminiba/parked-domain-operator/api/v1alpha1"
+	"github.com/gminiba/parked-domain-operator/internal/awserr"
+)
+
+// MockDNSFirewallClient is a mock implementation of DNSFirewallClientAPI that keeps its
+// associations in memory. It rejects a second association of the same rule group with the
+// same VPC the way Route 53 Resolver does.
+type MockDNSFirewallClient struct {
+	Associations    map[string]FirewallRuleGroupAssociation
+	Disassociated   []string
+	nextID          int
+	AssociateFunc   func(ctx context.Context, params *AssociateFirewallRuleGroupInput) (*AssociateFirewallRuleGroupOutput, error)
+	DisassociateErr error
+}
+
+func (m *MockDNSFirewallClient) AssociateFirewallRuleGroup(ctx context.Context, params *AssociateFirewallRuleGroupInput) (*AssociateFirewallRuleGroupOutput, error) {
+	if m.AssociateFunc != nil {
+		return m.AssociateFunc(ctx, params)
+	}
+	if m.Associations == nil {
+		m.Associations = map[string]FirewallRuleGroupAssociation{}
+	}
+	for _, association := range m.Associations {
+		if association.FirewallRuleGroupID == params.FirewallRuleGroupID && association.VPCID == params.VPCID {
+			return nil, &smithy.GenericAPIError{Code: "ConflictException"}
+		}
+	}
+	m.nextID++
+	association := FirewallRuleGroupAssociation{
+		ID:                  fmt.Sprintf("rslvr-frgassoc-%d", m.nextID),
+		FirewallRuleGroupID: params.FirewallRuleGroupID,
+		VPCID:               params.VPCID,
+		Name:                params.Name,
+		Priority:            params.Priority,
+		Status:              "CREATING",
+	}
+	m.Associations[association.ID] = association
+	return &AssociateFirewallRuleGroupOutput{FirewallRuleGroupAssociation: &association}, nil
+}
+
+func (m *MockDNSFirewallClient) GetFirewallRuleGroupAssociation(ctx context.Context, params *GetFirewallRuleGroupAssociationInput) (*GetFirewallRuleGroupAssociationOutput, error) {
+	association, ok := m.Associations[params.FirewallRuleGroupAssociationID]
+	if !ok {
+		return nil, &smithy.GenericAPIError{Code: "ResourceNotFoundException"}
+	}
+	return &GetFirewallRuleGroupAssociationOutput{FirewallRuleGroupAssociation: &association}, nil
+}
+
+func (m *MockDNSFirewallClient) ListFirewallRuleGroupAssociations(ctx context.Context, params *ListFirewallRuleGroupAssociationsInput) (*ListFirewallRuleGroupAssociationsOutput, error) {
+	output := &ListFirewallRuleGroupAssociationsOutput{}
+	for _, association := range m.Associations {
+		if (params.FirewallRuleGroupID == "" || association.FirewallRuleGroupID == params.FirewallRuleGroupID) &&
+			(params.VPCID == "" || association.VPCID == params.VPCID) {
+			output.FirewallRuleGroupAssociations = append(output.FirewallRuleGroupAssociations, association)
+		}
+	}
+	return output, nil
+}
+
+func (m *MockDNSFirewallClient) DisassociateFirewallRuleGroup(ctx context.Context, params *DisassociateFirewallRuleGroupInput) (*DisassociateFirewallRuleGroupOutput, error) {
+	if m.DisassociateErr != nil {
+		return nil, m.DisassociateErr
+	}
+	association, ok := m.Associations[params.FirewallRuleGroupAssociationID]
+	if !ok {
+		return nil, &smithy.GenericAPIError{Code: "ResourceNotFoundException"}
+	}
+	delete(m.Associations, association.ID)
+	m.Disassociated = append(m.Disassociated, association.ID)
+	return &DisassociateFirewallRuleGroupOutput{FirewallRuleGroupAssociation: &association}, nil
+}
+
+// MockDNSFirewallClientFactory records the region and role it was asked for and returns a fixed mock client.
+type MockDNSFirewallClientFactory struct {
+	MockDNSFirewall  DNSFirewallClientAPI
+	RequestedRegion  string
+	RequestedRoleARN string
+}
+
+func (f *MockDNSFirewallClientFactory) GetClient(ctx context.Context, region, roleARN string) (DNSFirewallClientAPI, error) {
+	f.RequestedRegion = region
+	f.RequestedRoleARN = roleARN
+	return f.MockDNSFirewall, nil
+}
+
+var _ = Describe("DNS Firewall association", func() {
+	const ruleGroupID = "rslvr-frg-0123456789abcdef"
+	var (
+		pd       *parkingv1alpha1.ParkedDomain
+		firewall *MockDNSFirewallClient
+		factory  *MockDNSFirewallClientFactory
+	)
+
+	// newReconciler returns a reconciler whose API server holds objs.
+	newReconciler := func(objs ...client.Object) *ParkedDomainReconciler {
+		return &ParkedDomainReconciler{
+			Client:                   fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(objs...).Build(),
+			DNSFirewallClientFactory: factory,
+			AllowedRoles:             []string{"arn:aws:iam::111111111111:role/parked-dns"},
+		}
+	}
+
+	BeforeEach(func() {
+		pd = &parkingv1alpha1.ParkedDomain{
+			ObjectMeta: metav1.ObjectMeta{Name: "filtered", Namespace: "default"},
+			Spec: parkingv1alpha1.ParkedDomainSpec{
+				DomainName:             "internal.example.com",
+				Region:                 "eu-west-1",
+				PrivateZone:            &parkingv1alpha1.PrivateZone{VPCID: "vpc-primary", VPCRegion: "eu-central-1"},
+				DNSRoleARN:             "arn:aws:iam::111111111111:role/parked-dns",
+				DNSFirewallRuleGroupID: ruleGroupID,
+			},
+			Status: parkingv1alpha1.ParkedDomainStatus{ZoneID: "ZPRIVATE"},
+		}
+		firewall = &MockDNSFirewallClient{}
+		factory = &MockDNSFirewallClientFactory{MockDNSFirewall: firewall}
+	})
+
+	It("should associate the rule group with the private zone's VPC once", func() {
+		r := newReconciler(pd)
+		Expect(r.reconcileDNSFirewall(context.Background(), pd)).To(Succeed())
+
+		Expect(factory.RequestedRegion).To(Equal("eu-central-1"))
+		Expect(factory.RequestedRoleARN).To(Equal("arn:aws:iam::111111111111:role/parked-dns"))
+		Expect(pd.Status.DNSFirewallAssociationID).To(Equal("rslvr-frgassoc-1"))
+		Expect(firewall.Associations["rslvr-frgassoc-1"]).To(And(
+			HaveField("FirewallRuleGroupID", ruleGroupID),
+			HaveField("VPCID", "vpc-primary"),
+			HaveField("Priority", int32(dnsFirewallPriority)),
+		))
+
+		By("keeping the association on the next reconcile")
+		Expect(r.reconcileDNSFirewall(context.Background(), pd)).To(Succeed())
+		Expect(firewall.Associations).To(HaveLen(1))
+		Expect(pd.Status.DNSFirewallAssociationID).To(Equal("rslvr-frgassoc-1"))
+	})
+
+	It("should adopt an association whose status write was lost", func() {
+		firewall.Associations = map[string]FirewallRuleGroupAssociation{
+			"rslvr-frgassoc-lost": {ID: "rslvr-frgassoc-lost", FirewallRuleGroupID: ruleGroupID, VPCID: "vpc-primary"},
+		}
+		r := newReconciler(pd)
+
+		Expect(r.reconcileDNSFirewall(context.Background(), pd)).To(Succeed())
+		Expect(pd.Status.DNSFirewallAssociationID).To(Equal("rslvr-frgassoc-lost"))
+		Expect(firewall.Associations).To(HaveLen(1))
+	})
+
+	It("should say when another rule group holds the priority", func() {
+		firewall.AssociateFunc = func(ctx context.Context, params *AssociateFirewallRuleGroupInput) (*AssociateFirewallRuleGroupOutput, error) {
+			return nil, &smithy.GenericAPIError{Code: "ConflictException"}
+		}
+		r := newReconciler(pd)
+
+		err := r.reconcileDNSFirewall(context.Background(), pd)
+		Expect(err).To(MatchError(ContainSubstring("already has a DNS Firewall rule group at priority 9000")))
+		Expect(pd.Status.DNSFirewallAssociationID).To(BeEmpty())
+	})
+
+	It("should replace the association when the rule group changes", func() {
+		r := newReconciler(pd)
+		Expect(r.reconcileDNSFirewall(context.Background(), pd)).To(Succeed())
+
+		pd.Spec.DNSFirewallRuleGroupID = "rslvr-frg-fedcba9876543210"
+		Expect(r.reconcileDNSFirewall(context.Background(), pd)).To(Succeed())
+		Expect(firewall.Disassociated).To(Equal([]string{"rslvr-frgassoc-1"}))
+		Expect(pd.Status.DNSFirewallAssociationID).To(Equal("rslvr-frgassoc-2"))
+		Expect(firewall.Associations["rslvr-frgassoc-2"].FirewallRuleGroupID).To(Equal("rslvr-frg-fedcba9876543210"))
+	})
+
+	It("should associate the rule group again when the association was removed outside the operator", func() {
+		pd.Status.DNSFirewallAssociationID = "rslvr-frgassoc-gone"
+		r := newReconciler(pd)
+
+		Expect(r.reconcileDNSFirewall(context.Background(), pd)).To(Succeed())
+		Expect(pd.Status.DNSFirewallAssociationID).To(Equal("rslvr-frgassoc-1"))
+		Expect(firewall.Disassociated).To(BeEmpty())
+	})
+
+	It("should remove the association when the rule group is cleared", func() {
+		r := newReconciler(pd)
+		Expect(r.reconcileDNSFirewall(context.Background(), pd)).To(Succeed())
+
+		pd.Spec.DNSFirewallRuleGroupID = ""
+		Expect(r.reconcileDNSFirewall(context.Background(), pd)).To(Succeed())
+		Expect(firewall.Disassociated).To(Equal([]string{"rslvr-frgassoc-1"}))
+		Expect(firewall.Associations).To(BeEmpty())
+		Expect(pd.Status.DNSFirewallAssociationID).To(BeEmpty())
+	})
+
+	It("should not need a client when no rule group was ever associated", func() {
+		pd.Spec.DNSFirewallRuleGroupID = ""
+		r := &ParkedDomainReconciler{}
+		Expect(r.reconcileDNSFirewall(context.Background(), pd)).To(Succeed())
+	})
+
+	It("should keep an association another ParkedDomain still uses", func() {
+		pd.Status.DNSFirewallAssociationID = "rslvr-frgassoc-shared"
+		firewall.Associations = map[string]FirewallRuleGroupAssociation{
+			"rslvr-frgassoc-shared": {ID: "rslvr-frgassoc-shared", FirewallRuleGroupID: ruleGroupID, VPCID: "vpc-primary"},
+		}
+		other := pd.DeepCopy()
+		other.Name = "also-filtered"
+		r := newReconciler(pd, other)
+
+		Expect(r.cleanupDNSFirewall(context.Background(), pd)).To(Succeed())
+		Expect(firewall.Disassociated).To(BeEmpty())
+		Expect(pd.Status.DNSFirewallAssociationID).To(BeEmpty())
+	})
+
+	It("should remove the association before the ParkedDomain is deleted", func() {
+		ctx := context.Background()
+		pd.Finalizers = []string{finalizerName}
+		pd.Status.DNSFirewallAssociationID = "rslvr-frgassoc-7"
+		firewall.Associations = map[string]FirewallRuleGroupAssociation{
+			"rslvr-frgassoc-7": {ID: "rslvr-frgassoc-7", FirewallRuleGroupID: ruleGroupID, VPCID: "vpc-primary"},
+		}
+		pd.Spec.StorageEnabled = aws.Bool(false)
+		pd.Spec.DNSRoleARN = ""
+		r := newTestReconciler(&MockR53Client{}, &MockS3Client{}, pd)
+		r.DNSFirewallClientFactory = factory
+		Expect(r.Delete(ctx, pd)).To(Succeed())
+
+		_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: client.ObjectKeyFromObject(pd)})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(firewall.Disassociated).To(Equal([]string{"rslvr-frgassoc-7"}))
+	})
+
+	It("should retry the deletion while the association cannot be removed", func() {
+		ctx := context.Background()
+		pd.Finalizers = []string{finalizerName}
+		pd.Status.DNSFirewallAssociationID = "rslvr-frgassoc-7"
+		pd.Spec.StorageEnabled = aws.Bool(false)
+		pd.Spec.DNSRoleARN = ""
+		firewall.DisassociateErr = &smithy.GenericAPIError{Code: "AccessDeniedException"}
+		r := newTestReconciler(&MockR53Client{}, &MockS3Client{}, pd)
+		r.DNSFirewallClientFactory = factory
+		Expect(r.Delete(ctx, pd)).To(Succeed())
+
+		_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: client.ObjectKeyFromObject(pd)})
+		Expect(err).To(HaveOccurred())
+		Expect(r.Get(ctx, client.ObjectKeyFromObject(pd), pd)).To(Succeed())
+		Expect(pd.Finalizers).To(ContainElement(finalizerName))
+	})
+})
+
+var _ = Describe("DNS Firewall client", func() {
+	// newClient returns a client sending its requests to handler.
+	newClient := func(handler http.HandlerFunc) *dnsFirewallClient {
+		server := httptest.NewServer(handler)
+		DeferCleanup(server.Close)
+		return &dnsFirewallClient{
+			cfg: aws.Config{
+				Region:      "eu-west-1",
+				Credentials: credentials.NewStaticCredentialsProvider("AKID", "SECRET", ""),
+				HTTPClient:  server.Client(),
+			},
+			endpoint: server.URL,
+			signer:   v4.NewSigner(),
+			retryer: retry.NewStandard(func(o *retry.StandardOptions) {
+				o.Backoff = retry.BackoffDelayerFunc(func(int, error) (time.Duration, error) { return 0, nil })
+			}),
+		}
+	}
+
+	It("should send a signed JSON request and decode the response", func() {
+		var target, authorization string
+		var input AssociateFirewallRuleGroupInput
+		c := newClient(func(w http.ResponseWriter, req *http.Request) {
+			target = req.Header.Get("X-Amz-Target")
+			authorization = req.Header.Get("Authorization")
+			Expect(json.NewDecoder(req.Body).Decode(&input)).To(Succeed())
+			_, _ = w.Write([]byte(`{"FirewallRuleGroupAssociation":{"Id":"rslvr-frgassoc-1","FirewallRuleGroupId":"rslvr-frg-1","VpcId":"vpc-1","Priority":9000}}`))
+		})
+
+		output, err := c.AssociateFirewallRuleGroup(context.Background(), &AssociateFirewallRuleGroupInput{
+			CreatorRequestID: "req", FirewallRuleGroupID: "rslvr-frg-1", VPCID: "vpc-1", Priority: 9000, Name: "parked",
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(output.FirewallRuleGroupAssociation).To(Equal(&FirewallRuleGroupAssociation{
+			ID: "rslvr-frgassoc-1", FirewallRuleGroupID: "rslvr-frg-1", VPCID: "vpc-1", Priority: 9000,
+		}))
+		Expect(target).To(Equal("Route53Resolver.AssociateFirewallRuleGroup"))
+		Expect(authorization).To(HavePrefix("AWS4-HMAC-SHA256 Credential=AKID/"))
+		Expect(authorization).To(ContainSubstring("/eu-west-1/route53resolver/aws4_request"))
+		Expect(input).To(HaveField("VPCID", "vpc-1"))
+	})
+
+	It("should retry throttled and failed requests like the SDK's clients", func() {
+		var attempts int
+		c := newClient(func(w http.ResponseWriter, req *http.Request) {
+			attempts++
+			switch attempts {
+			case 1:
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write([]byte(`{"__type":"ThrottlingException","message":"Rate exceeded"}`))
+			case 2:
+				w.WriteHeader(http.StatusServiceUnavailable)
+			default:
+				_, _ = w.Write([]byte(`{"FirewallRuleGroupAssociation":{"Id":"rslvr-frgassoc-1"}}`))
+			}
+		})
+
+		output, err := c.GetFirewallRuleGroupAssociation(context.Background(), &GetFirewallRuleGroupAssociationInput{FirewallRuleGroupAssociationID: "rslvr-frgassoc-1"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(output.FirewallRuleGroupAssociation.ID).To(Equal("rslvr-frgassoc-1"))
+		Expect(attempts).To(Equal(3))
+
+		By("giving up after the retryer's attempts")
+		attempts = 0
+		c = newClient(func(w http.ResponseWriter, req *http.Request) {
+			attempts++
+			w.WriteHeader(http.StatusServiceUnavailable)
+		})
+		_, err = c.GetFirewallRuleGroupAssociation(context.Background(), &GetFirewallRuleGroupAssociationInput{FirewallRuleGroupAssociationID: "rslvr-frgassoc-1"})
+		Expect(err).To(HaveOccurred())
+		Expect(attempts).To(Equal(retry.DefaultMaxAttempts))
+	})
+
+	It("should return error responses as API errors", func() {
+		c := newClient(func(w http.ResponseWriter, req *http.Request) {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"__type":"com.amazonaws.route53resolver#ResourceNotFoundException","Message":"no such association"}`))
+		})
+
+		_, err := c.GetFirewallRuleGroupAssociation(context.Background(), &GetFirewallRuleGroupAssociationInput{FirewallRuleGroupAssociationID: "rslvr-frgassoc-1"})
+		Expect(awserr.IsNotFound(err)).To(BeTrue())
+		Expect(err).To(MatchError(ContainSubstring("no such association")))
+		Expect(strings.Contains(err.Error(), "com.amazonaws")).To(BeFalse())
+	})
+})
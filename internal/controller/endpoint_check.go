@@ -0,0 +1,161 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	parkingv1alpha1 "github.com/gminiba/parked-domain-operator/api/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const (
+	// endpointCheckTimeout bounds how long an unhealthy endpoint is rechecked before giving up.
+	endpointCheckTimeout = 10 * time.Minute
+	// endpointCheckMinDelay and endpointCheckMaxDelay bound the backoff between endpoint checks.
+	endpointCheckMinDelay = 5 * time.Second
+	endpointCheckMaxDelay = time.Minute
+	// endpointCheckTimedOut is the EndpointHealthy reason once rechecks stopped.
+	endpointCheckTimedOut = "VerifyTimeout"
+)
+
+// endpointHTTPClient is used to check that the website endpoint serves the parked page.
+var endpointHTTPClient = &http.Client{Timeout: 10 * time.Second}
+
+// verifyEndpoint checks that the website endpoint serves the parked page, records the result
+// in the EndpointHealthy condition and returns how long to wait before checking again. It
+// returns 0 once the endpoint is healthy or the check timed out.
+func (r *ParkedDomainReconciler) verifyEndpoint(ctx context.Context, pd *parkingv1alpha1.ParkedDomain) time.Duration {
+	if cond := meta.FindStatusCondition(pd.Status.Conditions, parkingv1alpha1.ConditionEndpointHealthy); cond != nil && cond.ObservedGeneration != pd.Generation {
+		// Start over for a new generation, so the timeout is measured from now.
+		meta.RemoveStatusCondition(&pd.Status.Conditions, parkingv1alpha1.ConditionEndpointHealthy)
+	}
+
+	pageURL := "http://" + pd.Status.Endpoint + "/"
+	if r.storageEndpointFor(pd) != "" {
+		pageURL = pd.Status.WebsiteURL
+	}
+	var err error
+	if pd.Spec.ForceHTTPS {
+		pageURL, err = checkHTTPSRedirect(ctx, pageURL)
+	}
+	if err == nil {
+		err = checkEndpoint(ctx, pageURL, recordNameFor(pd))
+	}
+	if err == nil {
+		meta.SetStatusCondition(&pd.Status.Conditions, metav1.Condition{
+			Type:               parkingv1alpha1.ConditionEndpointHealthy,
+			Status:             metav1.ConditionTrue,
+			ObservedGeneration: pd.Generation,
+			Reason:             "PageServed",
+			Message:            "The website endpoint serves the parked page",
+		})
+		return 0
+	}
+
+	log.FromContext(ctx).Info("Website endpoint does not serve the parked page yet", "reason", err.Error())
+	meta.SetStatusCondition(&pd.Status.Conditions, metav1.Condition{
+		Type:               parkingv1alpha1.ConditionEndpointHealthy,
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: pd.Generation,
+		Reason:             "PageNotServed",
+		Message:            err.Error(),
+	})
+	cond := meta.FindStatusCondition(pd.Status.Conditions, parkingv1alpha1.ConditionEndpointHealthy)
+	elapsed := time.Since(cond.LastTransitionTime.Time)
+	if elapsed >= endpointCheckTimeout {
+		cond.Reason = endpointCheckTimedOut
+		cond.Message = fmt.Sprintf("Gave up after %s: %v", endpointCheckTimeout, err)
+		return 0
+	}
+	// Wait about as long as the endpoint has been unhealthy, doubling the delay each time.
+	return min(max(elapsed, endpointCheckMinDelay), endpointCheckMaxDelay)
+}
+
+// endpointCheckPending reports whether the endpoint still has to be checked for the current generation.
+func endpointCheckPending(pd *parkingv1alpha1.ParkedDomain) bool {
+	if !pd.Spec.VerifyHTTP || !storageEnabled(pd) {
+		return false
+	}
+	cond := meta.FindStatusCondition(pd.Status.Conditions, parkingv1alpha1.ConditionEndpointHealthy)
+	if cond == nil || cond.ObservedGeneration != pd.Generation {
+		return true
+	}
+	return cond.Status != metav1.ConditionTrue && cond.Reason != endpointCheckTimedOut
+}
+
+// checkHTTPSRedirect GETs the plain-HTTP url and expects a redirect to HTTPS, as CloudFront
+// answers with the viewer protocol policy redirect-to-https. It returns the redirect target.
+func checkHTTPSRedirect(ctx context.Context, url string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return "", err
+	}
+	noRedirects := *endpointHTTPClient
+	noRedirects.CheckRedirect = func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse }
+	resp, err := noRedirects.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	switch resp.StatusCode {
+	case http.StatusMovedPermanently, http.StatusFound, http.StatusTemporaryRedirect, http.StatusPermanentRedirect:
+	default:
+		return "", fmt.Errorf("plain HTTP is not redirected to HTTPS, got status %s", resp.Status)
+	}
+	location, err := resp.Location()
+	if err != nil {
+		return "", err
+	}
+	if location.Scheme != "https" {
+		return "", fmt.Errorf("plain HTTP is redirected to %s instead of HTTPS", location)
+	}
+	return location.String(), nil
+}
+
+// checkEndpoint GETs url and expects a 200 response whose body mentions the domain name.
+func checkEndpoint(ctx context.Context, url, domainName string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := endpointHTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxTemplateSize))
+	if err != nil {
+		return err
+	}
+	if !strings.Contains(string(body), domainName) {
+		return fmt.Errorf("page does not mention %s", domainName)
+	}
+	return nil
+}
@@ -0,0 +1,148 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	parkingv1alpha1 "github.com/gminiba/parked-domain-operator/api/v1alpha1"
+)
+
+var _ = Describe("Endpoint verification", func() {
+	var (
+		server *httptest.Server
+		page   string
+		pd     *parkingv1alpha1.ParkedDomain
+		r      *ParkedDomainReconciler
+	)
+
+	BeforeEach(func() {
+		page = "<h1>verify.example.com</h1>"
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			if page == "" {
+				http.NotFound(w, req)
+				return
+			}
+			_, _ = io.WriteString(w, page)
+		}))
+		pd = &parkingv1alpha1.ParkedDomain{
+			ObjectMeta: metav1.ObjectMeta{Name: "verify", Namespace: "default", Generation: 1},
+			Spec:       parkingv1alpha1.ParkedDomainSpec{DomainName: "verify.example.com", VerifyHTTP: true},
+			Status:     parkingv1alpha1.ParkedDomainStatus{Endpoint: strings.TrimPrefix(server.URL, "http://")},
+		}
+		r = &ParkedDomainReconciler{}
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("should mark the endpoint healthy when it serves the page", func() {
+		Expect(r.verifyEndpoint(context.Background(), pd)).To(BeZero())
+		Expect(meta.IsStatusConditionTrue(pd.Status.Conditions, parkingv1alpha1.ConditionEndpointHealthy)).To(BeTrue())
+		Expect(endpointCheckPending(pd)).To(BeFalse())
+	})
+
+	It("should recheck with backoff while the page is not served", func() {
+		page = ""
+
+		Expect(r.verifyEndpoint(context.Background(), pd)).To(Equal(endpointCheckMinDelay))
+		cond := meta.FindStatusCondition(pd.Status.Conditions, parkingv1alpha1.ConditionEndpointHealthy)
+		Expect(cond.Status).To(Equal(metav1.ConditionFalse))
+		Expect(cond.Message).To(ContainSubstring("404"))
+		Expect(endpointCheckPending(pd)).To(BeTrue())
+
+		By("backing off further the longer the endpoint stays unhealthy")
+		cond.LastTransitionTime = metav1.NewTime(time.Now().Add(-30 * time.Second))
+		Expect(r.verifyEndpoint(context.Background(), pd)).To(BeNumerically("~", 30*time.Second, time.Second))
+	})
+
+	It("should reject a page that does not mention the domain", func() {
+		page = "<h1>Welcome to nginx!</h1>"
+
+		Expect(r.verifyEndpoint(context.Background(), pd)).NotTo(BeZero())
+		Expect(meta.IsStatusConditionFalse(pd.Status.Conditions, parkingv1alpha1.ConditionEndpointHealthy)).To(BeTrue())
+	})
+
+	It("should stop rechecking after the timeout", func() {
+		page = ""
+		meta.SetStatusCondition(&pd.Status.Conditions, metav1.Condition{
+			Type:               parkingv1alpha1.ConditionEndpointHealthy,
+			Status:             metav1.ConditionFalse,
+			ObservedGeneration: pd.Generation,
+			Reason:             "PageNotServed",
+			LastTransitionTime: metav1.NewTime(time.Now().Add(-endpointCheckTimeout)),
+		})
+
+		Expect(r.verifyEndpoint(context.Background(), pd)).To(BeZero())
+		cond := meta.FindStatusCondition(pd.Status.Conditions, parkingv1alpha1.ConditionEndpointHealthy)
+		Expect(cond.Reason).To(Equal(endpointCheckTimedOut))
+		Expect(endpointCheckPending(pd)).To(BeFalse())
+
+		By("checking again for a new generation")
+		pd.Generation = 2
+		Expect(endpointCheckPending(pd)).To(BeTrue())
+	})
+
+	Context("When HTTPS is forced", func() {
+		var (
+			tlsServer  *httptest.Server
+			httpClient *http.Client
+		)
+
+		BeforeEach(func() {
+			// The plain-HTTP server plays CloudFront with the viewer protocol policy
+			// redirect-to-https, sending every request to the TLS server.
+			tlsServer = httptest.NewTLSServer(server.Config.Handler)
+			server.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				http.Redirect(w, req, tlsServer.URL+req.URL.Path, http.StatusMovedPermanently)
+			})
+			httpClient = endpointHTTPClient
+			endpointHTTPClient = tlsServer.Client()
+			pd.Spec.ForceHTTPS = true
+		})
+
+		AfterEach(func() {
+			endpointHTTPClient = httpClient
+			tlsServer.Close()
+		})
+
+		It("should mark the endpoint healthy when plain HTTP redirects to the page over HTTPS", func() {
+			Expect(r.verifyEndpoint(context.Background(), pd)).To(BeZero())
+			Expect(meta.IsStatusConditionTrue(pd.Status.Conditions, parkingv1alpha1.ConditionEndpointHealthy)).To(BeTrue())
+		})
+
+		It("should reject a page served over plain HTTP", func() {
+			server.Config.Handler = tlsServer.Config.Handler
+
+			Expect(r.verifyEndpoint(context.Background(), pd)).NotTo(BeZero())
+			cond := meta.FindStatusCondition(pd.Status.Conditions, parkingv1alpha1.ConditionEndpointHealthy)
+			Expect(cond.Status).To(Equal(metav1.ConditionFalse))
+			Expect(cond.Message).To(ContainSubstring("not redirected to HTTPS"))
+		})
+	})
+})
@@ -0,0 +1,44 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// jitter adds up to maxFactor*d to d at random, so ParkedDomains queued together do not all
+// come back to AWS at the same moment. A non-positive d or maxFactor is returned unchanged.
+func jitter(d time.Duration, maxFactor float64) time.Duration {
+	if d <= 0 || maxFactor <= 0 {
+		return d
+	}
+	return wait.Jitter(d, maxFactor)
+}
+
+// jitteredRateLimiter jitters the retry delays of the wrapped rate limiter.
+type jitteredRateLimiter struct {
+	workqueue.TypedRateLimiter[reconcile.Request]
+	maxFactor float64
+}
+
+func (l *jitteredRateLimiter) When(item reconcile.Request) time.Duration {
+	return jitter(l.TypedRateLimiter.When(item), l.maxFactor)
+}
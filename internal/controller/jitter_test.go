@@ -0,0 +1,55 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+var _ = Describe("Requeue jitter", func() {
+	It("should stretch delays by at most the jitter factor", func() {
+		for range 100 {
+			Expect(jitter(time.Minute, 0.2)).To(And(
+				BeNumerically(">=", time.Minute),
+				BeNumerically("<=", 72*time.Second),
+			))
+		}
+	})
+
+	It("should leave delays alone without a jitter factor or delay", func() {
+		Expect(jitter(time.Minute, 0)).To(Equal(time.Minute))
+		Expect(jitter(0, 0.2)).To(BeZero())
+	})
+
+	It("should jitter the retry delays of the wrapped rate limiter", func() {
+		limiter := &jitteredRateLimiter{
+			TypedRateLimiter: workqueue.NewTypedItemExponentialFailureRateLimiter[reconcile.Request](time.Second, time.Minute),
+			maxFactor:        0.5,
+		}
+		item := reconcile.Request{NamespacedName: types.NamespacedName{Name: "jitter", Namespace: "default"}}
+
+		Expect(limiter.When(item)).To(And(BeNumerically(">=", time.Second), BeNumerically("<=", 1500*time.Millisecond)))
+		Expect(limiter.When(item)).To(And(BeNumerically(">=", 2*time.Second), BeNumerically("<=", 3*time.Second)))
+		Expect(limiter.NumRequeues(item)).To(Equal(2))
+	})
+})
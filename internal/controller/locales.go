@@ -0,0 +1,81 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
+	parkingv1alpha1 "github.com/gminiba/parked-domain-operator/api/v1alpha1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// localizedName returns name with locale inserted before its extension, e.g. default.de.html
+// for default.html and de.
+func localizedName(name, locale string) string {
+	ext := path.Ext(name)
+	return strings.TrimSuffix(name, ext) + "." + locale + ext
+}
+
+// localePageKey returns the key of the object a locale's page is published at.
+func localePageKey(locale string) string {
+	return localizedName(indexDocument, locale)
+}
+
+// renderLocalePages renders the templates of Spec.Locales, keyed by locale, recording a
+// failure on the ContentReady condition like the page itself.
+func renderLocalePages(pd *parkingv1alpha1.ParkedDomain, localeTemplates map[string]string) (map[string]string, error) {
+	if len(localeTemplates) == 0 {
+		return nil, nil
+	}
+	pages := make(map[string]string, len(localeTemplates))
+	for locale, content := range localeTemplates {
+		if strings.TrimSpace(content) == "" {
+			return nil, contentFailed(pd, "TemplateEmpty", fmt.Errorf("template for locale %s is empty, refusing to publish a blank page", locale))
+		}
+		page, err := renderTemplate(content, pd)
+		if err != nil {
+			return nil, contentFailed(pd, "TemplateInvalid", fmt.Errorf("locale %s: %w", locale, err))
+		}
+		pages[locale] = page
+	}
+	return pages, nil
+}
+
+// deleteRemovedLocalePages deletes the pages of the locales in Status.Locales that were removed
+// from the spec, and records the published locales.
+func deleteRemovedLocalePages(ctx context.Context, s3Client S3ClientAPI, pd *parkingv1alpha1.ParkedDomain, bucketName string) error {
+	var removed []s3types.Object
+	for _, locale := range pd.Status.Locales {
+		if !slices.Contains(pd.Spec.Locales, locale) {
+			removed = append(removed, s3types.Object{Key: aws.String(keyPrefixFor(pd) + localePageKey(locale))})
+		}
+	}
+	if len(removed) > 0 {
+		if err := deleteObjects(ctx, s3Client, bucketName, removed); err != nil {
+			return err
+		}
+		log.FromContext(ctx).Info("Deleted pages of removed locales", "pages", len(removed))
+	}
+	pd.Status.Locales = slices.Clone(pd.Spec.Locales)
+	return nil
+}
@@ -0,0 +1,132 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"io"
+	"os"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	parkingv1alpha1 "github.com/gminiba/parked-domain-operator/api/v1alpha1"
+)
+
+var _ = Describe("Localized pages", func() {
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: "localized", Namespace: "default"}}
+	var templateCM *corev1.ConfigMap
+
+	BeforeEach(func() {
+		os.Setenv("TEMPLATE_CONFIGMAP_NAME", "parked-domain-templates")
+		DeferCleanup(os.Unsetenv, "TEMPLATE_CONFIGMAP_NAME")
+		templateCM = &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "parked-domain-templates", Namespace: "default"},
+			Data: map[string]string{
+				"default.html":    "<h1>{{DOMAIN_NAME}} is parked</h1>",
+				"default.en.html": "<h1>{{DOMAIN_NAME}} is parked</h1>",
+				"default.de.html": "<h1>{{DOMAIN_NAME}} ist geparkt</h1>",
+			},
+		}
+	})
+
+	// recordUploads returns a mock recording the content of every uploaded object by key.
+	recordUploads := func(uploads map[string]string) *MockS3Client {
+		return &MockS3Client{
+			PutObjectFunc: func(ctx context.Context, params *s3.PutObjectInput, optFns ...func(*s3.Options)) (*s3.PutObjectOutput, error) {
+				body, err := io.ReadAll(params.Body)
+				Expect(err).NotTo(HaveOccurred())
+				uploads[aws.ToString(params.Key)] = string(body)
+				return &s3.PutObjectOutput{}, nil
+			},
+		}
+	}
+
+	It("should name localized templates and pages after the locale", func() {
+		Expect(localizedName("for-sale.html", "pt-BR")).To(Equal("for-sale.pt-BR.html"))
+		Expect(localePageKey("de")).To(Equal("index.de.html"))
+	})
+
+	It("should upload a page per locale next to the default index.html", func() {
+		ctx := context.Background()
+		pd := &parkingv1alpha1.ParkedDomain{
+			ObjectMeta: metav1.ObjectMeta{Name: "localized", Namespace: "default"},
+			Spec:       parkingv1alpha1.ParkedDomainSpec{DomainName: "localized.example.com", Locales: []string{"en", "de"}},
+		}
+		uploads := map[string]string{}
+		r := newTestReconciler(&MockR53Client{}, recordUploads(uploads), pd, templateCM)
+
+		_, err := r.Reconcile(ctx, req)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(uploads).To(Equal(map[string]string{
+			"index.html":    "<h1>localized.example.com is parked</h1>",
+			"index.en.html": "<h1>localized.example.com is parked</h1>",
+			"index.de.html": "<h1>localized.example.com ist geparkt</h1>",
+		}))
+
+		reconciled := &parkingv1alpha1.ParkedDomain{}
+		Expect(r.Get(ctx, req.NamespacedName, reconciled)).To(Succeed())
+		Expect(reconciled.Status.Locales).To(Equal([]string{"en", "de"}))
+	})
+
+	It("should fail the content step when a locale has no template", func() {
+		ctx := context.Background()
+		pd := &parkingv1alpha1.ParkedDomain{
+			ObjectMeta: metav1.ObjectMeta{Name: "localized", Namespace: "default"},
+			Spec:       parkingv1alpha1.ParkedDomainSpec{DomainName: "localized.example.com", Locales: []string{"fr"}},
+		}
+		uploads := map[string]string{}
+		r := newTestReconciler(&MockR53Client{}, recordUploads(uploads), pd, templateCM)
+
+		_, err := r.Reconcile(ctx, req)
+		Expect(err).To(MatchError(ContainSubstring("template key 'default.fr.html' not found")))
+		Expect(uploads).To(BeEmpty())
+
+		failed := &parkingv1alpha1.ParkedDomain{}
+		Expect(r.Get(ctx, req.NamespacedName, failed)).To(Succeed())
+		content := meta.FindStatusCondition(failed.Status.Conditions, parkingv1alpha1.ConditionContentReady)
+		Expect(content).NotTo(BeNil())
+		Expect(content.Reason).To(Equal("TemplateUnavailable"))
+	})
+
+	It("should delete the pages of locales removed from the spec", func() {
+		pd := &parkingv1alpha1.ParkedDomain{
+			Spec:   parkingv1alpha1.ParkedDomainSpec{DomainName: "localized.example.com", Locales: []string{"en"}},
+			Status: parkingv1alpha1.ParkedDomainStatus{Locales: []string{"en", "de"}},
+		}
+		var deleted []string
+		s3Client := &MockS3Client{
+			DeleteObjectsFunc: func(ctx context.Context, params *s3.DeleteObjectsInput, optFns ...func(*s3.Options)) (*s3.DeleteObjectsOutput, error) {
+				for _, obj := range params.Delete.Objects {
+					deleted = append(deleted, aws.ToString(obj.Key))
+				}
+				return &s3.DeleteObjectsOutput{}, nil
+			},
+		}
+
+		Expect(deleteRemovedLocalePages(context.Background(), s3Client, pd, "localized.example.com")).To(Succeed())
+		Expect(deleted).To(Equal([]string{"index.de.html"}))
+		Expect(pd.Status.Locales).To(Equal([]string{"en"}))
+	})
+})
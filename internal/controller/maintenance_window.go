@@ -0,0 +1,82 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"time"
+
+	parkingv1alpha1 "github.com/gminiba/parked-domain-operator/api/v1alpha1"
+)
+
+// windowTimeLayout is the layout of the times of day of Spec.MaintenanceWindow.
+const windowTimeLayout = "15:04"
+
+// parseWindowTime returns the offset from midnight of a "HH:MM" time of day.
+func parseWindowTime(value string) (time.Duration, error) {
+	t, err := time.Parse(windowTimeLayout, value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid time of day %q, expected HH:MM", value)
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
+
+// maintenanceWindowOpensIn returns how long until the window next opens, or zero when now is
+// inside it. The window includes its start and excludes its end; a window that starts and
+// ends at the same time never closes.
+func maintenanceWindowOpensIn(window *parkingv1alpha1.MaintenanceWindow, now time.Time) (time.Duration, error) {
+	start, err := parseWindowTime(window.Start)
+	if err != nil {
+		return 0, err
+	}
+	end, err := parseWindowTime(window.End)
+	if err != nil {
+		return 0, err
+	}
+
+	now = now.UTC()
+	midnight := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	sinceMidnight := now.Sub(midnight)
+	var open bool
+	switch {
+	case start == end:
+		open = true
+	case start < end:
+		open = sinceMidnight >= start && sinceMidnight < end
+	default:
+		open = sinceMidnight >= start || sinceMidnight < end
+	}
+	if open {
+		return 0, nil
+	}
+
+	opens := midnight.Add(start)
+	if !opens.After(now) {
+		opens = opens.AddDate(0, 0, 1)
+	}
+	return opens.Sub(now), nil
+}
+
+// deferredToMaintenanceWindow reports how long changes to pd wait for its maintenance window,
+// or zero when they proceed now. Only a ParkedDomain provisioned before waits, so a new one is
+// served right away.
+func deferredToMaintenanceWindow(pd *parkingv1alpha1.ParkedDomain, now time.Time) (time.Duration, error) {
+	if pd.Spec.MaintenanceWindow == nil || pd.Status.ObservedGeneration == 0 {
+		return 0, nil
+	}
+	return maintenanceWindowOpensIn(pd.Spec.MaintenanceWindow, now)
+}
@@ -0,0 +1,126 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	parkingv1alpha1 "github.com/gminiba/parked-domain-operator/api/v1alpha1"
+)
+
+var _ = Describe("Maintenance window", func() {
+	at := func(hour, minute, second int) time.Time {
+		return time.Date(2026, time.March, 14, hour, minute, second, 0, time.UTC)
+	}
+
+	DescribeTable("reports how long until the window opens",
+		func(start, end string, now time.Time, expected time.Duration) {
+			opensIn, err := maintenanceWindowOpensIn(&parkingv1alpha1.MaintenanceWindow{Start: start, End: end}, now)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(opensIn).To(Equal(expected))
+		},
+		Entry("just before the start", "02:00", "04:00", at(1, 59, 59), time.Second),
+		Entry("at the start", "02:00", "04:00", at(2, 0, 0), time.Duration(0)),
+		Entry("just before the end", "02:00", "04:00", at(3, 59, 59), time.Duration(0)),
+		Entry("at the end", "02:00", "04:00", at(4, 0, 0), 22*time.Hour),
+		Entry("before midnight in a window spanning it", "22:00", "02:00", at(23, 30, 0), time.Duration(0)),
+		Entry("after midnight in a window spanning it", "22:00", "02:00", at(1, 59, 0), time.Duration(0)),
+		Entry("at the end of a window spanning midnight", "22:00", "02:00", at(2, 0, 0), 20*time.Hour),
+		Entry("a window that never closes", "03:00", "03:00", at(12, 0, 0), time.Duration(0)),
+	)
+
+	It("should read the time of day in UTC", func() {
+		cet := time.FixedZone("CET", 3600)
+		opensIn, err := maintenanceWindowOpensIn(&parkingv1alpha1.MaintenanceWindow{Start: "02:00", End: "04:00"},
+			time.Date(2026, time.March, 14, 2, 30, 0, 0, cet))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(opensIn).To(Equal(30 * time.Minute))
+	})
+
+	Context("When reconciling", func() {
+		req := ctrl.Request{NamespacedName: types.NamespacedName{Name: "windowed", Namespace: "default"}}
+		var (
+			pd      *parkingv1alpha1.ParkedDomain
+			created bool
+			s3Mock  *MockS3Client
+		)
+
+		BeforeEach(func() {
+			now := time.Now().UTC()
+			pd = &parkingv1alpha1.ParkedDomain{
+				ObjectMeta: metav1.ObjectMeta{Name: "windowed", Namespace: "default", Generation: 2},
+				Spec: parkingv1alpha1.ParkedDomainSpec{
+					DomainName:     "windowed.example.com",
+					DNSEnabled:     aws.Bool(false),
+					InlineTemplate: "<h1>{{DOMAIN_NAME}}</h1>",
+					MaintenanceWindow: &parkingv1alpha1.MaintenanceWindow{
+						Start: now.Add(2 * time.Hour).Format(windowTimeLayout),
+						End:   now.Add(3 * time.Hour).Format(windowTimeLayout),
+					},
+				},
+			}
+			created = false
+			s3Mock = &MockS3Client{
+				CreateBucketFunc: func(ctx context.Context, params *s3.CreateBucketInput, optFns ...func(*s3.Options)) (*s3.CreateBucketOutput, error) {
+					created = true
+					return &s3.CreateBucketOutput{}, nil
+				},
+			}
+		})
+
+		It("should defer changes to a provisioned ParkedDomain until the window opens", func() {
+			ctx := context.Background()
+			pd.Status = parkingv1alpha1.ParkedDomainStatus{ObservedGeneration: 1, Status: "Provisioned", Ready: true}
+			r := newTestReconciler(&MockR53Client{}, s3Mock, pd)
+
+			result, err := r.Reconcile(ctx, req)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(created).To(BeFalse())
+			Expect(result.RequeueAfter).To(And(BeNumerically(">", 119*time.Minute), BeNumerically("<=", 2*time.Hour)))
+
+			deferred := &parkingv1alpha1.ParkedDomain{}
+			Expect(r.Get(ctx, req.NamespacedName, deferred)).To(Succeed())
+			Expect(deferred.Status.Status).To(Equal("Pending: Maintenance Window"))
+			Expect(deferred.Status.Ready).To(BeTrue())
+			Expect(deferred.Status.DeferredUntil).NotTo(BeNil())
+			Expect(deferred.Status.DeferredUntil.Time).To(BeTemporally("~", time.Now().Add(result.RequeueAfter), time.Second))
+		})
+
+		It("should provision a new ParkedDomain right away", func() {
+			ctx := context.Background()
+			r := newTestReconciler(&MockR53Client{}, s3Mock, pd)
+
+			_, err := r.Reconcile(ctx, req)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(created).To(BeTrue())
+
+			provisioned := &parkingv1alpha1.ParkedDomain{}
+			Expect(r.Get(ctx, req.NamespacedName, provisioned)).To(Succeed())
+			Expect(provisioned.Status.Status).To(Equal("Provisioned"))
+			Expect(provisioned.Status.DeferredUntil).To(BeNil())
+		})
+	})
+})
@@ -0,0 +1,205 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	parkingv1alpha1 "github.com/gminiba/parked-domain-operator/api/v1alpha1"
+	"github.com/gminiba/parked-domain-operator/internal/awserr"
+)
+
+// pageManifest is the object at Spec.ManifestKey listing the pages the operator uploaded,
+// keyed by object key, with the hash of each page as uploaded.
+type pageManifest struct {
+	Pages map[string]string `json:"pages"`
+}
+
+// manifestDiff is what it takes to bring a bucket from one manifest to another.
+type manifestDiff struct {
+	// Upload are the keys of pages that are new or changed, sorted.
+	Upload []string
+	// Prune are the keys of pages that are no longer published, sorted.
+	Prune []string
+}
+
+// pageKeysFor returns the keys of the pages pd publishes, without the key prefix.
+func pageKeysFor(pd *parkingv1alpha1.ParkedDomain) []string {
+	keys := []string{indexDocument}
+	for _, locale := range pd.Spec.Locales {
+		keys = append(keys, localePageKey(locale))
+	}
+	return keys
+}
+
+// statePages returns the pages of state keyed by object key.
+func statePages(state bucketState) map[string]string {
+	pages := map[string]string{state.KeyPrefix + indexDocument: state.Content}
+	for locale, content := range state.LocaleContent {
+		pages[state.KeyPrefix+localePageKey(locale)] = content
+	}
+	return pages
+}
+
+// pageHash returns a hex-encoded SHA-256 digest of a page and the settings it is uploaded with,
+// so a page is uploaded again when either changes.
+func pageHash(state bucketState, content string) (string, error) {
+	data, err := json.Marshal(struct {
+		Content      string                      `json:"content"`
+		ContentType  string                      `json:"contentType"`
+		Metadata     map[string]string           `json:"metadata,omitempty"`
+		Compress     bool                        `json:"compress,omitempty"`
+		StorageClass string                      `json:"storageClass,omitempty"`
+		Encryption   *parkingv1alpha1.Encryption `json:"encryption,omitempty"`
+		ACL          s3types.ObjectCannedACL     `json:"acl,omitempty"`
+	}{content, state.ContentType, state.Metadata, state.Compress, state.StorageClass, state.Encryption, state.ObjectACL})
+	if err != nil {
+		return "", fmt.Errorf("failed to hash page: %w", err)
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// desiredManifest returns the manifest of the pages of state.
+func desiredManifest(state bucketState) (pageManifest, error) {
+	pages := statePages(state)
+	manifest := pageManifest{Pages: make(map[string]string, len(pages))}
+	for key, content := range pages {
+		hash, err := pageHash(state, content)
+		if err != nil {
+			return pageManifest{}, err
+		}
+		manifest.Pages[key] = hash
+	}
+	return manifest, nil
+}
+
+// diffManifests returns the pages to upload and to delete to go from current to desired.
+func diffManifests(current, desired pageManifest) manifestDiff {
+	var diff manifestDiff
+	for _, key := range slices.Sorted(maps.Keys(desired.Pages)) {
+		if current.Pages[key] != desired.Pages[key] {
+			diff.Upload = append(diff.Upload, key)
+		}
+	}
+	for _, key := range slices.Sorted(maps.Keys(current.Pages)) {
+		if _, ok := desired.Pages[key]; !ok {
+			diff.Prune = append(diff.Prune, key)
+		}
+	}
+	return diff
+}
+
+// readManifest returns the manifest stored at key, or an empty one when there is none yet.
+func readManifest(ctx context.Context, s3Client S3ClientAPI, bucketName, key string) (pageManifest, error) {
+	output, err := s3Client.GetObject(ctx, &s3.GetObjectInput{Bucket: aws.String(bucketName), Key: aws.String(key)})
+	if awserr.IsNotFound(err) {
+		return pageManifest{}, nil
+	}
+	if err != nil {
+		return pageManifest{}, fmt.Errorf("failed to read manifest %s: %w", key, err)
+	}
+	defer func() { _ = output.Body.Close() }()
+	data, err := io.ReadAll(output.Body)
+	if err != nil {
+		return pageManifest{}, fmt.Errorf("failed to read manifest %s: %w", key, err)
+	}
+	var manifest pageManifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		// A manifest that cannot be read is replaced; no page is pruned on its word.
+		log.FromContext(ctx).Info("Ignoring unreadable manifest", "key", key, "error", err.Error())
+		return pageManifest{}, nil
+	}
+	return manifest, nil
+}
+
+// writeManifest stores manifest at key. The manifest is never uploaded with a public ACL.
+func writeManifest(ctx context.Context, s3Client S3ClientAPI, bucketName, key string, manifest pageManifest, state bucketState) error {
+	data, err := json.Marshal(manifest)
+	if err != nil {
+		return fmt.Errorf("failed to encode manifest: %w", err)
+	}
+	put := &s3.PutObjectInput{
+		Bucket:      aws.String(bucketName),
+		Key:         aws.String(key),
+		Body:        bytes.NewReader(data),
+		ContentType: aws.String("application/json"),
+	}
+	if enc := state.Encryption; enc != nil {
+		put.ServerSideEncryption = s3types.ServerSideEncryption(enc.Algorithm)
+		if enc.KMSKeyID != "" {
+			put.SSEKMSKeyId = aws.String(enc.KMSKeyID)
+		}
+	}
+	if _, err := s3Client.PutObject(ctx, put); err != nil {
+		return fmt.Errorf("failed to write manifest %s: %w", key, err)
+	}
+	return nil
+}
+
+// syncPages uploads the pages of state that changed since the manifest at state.ManifestKey was
+// written, deletes the pages it lists that are no longer published, and then rewrites it. Stale
+// pages are deleted before the manifest drops them, so a failed pass is retried in full.
+func syncPages(ctx context.Context, s3Client S3ClientAPI, bucketName string, state bucketState) error {
+	manifestKey := state.KeyPrefix + state.ManifestKey
+	current, err := readManifest(ctx, s3Client, bucketName, manifestKey)
+	if err != nil {
+		return err
+	}
+	desired, err := desiredManifest(state)
+	if err != nil {
+		return err
+	}
+	diff := diffManifests(current, desired)
+	if state.Reupload {
+		// Pages changed outside the operator still match the manifest, so all are uploaded.
+		diff.Upload = slices.Sorted(maps.Keys(desired.Pages))
+	}
+
+	pages := statePages(state)
+	for _, key := range diff.Upload {
+		if err := putPage(ctx, s3Client, bucketName, key, pages[key], state); err != nil {
+			return err
+		}
+	}
+	if len(diff.Prune) > 0 {
+		stale := make([]s3types.Object, 0, len(diff.Prune))
+		for _, key := range diff.Prune {
+			stale = append(stale, s3types.Object{Key: aws.String(key)})
+		}
+		if err := deleteObjects(ctx, s3Client, bucketName, stale); err != nil {
+			return err
+		}
+	}
+	if len(diff.Upload) > 0 || len(diff.Prune) > 0 {
+		log.FromContext(ctx).Info("Synced pages with the manifest", "uploaded", len(diff.Upload), "pruned", len(diff.Prune))
+	}
+	return writeManifest(ctx, s3Client, bucketName, manifestKey, desired, state)
+}
@@ -0,0 +1,145 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"maps"
+	"slices"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+// memoryBucket returns a mock S3 client keeping uploaded objects in objects, and counting the
+// uploads per key in puts.
+func memoryBucket(objects map[string][]byte, puts map[string]int) *MockS3Client {
+	return &MockS3Client{
+		PutObjectFunc: func(ctx context.Context, params *s3.PutObjectInput, optFns ...func(*s3.Options)) (*s3.PutObjectOutput, error) {
+			body, err := io.ReadAll(params.Body)
+			if err != nil {
+				return nil, err
+			}
+			objects[aws.ToString(params.Key)] = body
+			puts[aws.ToString(params.Key)]++
+			return &s3.PutObjectOutput{}, nil
+		},
+		GetObjectFunc: func(ctx context.Context, params *s3.GetObjectInput, optFns ...func(*s3.Options)) (*s3.GetObjectOutput, error) {
+			body, ok := objects[aws.ToString(params.Key)]
+			if !ok {
+				return nil, &s3types.NoSuchKey{}
+			}
+			return &s3.GetObjectOutput{Body: io.NopCloser(bytes.NewReader(body))}, nil
+		},
+		DeleteObjectsFunc: func(ctx context.Context, params *s3.DeleteObjectsInput, optFns ...func(*s3.Options)) (*s3.DeleteObjectsOutput, error) {
+			for _, obj := range params.Delete.Objects {
+				delete(objects, aws.ToString(obj.Key))
+			}
+			return &s3.DeleteObjectsOutput{}, nil
+		},
+	}
+}
+
+var _ = Describe("Page manifest", func() {
+	const manifestKey = ".pdo-manifest.json"
+
+	It("should diff the pages to upload and prune", func() {
+		current := pageManifest{Pages: map[string]string{"index.html": "a", "index.de.html": "b", "index.fr.html": "c"}}
+		desired := pageManifest{Pages: map[string]string{"index.html": "a", "index.de.html": "B", "index.es.html": "d"}}
+
+		diff := diffManifests(current, desired)
+		Expect(diff.Upload).To(Equal([]string{"index.de.html", "index.es.html"}))
+		Expect(diff.Prune).To(Equal([]string{"index.fr.html"}))
+
+		Expect(diffManifests(desired, desired)).To(Equal(manifestDiff{}))
+		Expect(diffManifests(pageManifest{}, desired).Upload).To(HaveLen(3))
+	})
+
+	It("should hash the upload settings along with the page", func() {
+		plain, err := pageHash(bucketState{ContentType: "text/html"}, "<h1>parked</h1>")
+		Expect(err).NotTo(HaveOccurred())
+		compressed, err := pageHash(bucketState{ContentType: "text/html", Compress: true}, "<h1>parked</h1>")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(compressed).NotTo(Equal(plain))
+	})
+
+	It("should read back the manifest it wrote", func() {
+		ctx := context.Background()
+		objects, puts := map[string][]byte{}, map[string]int{}
+		s3Mock := memoryBucket(objects, puts)
+
+		missing, err := readManifest(ctx, s3Mock, "example.com", manifestKey)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(missing.Pages).To(BeEmpty())
+
+		written := pageManifest{Pages: map[string]string{"index.html": "a"}}
+		Expect(writeManifest(ctx, s3Mock, "example.com", manifestKey, written, bucketState{})).To(Succeed())
+		read, err := readManifest(ctx, s3Mock, "example.com", manifestKey)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(read).To(Equal(written))
+
+		By("ignoring a manifest that is not JSON")
+		objects[manifestKey] = []byte("not json")
+		read, err = readManifest(ctx, s3Mock, "example.com", manifestKey)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(read.Pages).To(BeEmpty())
+	})
+
+	It("should only upload changed pages and prune the ones no longer published", func() {
+		ctx := context.Background()
+		objects, puts := map[string][]byte{}, map[string]int{}
+		s3Mock := memoryBucket(objects, puts)
+		state := bucketState{
+			Content:       "<h1>parked</h1>",
+			LocaleContent: map[string]string{"de": "<h1>geparkt</h1>", "fr": "<h1>garé</h1>"},
+			ContentType:   "text/html",
+			ManifestKey:   manifestKey,
+		}
+
+		Expect(putPages(ctx, s3Mock, "example.com", state)).To(Succeed())
+		Expect(slices.Sorted(maps.Keys(objects))).To(Equal([]string{manifestKey, "index.de.html", "index.fr.html", "index.html"}))
+		var manifest pageManifest
+		Expect(json.Unmarshal(objects[manifestKey], &manifest)).To(Succeed())
+		Expect(manifest.Pages).To(HaveLen(3))
+
+		By("uploading the changed page and deleting the removed locale")
+		state.LocaleContent = map[string]string{"de": "<h1>Geparkt</h1>"}
+		Expect(putPages(ctx, s3Mock, "example.com", state)).To(Succeed())
+		Expect(slices.Sorted(maps.Keys(objects))).To(Equal([]string{manifestKey, "index.de.html", "index.html"}))
+		Expect(puts).To(Equal(map[string]int{manifestKey: 2, "index.html": 1, "index.de.html": 2, "index.fr.html": 1}))
+
+		By("uploading every page again when asked to")
+		state.Reupload = true
+		Expect(putPages(ctx, s3Mock, "example.com", state)).To(Succeed())
+		Expect(puts["index.html"]).To(Equal(2))
+		Expect(puts["index.de.html"]).To(Equal(3))
+	})
+
+	It("should keep the manifest from being served", func() {
+		policy := bucketReadPolicy("example.com", nil, manifestKey)
+		Expect(json.Valid([]byte(policy))).To(BeTrue())
+		Expect(policy).To(ContainSubstring(`"Effect":"Deny"`))
+		Expect(policy).To(ContainSubstring(`"Resource":"arn:aws:s3:::example.com/.pdo-manifest.json"`))
+		Expect(bucketReadPolicy("example.com", nil, "")).NotTo(ContainSubstring("Deny"))
+	})
+})
@@ -0,0 +1,97 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	parkingv1alpha1 "github.com/gminiba/parked-domain-operator/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// errNameServersExportNotOwned is returned when the object the nameservers are exported to
+// exists and belongs to someone else, so it is never overwritten.
+var errNameServersExportNotOwned = errors.New("exists and is not owned by the ParkedDomain")
+
+// nameServersExportObject returns an empty object of the kind and name pd's nameservers are
+// exported to.
+func nameServersExportObject(pd *parkingv1alpha1.ParkedDomain) client.Object {
+	export := pd.Spec.NameServersExport
+	objectMeta := metav1.ObjectMeta{Name: export.Name, Namespace: pd.Namespace}
+	if objectMeta.Name == "" {
+		objectMeta.Name = pd.Name + "-nameservers"
+	}
+	if export.Kind == parkingv1alpha1.NameServersExportSecret {
+		return &corev1.Secret{ObjectMeta: objectMeta}
+	}
+	return &corev1.ConfigMap{ObjectMeta: objectMeta}
+}
+
+// nameServersExportData is the content of the object pd's nameservers are exported to.
+func nameServersExportData(pd *parkingv1alpha1.ParkedDomain) map[string]string {
+	return map[string]string{
+		"domainName":  pd.Spec.DomainName,
+		"zoneID":      pd.Status.ZoneID,
+		"nameServers": strings.Join(pd.Status.NameServers, "\n"),
+	}
+}
+
+// reconcileNameServersExport writes pd's nameservers into the ConfigMap or Secret named by
+// Spec.NameServersExport, owned by pd so it is garbage-collected with it. It runs on every
+// pass that reaches the end of provisioning, which includes every change of the nameservers.
+func (r *ParkedDomainReconciler) reconcileNameServersExport(ctx context.Context, pd *parkingv1alpha1.ParkedDomain) error {
+	if pd.Spec.NameServersExport == nil || len(pd.Status.NameServers) == 0 {
+		return nil
+	}
+	obj := nameServersExportObject(pd)
+	kind := string(parkingv1alpha1.NameServersExportConfigMap)
+	if _, ok := obj.(*corev1.Secret); ok {
+		kind = string(parkingv1alpha1.NameServersExportSecret)
+	}
+	data := nameServersExportData(pd)
+
+	op, err := controllerutil.CreateOrUpdate(ctx, r.Client, obj, func() error {
+		if obj.GetResourceVersion() != "" && !metav1.IsControlledBy(obj, pd) {
+			return errNameServersExportNotOwned
+		}
+		switch obj := obj.(type) {
+		case *corev1.Secret:
+			obj.Data = make(map[string][]byte, len(data))
+			for key, value := range data {
+				obj.Data[key] = []byte(value)
+			}
+		case *corev1.ConfigMap:
+			obj.Data = data
+		}
+		_, err := setOwnerRef(pd, obj, r.Scheme)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to export nameservers to %s '%s': %w", kind, obj.GetName(), err)
+	}
+	if op != controllerutil.OperationResultNone {
+		log.FromContext(ctx).Info("Exported nameservers", "kind", kind, "name", obj.GetName(), "operation", op)
+	}
+	return nil
+}
@@ -0,0 +1,112 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/service/route53"
+	r53types "github.com/aws/aws-sdk-go-v2/service/route53/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	parkingv1alpha1 "github.com/gminiba/parked-domain-operator/api/v1alpha1"
+)
+
+var _ = Describe("Nameserver export", func() {
+	var (
+		pd  *parkingv1alpha1.ParkedDomain
+		req ctrl.Request
+	)
+
+	BeforeEach(func() {
+		pd = &parkingv1alpha1.ParkedDomain{
+			ObjectMeta: metav1.ObjectMeta{Name: "exported", Namespace: "default", Generation: 1},
+			Spec: parkingv1alpha1.ParkedDomainSpec{
+				DomainName:        "exported.example.com",
+				InlineTemplate:    "<h1>{{DOMAIN_NAME}}</h1>",
+				NameServersExport: &parkingv1alpha1.NameServersExport{},
+			},
+		}
+		req = ctrl.Request{NamespacedName: types.NamespacedName{Name: "exported", Namespace: "default"}}
+	})
+
+	It("should write the delegation set into a ConfigMap owned by the ParkedDomain", func() {
+		ctx := context.Background()
+		r53 := &MockR53Client{}
+		r := newTestReconciler(r53, &MockS3Client{}, pd)
+
+		_, err := r.Reconcile(ctx, req)
+		Expect(err).NotTo(HaveOccurred())
+
+		cm := &corev1.ConfigMap{}
+		Expect(r.Get(ctx, types.NamespacedName{Name: "exported-nameservers", Namespace: "default"}, cm)).To(Succeed())
+		Expect(cm.Data).To(Equal(map[string]string{
+			"domainName":  "exported.example.com",
+			"zoneID":      "MOCKZONEID123",
+			"nameServers": "ns-1.awsdns.com\nns-2.awsdns.com",
+		}))
+		Expect(cm.OwnerReferences).To(HaveLen(1))
+		Expect(cm.OwnerReferences[0].Name).To(Equal("exported"))
+		Expect(cm.OwnerReferences[0].Controller).To(HaveValue(BeTrue()))
+
+		By("following a change of the delegation set")
+		r53.GetHostedZoneFunc = func(ctx context.Context, params *route53.GetHostedZoneInput, optFns ...func(*route53.Options)) (*route53.GetHostedZoneOutput, error) {
+			return &route53.GetHostedZoneOutput{
+				HostedZone:    &r53types.HostedZone{Id: params.Id},
+				DelegationSet: &r53types.DelegationSet{NameServers: []string{"ns-3.awsdns.com", "ns-4.awsdns.com"}},
+			}, nil
+		}
+		_, err = r.Reconcile(ctx, req)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(r.Get(ctx, client.ObjectKeyFromObject(cm), cm)).To(Succeed())
+		Expect(cm.Data["nameServers"]).To(Equal("ns-3.awsdns.com\nns-4.awsdns.com"))
+	})
+
+	It("should write a Secret when asked to", func() {
+		ctx := context.Background()
+		pd.Spec.NameServersExport = &parkingv1alpha1.NameServersExport{Kind: parkingv1alpha1.NameServersExportSecret, Name: "registrar"}
+		r := newTestReconciler(&MockR53Client{}, &MockS3Client{}, pd)
+
+		_, err := r.Reconcile(ctx, req)
+		Expect(err).NotTo(HaveOccurred())
+
+		secret := &corev1.Secret{}
+		Expect(r.Get(ctx, types.NamespacedName{Name: "registrar", Namespace: "default"}, secret)).To(Succeed())
+		Expect(string(secret.Data["nameServers"])).To(Equal("ns-1.awsdns.com\nns-2.awsdns.com"))
+		Expect(secret.OwnerReferences).To(HaveLen(1))
+	})
+
+	It("should not overwrite an object it does not own", func() {
+		ctx := context.Background()
+		foreign := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "exported-nameservers", Namespace: "default"},
+			Data:       map[string]string{"owner": "someone else"},
+		}
+		r := newTestReconciler(&MockR53Client{}, &MockS3Client{}, pd, foreign)
+
+		_, err := r.Reconcile(ctx, req)
+		Expect(err).To(MatchError(errNameServersExportNotOwned))
+		Expect(r.Get(ctx, client.ObjectKeyFromObject(foreign), foreign)).To(Succeed())
+		Expect(foreign.Data).To(Equal(map[string]string{"owner": "someone else"}))
+	})
+})
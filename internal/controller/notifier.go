@@ -0,0 +1,128 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sns"
+	parkingv1alpha1 "github.com/gminiba/parked-domain-operator/api/v1alpha1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// notificationTimeout bounds the delivery of a single notification.
+const notificationTimeout = 10 * time.Second
+
+// NotificationEvent is the payload sent to a Notifier when a reconcile succeeds or fails.
+type NotificationEvent struct {
+	Domain      string   `json:"domain"`
+	Status      string   `json:"status"`
+	ZoneID      string   `json:"zoneID,omitempty"`
+	NameServers []string `json:"nameservers,omitempty"`
+	Error       string   `json:"error,omitempty"`
+}
+
+// Notifier delivers provisioning outcomes out-of-band.
+type Notifier interface {
+	Notify(ctx context.Context, event NotificationEvent) error
+}
+
+// WebhookNotifier posts notifications as JSON to a URL.
+type WebhookNotifier struct {
+	URL    string
+	Client *http.Client
+}
+
+func (n *WebhookNotifier) Notify(ctx context.Context, event NotificationEvent) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	httpClient := n.Client
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to post notification: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("notification webhook responded with %s", resp.Status)
+	}
+	return nil
+}
+
+// SNSNotifier publishes notifications as JSON messages to an SNS topic.
+type SNSNotifier struct {
+	TopicARN string
+	Client   SNSClientAPI
+}
+
+func (n *SNSNotifier) Notify(ctx context.Context, event NotificationEvent) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	_, err = n.Client.Publish(ctx, &sns.PublishInput{
+		TopicArn: aws.String(n.TopicARN),
+		Message:  aws.String(string(body)),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to publish notification to SNS: %w", err)
+	}
+	return nil
+}
+
+// notify sends the outcome of a reconcile to the configured Notifier in the background.
+// Delivery is best-effort: failures are logged and never fail or delay the reconcile.
+func (r *ParkedDomainReconciler) notify(ctx context.Context, pd *parkingv1alpha1.ParkedDomain, reconcileErr error) {
+	if r.Notifier == nil {
+		return
+	}
+	event := NotificationEvent{
+		Domain:      pd.Spec.DomainName,
+		Status:      pd.Status.Status,
+		ZoneID:      pd.Status.ZoneID,
+		NameServers: slices.Clone(pd.Status.NameServers),
+	}
+	if reconcileErr != nil {
+		event.Error = reconcileErr.Error()
+	}
+
+	logger := log.FromContext(ctx)
+	go func() {
+		notifyCtx, cancel := context.WithTimeout(context.Background(), notificationTimeout)
+		defer cancel()
+		if err := r.Notifier.Notify(notifyCtx, event); err != nil {
+			logger.Error(err, "Failed to send provisioning notification")
+		}
+	}()
+}
@@ -0,0 +1,131 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	parkingv1alpha1 "github.com/gminiba/parked-domain-operator/api/v1alpha1"
+)
+
+// stubNotifier forwards every notification to a channel, optionally failing delivery.
+type stubNotifier struct {
+	events chan NotificationEvent
+	err    error
+}
+
+func (n *stubNotifier) Notify(ctx context.Context, event NotificationEvent) error {
+	n.events <- event
+	return n.err
+}
+
+var _ = Describe("Provisioning notifications", func() {
+	var (
+		pd         *parkingv1alpha1.ParkedDomain
+		templateCM *corev1.ConfigMap
+		req        ctrl.Request
+		notifier   *stubNotifier
+	)
+
+	BeforeEach(func() {
+		Expect(os.Setenv("TEMPLATE_CONFIGMAP_NAME", "parked-domain-templates")).To(Succeed())
+		pd = &parkingv1alpha1.ParkedDomain{
+			ObjectMeta: metav1.ObjectMeta{Name: "notify", Namespace: "default"},
+			Spec:       parkingv1alpha1.ParkedDomainSpec{DomainName: "notify.example.com"},
+		}
+		templateCM = &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "parked-domain-templates", Namespace: "default"},
+			Data:       map[string]string{"default.html": "<h1>{{DOMAIN_NAME}}</h1>"},
+		}
+		req = ctrl.Request{NamespacedName: types.NamespacedName{Name: "notify", Namespace: "default"}}
+		notifier = &stubNotifier{events: make(chan NotificationEvent, 10)}
+	})
+
+	AfterEach(func() {
+		Expect(os.Unsetenv("TEMPLATE_CONFIGMAP_NAME")).To(Succeed())
+	})
+
+	It("should notify once the domain is provisioned", func() {
+		r := newTestReconciler(&MockR53Client{}, &MockS3Client{}, pd, templateCM)
+		r.Notifier = notifier
+
+		_, err := r.Reconcile(context.Background(), req)
+		Expect(err).NotTo(HaveOccurred())
+
+		var event NotificationEvent
+		Eventually(notifier.events).Should(Receive(&event))
+		Expect(event.Domain).To(Equal("notify.example.com"))
+		Expect(event.Status).To(Equal("Provisioned"))
+		Expect(event.ZoneID).To(Equal("MOCKZONEID123"))
+		Expect(event.NameServers).To(ContainElement("ns-1.awsdns.com"))
+		Expect(event.Error).To(BeEmpty())
+	})
+
+	It("should notify a failure once and not fail the reconcile when delivery fails", func() {
+		bucketErr := errors.New("access denied")
+		s3Client := &MockS3Client{
+			HeadBucketFunc: func(ctx context.Context, params *s3.HeadBucketInput, optFns ...func(*s3.Options)) (*s3.HeadBucketOutput, error) {
+				return nil, bucketErr
+			},
+		}
+		notifier.err = errors.New("notification endpoint down")
+		r := newTestReconciler(&MockR53Client{}, s3Client, pd, templateCM)
+		r.Notifier = notifier
+
+		_, err := r.Reconcile(context.Background(), req)
+		Expect(err).To(MatchError(bucketErr))
+
+		var event NotificationEvent
+		Eventually(notifier.events).Should(Receive(&event))
+		Expect(event.Status).To(Equal("Error: S3 Bucket"))
+		Expect(event.Error).To(ContainSubstring("access denied"))
+
+		By("retrying without a status change")
+		_, err = r.Reconcile(context.Background(), req)
+		Expect(err).To(MatchError(bucketErr))
+		Consistently(notifier.events).ShouldNot(Receive())
+	})
+
+	It("should post the event as JSON to a webhook", func() {
+		received := make(chan NotificationEvent, 1)
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			defer GinkgoRecover()
+			Expect(r.Header.Get("Content-Type")).To(Equal("application/json"))
+			var event NotificationEvent
+			Expect(json.NewDecoder(r.Body).Decode(&event)).To(Succeed())
+			received <- event
+		}))
+		defer server.Close()
+
+		webhook := &WebhookNotifier{URL: server.URL}
+		Expect(webhook.Notify(context.Background(), NotificationEvent{Domain: "notify.example.com", Status: "Provisioned"})).To(Succeed())
+		Expect(received).To(Receive(Equal(NotificationEvent{Domain: "notify.example.com", Status: "Provisioned"})))
+	})
+})
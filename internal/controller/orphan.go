@@ -0,0 +1,83 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"strings"
+
+	parkingv1alpha1 "github.com/gminiba/parked-domain-operator/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// orphanedResources lists the AWS resources a ParkedDomain leaves running when it is deleted
+// with DeletionPolicy Orphan.
+func orphanedResources(pd *parkingv1alpha1.ParkedDomain) []string {
+	var resources []string
+	if pd.Status.ZoneID != "" {
+		resources = append(resources, "Hosted Zone "+pd.Status.ZoneID)
+	}
+	if pd.Status.QueryLoggingConfigID != "" {
+		resources = append(resources, "query logging configuration "+pd.Status.QueryLoggingConfigID)
+	}
+	switch {
+	case pd.Spec.SharedBucket != nil:
+		resources = append(resources, "pages under s3://"+bucketNameFor(pd)+"/"+keyPrefixFor(pd))
+	case storageEnabled(pd) || pd.Status.Endpoint != "":
+		resources = append(resources, "S3 bucket "+bucketNameFor(pd))
+	}
+	if pd.Status.AlarmName != "" {
+		resources = append(resources, "CloudWatch alarm "+pd.Status.AlarmName)
+	}
+	return resources
+}
+
+// orphan releases a ParkedDomain deleted with DeletionPolicy Orphan without touching its AWS
+// resources. What was left running is recorded in the Orphaned condition and an event before
+// the finalizer is removed, as the status goes away with the object.
+func (r *ParkedDomainReconciler) orphan(ctx context.Context, pd *parkingv1alpha1.ParkedDomain) (ctrl.Result, error) {
+	resources := orphanedResources(pd)
+	message := "No AWS resources were left running"
+	if len(resources) > 0 {
+		message = "Left running unmanaged: " + strings.Join(resources, ", ")
+	}
+	log.FromContext(ctx).Info("Orphaning AWS resources of ParkedDomain", "resources", resources)
+
+	meta.SetStatusCondition(&pd.Status.Conditions, metav1.Condition{
+		Type:               parkingv1alpha1.ConditionOrphaned,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: pd.Generation,
+		Reason:             "DeletionPolicyOrphan",
+		Message:            message,
+	})
+	if err := r.updateStatus(ctx, pd); err != nil {
+		return statusUpdateResult(err)
+	}
+	r.recordEvent(pd, corev1.EventTypeNormal, "Orphaned", message)
+
+	controllerutil.RemoveFinalizer(pd, finalizerName)
+	removeLegacyFinalizers(pd)
+	if err := r.Update(ctx, pd); err != nil {
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{}, nil
+}
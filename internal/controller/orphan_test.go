@@ -0,0 +1,93 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/service/route53"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	parkingv1alpha1 "github.com/gminiba/parked-domain-operator/api/v1alpha1"
+)
+
+var _ = Describe("ParkedDomain orphan deletion", func() {
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: "handed-off", Namespace: "default"}}
+
+	It("should release the ParkedDomain without deleting its AWS resources", func() {
+		ctx := context.Background()
+		now := metav1.Now()
+		pd := &parkingv1alpha1.ParkedDomain{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "handed-off", Namespace: "default",
+				Finalizers:        []string{finalizerName},
+				DeletionTimestamp: &now,
+			},
+			Spec: parkingv1alpha1.ParkedDomainSpec{
+				DomainName:     "handed-off.example.com",
+				DeletionPolicy: parkingv1alpha1.DeletionPolicyOrphan,
+			},
+			Status: parkingv1alpha1.ParkedDomainStatus{ZoneID: "ZORPHAN", AlarmName: "parked-domain-operator-handed-off.example.com"},
+		}
+		s3Client := &MockS3Client{
+			DeleteBucketFunc: func(ctx context.Context, params *s3.DeleteBucketInput, optFns ...func(*s3.Options)) (*s3.DeleteBucketOutput, error) {
+				Fail("the bucket should be left running")
+				return nil, nil
+			},
+		}
+		r53 := &MockR53Client{
+			DeleteHostedZoneFunc: func(ctx context.Context, params *route53.DeleteHostedZoneInput, optFns ...func(*route53.Options)) (*route53.DeleteHostedZoneOutput, error) {
+				Fail("the Hosted Zone should be left running")
+				return nil, nil
+			},
+		}
+		r := newTestReconciler(r53, s3Client, pd)
+		recorder := record.NewFakeRecorder(10)
+		r.Recorder = recorder
+		var finalStatus *parkingv1alpha1.ParkedDomainStatus
+		r.Client = interceptor.NewClient(r.Client.(client.WithWatch), interceptor.Funcs{
+			SubResourceUpdate: func(ctx context.Context, c client.Client, subResourceName string, obj client.Object, opts ...client.SubResourceUpdateOption) error {
+				finalStatus = obj.(*parkingv1alpha1.ParkedDomain).Status.DeepCopy()
+				return c.SubResource(subResourceName).Update(ctx, obj, opts...)
+			},
+		})
+
+		_, err := r.Reconcile(ctx, req)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(finalStatus).NotTo(BeNil())
+		orphaned := meta.FindStatusCondition(finalStatus.Conditions, parkingv1alpha1.ConditionOrphaned)
+		Expect(orphaned).NotTo(BeNil())
+		Expect(orphaned.Message).To(Equal("Left running unmanaged: Hosted Zone ZORPHAN, S3 bucket handed-off.example.com, " +
+			"CloudWatch alarm parked-domain-operator-handed-off.example.com"))
+		Expect(drainEvents(recorder)).To(ConsistOf(ContainSubstring("Normal Orphaned Left running unmanaged")))
+
+		By("letting the API server finish the deletion")
+		err = r.Get(ctx, req.NamespacedName, &parkingv1alpha1.ParkedDomain{})
+		Expect(apierrors.IsNotFound(err)).To(BeTrue())
+	})
+})
@@ -0,0 +1,36 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// setOwnerRef makes owner the controller of obj, so Kubernetes garbage-collects obj once owner
+// is deleted. Owner references cannot point across namespaces, so for an obj in another
+// namespace than owner it does nothing and returns false; owner must then delete obj itself.
+func setOwnerRef(owner, obj client.Object, scheme *runtime.Scheme) (bool, error) {
+	if owner.GetNamespace() != obj.GetNamespace() {
+		return false, nil
+	}
+	if err := controllerutil.SetControllerReference(owner, obj, scheme); err != nil {
+		return false, err
+	}
+	return true, nil
+}
@@ -0,0 +1,98 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	parkingv1alpha1 "github.com/gminiba/parked-domain-operator/api/v1alpha1"
+)
+
+var _ = Describe("Owner references", func() {
+	// ownedBy matches an owner reference the garbage collector deletes the object with once
+	// owner is gone, and which blocks a foreground deletion of owner until it is.
+	ownedBy := func(owner client.Object) OmegaMatcher {
+		return ContainElement(And(
+			HaveField("UID", owner.GetUID()),
+			HaveField("Name", owner.GetName()),
+			HaveField("Controller", HaveValue(BeTrue())),
+			HaveField("BlockOwnerDeletion", HaveValue(BeTrue())),
+		))
+	}
+
+	It("should only set an owner reference within the owner's namespace", func() {
+		owner := &parkingv1alpha1.ParkedDomain{ObjectMeta: metav1.ObjectMeta{Name: "owner", Namespace: "default", UID: "owner-uid"}}
+
+		local := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "local", Namespace: "default"}}
+		set, err := setOwnerRef(owner, local, scheme.Scheme)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(set).To(BeTrue())
+		Expect(local.OwnerReferences).To(ownedBy(owner))
+
+		remote := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "remote", Namespace: "elsewhere"}}
+		set, err = setOwnerRef(owner, remote, scheme.Scheme)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(set).To(BeFalse())
+		Expect(remote.OwnerReferences).To(BeEmpty())
+	})
+
+	It("should cascade the deletion of a ParkedDomain to its nameserver export", func() {
+		ctx := context.Background()
+		pd := &parkingv1alpha1.ParkedDomain{
+			ObjectMeta: metav1.ObjectMeta{Name: "cascade", Namespace: "default", UID: "cascade-uid", Generation: 1},
+			Spec: parkingv1alpha1.ParkedDomainSpec{
+				DomainName:        "cascade.example.com",
+				InlineTemplate:    "<h1>{{DOMAIN_NAME}}</h1>",
+				NameServersExport: &parkingv1alpha1.NameServersExport{},
+			},
+		}
+		r := newTestReconciler(&MockR53Client{}, &MockS3Client{}, pd)
+		_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: client.ObjectKeyFromObject(pd)})
+		Expect(err).NotTo(HaveOccurred())
+
+		cm := &corev1.ConfigMap{}
+		Expect(r.Get(ctx, client.ObjectKey{Name: "cascade-nameservers", Namespace: "default"}, cm)).To(Succeed())
+		Expect(cm.OwnerReferences).To(ownedBy(pd))
+	})
+
+	It("should cascade the deletion of a claim in the claim namespace to its ParkedDomain", func() {
+		ctx := context.Background()
+		namespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+			Name:        "parking",
+			Annotations: map[string]string{allowedDomainsAnnotation: "*"},
+		}}
+		claim := &parkingv1alpha1.ParkedDomainClaim{
+			ObjectMeta: metav1.ObjectMeta{Name: "local", Namespace: "parking", UID: "claim-uid"},
+			Spec:       parkingv1alpha1.ParkedDomainClaimSpec{DomainName: "local.example.com"},
+		}
+		r := newTestClaimReconciler(namespace, claim)
+		_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: client.ObjectKeyFromObject(claim)})
+		Expect(err).NotTo(HaveOccurred())
+
+		pd := &parkingv1alpha1.ParkedDomain{}
+		Expect(r.Get(ctx, client.ObjectKey{Name: "parking-local", Namespace: "parking"}, pd)).To(Succeed())
+		Expect(pd.OwnerReferences).To(ownedBy(claim))
+	})
+})
@@ -18,23 +18,63 @@ package controller
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
 
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 
 	parkingv1alpha1 "github.com/gminiba/parked-domain-operator/api/v1alpha1"
+	"github.com/gminiba/parked-domain-operator/internal/awserr"
 )
 
 const (
-	finalizerName = "parking.minibaev.eu/finalizer"
+	finalizerName = parkingv1alpha1.GroupName + "/finalizer"
 	DefaultRegion = "eu-central-1"
+
+	// lastAppliedHashAnnotation records a hash of the bucket state last applied to S3,
+	// so reconciles with an unchanged desired state skip the S3 mutations.
+	lastAppliedHashAnnotation = parkingv1alpha1.GroupName + "/last-applied-hash"
+	// operatorVersionAnnotation records the version of the operator managing the object.
+	operatorVersionAnnotation = parkingv1alpha1.GroupName + "/operator-version"
+
+	// templateMissingRequeueDelay is how long to wait before checking again for a template
+	// ConfigMap that does not exist yet, in case its creation event is missed.
+	templateMissingRequeueDelay = time.Minute
+	// maxStatusHistory is the number of status changes kept in Status.History.
+	maxStatusHistory = 10
+	// statusConflictRequeueDelay is how soon a reconcile whose status write lost a
+	// conflict runs again, starting over from the latest object.
+	statusConflictRequeueDelay = time.Second
 )
 
+// legacyFinalizerNames are finalizers set by older operator versions under a
+// previous API group. They are replaced by finalizerName on live objects and
+// honoured like it on deletion, so renamed objects are never stuck.
+var legacyFinalizerNames = []string{
+	"parking.yourcompany.com/finalizer",
+}
+
 // ParkedDomainReconciler reconciles a ParkedDomain object
 type ParkedDomainReconciler struct {
 	client.Client
@@ -43,9 +83,96 @@ type ParkedDomainReconciler struct {
 	S3Client        S3ClientAPI
 	R53Client       R53ClientAPI
 	S3ClientFactory S3ClientFactoryAPI
+	// S3Endpoint, if set, is the URL of the S3-compatible service buckets are
+	// created in instead of AWS S3. Spec.StorageEndpoint overrides it.
+	S3Endpoint string
+	// S3ForcePathStyle tells the reconciler that S3ClientFactory addresses
+	// buckets path-style, which decides the endpoint of S3-compatible services.
+	S3ForcePathStyle bool
+	// R53ClientFactory provides Route 53 clients for domains whose region is
+	// outside the standard AWS partition (aws-cn, aws-us-gov) or that set
+	// Spec.DNSRoleARN.
+	R53ClientFactory R53ClientFactoryAPI
+	// CloudWatchClientFactory provides the CloudWatch clients Spec.Alarm is
+	// created with, in the bucket's region and account.
+	CloudWatchClientFactory CloudWatchClientFactoryAPI
+	// STSClient, if set, looks up the operator's AWS account, so a bucket is
+	// only configured when that account owns it. Buckets accessed through
+	// Spec.StorageRoleARN must be owned by the role's account either way.
+	STSClient STSClientAPI
+	// DNSFirewallClientFactory provides the Route 53 Resolver clients
+	// Spec.DNSFirewallRuleGroupID is associated with, in the VPC's region.
+	DNSFirewallClientFactory DNSFirewallClientFactoryAPI
+	// AllowedRoles lists the IAM roles Spec.DNSRoleARN and Spec.StorageRoleARN may
+	// name, as role ARNs, account IDs allowing all roles of an account, or "*".
+	// Empty allows no role, so only the operator's own credentials are used.
+	AllowedRoles []string
+	// AllowedSharedBuckets lists the buckets Spec.SharedBucket may name, or "*" for any.
+	// Empty allows no shared bucket.
+	AllowedSharedBuckets []string
+	// Notifier, if set, is told when a ParkedDomain is provisioned or fails to provision.
+	Notifier Notifier
+	// Recorder, if set, emits Kubernetes events for changes users must act on.
+	Recorder record.EventRecorder
+	// DelegationSetID, if set, is the reusable delegation set new Hosted Zones
+	// are created with when the ParkedDomain does not name one.
+	DelegationSetID string
+	// ManagedLabelKey and ManagedLabelValue, if set, label every managed
+	// ParkedDomain for inventory queries.
+	ManagedLabelKey   string
+	ManagedLabelValue string
+	// OperatorVersion, if set, is recorded on every managed ParkedDomain.
+	OperatorVersion string
+	// BucketUsageInterval is how often the bucket's object count and size are
+	// counted into the status. Zero disables counting.
+	BucketUsageInterval time.Duration
+	// AllowCrossNamespaceTemplates lets Spec.TemplateConfigMapRef point at a
+	// ConfigMap outside the ParkedDomain's namespace.
+	AllowCrossNamespaceTemplates bool
+	// AllowSecretTemplates lets Spec.TemplateSecretRef read templates from Secrets
+	// labeled parking.minibaev.eu/template=true. Other Secrets are always refused.
+	AllowSecretTemplates bool
+	// TemplateSources, if set, replaces the built-in template source of each
+	// listed type, e.g. to serve templates from another store.
+	TemplateSources map[parkingv1alpha1.TemplateSourceType]TemplateSource
+	// RequeueJitter is the largest fraction added at random to requeue and
+	// retry delays, spreading out ParkedDomains queued at the same time.
+	// Zero disables jitter.
+	RequeueJitter float64
+	// AWSCallTimeout bounds every S3 and Route 53 call, so a hung call fails and
+	// is retried instead of blocking a worker. Zero disables the bound.
+	AWSCallTimeout time.Duration
+	// WatchNamespaces, if set, are the only namespaces whose ParkedDomains are
+	// reconciled. The manager's cache should be limited to them as well.
+	WatchNamespaces []string
+	// ResyncOnStart re-applies every step of every ParkedDomain once after the
+	// operator starts, repairing drift its status does not know about.
+	ResyncOnStart bool
+	// ResyncInterval spaces out the ParkedDomains queued by ResyncOnStart, so a
+	// large fleet stays within the AWS request limits. Zero queues them all at once.
+	ResyncInterval time.Duration
+	// ZoneVerifyInterval is how long a Hosted Zone confirmed with GetHostedZone
+	// is trusted before it is confirmed again, which also refreshes its
+	// nameservers. Zero confirms it on every reconcile.
+	ZoneVerifyInterval time.Duration
+
+	// locks serializes reconciles of the same ParkedDomain, so a provisioning
+	// pass and a cleanup pass never act on the same bucket and zone at once.
+	locks keyedMutex
+	// resync holds the ParkedDomains queued by ResyncOnStart that were not
+	// provisioned since.
+	resync resyncSet
+	// zones caches the Hosted Zones confirmed to exist, so they are not looked
+	// up by name on every pass of the zone step.
+	zones zoneCache
+	// callerAccount caches the AWS account STSClient looked up.
+	callerAccount callerAccount
 }
 
-// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch;create;update
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;create;update
+// +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+// +kubebuilder:rbac:groups="",resources=namespaces,verbs=get
 // +kubebuilder:rbac:groups=parking.minibaev.eu,resources=parkeddomains,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=parking.minibaev.eu,resources=parkeddomains/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=parking.minibaev.eu,resources=parkeddomains/finalizers,verbs=update
@@ -56,7 +183,20 @@ type ParkedDomainReconciler struct {
 // For more details, check Reconcile and its Result here:
 // - https://pkg.go.dev/sigs.k8s.io/controller-runtime@v0.21.0/pkg/reconcile
 func (r *ParkedDomainReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
-	logger := log.FromContext(ctx)
+	result, err := r.reconcile(ctx, req)
+	result.RequeueAfter = jitter(result.RequeueAfter, r.RequeueJitter)
+	return result, err
+}
+
+func (r *ParkedDomainReconciler) reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	baseLogger := log.FromContext(ctx)
+	logger := baseLogger
+
+	// The workqueue never hands the same key to two workers, so this lock only
+	// waits on reconciles started outside of it and cannot deadlock with it. It
+	// is taken before the fetch so a waiting reconcile sees the latest object.
+	unlock := r.locks.Lock(req.String())
+	defer unlock()
 
 	// 1. Fetch the ParkedDomain instance
 	pd := &parkingv1alpha1.ParkedDomain{}
@@ -69,11 +209,17 @@ func (r *ParkedDomainReconciler) Reconcile(ctx context.Context, req ctrl.Request
 		logger.Error(err, "Failed to get ParkedDomain")
 		return ctrl.Result{}, err
 	}
+	ctx, logger = withDomainLogger(ctx, baseLogger, pd)
+	resyncing := r.resync.has(req.NamespacedName)
 
 	// 2. Handle Finalizer for cleanup
 	if pd.DeletionTimestamp.IsZero() {
-		// The object is not being deleted, so we add our finalizer if it doesn't exist.
-		if !controllerutil.ContainsFinalizer(pd, finalizerName) {
+		// The object is not being deleted, so we add our finalizer and managed-by
+		// metadata if they don't exist and drop any finalizer left behind by an
+		// older operator version.
+		removedLegacy := removeLegacyFinalizers(pd)
+		metadataChanged := r.ensureManagedMetadata(pd)
+		if !controllerutil.ContainsFinalizer(pd, finalizerName) || removedLegacy || metadataChanged {
 			controllerutil.AddFinalizer(pd, finalizerName)
 			if err := r.Update(ctx, pd); err != nil {
 				return ctrl.Result{}, err
@@ -81,21 +227,32 @@ func (r *ParkedDomainReconciler) Reconcile(ctx context.Context, req ctrl.Request
 		}
 	} else {
 		// The object is being deleted.
-		if controllerutil.ContainsFinalizer(pd, finalizerName) {
-			logger.Info("Performing cleanup for ParkedDomain")
-
-			if err := r.cleanupS3Bucket(ctx, pd); err != nil {
-				logger.Error(err, "S3 cleanup failed")
-				return ctrl.Result{}, err
+		if controllerutil.ContainsFinalizer(pd, finalizerName) || hasLegacyFinalizer(pd) {
+			if pd.Spec.DeletionPolicy == parkingv1alpha1.DeletionPolicyOrphan {
+				return r.orphan(ctx, pd)
 			}
+			logger.Info("Performing cleanup for ParkedDomain", "order", cleanupOrderFor(pd))
 
-			if err := r.cleanupRoute53Zone(ctx, pd); err != nil {
-				logger.Error(err, "Route53 cleanup failed")
-				return ctrl.Result{}, err
+			// Both steps are idempotent, so a step done on an earlier pass, e.g. while the
+			// bucket was still being emptied, finds nothing left to delete.
+			steps := []func(context.Context, *parkingv1alpha1.ParkedDomain) (*ctrl.Result, error){r.cleanupDNS, r.cleanupStorage}
+			if cleanupOrderFor(pd) == parkingv1alpha1.CleanupOrderStorageFirst {
+				slices.Reverse(steps)
+			}
+			for _, step := range steps {
+				if result, err := step(ctx, pd); result != nil {
+					return *result, err
+				}
 			}
 
-			// All cleanup successful, remove the finalizer.
+			// All cleanup successful, clear any earlier failure and remove the finalizer.
+			if meta.RemoveStatusCondition(&pd.Status.Conditions, parkingv1alpha1.ConditionCleanupFailed) {
+				if err := r.updateStatus(ctx, pd); err != nil {
+					return statusUpdateResult(err)
+				}
+			}
 			controllerutil.RemoveFinalizer(pd, finalizerName)
+			removeLegacyFinalizers(pd)
 			if err := r.Update(ctx, pd); err != nil {
 				return ctrl.Result{}, err
 			}
@@ -104,46 +261,735 @@ func (r *ParkedDomainReconciler) Reconcile(ctx context.Context, req ctrl.Request
 		return ctrl.Result{}, nil
 	}
 
-	// 3. Reconcile AWS Resources by calling helper functions
+	// The webhook is optional, so a spec it would have rejected, e.g. a record outside the
+	// domain, is refused here before any AWS resource is touched.
+	if fieldErrs := ValidateParkedDomain(pd); len(fieldErrs) > 0 {
+		err := reconcile.TerminalError(fmt.Errorf("%w: %w", errInvalidSpec, fieldErrs.ToAggregate()))
+		r.recordEvent(pd, corev1.EventTypeWarning, "InvalidSpec", err.Error())
+		return r.failStep(ctx, pd, parkingv1alpha1.ConditionSpecValid, "Error: Invalid Spec", err)
+	}
+	meta.RemoveStatusCondition(&pd.Status.Conditions, parkingv1alpha1.ConditionSpecValid)
+
+	// Refresh the nameservers of an existing zone on every reconcile, so a
+	// recreated zone or a changed delegation set is noticed and reported.
+	nameServersChanged := false
+	if dnsEnabled(pd) && pd.Status.ZoneID != "" {
+		nameServersChanged, err = r.refreshNameServers(ctx, pd)
+		if err != nil {
+			return r.failStep(ctx, pd, parkingv1alpha1.ConditionZoneReady, "Error: Route53 Zone", err)
+		}
+	}
+
+	// Skip the remaining AWS work and the status write entirely when this
+	// generation was already fully reconciled, e.g. for status-only or
+	// metadata-only updates, unless the template ConfigMap was edited since
+	// the page was rendered.
+	templateEdited := false
+	if !nameServersChanged && pd.Status.ObservedGeneration == pd.Generation && allStepsSatisfied(pd) && !endpointCheckPending(pd) && !resyncing {
+		sourceChanged, templateVersion := r.checkTemplateConfigMap(ctx, pd)
+		templateEdited = templateVersion != "" && templateVersion != pd.Status.TemplateConfigMapVersion
+		if templateEdited {
+			logger.Info("Template ConfigMap changed, rendering the page again", "resourceVersion", templateVersion)
+		} else if !r.usageRefreshDue(pd) {
+			if sourceChanged {
+				if err := r.updateStatus(ctx, pd); err != nil {
+					return statusUpdateResult(err)
+				}
+			}
+			logger.V(1).Info("Generation already reconciled, nothing to do", "generation", pd.Generation)
+			return ctrl.Result{RequeueAfter: r.nextUsageRefresh(pd)}, nil
+		} else if r.refreshBucketUsage(ctx, pd) {
+			// Counting the bucket's usage also notices a bucket deleted outside the
+			// operator, which the steps below then recreate.
+			if err := r.updateStatus(ctx, pd); err != nil {
+				return statusUpdateResult(err)
+			}
+			return ctrl.Result{RequeueAfter: r.nextUsageRefresh(pd)}, nil
+		}
+	}
+
+	// Changes to a provisioned ParkedDomain wait for its maintenance window. Changed
+	// nameservers break the delegation until it is updated, so they never wait.
+	if !nameServersChanged {
+		opensIn, err := deferredToMaintenanceWindow(pd, time.Now())
+		if err != nil {
+			return ctrl.Result{}, reconcile.TerminalError(err)
+		}
+		if opensIn > 0 {
+			return r.deferToMaintenanceWindow(ctx, pd, opensIn)
+		}
+	}
+	pd.Status.DeferredUntil = nil
+
+	// 3. Reconcile AWS Resources by calling helper functions. Steps whose
+	// condition is already True for this generation are skipped, so a retry
+	// after a partial failure resumes where the previous attempt stopped.
 	logger.Info("Reconciling AWS resources")
 
-	zoneID, nameservers, err := r.reconcileRoute53Zone(ctx, pd)
+	// Tear down the half of the setup that was disabled, keeping the other.
+	if !storageEnabled(pd) && (pd.Status.Endpoint != "" || meta.FindStatusCondition(pd.Status.Conditions, parkingv1alpha1.ConditionBucketReady) != nil) {
+		done, err := r.disableStorage(ctx, pd)
+		if err != nil {
+			return r.failStep(ctx, pd, parkingv1alpha1.ConditionBucketReady, "Error: S3 Bucket", err)
+		}
+		if !done {
+			return ctrl.Result{RequeueAfter: s3CleanupResumeDelay}, nil
+		}
+	}
+	if !dnsEnabled(pd) && pd.Status.ZoneID != "" {
+		done, err := r.disableDNS(ctx, pd)
+		if err != nil {
+			return r.failStep(ctx, pd, parkingv1alpha1.ConditionZoneReady, "Error: Route53 Zone", err)
+		}
+		if !done {
+			if err := r.updateStatus(ctx, pd); err != nil {
+				return statusUpdateResult(err)
+			}
+			return ctrl.Result{RequeueAfter: changeSyncPollDelay}, nil
+		}
+	}
+
+	zoneID, nameservers := pd.Status.ZoneID, pd.Status.NameServers
+	if dnsEnabled(pd) && (resyncing || !stepSatisfied(pd, parkingv1alpha1.ConditionZoneReady) || zoneID == "") {
+		zoneID, nameservers, err = r.reconcileRoute53Zone(ctx, pd)
+		if err != nil {
+			return r.failStep(ctx, pd, parkingv1alpha1.ConditionZoneReady, "Error: Route53 Zone", err)
+		}
+		pd.Status.ZoneID = zoneID
+		r.updateNameServers(pd, nameservers)
+		ctx, logger = withDomainLogger(ctx, baseLogger, pd)
+		if err := r.reconcileParentDelegation(ctx, pd); err != nil {
+			return r.failStep(ctx, pd, parkingv1alpha1.ConditionZoneReady, "Error: Route53 Zone", err)
+		}
+		if err := r.reconcileQueryLogging(ctx, pd); err != nil {
+			return r.failStep(ctx, pd, parkingv1alpha1.ConditionZoneReady, "Error: Route53 Zone", err)
+		}
+		if err := r.reconcileZoneTags(ctx, pd); err != nil {
+			return r.failStep(ctx, pd, parkingv1alpha1.ConditionZoneReady, "Error: Route53 Zone", err)
+		}
+		if err := r.reconcileVPCAssociations(ctx, pd); err != nil {
+			return r.failStep(ctx, pd, parkingv1alpha1.ConditionZoneReady, "Error: Route53 Zone", err)
+		}
+		if err := r.reconcileDNSFirewall(ctx, pd); err != nil {
+			return r.failStep(ctx, pd, parkingv1alpha1.ConditionZoneReady, "Error: DNS Firewall", err)
+		}
+		markStep(pd, parkingv1alpha1.ConditionZoneReady, "Hosted Zone is ready")
+	} else if nameServersChanged {
+		// Keep the parent zone's delegation in step with the zone's new nameservers.
+		if err := r.reconcileParentDelegation(ctx, pd); err != nil {
+			return r.failStep(ctx, pd, parkingv1alpha1.ConditionZoneReady, "Error: Route53 Zone", err)
+		}
+	}
+
+	s3Endpoint := pd.Status.Endpoint
+	if placeholderDue(pd) {
+		if err := r.reconcileRoute53ARecord(ctx, pd, zoneID, ""); err != nil {
+			return r.failStep(ctx, pd, parkingv1alpha1.ConditionRecordReady, "Error: Route53 A Record", err)
+		}
+		meta.SetStatusCondition(&pd.Status.Conditions, metav1.Condition{
+			Type:               parkingv1alpha1.ConditionRecordReady,
+			Status:             metav1.ConditionFalse,
+			ObservedGeneration: pd.Generation,
+			Reason:             placeholderReason,
+			Message:            "A record points at the placeholder address until the bucket is ready",
+		})
+	}
+	if storageEnabled(pd) && (resyncing || templateEdited || !stepSatisfied(pd, parkingv1alpha1.ConditionBucketReady) || s3Endpoint == "") {
+		// Read before rendering, so an edit made meanwhile is rendered again on its own event.
+		_, templateVersion := r.checkTemplateConfigMap(ctx, pd)
+		s3Endpoint, err = r.reconcileS3Bucket(ctx, pd)
+		if errors.Is(err, errTemplateConfigMapMissing) {
+			// Only the content depends on the ConfigMap, so report the zone and its
+			// nameservers now and finish the bucket once the ConfigMap exists.
+			logger.Info("Template ConfigMap not found, waiting for it", "reason", err.Error())
+			setContentSourceMissing(pd, err)
+			setStatus(pd, "Pending: Template", "TemplateConfigMapMissing")
+			pd.Status.Ready = false
+			if err := r.updateStatus(ctx, pd); err != nil {
+				return statusUpdateResult(err)
+			}
+			return ctrl.Result{RequeueAfter: templateMissingRequeueDelay}, nil
+		}
+		if err != nil {
+			return r.failStep(ctx, pd, parkingv1alpha1.ConditionBucketReady, "Error: S3 Bucket", err)
+		}
+		markStep(pd, parkingv1alpha1.ConditionBucketReady, "S3 bucket is configured for website hosting")
+		pd.Status.TemplateConfigMapVersion = templateVersion
+	}
+
+	if recordEnabled(pd) && (resyncing || !stepSatisfied(pd, parkingv1alpha1.ConditionRecordReady)) {
+		err = r.reconcileRoute53ARecord(ctx, pd, zoneID, s3Endpoint)
+		if err != nil {
+			return r.failStep(ctx, pd, parkingv1alpha1.ConditionRecordReady, "Error: Route53 A Record", err)
+		}
+		markStep(pd, parkingv1alpha1.ConditionRecordReady, "A record points at the website endpoint")
+	}
+
+	// Optionally confirm the page is actually served, rechecking with backoff until it is.
+	var result ctrl.Result
+	if pd.Spec.VerifyHTTP && storageEnabled(pd) {
+		result.RequeueAfter = r.verifyEndpoint(ctx, pd)
+	} else {
+		meta.RemoveStatusCondition(&pd.Status.Conditions, parkingv1alpha1.ConditionEndpointHealthy)
+	}
+
+	if r.usageRefreshDue(pd) {
+		r.refreshBucketUsage(ctx, pd)
+	}
+	if next := r.nextUsageRefresh(pd); next > 0 && (result.RequeueAfter == 0 || next < result.RequeueAfter) {
+		result.RequeueAfter = next
+	}
+
+	if err := r.reconcileNameServersExport(ctx, pd); err != nil {
+		return r.failStep(ctx, pd, parkingv1alpha1.ConditionZoneReady, "Error: Nameserver Export", err)
+	}
+
+	// 4. Update the Status of the CR
+	setStatus(pd, "Provisioned", "Reconciled")
+	pd.Status.Ready = allStepsSatisfied(pd)
+	pd.Status.ObservedGeneration = pd.Generation
+	// Only now are the tags applied to both the zone and the bucket, so until then a retry
+	// still knows which removed keys to clean up.
+	pd.Status.TagKeys = tagKeysFor(pd)
+	pd.Status.EstimatedMonthlyCostUSD = r.estimateMonthlyCost(pd)
+	if err := r.updateStatus(ctx, pd); err != nil {
+		return statusUpdateResult(err)
+	}
+	r.resync.remove(req.NamespacedName)
+	r.notify(ctx, pd, nil)
+
+	logger.Info("Successfully reconciled ParkedDomain")
+	return result, nil
+}
+
+// deferToMaintenanceWindow records that pd's changes wait for its maintenance window, which
+// opens after opensIn, and requeues pd for then.
+func (r *ParkedDomainReconciler) deferToMaintenanceWindow(ctx context.Context, pd *parkingv1alpha1.ParkedDomain, opensIn time.Duration) (ctrl.Result, error) {
+	until := metav1.NewTime(time.Now().Add(opensIn).Truncate(time.Second))
+	log.FromContext(ctx).Info("Deferring changes to the maintenance window", "deferredUntil", until)
+	pd.Status.DeferredUntil = &until
+	setStatus(pd, "Pending: Maintenance Window", "MaintenanceWindowClosed")
+	if err := r.updateStatus(ctx, pd); err != nil {
+		return statusUpdateResult(err)
+	}
+	// Reconcile adds jitter to the delay, which must not push the requeue past the window's
+	// opening. A requeue that comes early is deferred again for the rest of the wait.
+	return ctrl.Result{RequeueAfter: time.Duration(float64(opensIn) / (1 + r.RequeueJitter))}, nil
+}
+
+// failStep records a failed provisioning step in the status and returns the original error.
+// Conditions of steps that already succeeded are kept, so the next attempt can skip them.
+// A notification is sent only when the status changes and was written, not on every retry.
+func (r *ParkedDomainReconciler) failStep(ctx context.Context, pd *parkingv1alpha1.ParkedDomain, condType, status string, err error) (ctrl.Result, error) {
+	statusChanged := pd.Status.Status != status
+	if awserr.IsNotFound(err) {
+		// The missing resource may be the cached Hosted Zone, so it is confirmed again.
+		r.zones.forget(pd.Status.ZoneID)
+	}
+	setStatus(pd, status, failureReason(err))
+	pd.Status.Ready = false
+	meta.SetStatusCondition(&pd.Status.Conditions, metav1.Condition{
+		Type:               condType,
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: pd.Generation,
+		Reason:             failureReason(err),
+		Message:            err.Error(),
+	})
+	// The returned error retries the step either way; a failed status write is
+	// only logged, and the retry records the failure again.
+	if r.updateStatus(ctx, pd) == nil && statusChanged {
+		r.notify(ctx, pd, err)
+	}
+	return ctrl.Result{}, err
+}
+
+// setStatus sets pd's status and records a change in Status.History, dropping the oldest
+// records beyond maxStatusHistory. Retries that keep the status add nothing, so the
+// history never causes a status write of its own.
+func setStatus(pd *parkingv1alpha1.ParkedDomain, status, reason string) {
+	if pd.Status.Status == status {
+		return
+	}
+	history := append(pd.Status.History, parkingv1alpha1.TransitionRecord{
+		Time:   metav1.Now(),
+		From:   pd.Status.Status,
+		To:     status,
+		Reason: reason,
+	})
+	if len(history) > maxStatusHistory {
+		history = history[len(history)-maxStatusHistory:]
+	}
+	pd.Status.History = history
+	pd.Status.Status = status
+}
+
+// failureReason returns the condition reason for a failed step, singling out failures
+// that need the user's attention rather than a retry.
+func failureReason(err error) string {
+	if errors.Is(err, errBucketNameTaken) {
+		return "BucketNameTaken"
+	}
+	if errors.Is(err, errNotBucketOwner) {
+		return "NotBucketOwner"
+	}
+	if errors.Is(err, errPublicAccessBlocked) {
+		return "PublicAccessBlocked"
+	}
+	if errors.Is(err, errInvalidRegion) {
+		return "InvalidRegion"
+	}
+	if errors.Is(err, errRecordConflict) {
+		return "RecordConflict"
+	}
+	if errors.Is(err, errRoleNotAllowed) {
+		return "RoleNotAllowed"
+	}
+	if errors.Is(err, errSharedBucketNotAllowed) {
+		return "SharedBucketNotAllowed"
+	}
+	if errors.Is(err, errInvalidSpec) {
+		return "InvalidSpec"
+	}
+	return "ReconcileFailed"
+}
+
+// cleanupOrderFor returns the order pd's AWS resources are deleted in.
+func cleanupOrderFor(pd *parkingv1alpha1.ParkedDomain) parkingv1alpha1.CleanupOrder {
+	if pd.Spec.CleanupOrder == "" {
+		return parkingv1alpha1.CleanupOrderDNSFirst
+	}
+	return pd.Spec.CleanupOrder
+}
+
+// cleanupStorage is the finalizer step deleting the bucket. It returns the result to end
+// the reconcile with while the bucket is still being emptied or its deletion failed.
+func (r *ParkedDomainReconciler) cleanupStorage(ctx context.Context, pd *parkingv1alpha1.ParkedDomain) (*ctrl.Result, error) {
+	// Without storage the operator owns no bucket, and a bucket named after
+	// the host may well back an alias target, so leave S3 alone.
+	if !storageEnabled(pd) && pd.Status.Endpoint == "" {
+		return nil, nil
+	}
+	done, err := r.cleanupS3Bucket(ctx, pd)
 	if err != nil {
-		pd.Status.Status = "Error: Route53 Zone"
-		_ = r.Status().Update(ctx, pd)
-		return ctrl.Result{}, err
+		log.FromContext(ctx).Error(err, "S3 cleanup failed")
+		reason := "S3CleanupFailed"
+		if errors.Is(err, errObjectLockRetention) {
+			reason = "ObjectLockRetention"
+		}
+		result, err := r.cleanupFailed(ctx, pd, reason, err)
+		return &result, err
+	}
+	if !done {
+		return &ctrl.Result{RequeueAfter: s3CleanupResumeDelay}, nil
 	}
+	return nil, nil
+}
 
-	s3Endpoint, err := r.reconcileS3Bucket(ctx, pd)
+// cleanupDNS is the finalizer step deleting the DNS Firewall association and the Hosted
+// Zone. It returns the result to end the reconcile with when either failed or record
+// deletions are still being applied.
+func (r *ParkedDomainReconciler) cleanupDNS(ctx context.Context, pd *parkingv1alpha1.ParkedDomain) (*ctrl.Result, error) {
+	if err := r.cleanupDNSFirewall(ctx, pd); err != nil {
+		log.FromContext(ctx).Error(err, "DNS Firewall cleanup failed")
+		result, err := r.cleanupFailed(ctx, pd, "DNSFirewallCleanupFailed", err)
+		return &result, err
+	}
+	done, err := r.cleanupRoute53Zone(ctx, pd)
 	if err != nil {
-		pd.Status.Status = "Error: S3 Bucket"
-		_ = r.Status().Update(ctx, pd)
-		return ctrl.Result{}, err
+		log.FromContext(ctx).Error(err, "Route53 cleanup failed")
+		result, err := r.cleanupFailed(ctx, pd, "Route53CleanupFailed", err)
+		return &result, err
+	}
+	if !done {
+		if err := r.updateStatus(ctx, pd); err != nil {
+			result, err := statusUpdateResult(err)
+			return &result, err
+		}
+		return &ctrl.Result{RequeueAfter: changeSyncPollDelay}, nil
+	}
+	return nil, nil
+}
+
+// cleanupFailed records the finalizer cleanup step that failed, so a stuck deletion
+// shows why in the object's status, and returns err to retry the cleanup.
+func (r *ParkedDomainReconciler) cleanupFailed(ctx context.Context, pd *parkingv1alpha1.ParkedDomain, reason string, err error) (ctrl.Result, error) {
+	meta.SetStatusCondition(&pd.Status.Conditions, metav1.Condition{
+		Type:               parkingv1alpha1.ConditionCleanupFailed,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: pd.Generation,
+		Reason:             reason,
+		Message:            err.Error(),
+	})
+	_ = r.updateStatus(ctx, pd)
+	return ctrl.Result{}, err
+}
+
+// updateStatus writes the status of pd and logs a failed write. The write carries
+// pd's resourceVersion, so it fails with a conflict instead of overwriting the status
+// of an object changed since it was read, e.g. to a newer generation.
+func (r *ParkedDomainReconciler) updateStatus(ctx context.Context, pd *parkingv1alpha1.ParkedDomain) error {
+	err := r.Status().Update(ctx, pd)
+	if apierrors.IsConflict(err) {
+		log.FromContext(ctx).V(1).Info("ParkedDomain changed during reconcile, dropping its stale status",
+			"resourceVersion", pd.ResourceVersion)
+	} else if err != nil {
+		log.FromContext(ctx).Error(err, "Failed to update ParkedDomain status")
+	}
+	return err
+}
+
+// statusUpdateResult ends a reconcile whose status write failed. A conflict is not
+// an error: the reconcile is simply repeated against the latest object.
+func statusUpdateResult(err error) (ctrl.Result, error) {
+	if apierrors.IsConflict(err) {
+		return ctrl.Result{RequeueAfter: statusConflictRequeueDelay}, nil
+	}
+	return ctrl.Result{}, err
+}
+
+// markStep records a successfully completed provisioning step for the current generation.
+func markStep(pd *parkingv1alpha1.ParkedDomain, condType, message string) {
+	meta.SetStatusCondition(&pd.Status.Conditions, metav1.Condition{
+		Type:               condType,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: pd.Generation,
+		Reason:             "Reconciled",
+		Message:            message,
+	})
+}
+
+// provisioningSteps returns the condition types of the steps a fully provisioned ParkedDomain
+// has completed, leaving out the steps of a disabled half.
+func provisioningSteps(pd *parkingv1alpha1.ParkedDomain) []string {
+	var steps []string
+	if dnsEnabled(pd) {
+		steps = append(steps, parkingv1alpha1.ConditionZoneReady)
+	}
+	if storageEnabled(pd) {
+		steps = append(steps, parkingv1alpha1.ConditionBucketReady)
+	}
+	if recordEnabled(pd) {
+		steps = append(steps, parkingv1alpha1.ConditionRecordReady)
+	}
+	return steps
+}
+
+// allStepsSatisfied reports whether every provisioning step already succeeded for the current generation.
+func allStepsSatisfied(pd *parkingv1alpha1.ParkedDomain) bool {
+	for _, step := range provisioningSteps(pd) {
+		if !stepSatisfied(pd, step) {
+			return false
+		}
+	}
+	return true
+}
+
+// stepSatisfied reports whether a provisioning step already succeeded for the current generation.
+func stepSatisfied(pd *parkingv1alpha1.ParkedDomain, condType string) bool {
+	cond := meta.FindStatusCondition(pd.Status.Conditions, condType)
+	return cond != nil && cond.Status == metav1.ConditionTrue && cond.ObservedGeneration == pd.Generation
+}
+
+// withDomainLogger adds the stable per-domain keys (domain, region and, once known, zoneID) to
+// base and stores the result in ctx, so helpers logging through log.FromContext carry them too.
+func withDomainLogger(ctx context.Context, base logr.Logger, pd *parkingv1alpha1.ParkedDomain) (context.Context, logr.Logger) {
+	logger := base.WithValues("domain", pd.Spec.DomainName, "region", regionFor(pd))
+	if pd.Status.ZoneID != "" {
+		logger = logger.WithValues("zoneID", pd.Status.ZoneID)
+	}
+	return log.IntoContext(ctx, logger), logger
+}
+
+// ensureManagedMetadata sets the managed-by label and the operator version annotation on pd,
+// reporting whether anything changed. Metadata already in place is left alone, so it only
+// causes an update the first time or after the operator is upgraded.
+func (r *ParkedDomainReconciler) ensureManagedMetadata(pd *parkingv1alpha1.ParkedDomain) bool {
+	changed := false
+	if r.ManagedLabelKey != "" {
+		if value, ok := pd.Labels[r.ManagedLabelKey]; !ok || value != r.ManagedLabelValue {
+			if pd.Labels == nil {
+				pd.Labels = map[string]string{}
+			}
+			pd.Labels[r.ManagedLabelKey] = r.ManagedLabelValue
+			changed = true
+		}
+	}
+	if r.OperatorVersion != "" && pd.Annotations[operatorVersionAnnotation] != r.OperatorVersion {
+		if pd.Annotations == nil {
+			pd.Annotations = map[string]string{}
+		}
+		pd.Annotations[operatorVersionAnnotation] = r.OperatorVersion
+		changed = true
+	}
+	return changed
+}
+
+// recordEvent emits an event for pd when a Recorder is configured.
+func (r *ParkedDomainReconciler) recordEvent(pd *parkingv1alpha1.ParkedDomain, eventType, reason, message string) {
+	if r.Recorder == nil {
+		return
+	}
+	r.Recorder.Event(pd, eventType, reason, message)
+}
+
+// hasLegacyFinalizer reports whether pd carries a finalizer from an older operator version.
+func hasLegacyFinalizer(pd *parkingv1alpha1.ParkedDomain) bool {
+	for _, name := range legacyFinalizerNames {
+		if controllerutil.ContainsFinalizer(pd, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// removeLegacyFinalizers strips finalizers from older operator versions and
+// reports whether any were removed.
+func removeLegacyFinalizers(pd *parkingv1alpha1.ParkedDomain) bool {
+	removed := false
+	for _, name := range legacyFinalizerNames {
+		removed = controllerutil.RemoveFinalizer(pd, name) || removed
 	}
+	return removed
+}
+
+// dnsEnabled reports whether the ParkedDomain wants its Hosted Zone, which is the default.
+func dnsEnabled(pd *parkingv1alpha1.ParkedDomain) bool {
+	return pd.Spec.DNSEnabled == nil || *pd.Spec.DNSEnabled
+}
+
+// storageEnabled reports whether the ParkedDomain wants its bucket, which is the default
+// unless the record points at Spec.AliasTarget without publishing into Spec.SharedBucket.
+func storageEnabled(pd *parkingv1alpha1.ParkedDomain) bool {
+	return (pd.Spec.AliasTarget == nil || pd.Spec.SharedBucket != nil) && (pd.Spec.StorageEnabled == nil || *pd.Spec.StorageEnabled)
+}
+
+// recordEnabled reports whether the ParkedDomain wants an alias record, pointing at its
+// bucket or at Spec.AliasTarget.
+func recordEnabled(pd *parkingv1alpha1.ParkedDomain) bool {
+	return dnsEnabled(pd) && (storageEnabled(pd) || pd.Spec.AliasTarget != nil)
+}
+
+// disableStorage deletes the bucket and the alias record pointing at it, so the record never
+// points at a bucket name someone else could claim. It returns false while the bucket is still
+// being emptied.
+func (r *ParkedDomainReconciler) disableStorage(ctx context.Context, pd *parkingv1alpha1.ParkedDomain) (bool, error) {
+	log.FromContext(ctx).Info("Storage disabled, removing the S3 bucket")
+	if dnsEnabled(pd) && pd.Status.ZoneID != "" {
+		if err := r.deleteParkedPageRecord(ctx, pd); err != nil {
+			return false, err
+		}
+	}
+	if done, err := r.cleanupS3Bucket(ctx, pd); !done || err != nil {
+		return false, err
+	}
+	pd.Status.Endpoint = ""
+	pd.Status.WebsiteURL = ""
+	pd.Status.ObjectCount = 0
+	pd.Status.TotalSizeBytes = 0
+	pd.Status.UsageUpdatedAt = nil
+	pd.Status.Locales = nil
+	pd.Status.BucketName = ""
+	pd.Status.TemplateConfigMapVersion = ""
+	for _, condType := range []string{
+		parkingv1alpha1.ConditionBucketReady,
+		parkingv1alpha1.ConditionContentReady,
+		parkingv1alpha1.ConditionRecordReady,
+		parkingv1alpha1.ConditionEndpointHealthy,
+	} {
+		meta.RemoveStatusCondition(&pd.Status.Conditions, condType)
+	}
+	return true, nil
+}
 
-	err = r.reconcileRoute53ARecord(ctx, pd, zoneID, s3Endpoint)
+// disableDNS deletes the Hosted Zone, keeping the bucket. It returns false while record
+// deletions are still being applied.
+func (r *ParkedDomainReconciler) disableDNS(ctx context.Context, pd *parkingv1alpha1.ParkedDomain) (bool, error) {
+	log.FromContext(ctx).Info("DNS disabled, removing the Route 53 Hosted Zone")
+	if err := r.cleanupDNSFirewall(ctx, pd); err != nil {
+		return false, err
+	}
+	if done, err := r.cleanupRoute53Zone(ctx, pd); !done || err != nil {
+		return false, err
+	}
+	pd.Status.ZoneID = ""
+	pd.Status.NameServers = nil
+	pd.Status.WebsiteURL = ""
+	for _, condType := range []string{
+		parkingv1alpha1.ConditionZoneReady,
+		parkingv1alpha1.ConditionRecordReady,
+		parkingv1alpha1.ConditionNameServersChanged,
+	} {
+		meta.RemoveStatusCondition(&pd.Status.Conditions, condType)
+	}
+	return true, nil
+}
+
+// placeholderDue reports whether the A record should point at Spec.PlaceholderAddress before
+// the bucket is provisioned, because the bucket has no website endpoint yet and the record
+// does not already point there for this generation.
+func placeholderDue(pd *parkingv1alpha1.ParkedDomain) bool {
+	if pd.Spec.PlaceholderAddress == "" || !recordEnabled(pd) || !storageEnabled(pd) || pd.Status.Endpoint != "" {
+		return false
+	}
+	cond := meta.FindStatusCondition(pd.Status.Conditions, parkingv1alpha1.ConditionRecordReady)
+	return cond == nil || cond.Reason != placeholderReason || cond.ObservedGeneration != pd.Generation
+}
+
+// regionFor returns the AWS region of the ParkedDomain, falling back to DefaultRegion.
+func regionFor(pd *parkingv1alpha1.ParkedDomain) string {
+	if pd.Spec.Region == "" {
+		return DefaultRegion
+	}
+	return pd.Spec.Region
+}
+
+// recordNameFor returns the host the ParkedDomain's page is served at. S3 website endpoints
+// only serve the bucket named after the requested host, so this is also the bucket name.
+func recordNameFor(pd *parkingv1alpha1.ParkedDomain) string {
+	if pd.Spec.RecordName != "" {
+		return pd.Spec.RecordName
+	}
+	return pd.Spec.DomainName
+}
+
+// bucketNameFor returns the name of the ParkedDomain's bucket: Spec.SharedBucket, or the host
+// name, unless Spec.AutoSuffixBucket created it under another name recorded in Status.BucketName.
+func bucketNameFor(pd *parkingv1alpha1.ParkedDomain) string {
+	if pd.Spec.SharedBucket != nil {
+		return pd.Spec.SharedBucket.Name
+	}
+	if pd.Status.BucketName != "" {
+		return pd.Status.BucketName
+	}
+	return recordNameFor(pd)
+}
+
+// keyPrefixFor returns the prefix of the ParkedDomain's object keys: the host name in a
+// Spec.SharedBucket, and none in a bucket of its own.
+func keyPrefixFor(pd *parkingv1alpha1.ParkedDomain) string {
+	if pd.Spec.SharedBucket != nil {
+		return recordNameFor(pd) + "/"
+	}
+	return ""
+}
+
+// r53ClientFor returns the Route 53 client for the partition of the ParkedDomain's region,
+// assuming Spec.DNSRoleARN when it is set and bounding each call by AWSCallTimeout.
+func (r *ParkedDomainReconciler) r53ClientFor(ctx context.Context, pd *parkingv1alpha1.ParkedDomain) (R53ClientAPI, error) {
+	partition, err := partitionForRegion(regionFor(pd))
 	if err != nil {
-		pd.Status.Status = "Error: Route53 A Record"
-		_ = r.Status().Update(ctx, pd)
-		return ctrl.Result{}, err
+		return nil, err
+	}
+	if err := r.checkRole(pd.Spec.DNSRoleARN); err != nil {
+		return nil, err
+	}
+	r53Client := r.R53Client
+	if partition != partitionAWS || pd.Spec.DNSRoleARN != "" {
+		if r.R53ClientFactory == nil {
+			return nil, fmt.Errorf("no Route 53 client configured for partition %s", partition.ID)
+		}
+		if r53Client, err = r.R53ClientFactory.GetClient(ctx, partition.Route53Region, pd.Spec.DNSRoleARN); err != nil {
+			return nil, err
+		}
 	}
+	if r.AWSCallTimeout > 0 {
+		return &timeoutR53Client{client: r53Client, timeout: r.AWSCallTimeout}, nil
+	}
+	return r53Client, nil
+}
 
-	// 4. Update the Status of the CR
-	pd.Status.Status = "Provisioned"
-	pd.Status.ZoneID = zoneID
-	pd.Status.NameServers = nameservers
-	if err := r.Status().Update(ctx, pd); err != nil {
-		logger.Error(err, "Failed to update ParkedDomain status")
-		return ctrl.Result{}, err
+// storageEndpointFor returns the URL of the S3-compatible service hosting the ParkedDomain's
+// bucket, or "" for AWS S3.
+func (r *ParkedDomainReconciler) storageEndpointFor(pd *parkingv1alpha1.ParkedDomain) string {
+	if pd.Spec.StorageEndpoint != "" {
+		return pd.Spec.StorageEndpoint
 	}
+	return r.S3Endpoint
+}
 
-	logger.Info("Successfully reconciled ParkedDomain")
-	return ctrl.Result{}, nil
+// s3ClientFor returns the S3 client for the ParkedDomain's region and storage endpoint,
+// assuming Spec.StorageRoleARN when it is set and bounding each call by AWSCallTimeout.
+func (r *ParkedDomainReconciler) s3ClientFor(ctx context.Context, pd *parkingv1alpha1.ParkedDomain) (S3ClientAPI, error) {
+	if err := r.checkRole(pd.Spec.StorageRoleARN); err != nil {
+		return nil, err
+	}
+	s3Client, err := r.S3ClientFactory.GetClient(ctx, regionFor(pd), pd.Spec.StorageRoleARN, r.storageEndpointFor(pd))
+	if err != nil || r.AWSCallTimeout <= 0 {
+		return s3Client, err
+	}
+	return &timeoutS3Client{client: s3Client, timeout: r.AWSCallTimeout}, nil
+}
+
+// cloudWatchClientFor returns the CloudWatch client for the bucket's region, assuming
+// Spec.StorageRoleARN when it is set, as S3 publishes the bucket's metrics in its account.
+func (r *ParkedDomainReconciler) cloudWatchClientFor(ctx context.Context, pd *parkingv1alpha1.ParkedDomain) (CloudWatchClientAPI, error) {
+	if r.CloudWatchClientFactory == nil {
+		return nil, errors.New("no CloudWatch client configured")
+	}
+	if err := r.checkRole(pd.Spec.StorageRoleARN); err != nil {
+		return nil, err
+	}
+	cwClient, err := r.CloudWatchClientFactory.GetClient(ctx, regionFor(pd), pd.Spec.StorageRoleARN)
+	if err != nil || r.AWSCallTimeout <= 0 {
+		return cwClient, err
+	}
+	return &timeoutCloudWatchClient{client: cwClient, timeout: r.AWSCallTimeout}, nil
+}
+
+// dnsFirewallClientFor returns the Route 53 Resolver client for the region of the private
+// zone's VPC, assuming Spec.DNSRoleARN when it is set.
+func (r *ParkedDomainReconciler) dnsFirewallClientFor(ctx context.Context, pd *parkingv1alpha1.ParkedDomain) (DNSFirewallClientAPI, error) {
+	if r.DNSFirewallClientFactory == nil {
+		return nil, errors.New("no DNS Firewall client configured")
+	}
+	if err := r.checkRole(pd.Spec.DNSRoleARN); err != nil {
+		return nil, err
+	}
+	region := regionFor(pd)
+	if pd.Spec.PrivateZone != nil && pd.Spec.PrivateZone.VPCRegion != "" {
+		region = pd.Spec.PrivateZone.VPCRegion
+	}
+	fwClient, err := r.DNSFirewallClientFactory.GetClient(ctx, region, pd.Spec.DNSRoleARN)
+	if err != nil || r.AWSCallTimeout <= 0 {
+		return fwClient, err
+	}
+	return &timeoutDNSFirewallClient{client: fwClient, timeout: r.AWSCallTimeout}, nil
 }
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *ParkedDomainReconciler) SetupWithManager(mgr ctrl.Manager) error {
-	return ctrl.NewControllerManagedBy(mgr).
-		For(&parkingv1alpha1.ParkedDomain{}).
-		Complete(r)
+	var opts controller.Options
+	if r.RequeueJitter > 0 {
+		opts.RateLimiter = &jitteredRateLimiter{
+			TypedRateLimiter: workqueue.DefaultTypedControllerRateLimiter[reconcile.Request](),
+			maxFactor:        r.RequeueJitter,
+		}
+	}
+	b := ctrl.NewControllerManagedBy(mgr).
+		For(&parkingv1alpha1.ParkedDomain{}, builder.WithPredicates(r.watchedNamespacePredicate())).
+		Watches(&corev1.ConfigMap{}, handler.EnqueueRequestsFromMapFunc(r.domainsForTemplateConfigMap),
+			builder.WithPredicates(templateConfigMapPredicate())).
+		WithOptions(opts)
+	if r.ResyncOnStart {
+		events := make(chan event.GenericEvent)
+		b = b.WatchesRawSource(source.Channel(events, &handler.EnqueueRequestForObject{}))
+		if err := mgr.Add(&startupResync{
+			client:     mgr.GetClient(),
+			namespaces: r.WatchNamespaces,
+			interval:   r.ResyncInterval,
+			pending:    &r.resync,
+			events:     events,
+		}); err != nil {
+			return err
+		}
+	}
+	return b.Complete(r)
+}
+
+// watchedNamespacePredicate drops events for ParkedDomains outside WatchNamespaces, in case
+// the manager's cache sees more namespaces than the operator is meant to act in.
+func (r *ParkedDomainReconciler) watchedNamespacePredicate() predicate.Predicate {
+	return predicate.NewPredicateFuncs(func(obj client.Object) bool {
+		return len(r.WatchNamespaces) == 0 || slices.Contains(r.WatchNamespaces, obj.GetNamespace())
+	})
 }
@@ -2,7 +2,13 @@ package controller
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"os"
+	"slices"
+	"sort"
+	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
@@ -10,11 +16,21 @@ import (
 	r53types "github.com/aws/aws-sdk-go-v2/service/route53/types"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
 	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/aws/smithy-go"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	parkingv1alpha1 "github.com/gminiba/parked-domain-operator/api/v1alpha1"
 )
@@ -23,8 +39,32 @@ import (
 
 // MockS3Client simulates the S3 client for tests.
 type MockS3Client struct {
-	HeadBucketFunc   func(ctx context.Context, params *s3.HeadBucketInput, optFns ...func(*s3.Options)) (*s3.HeadBucketOutput, error)
-	DeleteBucketFunc func(ctx context.Context, params *s3.DeleteBucketInput, optFns ...func(*s3.Options)) (*s3.DeleteBucketOutput, error)
+	HeadBucketFunc           func(ctx context.Context, params *s3.HeadBucketInput, optFns ...func(*s3.Options)) (*s3.HeadBucketOutput, error)
+	CreateBucketFunc         func(ctx context.Context, params *s3.CreateBucketInput, optFns ...func(*s3.Options)) (*s3.CreateBucketOutput, error)
+	DeleteBucketFunc         func(ctx context.Context, params *s3.DeleteBucketInput, optFns ...func(*s3.Options)) (*s3.DeleteBucketOutput, error)
+	PutBucketWebsiteFunc     func(ctx context.Context, params *s3.PutBucketWebsiteInput, optFns ...func(*s3.Options)) (*s3.PutBucketWebsiteOutput, error)
+	GetPublicAccessBlockFunc func(ctx context.Context, params *s3.GetPublicAccessBlockInput, optFns ...func(*s3.Options)) (*s3.GetPublicAccessBlockOutput, error)
+	GetBucketWebsiteFunc     func(ctx context.Context, params *s3.GetBucketWebsiteInput, optFns ...func(*s3.Options)) (*s3.GetBucketWebsiteOutput, error)
+	PutObjectFunc            func(ctx context.Context, params *s3.PutObjectInput, optFns ...func(*s3.Options)) (*s3.PutObjectOutput, error)
+	GetObjectFunc            func(ctx context.Context, params *s3.GetObjectInput, optFns ...func(*s3.Options)) (*s3.GetObjectOutput, error)
+
+	PutBucketLifecycleConfigurationFunc  func(ctx context.Context, params *s3.PutBucketLifecycleConfigurationInput, optFns ...func(*s3.Options)) (*s3.PutBucketLifecycleConfigurationOutput, error)
+	DeleteBucketLifecycleFunc            func(ctx context.Context, params *s3.DeleteBucketLifecycleInput, optFns ...func(*s3.Options)) (*s3.DeleteBucketLifecycleOutput, error)
+	PutBucketOwnershipControlsFunc       func(ctx context.Context, params *s3.PutBucketOwnershipControlsInput, optFns ...func(*s3.Options)) (*s3.PutBucketOwnershipControlsOutput, error)
+	GetBucketOwnershipControlsFunc       func(ctx context.Context, params *s3.GetBucketOwnershipControlsInput, optFns ...func(*s3.Options)) (*s3.GetBucketOwnershipControlsOutput, error)
+	PutBucketAccelerateConfigurationFunc func(ctx context.Context, params *s3.PutBucketAccelerateConfigurationInput, optFns ...func(*s3.Options)) (*s3.PutBucketAccelerateConfigurationOutput, error)
+	PutBucketRequestPaymentFunc          func(ctx context.Context, params *s3.PutBucketRequestPaymentInput, optFns ...func(*s3.Options)) (*s3.PutBucketRequestPaymentOutput, error)
+	PutBucketPolicyFunc                  func(ctx context.Context, params *s3.PutBucketPolicyInput, optFns ...func(*s3.Options)) (*s3.PutBucketPolicyOutput, error)
+	ListObjectsV2Func                    func(ctx context.Context, params *s3.ListObjectsV2Input, optFns ...func(*s3.Options)) (*s3.ListObjectsV2Output, error)
+	DeleteObjectsFunc                    func(ctx context.Context, params *s3.DeleteObjectsInput, optFns ...func(*s3.Options)) (*s3.DeleteObjectsOutput, error)
+	ListObjectVersionsFunc               func(ctx context.Context, params *s3.ListObjectVersionsInput, optFns ...func(*s3.Options)) (*s3.ListObjectVersionsOutput, error)
+	PutObjectLockConfigurationFunc       func(ctx context.Context, params *s3.PutObjectLockConfigurationInput, optFns ...func(*s3.Options)) (*s3.PutObjectLockConfigurationOutput, error)
+	PutBucketLoggingFunc                 func(ctx context.Context, params *s3.PutBucketLoggingInput, optFns ...func(*s3.Options)) (*s3.PutBucketLoggingOutput, error)
+	GetBucketTaggingFunc                 func(ctx context.Context, params *s3.GetBucketTaggingInput, optFns ...func(*s3.Options)) (*s3.GetBucketTaggingOutput, error)
+	PutBucketTaggingFunc                 func(ctx context.Context, params *s3.PutBucketTaggingInput, optFns ...func(*s3.Options)) (*s3.PutBucketTaggingOutput, error)
+	DeleteBucketTaggingFunc              func(ctx context.Context, params *s3.DeleteBucketTaggingInput, optFns ...func(*s3.Options)) (*s3.DeleteBucketTaggingOutput, error)
+	PutBucketMetricsConfigurationFunc    func(ctx context.Context, params *s3.PutBucketMetricsConfigurationInput, optFns ...func(*s3.Options)) (*s3.PutBucketMetricsConfigurationOutput, error)
+	DeleteBucketMetricsConfigurationFunc func(ctx context.Context, params *s3.DeleteBucketMetricsConfigurationInput, optFns ...func(*s3.Options)) (*s3.DeleteBucketMetricsConfigurationOutput, error)
 	// Add other functions as needed, returning nil or empty structs
 }
 
@@ -37,15 +77,45 @@ func (m *MockS3Client) HeadBucket(ctx context.Context, params *s3.HeadBucketInpu
 }
 
 func (m *MockS3Client) CreateBucket(ctx context.Context, params *s3.CreateBucketInput, optFns ...func(*s3.Options)) (*s3.CreateBucketOutput, error) {
+	if m.CreateBucketFunc != nil {
+		return m.CreateBucketFunc(ctx, params, optFns...)
+	}
 	return &s3.CreateBucketOutput{}, nil
 }
 func (m *MockS3Client) PutObject(ctx context.Context, params *s3.PutObjectInput, optFns ...func(*s3.Options)) (*s3.PutObjectOutput, error) {
+	if m.PutObjectFunc != nil {
+		return m.PutObjectFunc(ctx, params, optFns...)
+	}
 	return &s3.PutObjectOutput{}, nil
 }
+func (m *MockS3Client) GetObject(ctx context.Context, params *s3.GetObjectInput, optFns ...func(*s3.Options)) (*s3.GetObjectOutput, error) {
+	if m.GetObjectFunc != nil {
+		return m.GetObjectFunc(ctx, params, optFns...)
+	}
+	return nil, &s3types.NoSuchKey{}
+}
 func (m *MockS3Client) PutBucketWebsite(ctx context.Context, params *s3.PutBucketWebsiteInput, optFns ...func(*s3.Options)) (*s3.PutBucketWebsiteOutput, error) {
+	if m.PutBucketWebsiteFunc != nil {
+		return m.PutBucketWebsiteFunc(ctx, params, optFns...)
+	}
 	return &s3.PutBucketWebsiteOutput{}, nil
 }
+func (m *MockS3Client) GetPublicAccessBlock(ctx context.Context, params *s3.GetPublicAccessBlockInput, optFns ...func(*s3.Options)) (*s3.GetPublicAccessBlockOutput, error) {
+	if m.GetPublicAccessBlockFunc != nil {
+		return m.GetPublicAccessBlockFunc(ctx, params, optFns...)
+	}
+	return &s3.GetPublicAccessBlockOutput{}, nil
+}
+func (m *MockS3Client) GetBucketWebsite(ctx context.Context, params *s3.GetBucketWebsiteInput, optFns ...func(*s3.Options)) (*s3.GetBucketWebsiteOutput, error) {
+	if m.GetBucketWebsiteFunc != nil {
+		return m.GetBucketWebsiteFunc(ctx, params, optFns...)
+	}
+	return &s3.GetBucketWebsiteOutput{IndexDocument: &s3types.IndexDocument{Suffix: aws.String(indexDocument)}}, nil
+}
 func (m *MockS3Client) PutBucketPolicy(ctx context.Context, params *s3.PutBucketPolicyInput, optFns ...func(*s3.Options)) (*s3.PutBucketPolicyOutput, error) {
+	if m.PutBucketPolicyFunc != nil {
+		return m.PutBucketPolicyFunc(ctx, params, optFns...)
+	}
 	return &s3.PutBucketPolicyOutput{}, nil
 }
 func (m *MockS3Client) DeleteBucket(ctx context.Context, params *s3.DeleteBucketInput, optFns ...func(*s3.Options)) (*s3.DeleteBucketOutput, error) {
@@ -55,15 +125,126 @@ func (m *MockS3Client) DeleteBucket(ctx context.Context, params *s3.DeleteBucket
 	return &s3.DeleteBucketOutput{}, nil
 }
 func (m *MockS3Client) ListObjectsV2(ctx context.Context, params *s3.ListObjectsV2Input, optFns ...func(*s3.Options)) (*s3.ListObjectsV2Output, error) {
+	if m.ListObjectsV2Func != nil {
+		return m.ListObjectsV2Func(ctx, params, optFns...)
+	}
 	return &s3.ListObjectsV2Output{Contents: []s3types.Object{}}, nil
 }
 func (m *MockS3Client) DeleteObjects(ctx context.Context, params *s3.DeleteObjectsInput, optFns ...func(*s3.Options)) (*s3.DeleteObjectsOutput, error) {
+	if m.DeleteObjectsFunc != nil {
+		return m.DeleteObjectsFunc(ctx, params, optFns...)
+	}
 	return &s3.DeleteObjectsOutput{}, nil
 }
+func (m *MockS3Client) ListObjectVersions(ctx context.Context, params *s3.ListObjectVersionsInput, optFns ...func(*s3.Options)) (*s3.ListObjectVersionsOutput, error) {
+	if m.ListObjectVersionsFunc != nil {
+		return m.ListObjectVersionsFunc(ctx, params, optFns...)
+	}
+	return &s3.ListObjectVersionsOutput{}, nil
+}
+func (m *MockS3Client) PutObjectLockConfiguration(ctx context.Context, params *s3.PutObjectLockConfigurationInput, optFns ...func(*s3.Options)) (*s3.PutObjectLockConfigurationOutput, error) {
+	if m.PutObjectLockConfigurationFunc != nil {
+		return m.PutObjectLockConfigurationFunc(ctx, params, optFns...)
+	}
+	return &s3.PutObjectLockConfigurationOutput{}, nil
+}
+func (m *MockS3Client) PutBucketLifecycleConfiguration(ctx context.Context, params *s3.PutBucketLifecycleConfigurationInput, optFns ...func(*s3.Options)) (*s3.PutBucketLifecycleConfigurationOutput, error) {
+	if m.PutBucketLifecycleConfigurationFunc != nil {
+		return m.PutBucketLifecycleConfigurationFunc(ctx, params, optFns...)
+	}
+	return &s3.PutBucketLifecycleConfigurationOutput{}, nil
+}
+func (m *MockS3Client) DeleteBucketLifecycle(ctx context.Context, params *s3.DeleteBucketLifecycleInput, optFns ...func(*s3.Options)) (*s3.DeleteBucketLifecycleOutput, error) {
+	if m.DeleteBucketLifecycleFunc != nil {
+		return m.DeleteBucketLifecycleFunc(ctx, params, optFns...)
+	}
+	return &s3.DeleteBucketLifecycleOutput{}, nil
+}
+func (m *MockS3Client) PutBucketOwnershipControls(ctx context.Context, params *s3.PutBucketOwnershipControlsInput, optFns ...func(*s3.Options)) (*s3.PutBucketOwnershipControlsOutput, error) {
+	if m.PutBucketOwnershipControlsFunc != nil {
+		return m.PutBucketOwnershipControlsFunc(ctx, params, optFns...)
+	}
+	return &s3.PutBucketOwnershipControlsOutput{}, nil
+}
+func (m *MockS3Client) GetBucketOwnershipControls(ctx context.Context, params *s3.GetBucketOwnershipControlsInput, optFns ...func(*s3.Options)) (*s3.GetBucketOwnershipControlsOutput, error) {
+	if m.GetBucketOwnershipControlsFunc != nil {
+		return m.GetBucketOwnershipControlsFunc(ctx, params, optFns...)
+	}
+	return &s3.GetBucketOwnershipControlsOutput{}, nil
+}
+func (m *MockS3Client) PutBucketAccelerateConfiguration(ctx context.Context, params *s3.PutBucketAccelerateConfigurationInput, optFns ...func(*s3.Options)) (*s3.PutBucketAccelerateConfigurationOutput, error) {
+	if m.PutBucketAccelerateConfigurationFunc != nil {
+		return m.PutBucketAccelerateConfigurationFunc(ctx, params, optFns...)
+	}
+	return &s3.PutBucketAccelerateConfigurationOutput{}, nil
+}
+func (m *MockS3Client) PutBucketRequestPayment(ctx context.Context, params *s3.PutBucketRequestPaymentInput, optFns ...func(*s3.Options)) (*s3.PutBucketRequestPaymentOutput, error) {
+	if m.PutBucketRequestPaymentFunc != nil {
+		return m.PutBucketRequestPaymentFunc(ctx, params, optFns...)
+	}
+	return &s3.PutBucketRequestPaymentOutput{}, nil
+}
+func (m *MockS3Client) PutBucketLogging(ctx context.Context, params *s3.PutBucketLoggingInput, optFns ...func(*s3.Options)) (*s3.PutBucketLoggingOutput, error) {
+	if m.PutBucketLoggingFunc != nil {
+		return m.PutBucketLoggingFunc(ctx, params, optFns...)
+	}
+	return &s3.PutBucketLoggingOutput{}, nil
+}
+
+func (m *MockS3Client) GetBucketTagging(ctx context.Context, params *s3.GetBucketTaggingInput, optFns ...func(*s3.Options)) (*s3.GetBucketTaggingOutput, error) {
+	if m.GetBucketTaggingFunc != nil {
+		return m.GetBucketTaggingFunc(ctx, params, optFns...)
+	}
+	return nil, &smithy.GenericAPIError{Code: "NoSuchTagSet"}
+}
+
+func (m *MockS3Client) PutBucketTagging(ctx context.Context, params *s3.PutBucketTaggingInput, optFns ...func(*s3.Options)) (*s3.PutBucketTaggingOutput, error) {
+	if m.PutBucketTaggingFunc != nil {
+		return m.PutBucketTaggingFunc(ctx, params, optFns...)
+	}
+	return &s3.PutBucketTaggingOutput{}, nil
+}
+
+func (m *MockS3Client) DeleteBucketTagging(ctx context.Context, params *s3.DeleteBucketTaggingInput, optFns ...func(*s3.Options)) (*s3.DeleteBucketTaggingOutput, error) {
+	if m.DeleteBucketTaggingFunc != nil {
+		return m.DeleteBucketTaggingFunc(ctx, params, optFns...)
+	}
+	return &s3.DeleteBucketTaggingOutput{}, nil
+}
+
+func (m *MockS3Client) PutBucketMetricsConfiguration(ctx context.Context, params *s3.PutBucketMetricsConfigurationInput, optFns ...func(*s3.Options)) (*s3.PutBucketMetricsConfigurationOutput, error) {
+	if m.PutBucketMetricsConfigurationFunc != nil {
+		return m.PutBucketMetricsConfigurationFunc(ctx, params, optFns...)
+	}
+	return &s3.PutBucketMetricsConfigurationOutput{}, nil
+}
+
+func (m *MockS3Client) DeleteBucketMetricsConfiguration(ctx context.Context, params *s3.DeleteBucketMetricsConfigurationInput, optFns ...func(*s3.Options)) (*s3.DeleteBucketMetricsConfigurationOutput, error) {
+	if m.DeleteBucketMetricsConfigurationFunc != nil {
+		return m.DeleteBucketMetricsConfigurationFunc(ctx, params, optFns...)
+	}
+	return &s3.DeleteBucketMetricsConfigurationOutput{}, nil
+}
 
 // MockR53Client simulates the Route53 client for tests.
 type MockR53Client struct {
-	CreateHostedZoneFunc func(ctx context.Context, params *route53.CreateHostedZoneInput, optFns ...func(*route53.Options)) (*route53.CreateHostedZoneOutput, error)
+	CreateHostedZoneFunc         func(ctx context.Context, params *route53.CreateHostedZoneInput, optFns ...func(*route53.Options)) (*route53.CreateHostedZoneOutput, error)
+	ChangeResourceRecordSetsFunc func(ctx context.Context, params *route53.ChangeResourceRecordSetsInput, optFns ...func(*route53.Options)) (*route53.ChangeResourceRecordSetsOutput, error)
+	ListHostedZonesByNameFunc    func(ctx context.Context, params *route53.ListHostedZonesByNameInput, optFns ...func(*route53.Options)) (*route53.ListHostedZonesByNameOutput, error)
+	ListResourceRecordSetsFunc   func(ctx context.Context, params *route53.ListResourceRecordSetsInput, optFns ...func(*route53.Options)) (*route53.ListResourceRecordSetsOutput, error)
+	DeleteHostedZoneFunc         func(ctx context.Context, params *route53.DeleteHostedZoneInput, optFns ...func(*route53.Options)) (*route53.DeleteHostedZoneOutput, error)
+	GetHostedZoneFunc            func(ctx context.Context, params *route53.GetHostedZoneInput, optFns ...func(*route53.Options)) (*route53.GetHostedZoneOutput, error)
+	GetChangeFunc                func(ctx context.Context, params *route53.GetChangeInput, optFns ...func(*route53.Options)) (*route53.GetChangeOutput, error)
+	GetReusableDelegationSetFunc func(ctx context.Context, params *route53.GetReusableDelegationSetInput, optFns ...func(*route53.Options)) (*route53.GetReusableDelegationSetOutput, error)
+	CreateQueryLoggingConfigFunc func(ctx context.Context, params *route53.CreateQueryLoggingConfigInput, optFns ...func(*route53.Options)) (*route53.CreateQueryLoggingConfigOutput, error)
+	GetQueryLoggingConfigFunc    func(ctx context.Context, params *route53.GetQueryLoggingConfigInput, optFns ...func(*route53.Options)) (*route53.GetQueryLoggingConfigOutput, error)
+	ListQueryLoggingConfigsFunc  func(ctx context.Context, params *route53.ListQueryLoggingConfigsInput, optFns ...func(*route53.Options)) (*route53.ListQueryLoggingConfigsOutput, error)
+	DeleteQueryLoggingConfigFunc func(ctx context.Context, params *route53.DeleteQueryLoggingConfigInput, optFns ...func(*route53.Options)) (*route53.DeleteQueryLoggingConfigOutput, error)
+	ListTagsForResourceFunc      func(ctx context.Context, params *route53.ListTagsForResourceInput, optFns ...func(*route53.Options)) (*route53.ListTagsForResourceOutput, error)
+	ChangeTagsForResourceFunc    func(ctx context.Context, params *route53.ChangeTagsForResourceInput, optFns ...func(*route53.Options)) (*route53.ChangeTagsForResourceOutput, error)
+
+	AssociateVPCWithHostedZoneFunc    func(ctx context.Context, params *route53.AssociateVPCWithHostedZoneInput, optFns ...func(*route53.Options)) (*route53.AssociateVPCWithHostedZoneOutput, error)
+	DisassociateVPCFromHostedZoneFunc func(ctx context.Context, params *route53.DisassociateVPCFromHostedZoneInput, optFns ...func(*route53.Options)) (*route53.DisassociateVPCFromHostedZoneOutput, error)
 	// Add other functions as needed
 }
 
@@ -78,9 +259,15 @@ func (m *MockR53Client) CreateHostedZone(ctx context.Context, params *route53.Cr
 	}, nil
 }
 func (m *MockR53Client) ChangeResourceRecordSets(ctx context.Context, params *route53.ChangeResourceRecordSetsInput, optFns ...func(*route53.Options)) (*route53.ChangeResourceRecordSetsOutput, error) {
+	if m.ChangeResourceRecordSetsFunc != nil {
+		return m.ChangeResourceRecordSetsFunc(ctx, params, optFns...)
+	}
 	return &route53.ChangeResourceRecordSetsOutput{}, nil
 }
 func (m *MockR53Client) ListResourceRecordSets(ctx context.Context, params *route53.ListResourceRecordSetsInput, optFns ...func(*route53.Options)) (*route53.ListResourceRecordSetsOutput, error) {
+	if m.ListResourceRecordSetsFunc != nil {
+		return m.ListResourceRecordSetsFunc(ctx, params, optFns...)
+	}
 	// Return a list that only contains default records to simulate an "empty" zone
 	return &route53.ListResourceRecordSetsOutput{ResourceRecordSets: []r53types.ResourceRecordSet{
 		{Type: "NS"},
@@ -88,10 +275,16 @@ func (m *MockR53Client) ListResourceRecordSets(ctx context.Context, params *rout
 	}}, nil
 }
 func (m *MockR53Client) DeleteHostedZone(ctx context.Context, params *route53.DeleteHostedZoneInput, optFns ...func(*route53.Options)) (*route53.DeleteHostedZoneOutput, error) {
+	if m.DeleteHostedZoneFunc != nil {
+		return m.DeleteHostedZoneFunc(ctx, params, optFns...)
+	}
 	return &route53.DeleteHostedZoneOutput{}, nil
 }
 
 func (m *MockR53Client) ListHostedZonesByName(ctx context.Context, params *route53.ListHostedZonesByNameInput, optFns ...func(*route53.Options)) (*route53.ListHostedZonesByNameOutput, error) {
+	if m.ListHostedZonesByNameFunc != nil {
+		return m.ListHostedZonesByNameFunc(ctx, params, optFns...)
+	}
 	// Simulate no zone being found initially
 	return &route53.ListHostedZonesByNameOutput{
 		HostedZones: []r53types.HostedZone{},
@@ -99,13 +292,106 @@ func (m *MockR53Client) ListHostedZonesByName(ctx context.Context, params *route
 }
 
 func (m *MockR53Client) GetHostedZone(ctx context.Context, params *route53.GetHostedZoneInput, optFns ...func(*route53.Options)) (*route53.GetHostedZoneOutput, error) {
-	// This would be used if you were testing the "adopt" logic
+	if m.GetHostedZoneFunc != nil {
+		return m.GetHostedZoneFunc(ctx, params, optFns...)
+	}
+	// Default behavior: report a zone created by the operator, with the delegation set the default CreateHostedZone returns
 	return &route53.GetHostedZoneOutput{
-		HostedZone:    &r53types.HostedZone{Id: params.Id},
-		DelegationSet: &r53types.DelegationSet{NameServers: []string{"ns-1.awsdns.com"}},
+		HostedZone:    &r53types.HostedZone{Id: params.Id, Config: &r53types.HostedZoneConfig{Comment: aws.String(managedComment)}},
+		DelegationSet: &r53types.DelegationSet{NameServers: []string{"ns-1.awsdns.com", "ns-2.awsdns.com"}},
 	}, nil
 }
 
+func (m *MockR53Client) GetChange(ctx context.Context, params *route53.GetChangeInput, optFns ...func(*route53.Options)) (*route53.GetChangeOutput, error) {
+	if m.GetChangeFunc != nil {
+		return m.GetChangeFunc(ctx, params, optFns...)
+	}
+	return &route53.GetChangeOutput{ChangeInfo: &r53types.ChangeInfo{Id: params.Id, Status: r53types.ChangeStatusInsync}}, nil
+}
+
+func (m *MockR53Client) GetReusableDelegationSet(ctx context.Context, params *route53.GetReusableDelegationSetInput, optFns ...func(*route53.Options)) (*route53.GetReusableDelegationSetOutput, error) {
+	if m.GetReusableDelegationSetFunc != nil {
+		return m.GetReusableDelegationSetFunc(ctx, params, optFns...)
+	}
+	return &route53.GetReusableDelegationSetOutput{DelegationSet: &r53types.DelegationSet{Id: params.Id}}, nil
+}
+
+func (m *MockR53Client) CreateQueryLoggingConfig(ctx context.Context, params *route53.CreateQueryLoggingConfigInput, optFns ...func(*route53.Options)) (*route53.CreateQueryLoggingConfigOutput, error) {
+	if m.CreateQueryLoggingConfigFunc != nil {
+		return m.CreateQueryLoggingConfigFunc(ctx, params, optFns...)
+	}
+	return &route53.CreateQueryLoggingConfigOutput{QueryLoggingConfig: &r53types.QueryLoggingConfig{
+		Id:                        aws.String("QLC-MOCK"),
+		HostedZoneId:              params.HostedZoneId,
+		CloudWatchLogsLogGroupArn: params.CloudWatchLogsLogGroupArn,
+	}}, nil
+}
+
+func (m *MockR53Client) GetQueryLoggingConfig(ctx context.Context, params *route53.GetQueryLoggingConfigInput, optFns ...func(*route53.Options)) (*route53.GetQueryLoggingConfigOutput, error) {
+	if m.GetQueryLoggingConfigFunc != nil {
+		return m.GetQueryLoggingConfigFunc(ctx, params, optFns...)
+	}
+	return nil, &r53types.NoSuchQueryLoggingConfig{}
+}
+
+func (m *MockR53Client) ListQueryLoggingConfigs(ctx context.Context, params *route53.ListQueryLoggingConfigsInput, optFns ...func(*route53.Options)) (*route53.ListQueryLoggingConfigsOutput, error) {
+	if m.ListQueryLoggingConfigsFunc != nil {
+		return m.ListQueryLoggingConfigsFunc(ctx, params, optFns...)
+	}
+	return &route53.ListQueryLoggingConfigsOutput{}, nil
+}
+
+func (m *MockR53Client) DeleteQueryLoggingConfig(ctx context.Context, params *route53.DeleteQueryLoggingConfigInput, optFns ...func(*route53.Options)) (*route53.DeleteQueryLoggingConfigOutput, error) {
+	if m.DeleteQueryLoggingConfigFunc != nil {
+		return m.DeleteQueryLoggingConfigFunc(ctx, params, optFns...)
+	}
+	return &route53.DeleteQueryLoggingConfigOutput{}, nil
+}
+
+func (m *MockR53Client) ListTagsForResource(ctx context.Context, params *route53.ListTagsForResourceInput, optFns ...func(*route53.Options)) (*route53.ListTagsForResourceOutput, error) {
+	if m.ListTagsForResourceFunc != nil {
+		return m.ListTagsForResourceFunc(ctx, params, optFns...)
+	}
+	return &route53.ListTagsForResourceOutput{ResourceTagSet: &r53types.ResourceTagSet{}}, nil
+}
+
+func (m *MockR53Client) ChangeTagsForResource(ctx context.Context, params *route53.ChangeTagsForResourceInput, optFns ...func(*route53.Options)) (*route53.ChangeTagsForResourceOutput, error) {
+	if m.ChangeTagsForResourceFunc != nil {
+		return m.ChangeTagsForResourceFunc(ctx, params, optFns...)
+	}
+	return &route53.ChangeTagsForResourceOutput{}, nil
+}
+
+func (m *MockR53Client) AssociateVPCWithHostedZone(ctx context.Context, params *route53.AssociateVPCWithHostedZoneInput, optFns ...func(*route53.Options)) (*route53.AssociateVPCWithHostedZoneOutput, error) {
+	if m.AssociateVPCWithHostedZoneFunc != nil {
+		return m.AssociateVPCWithHostedZoneFunc(ctx, params, optFns...)
+	}
+	return &route53.AssociateVPCWithHostedZoneOutput{}, nil
+}
+
+func (m *MockR53Client) DisassociateVPCFromHostedZone(ctx context.Context, params *route53.DisassociateVPCFromHostedZoneInput, optFns ...func(*route53.Options)) (*route53.DisassociateVPCFromHostedZoneOutput, error) {
+	if m.DisassociateVPCFromHostedZoneFunc != nil {
+		return m.DisassociateVPCFromHostedZoneFunc(ctx, params, optFns...)
+	}
+	return &route53.DisassociateVPCFromHostedZoneOutput{}, nil
+}
+
+// newTestReconciler returns a reconciler backed by a fake client seeded with objs,
+// so individual Reconcile calls can be driven and inspected synchronously.
+func newTestReconciler(r53 *MockR53Client, s3Client *MockS3Client, objs ...client.Object) *ParkedDomainReconciler {
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(scheme.Scheme).
+		WithObjects(objs...).
+		WithStatusSubresource(&parkingv1alpha1.ParkedDomain{}).
+		Build()
+	return &ParkedDomainReconciler{
+		Client:          fakeClient,
+		Scheme:          scheme.Scheme,
+		R53Client:       r53,
+		S3ClientFactory: &MockS3ClientFactory{MockS3: s3Client},
+	}
+}
+
 // --- Test Suite ---
 
 var _ = Describe("ParkedDomain Controller", func() {
@@ -180,8 +466,11 @@ var _ = Describe("ParkedDomain Controller", func() {
 			}, Timeout, Interval).Should(Equal("Provisioned"))
 
 			// Check that the status fields were populated correctly by the mock
+			Expect(createdParkedDomain.Status.Ready).To(BeTrue())
 			Expect(createdParkedDomain.Status.ZoneID).To(Equal("MOCKZONEID123"))
 			Expect(createdParkedDomain.Status.NameServers).To(ContainElement("ns-1.awsdns.com"))
+			Expect(createdParkedDomain.Status.Endpoint).To(Equal("test.example.com.s3-website.eu-central-1.amazonaws.com"))
+			Expect(createdParkedDomain.Status.WebsiteURL).To(Equal("http://test.example.com"))
 
 			// --- Trigger and Assert Deletion ---
 			By("deleting the custom resource for the Kind ParkedDomain")
@@ -194,3 +483,1560 @@ var _ = Describe("ParkedDomain Controller", func() {
 		})
 	})
 })
+
+var _ = Describe("ParkedDomain partial-failure recovery", func() {
+	const (
+		recoveryName   = "recovery-domain"
+		recoveryDomain = "recovery.example.com"
+	)
+	var (
+		pd         *parkingv1alpha1.ParkedDomain
+		templateCM *corev1.ConfigMap
+		req        ctrl.Request
+	)
+
+	BeforeEach(func() {
+		Expect(os.Setenv("TEMPLATE_CONFIGMAP_NAME", "parked-domain-templates")).To(Succeed())
+		pd = &parkingv1alpha1.ParkedDomain{
+			ObjectMeta: metav1.ObjectMeta{Name: recoveryName, Namespace: "default"},
+			Spec:       parkingv1alpha1.ParkedDomainSpec{DomainName: recoveryDomain},
+		}
+		templateCM = &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "parked-domain-templates", Namespace: "default"},
+			Data:       map[string]string{"default.html": "<h1>{{DOMAIN_NAME}}</h1>"},
+		}
+		req = ctrl.Request{NamespacedName: types.NamespacedName{Name: recoveryName, Namespace: "default"}}
+	})
+
+	AfterEach(func() {
+		Expect(os.Unsetenv("TEMPLATE_CONFIGMAP_NAME")).To(Succeed())
+	})
+
+	It("should not look up the zone again after an S3 failure", func() {
+		ctx := context.Background()
+		zoneLookups := 0
+		r53 := &MockR53Client{
+			ListHostedZonesByNameFunc: func(ctx context.Context, params *route53.ListHostedZonesByNameInput, optFns ...func(*route53.Options)) (*route53.ListHostedZonesByNameOutput, error) {
+				zoneLookups++
+				return &route53.ListHostedZonesByNameOutput{}, nil
+			},
+		}
+		websiteErr := errors.New("website configuration unavailable")
+		s3Client := &MockS3Client{
+			PutBucketWebsiteFunc: func(ctx context.Context, params *s3.PutBucketWebsiteInput, optFns ...func(*s3.Options)) (*s3.PutBucketWebsiteOutput, error) {
+				return nil, websiteErr
+			},
+		}
+		r := newTestReconciler(r53, s3Client, pd, templateCM)
+
+		_, err := r.Reconcile(ctx, req)
+		Expect(err).To(MatchError(websiteErr))
+
+		failed := &parkingv1alpha1.ParkedDomain{}
+		Expect(r.Get(ctx, req.NamespacedName, failed)).To(Succeed())
+		Expect(failed.Status.Status).To(Equal("Error: S3 Bucket"))
+		Expect(failed.Status.Ready).To(BeFalse())
+		Expect(failed.Status.ZoneID).To(Equal("MOCKZONEID123"))
+
+		By("retrying once S3 recovers")
+		s3Client.PutBucketWebsiteFunc = nil
+		_, err = r.Reconcile(ctx, req)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(zoneLookups).To(Equal(1))
+
+		recovered := &parkingv1alpha1.ParkedDomain{}
+		Expect(r.Get(ctx, req.NamespacedName, recovered)).To(Succeed())
+		Expect(recovered.Status.Status).To(Equal("Provisioned"))
+		Expect(recovered.Status.Ready).To(BeTrue())
+		Expect(recovered.Status.ZoneID).To(Equal("MOCKZONEID123"))
+	})
+
+	It("should not reconfigure the bucket again after an A record failure", func() {
+		ctx := context.Background()
+		bucketChecks := 0
+		s3Client := &MockS3Client{
+			HeadBucketFunc: func(ctx context.Context, params *s3.HeadBucketInput, optFns ...func(*s3.Options)) (*s3.HeadBucketOutput, error) {
+				bucketChecks++
+				return &s3.HeadBucketOutput{}, nil
+			},
+		}
+		recordErr := errors.New("throttled")
+		var aliasTarget *r53types.AliasTarget
+		r53 := &MockR53Client{
+			ChangeResourceRecordSetsFunc: func(ctx context.Context, params *route53.ChangeResourceRecordSetsInput, optFns ...func(*route53.Options)) (*route53.ChangeResourceRecordSetsOutput, error) {
+				if aliasTarget == nil {
+					aliasTarget = &r53types.AliasTarget{}
+					return nil, recordErr
+				}
+				aliasTarget = params.ChangeBatch.Changes[0].ResourceRecordSet.AliasTarget
+				return &route53.ChangeResourceRecordSetsOutput{}, nil
+			},
+		}
+		r := newTestReconciler(r53, s3Client, pd, templateCM)
+
+		_, err := r.Reconcile(ctx, req)
+		Expect(err).To(MatchError(recordErr))
+
+		By("retrying once Route 53 recovers")
+		_, err = r.Reconcile(ctx, req)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(bucketChecks).To(Equal(1))
+		Expect(aws.ToString(aliasTarget.DNSName)).To(Equal("recovery.example.com.s3-website.eu-central-1.amazonaws.com"))
+
+		recovered := &parkingv1alpha1.ParkedDomain{}
+		Expect(r.Get(ctx, req.NamespacedName, recovered)).To(Succeed())
+		Expect(recovered.Status.Status).To(Equal("Provisioned"))
+		Expect(recovered.Status.Endpoint).To(Equal("recovery.example.com.s3-website.eu-central-1.amazonaws.com"))
+		Expect(recovered.Status.WebsiteURL).To(Equal("http://recovery.example.com"))
+	})
+
+	It("should skip all AWS calls once every step is satisfied", func() {
+		ctx := context.Background()
+		calls := 0
+		r53 := &MockR53Client{
+			ListHostedZonesByNameFunc: func(ctx context.Context, params *route53.ListHostedZonesByNameInput, optFns ...func(*route53.Options)) (*route53.ListHostedZonesByNameOutput, error) {
+				calls++
+				return &route53.ListHostedZonesByNameOutput{}, nil
+			},
+			ChangeResourceRecordSetsFunc: func(ctx context.Context, params *route53.ChangeResourceRecordSetsInput, optFns ...func(*route53.Options)) (*route53.ChangeResourceRecordSetsOutput, error) {
+				calls++
+				return &route53.ChangeResourceRecordSetsOutput{}, nil
+			},
+		}
+		r := newTestReconciler(r53, &MockS3Client{}, pd, templateCM)
+
+		_, err := r.Reconcile(ctx, req)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(calls).To(Equal(2))
+
+		reconciled := &parkingv1alpha1.ParkedDomain{}
+		Expect(r.Get(ctx, req.NamespacedName, reconciled)).To(Succeed())
+		Expect(reconciled.Status.ObservedGeneration).To(Equal(reconciled.Generation))
+
+		_, err = r.Reconcile(ctx, req)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(calls).To(Equal(2))
+
+		By("returning early without writing the status again")
+		unchanged := &parkingv1alpha1.ParkedDomain{}
+		Expect(r.Get(ctx, req.NamespacedName, unchanged)).To(Succeed())
+		Expect(unchanged.ResourceVersion).To(Equal(reconciled.ResourceVersion))
+	})
+
+	It("should not configure the bucket again for a generation it already configured", func() {
+		ctx := context.Background()
+		bucketCalls := 0
+		s3Mock := &MockS3Client{
+			HeadBucketFunc: func(ctx context.Context, params *s3.HeadBucketInput, optFns ...func(*s3.Options)) (*s3.HeadBucketOutput, error) {
+				bucketCalls++
+				return nil, &s3types.NotFound{}
+			},
+			PutBucketWebsiteFunc: func(ctx context.Context, params *s3.PutBucketWebsiteInput, optFns ...func(*s3.Options)) (*s3.PutBucketWebsiteOutput, error) {
+				bucketCalls++
+				return &s3.PutBucketWebsiteOutput{}, nil
+			},
+			PutBucketPolicyFunc: func(ctx context.Context, params *s3.PutBucketPolicyInput, optFns ...func(*s3.Options)) (*s3.PutBucketPolicyOutput, error) {
+				bucketCalls++
+				return &s3.PutBucketPolicyOutput{}, nil
+			},
+		}
+		r := newTestReconciler(&MockR53Client{}, s3Mock, pd, templateCM)
+
+		_, err := r.Reconcile(ctx, req)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(bucketCalls).To(Equal(3))
+		configured := &parkingv1alpha1.ParkedDomain{}
+		Expect(r.Get(ctx, req.NamespacedName, configured)).To(Succeed())
+		bucket := meta.FindStatusCondition(configured.Status.Conditions, parkingv1alpha1.ConditionBucketReady)
+		Expect(bucket).NotTo(BeNil())
+		Expect(bucket.ObservedGeneration).To(Equal(configured.Generation))
+
+		By("a reconcile of the same generation, e.g. after a status update")
+		configured.Status.Status = "Provisioned"
+		Expect(r.Status().Update(ctx, configured)).To(Succeed())
+		_, err = r.Reconcile(ctx, req)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(bucketCalls).To(Equal(3))
+	})
+})
+
+var _ = Describe("ParkedDomain status history", func() {
+	It("should record each status change once", func() {
+		Expect(os.Setenv("TEMPLATE_CONFIGMAP_NAME", "parked-domain-templates")).To(Succeed())
+		DeferCleanup(os.Unsetenv, "TEMPLATE_CONFIGMAP_NAME")
+		ctx := context.Background()
+		pd := &parkingv1alpha1.ParkedDomain{
+			ObjectMeta: metav1.ObjectMeta{Name: "history-domain", Namespace: "default"},
+			Spec:       parkingv1alpha1.ParkedDomainSpec{DomainName: "history.example.com"},
+		}
+		templateCM := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "parked-domain-templates", Namespace: "default"},
+			Data:       map[string]string{"default.html": "<h1>{{DOMAIN_NAME}}</h1>"},
+		}
+		req := ctrl.Request{NamespacedName: types.NamespacedName{Name: "history-domain", Namespace: "default"}}
+		websiteErr := errors.New("website configuration unavailable")
+		s3Client := &MockS3Client{
+			PutBucketWebsiteFunc: func(ctx context.Context, params *s3.PutBucketWebsiteInput, optFns ...func(*s3.Options)) (*s3.PutBucketWebsiteOutput, error) {
+				return nil, websiteErr
+			},
+		}
+		r := newTestReconciler(&MockR53Client{}, s3Client, pd, templateCM)
+
+		By("failing the bucket step twice")
+		for range 2 {
+			_, err := r.Reconcile(ctx, req)
+			Expect(err).To(MatchError(websiteErr))
+		}
+
+		By("recovering")
+		s3Client.PutBucketWebsiteFunc = nil
+		_, err := r.Reconcile(ctx, req)
+		Expect(err).NotTo(HaveOccurred())
+
+		recovered := &parkingv1alpha1.ParkedDomain{}
+		Expect(r.Get(ctx, req.NamespacedName, recovered)).To(Succeed())
+		Expect(recovered.Status.History).To(HaveLen(2))
+		failed, provisioned := recovered.Status.History[0], recovered.Status.History[1]
+		Expect([]string{failed.From, failed.To, failed.Reason}).To(Equal([]string{"", "Error: S3 Bucket", "ReconcileFailed"}))
+		Expect([]string{provisioned.From, provisioned.To, provisioned.Reason}).To(Equal([]string{"Error: S3 Bucket", "Provisioned", "Reconciled"}))
+		Expect(provisioned.Time.IsZero()).To(BeFalse())
+	})
+
+	It("should keep only the most recent changes", func() {
+		pd := &parkingv1alpha1.ParkedDomain{}
+		for i := range maxStatusHistory + 5 {
+			setStatus(pd, fmt.Sprintf("status %d", i), "Test")
+		}
+
+		Expect(pd.Status.History).To(HaveLen(maxStatusHistory))
+		Expect(pd.Status.History[0].From).To(Equal("status 4"))
+		Expect(pd.Status.History[maxStatusHistory-1].To).To(Equal(fmt.Sprintf("status %d", maxStatusHistory+4)))
+	})
+})
+
+var _ = Describe("ParkedDomain legacy finalizer migration", func() {
+	const (
+		legacyName      = "legacy-domain"
+		legacyFinalizer = "parking.yourcompany.com/finalizer"
+	)
+	var req ctrl.Request
+
+	BeforeEach(func() {
+		Expect(os.Setenv("TEMPLATE_CONFIGMAP_NAME", "parked-domain-templates")).To(Succeed())
+		req = ctrl.Request{NamespacedName: types.NamespacedName{Name: legacyName, Namespace: "default"}}
+	})
+
+	AfterEach(func() {
+		Expect(os.Unsetenv("TEMPLATE_CONFIGMAP_NAME")).To(Succeed())
+	})
+
+	It("should replace the legacy finalizer on a live object", func() {
+		ctx := context.Background()
+		pd := &parkingv1alpha1.ParkedDomain{
+			ObjectMeta: metav1.ObjectMeta{Name: legacyName, Namespace: "default", Finalizers: []string{legacyFinalizer}},
+			Spec:       parkingv1alpha1.ParkedDomainSpec{DomainName: "legacy.example.com"},
+		}
+		templateCM := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "parked-domain-templates", Namespace: "default"},
+			Data:       map[string]string{"default.html": "<h1>{{DOMAIN_NAME}}</h1>"},
+		}
+		r := newTestReconciler(&MockR53Client{}, &MockS3Client{}, pd, templateCM)
+
+		_, err := r.Reconcile(ctx, req)
+		Expect(err).NotTo(HaveOccurred())
+
+		migrated := &parkingv1alpha1.ParkedDomain{}
+		Expect(r.Get(ctx, req.NamespacedName, migrated)).To(Succeed())
+		Expect(migrated.Finalizers).To(ConsistOf("parking.minibaev.eu/finalizer"))
+	})
+
+	It("should clean up and release an object holding only the legacy finalizer", func() {
+		ctx := context.Background()
+		now := metav1.Now()
+		pd := &parkingv1alpha1.ParkedDomain{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: legacyName, Namespace: "default",
+				Finalizers:        []string{legacyFinalizer},
+				DeletionTimestamp: &now,
+			},
+			Spec:   parkingv1alpha1.ParkedDomainSpec{DomainName: "legacy.example.com"},
+			Status: parkingv1alpha1.ParkedDomainStatus{ZoneID: "MOCKZONEID123"},
+		}
+		bucketDeleted, zoneDeleted := false, false
+		s3Client := &MockS3Client{
+			DeleteBucketFunc: func(ctx context.Context, params *s3.DeleteBucketInput, optFns ...func(*s3.Options)) (*s3.DeleteBucketOutput, error) {
+				bucketDeleted = true
+				return &s3.DeleteBucketOutput{}, nil
+			},
+		}
+		r53 := &MockR53Client{
+			DeleteHostedZoneFunc: func(ctx context.Context, params *route53.DeleteHostedZoneInput, optFns ...func(*route53.Options)) (*route53.DeleteHostedZoneOutput, error) {
+				zoneDeleted = true
+				return &route53.DeleteHostedZoneOutput{}, nil
+			},
+		}
+		r := newTestReconciler(r53, s3Client, pd)
+
+		_, err := r.Reconcile(ctx, req)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(bucketDeleted).To(BeTrue())
+		Expect(zoneDeleted).To(BeTrue())
+
+		By("letting the API server finish the deletion")
+		err = r.Get(ctx, req.NamespacedName, &parkingv1alpha1.ParkedDomain{})
+		Expect(apierrors.IsNotFound(err)).To(BeTrue())
+	})
+})
+
+var _ = Describe("ParkedDomain cleanup failures", func() {
+	const cleanupName = "cleanup-domain"
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: cleanupName, Namespace: "default"}}
+
+	newDeletingDomain := func() *parkingv1alpha1.ParkedDomain {
+		now := metav1.Now()
+		return &parkingv1alpha1.ParkedDomain{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: cleanupName, Namespace: "default",
+				Finalizers:        []string{finalizerName},
+				DeletionTimestamp: &now,
+			},
+			Spec:   parkingv1alpha1.ParkedDomainSpec{DomainName: "cleanup.example.com"},
+			Status: parkingv1alpha1.ParkedDomainStatus{ZoneID: "MOCKZONEID123"},
+		}
+	}
+
+	It("should report the failing step and clear it once cleanup succeeds", func() {
+		ctx := context.Background()
+		bucketErr := errors.New("access denied")
+		s3Client := &MockS3Client{
+			DeleteBucketFunc: func(ctx context.Context, params *s3.DeleteBucketInput, optFns ...func(*s3.Options)) (*s3.DeleteBucketOutput, error) {
+				return nil, bucketErr
+			},
+		}
+		zoneErr := errors.New("throttled")
+		r53 := &MockR53Client{
+			DeleteHostedZoneFunc: func(ctx context.Context, params *route53.DeleteHostedZoneInput, optFns ...func(*route53.Options)) (*route53.DeleteHostedZoneOutput, error) {
+				return nil, zoneErr
+			},
+		}
+		r := newTestReconciler(r53, s3Client, newDeletingDomain())
+
+		By("failing the Route53 step")
+		_, err := r.Reconcile(ctx, req)
+		Expect(err).To(HaveOccurred())
+		stuck := &parkingv1alpha1.ParkedDomain{}
+		Expect(r.Get(ctx, req.NamespacedName, stuck)).To(Succeed())
+		cond := meta.FindStatusCondition(stuck.Status.Conditions, parkingv1alpha1.ConditionCleanupFailed)
+		Expect(cond).NotTo(BeNil())
+		Expect(cond.Status).To(Equal(metav1.ConditionTrue))
+		Expect(cond.Reason).To(Equal("Route53CleanupFailed"))
+		Expect(cond.Message).To(ContainSubstring("throttled"))
+
+		By("failing the S3 step")
+		zoneErr = nil
+		_, err = r.Reconcile(ctx, req)
+		Expect(err).To(HaveOccurred())
+		Expect(r.Get(ctx, req.NamespacedName, stuck)).To(Succeed())
+		cond = meta.FindStatusCondition(stuck.Status.Conditions, parkingv1alpha1.ConditionCleanupFailed)
+		Expect(cond).NotTo(BeNil())
+		Expect(cond.Reason).To(Equal("S3CleanupFailed"))
+		Expect(cond.Message).To(ContainSubstring("access denied"))
+
+		By("succeeding and removing the finalizer")
+		bucketErr = nil
+		_, err = r.Reconcile(ctx, req)
+		Expect(err).NotTo(HaveOccurred())
+		err = r.Get(ctx, req.NamespacedName, &parkingv1alpha1.ParkedDomain{})
+		Expect(apierrors.IsNotFound(err)).To(BeTrue())
+	})
+
+	DescribeTable("deleting the DNS and the bucket in the configured order",
+		func(order parkingv1alpha1.CleanupOrder, expected []string) {
+			ctx := context.Background()
+			var deleted []string
+			s3Client := &MockS3Client{
+				DeleteBucketFunc: func(ctx context.Context, params *s3.DeleteBucketInput, optFns ...func(*s3.Options)) (*s3.DeleteBucketOutput, error) {
+					deleted = append(deleted, "bucket")
+					return &s3.DeleteBucketOutput{}, nil
+				},
+			}
+			r53 := &MockR53Client{
+				DeleteHostedZoneFunc: func(ctx context.Context, params *route53.DeleteHostedZoneInput, optFns ...func(*route53.Options)) (*route53.DeleteHostedZoneOutput, error) {
+					deleted = append(deleted, "zone")
+					return &route53.DeleteHostedZoneOutput{}, nil
+				},
+			}
+			pd := newDeletingDomain()
+			pd.Spec.CleanupOrder = order
+			r := newTestReconciler(r53, s3Client, pd)
+
+			_, err := r.Reconcile(ctx, req)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(deleted).To(Equal(expected))
+			err = r.Get(ctx, req.NamespacedName, &parkingv1alpha1.ParkedDomain{})
+			Expect(apierrors.IsNotFound(err)).To(BeTrue())
+		},
+		Entry("DNS first by default", parkingv1alpha1.CleanupOrder(""), []string{"zone", "bucket"}),
+		Entry("DNS first", parkingv1alpha1.CleanupOrderDNSFirst, []string{"zone", "bucket"}),
+		Entry("storage first", parkingv1alpha1.CleanupOrderStorageFirst, []string{"bucket", "zone"}),
+	)
+
+	It("should keep the domain dark while the bucket is still being emptied", func() {
+		ctx := context.Background()
+		emptied := false
+		zoneLookups := 0
+		s3Client := &MockS3Client{
+			ListObjectsV2Func: func(ctx context.Context, params *s3.ListObjectsV2Input, optFns ...func(*s3.Options)) (*s3.ListObjectsV2Output, error) {
+				if emptied {
+					return &s3.ListObjectsV2Output{}, nil
+				}
+				emptied = true
+				return nil, errors.New("slow down")
+			},
+		}
+		r53 := &MockR53Client{
+			GetHostedZoneFunc: func(ctx context.Context, params *route53.GetHostedZoneInput, optFns ...func(*route53.Options)) (*route53.GetHostedZoneOutput, error) {
+				zoneLookups++
+				if zoneLookups > 1 {
+					return nil, &r53types.NoSuchHostedZone{}
+				}
+				return &route53.GetHostedZoneOutput{HostedZone: &r53types.HostedZone{Id: params.Id, Config: &r53types.HostedZoneConfig{Comment: aws.String(managedComment)}}}, nil
+			},
+		}
+		r := newTestReconciler(r53, s3Client, newDeletingDomain())
+
+		By("deleting the zone before the bucket cleanup fails")
+		_, err := r.Reconcile(ctx, req)
+		Expect(err).To(HaveOccurred())
+		Expect(zoneLookups).To(Equal(1))
+
+		By("finding the zone gone on the next pass and finishing the bucket")
+		_, err = r.Reconcile(ctx, req)
+		Expect(err).NotTo(HaveOccurred())
+		err = r.Get(ctx, req.NamespacedName, &parkingv1alpha1.ParkedDomain{})
+		Expect(apierrors.IsNotFound(err)).To(BeTrue())
+	})
+})
+
+var _ = Describe("ParkedDomain large bucket cleanup", func() {
+	const (
+		largeName = "large-domain"
+		pageSize  = 1000
+	)
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: largeName, Namespace: "default"}}
+
+	BeforeEach(func() {
+		saved := s3CleanupBudget
+		s3CleanupBudget = 0
+		DeferCleanup(func() { s3CleanupBudget = saved })
+	})
+
+	It("should pause and resume emptying the bucket across reconciles", func() {
+		ctx := context.Background()
+		now := metav1.Now()
+		pd := &parkingv1alpha1.ParkedDomain{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: largeName, Namespace: "default",
+				Finalizers:        []string{finalizerName},
+				DeletionTimestamp: &now,
+			},
+			Spec: parkingv1alpha1.ParkedDomainSpec{DomainName: "large.example.com"},
+		}
+
+		// The bucket holds five pages of objects, listed in key order like S3 does.
+		remaining := map[string]bool{}
+		for i := 0; i < 5*pageSize; i++ {
+			remaining[fmt.Sprintf("logs/%05d", i)] = true
+		}
+		bucketDeleted := false
+		s3Client := &MockS3Client{
+			ListObjectsV2Func: func(ctx context.Context, params *s3.ListObjectsV2Input, optFns ...func(*s3.Options)) (*s3.ListObjectsV2Output, error) {
+				keys := make([]string, 0, len(remaining))
+				for key := range remaining {
+					if key > aws.ToString(params.ContinuationToken) {
+						keys = append(keys, key)
+					}
+				}
+				sort.Strings(keys)
+				output := &s3.ListObjectsV2Output{}
+				for _, key := range keys[:min(pageSize, len(keys))] {
+					output.Contents = append(output.Contents, s3types.Object{Key: aws.String(key)})
+				}
+				if len(keys) > pageSize {
+					output.IsTruncated = aws.Bool(true)
+					output.NextContinuationToken = aws.String(keys[pageSize-1])
+				}
+				return output, nil
+			},
+			DeleteObjectsFunc: func(ctx context.Context, params *s3.DeleteObjectsInput, optFns ...func(*s3.Options)) (*s3.DeleteObjectsOutput, error) {
+				for _, obj := range params.Delete.Objects {
+					delete(remaining, aws.ToString(obj.Key))
+				}
+				return &s3.DeleteObjectsOutput{}, nil
+			},
+			DeleteBucketFunc: func(ctx context.Context, params *s3.DeleteBucketInput, optFns ...func(*s3.Options)) (*s3.DeleteBucketOutput, error) {
+				Expect(remaining).To(BeEmpty())
+				bucketDeleted = true
+				return &s3.DeleteBucketOutput{}, nil
+			},
+		}
+		r := newTestReconciler(&MockR53Client{}, s3Client, pd)
+
+		By("stopping after a page once the time budget is used up")
+		result, err := r.Reconcile(ctx, req)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.RequeueAfter).To(Equal(s3CleanupResumeDelay))
+		Expect(remaining).To(HaveLen(4 * pageSize))
+		Expect(bucketDeleted).To(BeFalse())
+		Expect(r.Get(ctx, req.NamespacedName, &parkingv1alpha1.ParkedDomain{})).To(Succeed())
+
+		By("resuming until the bucket is empty")
+		for i := 0; i < 10 && !bucketDeleted; i++ {
+			_, err = r.Reconcile(ctx, req)
+			Expect(err).NotTo(HaveOccurred())
+		}
+		Expect(bucketDeleted).To(BeTrue())
+		err = r.Get(ctx, req.NamespacedName, &parkingv1alpha1.ParkedDomain{})
+		Expect(apierrors.IsNotFound(err)).To(BeTrue())
+	})
+
+	It("should treat objects that are already gone as deleted", func() {
+		s3Client := &MockS3Client{
+			DeleteObjectsFunc: func(ctx context.Context, params *s3.DeleteObjectsInput, optFns ...func(*s3.Options)) (*s3.DeleteObjectsOutput, error) {
+				return &s3.DeleteObjectsOutput{Errors: []s3types.Error{{Key: params.Delete.Objects[0].Key, Code: aws.String("NoSuchKey")}}}, nil
+			},
+		}
+		objects := []s3types.Object{{Key: aws.String("index.html")}}
+		Expect(deleteObjects(context.Background(), s3Client, "large.example.com", objects)).To(Succeed())
+
+		s3Client.DeleteObjectsFunc = func(ctx context.Context, params *s3.DeleteObjectsInput, optFns ...func(*s3.Options)) (*s3.DeleteObjectsOutput, error) {
+			return &s3.DeleteObjectsOutput{Errors: []s3types.Error{{Key: params.Delete.Objects[0].Key, Code: aws.String("AccessDenied")}}}, nil
+		}
+		Expect(deleteObjects(context.Background(), s3Client, "large.example.com", objects)).To(MatchError(ContainSubstring("index.html")))
+	})
+})
+
+var _ = Describe("ParkedDomain managed-by metadata", func() {
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: "managed-domain", Namespace: "default"}}
+
+	BeforeEach(func() {
+		Expect(os.Setenv("TEMPLATE_CONFIGMAP_NAME", "parked-domain-templates")).To(Succeed())
+	})
+
+	AfterEach(func() {
+		Expect(os.Unsetenv("TEMPLATE_CONFIGMAP_NAME")).To(Succeed())
+	})
+
+	It("should label the object once and record the operator version", func() {
+		ctx := context.Background()
+		pd := &parkingv1alpha1.ParkedDomain{
+			ObjectMeta: metav1.ObjectMeta{Name: "managed-domain", Namespace: "default", Labels: map[string]string{"team": "web"}},
+			Spec:       parkingv1alpha1.ParkedDomainSpec{DomainName: "managed.example.com"},
+		}
+		templateCM := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "parked-domain-templates", Namespace: "default"},
+			Data:       map[string]string{"default.html": "<h1>{{DOMAIN_NAME}}</h1>"},
+		}
+		r := newTestReconciler(&MockR53Client{}, &MockS3Client{}, pd, templateCM)
+		r.ManagedLabelKey, r.ManagedLabelValue = "parking.minibaev.eu/managed", "true"
+		r.OperatorVersion = "0.0.1"
+
+		_, err := r.Reconcile(ctx, req)
+		Expect(err).NotTo(HaveOccurred())
+		managed := &parkingv1alpha1.ParkedDomain{}
+		Expect(r.Get(ctx, req.NamespacedName, managed)).To(Succeed())
+		Expect(managed.Labels).To(Equal(map[string]string{"team": "web", "parking.minibaev.eu/managed": "true"}))
+		Expect(managed.Annotations).To(HaveKeyWithValue("parking.minibaev.eu/operator-version", "0.0.1"))
+
+		By("leaving the object alone once the metadata is in place")
+		_, err = r.Reconcile(ctx, req)
+		Expect(err).NotTo(HaveOccurred())
+		unchanged := &parkingv1alpha1.ParkedDomain{}
+		Expect(r.Get(ctx, req.NamespacedName, unchanged)).To(Succeed())
+		Expect(unchanged.ResourceVersion).To(Equal(managed.ResourceVersion))
+
+		By("recording a new operator version after an upgrade")
+		r.OperatorVersion = "0.0.2"
+		_, err = r.Reconcile(ctx, req)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(r.Get(ctx, req.NamespacedName, managed)).To(Succeed())
+		Expect(managed.Annotations).To(HaveKeyWithValue("parking.minibaev.eu/operator-version", "0.0.2"))
+	})
+})
+
+var _ = Describe("ParkedDomain missing template ConfigMap", func() {
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: "early-domain", Namespace: "default"}}
+
+	BeforeEach(func() {
+		Expect(os.Setenv("TEMPLATE_CONFIGMAP_NAME", "parked-domain-templates")).To(Succeed())
+	})
+
+	AfterEach(func() {
+		Expect(os.Unsetenv("TEMPLATE_CONFIGMAP_NAME")).To(Succeed())
+	})
+
+	It("should report the zone and wait for the ConfigMap instead of failing", func() {
+		ctx := context.Background()
+		pd := &parkingv1alpha1.ParkedDomain{
+			ObjectMeta: metav1.ObjectMeta{Name: "early-domain", Namespace: "default"},
+			Spec:       parkingv1alpha1.ParkedDomainSpec{DomainName: "early.example.com"},
+		}
+		r := newTestReconciler(&MockR53Client{}, &MockS3Client{}, pd)
+
+		result, err := r.Reconcile(ctx, req)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.RequeueAfter).To(Equal(templateMissingRequeueDelay))
+
+		pending := &parkingv1alpha1.ParkedDomain{}
+		Expect(r.Get(ctx, req.NamespacedName, pending)).To(Succeed())
+		Expect(pending.Status.Status).To(Equal("Pending: Template"))
+		Expect(pending.Status.Ready).To(BeFalse())
+		Expect(pending.Status.ZoneID).NotTo(BeEmpty())
+		Expect(pending.Status.NameServers).To(ConsistOf("ns-1.awsdns.com", "ns-2.awsdns.com"))
+		Expect(meta.IsStatusConditionTrue(pending.Status.Conditions, parkingv1alpha1.ConditionZoneReady)).To(BeTrue())
+		content := meta.FindStatusCondition(pending.Status.Conditions, parkingv1alpha1.ConditionContentReady)
+		Expect(content).NotTo(BeNil())
+		Expect(content.Status).To(Equal(metav1.ConditionFalse))
+		Expect(content.Reason).To(Equal("TemplateConfigMapMissing"))
+		Expect(meta.FindStatusCondition(pending.Status.Conditions, parkingv1alpha1.ConditionBucketReady)).To(BeNil())
+
+		By("finishing once the ConfigMap is created")
+		Expect(r.Create(ctx, &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "parked-domain-templates", Namespace: "default"},
+			Data:       map[string]string{"default.html": "<h1>{{DOMAIN_NAME}}</h1>"},
+		})).To(Succeed())
+		_, err = r.Reconcile(ctx, req)
+		Expect(err).NotTo(HaveOccurred())
+		ready := &parkingv1alpha1.ParkedDomain{}
+		Expect(r.Get(ctx, req.NamespacedName, ready)).To(Succeed())
+		Expect(ready.Status.Ready).To(BeTrue())
+		Expect(meta.IsStatusConditionTrue(ready.Status.Conditions, parkingv1alpha1.ConditionContentReady)).To(BeTrue())
+	})
+})
+
+var _ = Describe("ParkedDomain status conflicts", func() {
+	var (
+		pd         *parkingv1alpha1.ParkedDomain
+		templateCM *corev1.ConfigMap
+		req        ctrl.Request
+	)
+
+	BeforeEach(func() {
+		Expect(os.Setenv("TEMPLATE_CONFIGMAP_NAME", "parked-domain-templates")).To(Succeed())
+		pd = &parkingv1alpha1.ParkedDomain{
+			ObjectMeta: metav1.ObjectMeta{Name: "conflict-domain", Namespace: "default"},
+			Spec:       parkingv1alpha1.ParkedDomainSpec{DomainName: "conflict.example.com"},
+		}
+		templateCM = &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "parked-domain-templates", Namespace: "default"},
+			Data: map[string]string{
+				"default.html":  "<h1>{{DOMAIN_NAME}}</h1>",
+				"for-sale.html": "<h1>{{DOMAIN_NAME}} is for sale</h1>",
+			},
+		}
+		req = ctrl.Request{NamespacedName: types.NamespacedName{Name: "conflict-domain", Namespace: "default"}}
+	})
+
+	AfterEach(func() {
+		Expect(os.Unsetenv("TEMPLATE_CONFIGMAP_NAME")).To(Succeed())
+	})
+
+	// editSpec changes the ParkedDomain behind the reconcile's back, as a user
+	// applying a new generation mid-reconcile would.
+	editSpec := func(ctx context.Context, r *ParkedDomainReconciler) {
+		latest := &parkingv1alpha1.ParkedDomain{}
+		Expect(r.Get(ctx, req.NamespacedName, latest)).To(Succeed())
+		latest.Spec.ParkingMode = parkingv1alpha1.ParkingModeForSale
+		Expect(r.Update(ctx, latest)).To(Succeed())
+	}
+
+	It("should requeue instead of overwriting the status of a changed object", func() {
+		ctx := context.Background()
+		edited := false
+		var r *ParkedDomainReconciler
+		r53 := &MockR53Client{
+			ChangeResourceRecordSetsFunc: func(ctx context.Context, params *route53.ChangeResourceRecordSetsInput, optFns ...func(*route53.Options)) (*route53.ChangeResourceRecordSetsOutput, error) {
+				if !edited {
+					edited = true
+					editSpec(ctx, r)
+				}
+				return &route53.ChangeResourceRecordSetsOutput{}, nil
+			},
+		}
+		r = newTestReconciler(r53, &MockS3Client{}, pd, templateCM)
+
+		result, err := r.Reconcile(ctx, req)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.RequeueAfter).To(Equal(statusConflictRequeueDelay))
+
+		stale := &parkingv1alpha1.ParkedDomain{}
+		Expect(r.Get(ctx, req.NamespacedName, stale)).To(Succeed())
+		Expect(stale.Spec.ParkingMode).To(Equal(parkingv1alpha1.ParkingModeForSale))
+		Expect(stale.Status.Status).To(BeEmpty())
+
+		By("reconciling the latest object on the requeue")
+		_, err = r.Reconcile(ctx, req)
+		Expect(err).NotTo(HaveOccurred())
+		latest := &parkingv1alpha1.ParkedDomain{}
+		Expect(r.Get(ctx, req.NamespacedName, latest)).To(Succeed())
+		Expect(latest.Status.Status).To(Equal("Provisioned"))
+		Expect(latest.Status.ObservedGeneration).To(Equal(latest.Generation))
+	})
+
+	It("should still return the step error when its status write conflicts", func() {
+		ctx := context.Background()
+		notifier := &stubNotifier{events: make(chan NotificationEvent, 10)}
+		websiteErr := errors.New("website configuration unavailable")
+		var r *ParkedDomainReconciler
+		s3Client := &MockS3Client{
+			PutBucketWebsiteFunc: func(ctx context.Context, params *s3.PutBucketWebsiteInput, optFns ...func(*s3.Options)) (*s3.PutBucketWebsiteOutput, error) {
+				editSpec(ctx, r)
+				return nil, websiteErr
+			},
+		}
+		r = newTestReconciler(&MockR53Client{}, s3Client, pd, templateCM)
+		r.Notifier = notifier
+
+		_, err := r.Reconcile(ctx, req)
+		Expect(err).To(MatchError(websiteErr))
+
+		stale := &parkingv1alpha1.ParkedDomain{}
+		Expect(r.Get(ctx, req.NamespacedName, stale)).To(Succeed())
+		Expect(stale.Status.Status).To(BeEmpty())
+		Consistently(notifier.events, 100*time.Millisecond).ShouldNot(Receive())
+	})
+})
+
+var _ = Describe("ParkedDomain alias targets", func() {
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: "landing-domain", Namespace: "default"}}
+
+	It("should alias the record to the target without provisioning a bucket", func() {
+		ctx := context.Background()
+		pd := &parkingv1alpha1.ParkedDomain{
+			ObjectMeta: metav1.ObjectMeta{Name: "landing-domain", Namespace: "default"},
+			Spec: parkingv1alpha1.ParkedDomainSpec{
+				DomainName: "landing.example.com",
+				AliasTarget: &parkingv1alpha1.AliasTarget{
+					HostedZoneID: "Z32O12XQLNTSW2",
+					DNSName:      "landing-123.eu-west-1.elb.amazonaws.com",
+					Type:         parkingv1alpha1.AliasTargetLoadBalancer,
+				},
+			},
+		}
+		s3Calls := 0
+		s3Mock := &MockS3Client{
+			HeadBucketFunc: func(ctx context.Context, params *s3.HeadBucketInput, optFns ...func(*s3.Options)) (*s3.HeadBucketOutput, error) {
+				s3Calls++
+				return nil, &s3types.NotFound{}
+			},
+			CreateBucketFunc: func(ctx context.Context, params *s3.CreateBucketInput, optFns ...func(*s3.Options)) (*s3.CreateBucketOutput, error) {
+				s3Calls++
+				return &s3.CreateBucketOutput{}, nil
+			},
+		}
+		var alias *r53types.AliasTarget
+		r53Mock := &MockR53Client{
+			ChangeResourceRecordSetsFunc: func(ctx context.Context, params *route53.ChangeResourceRecordSetsInput, optFns ...func(*route53.Options)) (*route53.ChangeResourceRecordSetsOutput, error) {
+				alias = params.ChangeBatch.Changes[0].ResourceRecordSet.AliasTarget
+				return &route53.ChangeResourceRecordSetsOutput{}, nil
+			},
+		}
+		r := newTestReconciler(r53Mock, s3Mock, pd)
+
+		_, err := r.Reconcile(ctx, req)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(s3Calls).To(BeZero())
+		Expect(aws.ToString(alias.HostedZoneId)).To(Equal("Z32O12XQLNTSW2"))
+		Expect(aws.ToString(alias.DNSName)).To(Equal("landing-123.eu-west-1.elb.amazonaws.com"))
+		Expect(alias.EvaluateTargetHealth).To(BeTrue())
+
+		landing := &parkingv1alpha1.ParkedDomain{}
+		Expect(r.Get(ctx, req.NamespacedName, landing)).To(Succeed())
+		Expect(landing.Status.Ready).To(BeTrue())
+		Expect(landing.Status.Endpoint).To(BeEmpty())
+		Expect(landing.Status.WebsiteURL).To(Equal("http://landing.example.com"))
+		Expect(meta.FindStatusCondition(landing.Status.Conditions, parkingv1alpha1.ConditionBucketReady)).To(BeNil())
+
+		By("leaving S3 alone on deletion")
+		s3Mock.ListObjectsV2Func = func(ctx context.Context, params *s3.ListObjectsV2Input, optFns ...func(*s3.Options)) (*s3.ListObjectsV2Output, error) {
+			s3Calls++
+			return &s3.ListObjectsV2Output{}, nil
+		}
+		Expect(r.Delete(ctx, landing)).To(Succeed())
+		_, err = r.Reconcile(ctx, req)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(s3Calls).To(BeZero())
+		Expect(apierrors.IsNotFound(r.Get(ctx, req.NamespacedName, landing))).To(BeTrue())
+	})
+})
+
+// drainEvents returns the events recorded so far.
+func drainEvents(recorder *record.FakeRecorder) []string {
+	var events []string
+	for {
+		select {
+		case event := <-recorder.Events:
+			events = append(events, event)
+		default:
+			return events
+		}
+	}
+}
+
+var _ = Describe("ParkedDomain bucket drift", func() {
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: "drift-domain", Namespace: "default"}}
+
+	BeforeEach(func() {
+		Expect(os.Setenv("TEMPLATE_CONFIGMAP_NAME", "parked-domain-templates")).To(Succeed())
+	})
+
+	AfterEach(func() {
+		Expect(os.Unsetenv("TEMPLATE_CONFIGMAP_NAME")).To(Succeed())
+	})
+
+	It("should recreate and reconfigure a bucket deleted outside the operator", func() {
+		ctx := context.Background()
+		pd := &parkingv1alpha1.ParkedDomain{
+			ObjectMeta: metav1.ObjectMeta{Name: "drift-domain", Namespace: "default"},
+			Spec:       parkingv1alpha1.ParkedDomainSpec{DomainName: "drift.example.com"},
+		}
+		templateCM := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "parked-domain-templates", Namespace: "default"},
+			Data:       map[string]string{"default.html": "<h1>{{DOMAIN_NAME}}</h1>"},
+		}
+		bucketExists := false
+		var created, uploaded, websites, policies int
+		s3Mock := &MockS3Client{
+			HeadBucketFunc: func(ctx context.Context, params *s3.HeadBucketInput, optFns ...func(*s3.Options)) (*s3.HeadBucketOutput, error) {
+				if !bucketExists {
+					return nil, &s3types.NotFound{}
+				}
+				return &s3.HeadBucketOutput{}, nil
+			},
+			CreateBucketFunc: func(ctx context.Context, params *s3.CreateBucketInput, optFns ...func(*s3.Options)) (*s3.CreateBucketOutput, error) {
+				bucketExists = true
+				created++
+				return &s3.CreateBucketOutput{}, nil
+			},
+			ListObjectsV2Func: func(ctx context.Context, params *s3.ListObjectsV2Input, optFns ...func(*s3.Options)) (*s3.ListObjectsV2Output, error) {
+				if !bucketExists {
+					return nil, &s3types.NoSuchBucket{}
+				}
+				return &s3.ListObjectsV2Output{Contents: []s3types.Object{{Key: aws.String("index.html"), Size: aws.Int64(10)}}}, nil
+			},
+			PutObjectFunc: func(ctx context.Context, params *s3.PutObjectInput, optFns ...func(*s3.Options)) (*s3.PutObjectOutput, error) {
+				uploaded++
+				return &s3.PutObjectOutput{}, nil
+			},
+			PutBucketWebsiteFunc: func(ctx context.Context, params *s3.PutBucketWebsiteInput, optFns ...func(*s3.Options)) (*s3.PutBucketWebsiteOutput, error) {
+				websites++
+				return &s3.PutBucketWebsiteOutput{}, nil
+			},
+			PutBucketPolicyFunc: func(ctx context.Context, params *s3.PutBucketPolicyInput, optFns ...func(*s3.Options)) (*s3.PutBucketPolicyOutput, error) {
+				policies++
+				return &s3.PutBucketPolicyOutput{}, nil
+			},
+		}
+		recorder := record.NewFakeRecorder(10)
+		r := newTestReconciler(&MockR53Client{}, s3Mock, pd, templateCM)
+		r.Recorder = recorder
+		r.BucketUsageInterval = time.Hour
+
+		_, err := r.Reconcile(ctx, req)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(created).To(Equal(1))
+		Expect(drainEvents(recorder)).NotTo(ContainElement(ContainSubstring("BucketRecreated")))
+
+		By("deleting the bucket out-of-band and letting the usage refresh come due")
+		bucketExists = false
+		uploaded, websites, policies = 0, 0, 0
+		Expect(r.Get(ctx, req.NamespacedName, pd)).To(Succeed())
+		Expect(meta.FindStatusCondition(pd.Status.Conditions, parkingv1alpha1.ConditionDriftRepaired)).To(BeNil())
+		pd.Status.UsageUpdatedAt = &metav1.Time{Time: time.Now().Add(-2 * time.Hour)}
+		Expect(r.Status().Update(ctx, pd)).To(Succeed())
+
+		_, err = r.Reconcile(ctx, req)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(created).To(Equal(2))
+		Expect(uploaded).To(Equal(1))
+		Expect(websites).To(Equal(1))
+		Expect(policies).To(Equal(1))
+		Expect(drainEvents(recorder)).To(ContainElement(ContainSubstring("Warning BucketRecreated")))
+
+		repaired := &parkingv1alpha1.ParkedDomain{}
+		Expect(r.Get(ctx, req.NamespacedName, repaired)).To(Succeed())
+		Expect(meta.IsStatusConditionTrue(repaired.Status.Conditions, parkingv1alpha1.ConditionBucketReady)).To(BeTrue())
+		drift := meta.FindStatusCondition(repaired.Status.Conditions, parkingv1alpha1.ConditionDriftRepaired)
+		Expect(drift).NotTo(BeNil())
+		Expect(drift.Status).To(Equal(metav1.ConditionTrue))
+		Expect(drift.Reason).To(Equal("BucketRecreated"))
+		Expect(repaired.Status.ObjectCount).To(Equal(int64(1)))
+	})
+
+	It("should reapply a website configuration removed outside the operator", func() {
+		ctx := context.Background()
+		pd := &parkingv1alpha1.ParkedDomain{
+			ObjectMeta: metav1.ObjectMeta{Name: "drift-domain", Namespace: "default"},
+			Spec:       parkingv1alpha1.ParkedDomainSpec{DomainName: "drift.example.com"},
+		}
+		templateCM := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "parked-domain-templates", Namespace: "default"},
+			Data:       map[string]string{"default.html": "<h1>{{DOMAIN_NAME}}</h1>"},
+		}
+		websiteConfigured := false
+		var created, websites int
+		s3Mock := &MockS3Client{
+			HeadBucketFunc: func(ctx context.Context, params *s3.HeadBucketInput, optFns ...func(*s3.Options)) (*s3.HeadBucketOutput, error) {
+				if created == 0 {
+					return nil, &s3types.NotFound{}
+				}
+				return &s3.HeadBucketOutput{}, nil
+			},
+			CreateBucketFunc: func(ctx context.Context, params *s3.CreateBucketInput, optFns ...func(*s3.Options)) (*s3.CreateBucketOutput, error) {
+				created++
+				return &s3.CreateBucketOutput{}, nil
+			},
+			PutBucketWebsiteFunc: func(ctx context.Context, params *s3.PutBucketWebsiteInput, optFns ...func(*s3.Options)) (*s3.PutBucketWebsiteOutput, error) {
+				websiteConfigured = true
+				websites++
+				return &s3.PutBucketWebsiteOutput{}, nil
+			},
+			GetBucketWebsiteFunc: func(ctx context.Context, params *s3.GetBucketWebsiteInput, optFns ...func(*s3.Options)) (*s3.GetBucketWebsiteOutput, error) {
+				if !websiteConfigured {
+					return nil, &smithy.GenericAPIError{Code: "NoSuchWebsiteConfiguration"}
+				}
+				return &s3.GetBucketWebsiteOutput{}, nil
+			},
+		}
+		recorder := record.NewFakeRecorder(10)
+		r := newTestReconciler(&MockR53Client{}, s3Mock, pd, templateCM)
+		r.Recorder = recorder
+		r.BucketUsageInterval = time.Hour
+
+		_, err := r.Reconcile(ctx, req)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(websites).To(Equal(1))
+		drainEvents(recorder)
+
+		By("removing the website configuration out-of-band and letting the usage refresh come due")
+		websiteConfigured = false
+		Expect(r.Get(ctx, req.NamespacedName, pd)).To(Succeed())
+		pd.Status.UsageUpdatedAt = &metav1.Time{Time: time.Now().Add(-2 * time.Hour)}
+		Expect(r.Status().Update(ctx, pd)).To(Succeed())
+
+		_, err = r.Reconcile(ctx, req)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(created).To(Equal(1))
+		Expect(websites).To(Equal(2))
+		Expect(drainEvents(recorder)).To(ContainElement(ContainSubstring("Warning WebsiteConfigurationRestored")))
+
+		repaired := &parkingv1alpha1.ParkedDomain{}
+		Expect(r.Get(ctx, req.NamespacedName, repaired)).To(Succeed())
+		Expect(meta.IsStatusConditionTrue(repaired.Status.Conditions, parkingv1alpha1.ConditionBucketReady)).To(BeTrue())
+		drift := meta.FindStatusCondition(repaired.Status.Conditions, parkingv1alpha1.ConditionDriftRepaired)
+		Expect(drift).NotTo(BeNil())
+		Expect(drift.Reason).To(Equal("WebsiteConfigurationRestored"))
+	})
+})
+
+var _ = Describe("ParkedDomain bucket creation conflicts", func() {
+	var (
+		pd         *parkingv1alpha1.ParkedDomain
+		templateCM *corev1.ConfigMap
+		req        ctrl.Request
+	)
+
+	BeforeEach(func() {
+		Expect(os.Setenv("TEMPLATE_CONFIGMAP_NAME", "parked-domain-templates")).To(Succeed())
+		pd = &parkingv1alpha1.ParkedDomain{
+			ObjectMeta: metav1.ObjectMeta{Name: "race-domain", Namespace: "default"},
+			Spec:       parkingv1alpha1.ParkedDomainSpec{DomainName: "race.example.com"},
+		}
+		templateCM = &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "parked-domain-templates", Namespace: "default"},
+			Data:       map[string]string{"default.html": "<h1>{{DOMAIN_NAME}}</h1>"},
+		}
+		req = ctrl.Request{NamespacedName: types.NamespacedName{Name: "race-domain", Namespace: "default"}}
+	})
+
+	AfterEach(func() {
+		Expect(os.Unsetenv("TEMPLATE_CONFIGMAP_NAME")).To(Succeed())
+	})
+
+	It("should configure a bucket created in this account since it was checked", func() {
+		ctx := context.Background()
+		var websites int
+		s3Mock := &MockS3Client{
+			CreateBucketFunc: func(ctx context.Context, params *s3.CreateBucketInput, optFns ...func(*s3.Options)) (*s3.CreateBucketOutput, error) {
+				return nil, &s3types.BucketAlreadyOwnedByYou{}
+			},
+			PutBucketWebsiteFunc: func(ctx context.Context, params *s3.PutBucketWebsiteInput, optFns ...func(*s3.Options)) (*s3.PutBucketWebsiteOutput, error) {
+				websites++
+				return &s3.PutBucketWebsiteOutput{}, nil
+			},
+		}
+		recorder := record.NewFakeRecorder(10)
+		r := newTestReconciler(&MockR53Client{}, s3Mock, pd, templateCM)
+		r.Recorder = recorder
+
+		_, err := r.Reconcile(ctx, req)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(websites).To(Equal(1))
+		Expect(drainEvents(recorder)).To(BeEmpty())
+
+		provisioned := &parkingv1alpha1.ParkedDomain{}
+		Expect(r.Get(ctx, req.NamespacedName, provisioned)).To(Succeed())
+		Expect(provisioned.Status.Ready).To(BeTrue())
+		Expect(meta.IsStatusConditionTrue(provisioned.Status.Conditions, parkingv1alpha1.ConditionBucketReady)).To(BeTrue())
+	})
+
+	It("should report a bucket name owned by another account", func() {
+		ctx := context.Background()
+		s3Mock := &MockS3Client{
+			CreateBucketFunc: func(ctx context.Context, params *s3.CreateBucketInput, optFns ...func(*s3.Options)) (*s3.CreateBucketOutput, error) {
+				return nil, &s3types.BucketAlreadyExists{}
+			},
+		}
+		recorder := record.NewFakeRecorder(10)
+		r := newTestReconciler(&MockR53Client{}, s3Mock, pd, templateCM)
+		r.Recorder = recorder
+
+		_, err := r.Reconcile(ctx, req)
+		Expect(err).To(MatchError(errBucketNameTaken))
+		Expect(drainEvents(recorder)).To(ContainElement(ContainSubstring("Warning BucketNameTaken")))
+
+		failed := &parkingv1alpha1.ParkedDomain{}
+		Expect(r.Get(ctx, req.NamespacedName, failed)).To(Succeed())
+		Expect(failed.Status.Status).To(Equal("Error: S3 Bucket"))
+		bucket := meta.FindStatusCondition(failed.Status.Conditions, parkingv1alpha1.ConditionBucketReady)
+		Expect(bucket).NotTo(BeNil())
+		Expect(bucket.Status).To(Equal(metav1.ConditionFalse))
+		Expect(bucket.Reason).To(Equal("BucketNameTaken"))
+		Expect(bucket.Message).To(ContainSubstring("race.example.com"))
+	})
+
+	It("should create the bucket under a suffixed name when the name is taken and autoSuffixBucket is set", func() {
+		ctx := context.Background()
+		pd.Spec.AutoSuffixBucket = true
+		pd.Spec.DNSEnabled = aws.Bool(false)
+		suffixed := suffixedBucketName(pd)
+		var created []string
+		websiteBuckets := map[string]bool{}
+		s3Mock := &MockS3Client{
+			CreateBucketFunc: func(ctx context.Context, params *s3.CreateBucketInput, optFns ...func(*s3.Options)) (*s3.CreateBucketOutput, error) {
+				created = append(created, aws.ToString(params.Bucket))
+				if aws.ToString(params.Bucket) == "race.example.com" {
+					return nil, &s3types.BucketAlreadyExists{}
+				}
+				return &s3.CreateBucketOutput{}, nil
+			},
+			PutBucketWebsiteFunc: func(ctx context.Context, params *s3.PutBucketWebsiteInput, optFns ...func(*s3.Options)) (*s3.PutBucketWebsiteOutput, error) {
+				websiteBuckets[aws.ToString(params.Bucket)] = true
+				return &s3.PutBucketWebsiteOutput{}, nil
+			},
+		}
+		recorder := record.NewFakeRecorder(10)
+		r := newTestReconciler(&MockR53Client{}, s3Mock, pd, templateCM)
+		r.Recorder = recorder
+
+		_, err := r.Reconcile(ctx, req)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(created).To(Equal([]string{"race.example.com", suffixed}))
+		Expect(websiteBuckets).To(Equal(map[string]bool{suffixed: true}))
+		Expect(drainEvents(recorder)).To(ContainElement(ContainSubstring("Normal BucketNameSuffixed")))
+
+		provisioned := &parkingv1alpha1.ParkedDomain{}
+		Expect(r.Get(ctx, req.NamespacedName, provisioned)).To(Succeed())
+		Expect(provisioned.Status.BucketName).To(Equal(suffixed))
+		Expect(provisioned.Status.Endpoint).To(Equal(suffixed + ".s3-website.eu-central-1.amazonaws.com"))
+
+		By("emptying and deleting the suffixed bucket on cleanup")
+		var deleted string
+		s3Mock.ListObjectsV2Func = func(ctx context.Context, params *s3.ListObjectsV2Input, optFns ...func(*s3.Options)) (*s3.ListObjectsV2Output, error) {
+			Expect(aws.ToString(params.Bucket)).To(Equal(suffixed))
+			return &s3.ListObjectsV2Output{}, nil
+		}
+		s3Mock.DeleteBucketFunc = func(ctx context.Context, params *s3.DeleteBucketInput, optFns ...func(*s3.Options)) (*s3.DeleteBucketOutput, error) {
+			deleted = aws.ToString(params.Bucket)
+			return &s3.DeleteBucketOutput{}, nil
+		}
+		done, err := r.cleanupS3Bucket(ctx, provisioned)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(done).To(BeTrue())
+		Expect(deleted).To(Equal(suffixed))
+	})
+
+	It("should fit a suffixed bucket name within the bucket name limit", func() {
+		pd.Spec.DomainName = strings.Repeat("a", 50) + ".example.com"
+		name := suffixedBucketName(pd)
+		Expect(len(name)).To(BeNumerically("<=", maxBucketNameLength))
+		Expect(name).To(MatchRegexp(`^a+\.exa-[0-9a-f]{8}$`))
+		Expect(suffixedBucketName(pd)).To(Equal(name))
+	})
+
+	It("should give up on a region without S3 website endpoints instead of retrying", func() {
+		ctx := context.Background()
+		pd.Spec.Region = "eu-nowhere-1"
+		s3Mock := &MockS3Client{
+			CreateBucketFunc: func(ctx context.Context, params *s3.CreateBucketInput, optFns ...func(*s3.Options)) (*s3.CreateBucketOutput, error) {
+				Fail("no bucket should be created in an unknown region")
+				return nil, nil
+			},
+		}
+		recorder := record.NewFakeRecorder(10)
+		r := newTestReconciler(&MockR53Client{}, s3Mock, pd, templateCM)
+		r.Recorder = recorder
+
+		result, err := r.Reconcile(ctx, req)
+		Expect(err).To(MatchError(errInvalidSpec))
+		Expect(errors.Is(err, reconcile.TerminalError(nil))).To(BeTrue())
+		Expect(result.RequeueAfter).To(BeZero())
+		Expect(drainEvents(recorder)).To(ContainElement(ContainSubstring("Warning InvalidSpec")))
+
+		failed := &parkingv1alpha1.ParkedDomain{}
+		Expect(r.Get(ctx, req.NamespacedName, failed)).To(Succeed())
+		spec := meta.FindStatusCondition(failed.Status.Conditions, parkingv1alpha1.ConditionSpecValid)
+		Expect(spec).NotTo(BeNil())
+		Expect(spec.Status).To(Equal(metav1.ConditionFalse))
+		Expect(spec.Reason).To(Equal("InvalidSpec"))
+		Expect(spec.Message).To(ContainSubstring("eu-nowhere-1"))
+	})
+
+	It("should refuse a record outside the domain before touching AWS", func() {
+		ctx := context.Background()
+		pd.Spec.RecordName = "www.victim.org"
+		r53Mock := &MockR53Client{
+			CreateHostedZoneFunc: func(ctx context.Context, params *route53.CreateHostedZoneInput, optFns ...func(*route53.Options)) (*route53.CreateHostedZoneOutput, error) {
+				Fail("no zone should be created for an invalid spec")
+				return nil, nil
+			},
+		}
+		r := newTestReconciler(r53Mock, &MockS3Client{}, pd, templateCM)
+
+		_, err := r.Reconcile(ctx, req)
+		Expect(err).To(MatchError(errInvalidSpec))
+		Expect(err).To(MatchError(ContainSubstring("spec.recordName")))
+		Expect(errors.Is(err, reconcile.TerminalError(nil))).To(BeTrue())
+
+		// Fixing the spec clears the condition on the next reconcile.
+		failed := &parkingv1alpha1.ParkedDomain{}
+		Expect(r.Get(ctx, req.NamespacedName, failed)).To(Succeed())
+		Expect(failed.Status.Status).To(Equal("Error: Invalid Spec"))
+		failed.Spec.RecordName = "www." + failed.Spec.DomainName
+		Expect(r.Update(ctx, failed)).To(Succeed())
+		r.R53Client = &MockR53Client{}
+		_, err = r.Reconcile(ctx, req)
+		Expect(err).NotTo(HaveOccurred())
+		fixed := &parkingv1alpha1.ParkedDomain{}
+		Expect(r.Get(ctx, req.NamespacedName, fixed)).To(Succeed())
+		Expect(meta.FindStatusCondition(fixed.Status.Conditions, parkingv1alpha1.ConditionSpecValid)).To(BeNil())
+	})
+})
+
+var _ = Describe("ParkedDomain concurrent reconciles", func() {
+	const concurrentName = "concurrent-domain"
+
+	BeforeEach(func() {
+		Expect(os.Setenv("TEMPLATE_CONFIGMAP_NAME", "parked-domain-templates")).To(Succeed())
+	})
+
+	AfterEach(func() {
+		Expect(os.Unsetenv("TEMPLATE_CONFIGMAP_NAME")).To(Succeed())
+	})
+
+	It("should not start cleanup while a create reconcile is still running", func() {
+		ctx := context.Background()
+		pd := &parkingv1alpha1.ParkedDomain{
+			ObjectMeta: metav1.ObjectMeta{Name: concurrentName, Namespace: "default", Finalizers: []string{finalizerName}},
+			Spec:       parkingv1alpha1.ParkedDomainSpec{DomainName: "concurrent.example.com"},
+		}
+		templateCM := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "parked-domain-templates", Namespace: "default"},
+			Data:       map[string]string{"default.html": "<h1>{{DOMAIN_NAME}}</h1>"},
+		}
+		req := ctrl.Request{NamespacedName: types.NamespacedName{Name: concurrentName, Namespace: "default"}}
+
+		createStarted := make(chan struct{})
+		releaseCreate := make(chan struct{})
+		var cleanupStarted atomic.Bool
+		s3Client := &MockS3Client{
+			HeadBucketFunc: func(ctx context.Context, params *s3.HeadBucketInput, optFns ...func(*s3.Options)) (*s3.HeadBucketOutput, error) {
+				close(createStarted)
+				<-releaseCreate
+				return &s3.HeadBucketOutput{}, nil
+			},
+			DeleteBucketFunc: func(ctx context.Context, params *s3.DeleteBucketInput, optFns ...func(*s3.Options)) (*s3.DeleteBucketOutput, error) {
+				cleanupStarted.Store(true)
+				return &s3.DeleteBucketOutput{}, nil
+			},
+		}
+		r := newTestReconciler(&MockR53Client{}, s3Client, pd, templateCM)
+
+		createDone := make(chan struct{})
+		go func() {
+			defer GinkgoRecover()
+			defer close(createDone)
+			_, _ = r.Reconcile(ctx, req)
+		}()
+		Eventually(createStarted).Should(BeClosed())
+
+		By("deleting the object while the create reconcile is blocked")
+		Expect(r.Delete(ctx, pd)).To(Succeed())
+		deleteDone := make(chan error, 1)
+		go func() {
+			_, err := r.Reconcile(ctx, req)
+			deleteDone <- err
+		}()
+		Consistently(cleanupStarted.Load, 200*time.Millisecond).Should(BeFalse())
+
+		close(releaseCreate)
+		Eventually(createDone).Should(BeClosed())
+		Eventually(deleteDone).Should(Receive(BeNil()))
+		Expect(cleanupStarted.Load()).To(BeTrue())
+
+		err := r.Get(ctx, req.NamespacedName, &parkingv1alpha1.ParkedDomain{})
+		Expect(apierrors.IsNotFound(err)).To(BeTrue())
+	})
+})
+
+var _ = Describe("ParkedDomain selective teardown", func() {
+	const (
+		selectiveName   = "selective-domain"
+		selectiveDomain = "selective.example.com"
+		websiteEndpoint = "selective.example.com.s3-website.eu-central-1.amazonaws.com"
+	)
+	var (
+		pd                         *parkingv1alpha1.ParkedDomain
+		templateCM                 *corev1.ConfigMap
+		req                        ctrl.Request
+		s3Client                   *MockS3Client
+		r53                        *MockR53Client
+		bucketDeleted, zoneDeleted bool
+		deletedRecords             []string
+		provisionAndUpdate         func(update func(spec *parkingv1alpha1.ParkedDomainSpec)) *ParkedDomainReconciler
+		fetch                      func(r *ParkedDomainReconciler) *parkingv1alpha1.ParkedDomain
+	)
+
+	BeforeEach(func() {
+		Expect(os.Setenv("TEMPLATE_CONFIGMAP_NAME", "parked-domain-templates")).To(Succeed())
+		pd = &parkingv1alpha1.ParkedDomain{
+			ObjectMeta: metav1.ObjectMeta{Name: selectiveName, Namespace: "default", Generation: 1},
+			Spec:       parkingv1alpha1.ParkedDomainSpec{DomainName: selectiveDomain},
+		}
+		templateCM = &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "parked-domain-templates", Namespace: "default"},
+			Data:       map[string]string{"default.html": "<h1>{{DOMAIN_NAME}}</h1>"},
+		}
+		req = ctrl.Request{NamespacedName: types.NamespacedName{Name: selectiveName, Namespace: "default"}}
+
+		bucketDeleted, zoneDeleted, deletedRecords = false, false, nil
+		s3Client = &MockS3Client{
+			DeleteBucketFunc: func(ctx context.Context, params *s3.DeleteBucketInput, optFns ...func(*s3.Options)) (*s3.DeleteBucketOutput, error) {
+				bucketDeleted = true
+				return &s3.DeleteBucketOutput{}, nil
+			},
+		}
+		r53 = &MockR53Client{
+			ListResourceRecordSetsFunc: func(ctx context.Context, params *route53.ListResourceRecordSetsInput, optFns ...func(*route53.Options)) (*route53.ListResourceRecordSetsOutput, error) {
+				if slices.Contains(deletedRecords, selectiveDomain+".") {
+					return &route53.ListResourceRecordSetsOutput{}, nil
+				}
+				return &route53.ListResourceRecordSetsOutput{ResourceRecordSets: []r53types.ResourceRecordSet{{
+					Name:        aws.String(selectiveDomain + "."),
+					Type:        r53types.RRTypeA,
+					AliasTarget: &r53types.AliasTarget{DNSName: aws.String(websiteEndpoint + ".")},
+				}}}, nil
+			},
+			ChangeResourceRecordSetsFunc: func(ctx context.Context, params *route53.ChangeResourceRecordSetsInput, optFns ...func(*route53.Options)) (*route53.ChangeResourceRecordSetsOutput, error) {
+				for _, change := range params.ChangeBatch.Changes {
+					if change.Action == r53types.ChangeActionDelete {
+						deletedRecords = append(deletedRecords, aws.ToString(change.ResourceRecordSet.Name))
+					}
+				}
+				return &route53.ChangeResourceRecordSetsOutput{}, nil
+			},
+			DeleteHostedZoneFunc: func(ctx context.Context, params *route53.DeleteHostedZoneInput, optFns ...func(*route53.Options)) (*route53.DeleteHostedZoneOutput, error) {
+				zoneDeleted = true
+				return &route53.DeleteHostedZoneOutput{}, nil
+			},
+		}
+
+		fetch = func(r *ParkedDomainReconciler) *parkingv1alpha1.ParkedDomain {
+			current := &parkingv1alpha1.ParkedDomain{}
+			Expect(r.Get(context.Background(), req.NamespacedName, current)).To(Succeed())
+			return current
+		}
+		provisionAndUpdate = func(update func(spec *parkingv1alpha1.ParkedDomainSpec)) *ParkedDomainReconciler {
+			ctx := context.Background()
+			r := newTestReconciler(r53, s3Client, pd, templateCM)
+			_, err := r.Reconcile(ctx, req)
+			Expect(err).NotTo(HaveOccurred())
+
+			provisioned := fetch(r)
+			Expect(provisioned.Status.Ready).To(BeTrue())
+			update(&provisioned.Spec)
+			provisioned.Generation++
+			Expect(r.Update(ctx, provisioned)).To(Succeed())
+
+			_, err = r.Reconcile(ctx, req)
+			Expect(err).NotTo(HaveOccurred())
+			return r
+		}
+	})
+
+	AfterEach(func() {
+		Expect(os.Unsetenv("TEMPLATE_CONFIGMAP_NAME")).To(Succeed())
+	})
+
+	It("should remove the bucket and its alias record but keep the zone when storage is disabled", func() {
+		r := provisionAndUpdate(func(spec *parkingv1alpha1.ParkedDomainSpec) { spec.StorageEnabled = aws.Bool(false) })
+
+		Expect(bucketDeleted).To(BeTrue())
+		Expect(deletedRecords).To(Equal([]string{selectiveDomain + "."}))
+		Expect(zoneDeleted).To(BeFalse())
+
+		updated := fetch(r)
+		Expect(updated.Status.Ready).To(BeTrue())
+		Expect(updated.Status.Endpoint).To(BeEmpty())
+		Expect(updated.Status.ZoneID).To(Equal("MOCKZONEID123"))
+		Expect(stepSatisfied(updated, parkingv1alpha1.ConditionZoneReady)).To(BeTrue())
+		Expect(meta.FindStatusCondition(updated.Status.Conditions, parkingv1alpha1.ConditionBucketReady)).To(BeNil())
+		Expect(meta.FindStatusCondition(updated.Status.Conditions, parkingv1alpha1.ConditionRecordReady)).To(BeNil())
+
+		By("still deleting the remaining zone when the ParkedDomain is deleted")
+		Expect(r.Delete(context.Background(), updated)).To(Succeed())
+		_, err := r.Reconcile(context.Background(), req)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(zoneDeleted).To(BeTrue())
+	})
+
+	It("should remove the zone but keep the bucket when DNS is disabled", func() {
+		r := provisionAndUpdate(func(spec *parkingv1alpha1.ParkedDomainSpec) { spec.DNSEnabled = aws.Bool(false) })
+
+		Expect(zoneDeleted).To(BeTrue())
+		Expect(bucketDeleted).To(BeFalse())
+
+		updated := fetch(r)
+		Expect(updated.Status.Ready).To(BeTrue())
+		Expect(updated.Status.ZoneID).To(BeEmpty())
+		Expect(updated.Status.NameServers).To(BeEmpty())
+		Expect(updated.Status.Endpoint).To(Equal(websiteEndpoint))
+		Expect(stepSatisfied(updated, parkingv1alpha1.ConditionBucketReady)).To(BeTrue())
+		Expect(meta.FindStatusCondition(updated.Status.Conditions, parkingv1alpha1.ConditionZoneReady)).To(BeNil())
+		Expect(meta.FindStatusCondition(updated.Status.Conditions, parkingv1alpha1.ConditionRecordReady)).To(BeNil())
+
+		By("still deleting the remaining bucket when the ParkedDomain is deleted")
+		Expect(r.Delete(context.Background(), updated)).To(Succeed())
+		_, err := r.Reconcile(context.Background(), req)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(bucketDeleted).To(BeTrue())
+	})
+})
+
+var _ = Describe("ParkedDomain enforced Block Public Access", func() {
+	var (
+		pd         *parkingv1alpha1.ParkedDomain
+		templateCM *corev1.ConfigMap
+		req        ctrl.Request
+		uploads    int
+		policies   int
+		s3Mock     *MockS3Client
+	)
+
+	BeforeEach(func() {
+		Expect(os.Setenv("TEMPLATE_CONFIGMAP_NAME", "parked-domain-templates")).To(Succeed())
+		pd = &parkingv1alpha1.ParkedDomain{
+			ObjectMeta: metav1.ObjectMeta{Name: "bpa-domain", Namespace: "default"},
+			Spec:       parkingv1alpha1.ParkedDomainSpec{DomainName: "bpa.example.com"},
+		}
+		templateCM = &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "parked-domain-templates", Namespace: "default"},
+			Data:       map[string]string{"default.html": "<h1>{{DOMAIN_NAME}}</h1>"},
+		}
+		req = ctrl.Request{NamespacedName: types.NamespacedName{Name: "bpa-domain", Namespace: "default"}}
+		uploads, policies = 0, 0
+		s3Mock = &MockS3Client{
+			GetPublicAccessBlockFunc: func(ctx context.Context, params *s3.GetPublicAccessBlockInput, optFns ...func(*s3.Options)) (*s3.GetPublicAccessBlockOutput, error) {
+				return &s3.GetPublicAccessBlockOutput{PublicAccessBlockConfiguration: &s3types.PublicAccessBlockConfiguration{
+					BlockPublicPolicy:     aws.Bool(true),
+					RestrictPublicBuckets: aws.Bool(true),
+				}}, nil
+			},
+			PutObjectFunc: func(ctx context.Context, params *s3.PutObjectInput, optFns ...func(*s3.Options)) (*s3.PutObjectOutput, error) {
+				uploads++
+				return &s3.PutObjectOutput{}, nil
+			},
+			PutBucketPolicyFunc: func(ctx context.Context, params *s3.PutBucketPolicyInput, optFns ...func(*s3.Options)) (*s3.PutBucketPolicyOutput, error) {
+				policies++
+				return &s3.PutBucketPolicyOutput{}, nil
+			},
+		}
+	})
+
+	AfterEach(func() {
+		Expect(os.Unsetenv("TEMPLATE_CONFIGMAP_NAME")).To(Succeed())
+	})
+
+	It("should fail before uploading a page that cannot be served", func() {
+		ctx := context.Background()
+		recorder := record.NewFakeRecorder(10)
+		r := newTestReconciler(&MockR53Client{}, s3Mock, pd, templateCM)
+		r.Recorder = recorder
+
+		_, err := r.Reconcile(ctx, req)
+		Expect(err).To(MatchError(errPublicAccessBlocked))
+		Expect(uploads).To(BeZero())
+		Expect(policies).To(BeZero())
+		Expect(drainEvents(recorder)).To(ContainElement(ContainSubstring("Warning PublicAccessBlocked")))
+
+		failed := &parkingv1alpha1.ParkedDomain{}
+		Expect(r.Get(ctx, req.NamespacedName, failed)).To(Succeed())
+		bucket := meta.FindStatusCondition(failed.Status.Conditions, parkingv1alpha1.ConditionBucketReady)
+		Expect(bucket).NotTo(BeNil())
+		Expect(bucket.Status).To(Equal(metav1.ConditionFalse))
+		Expect(bucket.Reason).To(Equal("PublicAccessBlocked"))
+		blocked := meta.FindStatusCondition(failed.Status.Conditions, parkingv1alpha1.ConditionPublicAccessBlocked)
+		Expect(blocked).NotTo(BeNil())
+		Expect(blocked.Reason).To(Equal("PublicPolicyBlocked"))
+		Expect(blocked.Message).To(ContainSubstring("spec.requireCDNWhenBPAEnforced"))
+	})
+
+	It("should keep the bucket private when a CDN is required", func() {
+		ctx := context.Background()
+		pd.Spec.RequireCDNWhenBPAEnforced = true
+		r := newTestReconciler(&MockR53Client{}, s3Mock, pd, templateCM)
+
+		_, err := r.Reconcile(ctx, req)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(uploads).To(Equal(1))
+		Expect(policies).To(BeZero())
+
+		provisioned := &parkingv1alpha1.ParkedDomain{}
+		Expect(r.Get(ctx, req.NamespacedName, provisioned)).To(Succeed())
+		Expect(provisioned.Status.Ready).To(BeTrue())
+		blocked := meta.FindStatusCondition(provisioned.Status.Conditions, parkingv1alpha1.ConditionPublicAccessBlocked)
+		Expect(blocked).NotTo(BeNil())
+		Expect(blocked.Reason).To(Equal("CDNRequired"))
+	})
+
+	It("should apply the public policy once the block is lifted", func() {
+		ctx := context.Background()
+		pd.Spec.RequireCDNWhenBPAEnforced = true
+		pd.Status.Conditions = []metav1.Condition{{
+			Type: parkingv1alpha1.ConditionPublicAccessBlocked, Status: metav1.ConditionTrue,
+			Reason: "CDNRequired", LastTransitionTime: metav1.Now(),
+		}}
+		s3Mock.GetPublicAccessBlockFunc = func(ctx context.Context, params *s3.GetPublicAccessBlockInput, optFns ...func(*s3.Options)) (*s3.GetPublicAccessBlockOutput, error) {
+			return nil, &smithy.GenericAPIError{Code: "NoSuchPublicAccessBlockConfiguration"}
+		}
+		r := newTestReconciler(&MockR53Client{}, s3Mock, pd, templateCM)
+
+		_, err := r.Reconcile(ctx, req)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(policies).To(Equal(1))
+
+		provisioned := &parkingv1alpha1.ParkedDomain{}
+		Expect(r.Get(ctx, req.NamespacedName, provisioned)).To(Succeed())
+		Expect(meta.FindStatusCondition(provisioned.Status.Conditions, parkingv1alpha1.ConditionPublicAccessBlocked)).To(BeNil())
+	})
+})
+
+var _ = Describe("ParkedDomain placeholder address", func() {
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: "placeholder-domain", Namespace: "default"}}
+
+	BeforeEach(func() {
+		Expect(os.Setenv("TEMPLATE_CONFIGMAP_NAME", "parked-domain-templates")).To(Succeed())
+	})
+
+	AfterEach(func() {
+		Expect(os.Unsetenv("TEMPLATE_CONFIGMAP_NAME")).To(Succeed())
+	})
+
+	It("should point the record at the placeholder until the bucket is ready", func() {
+		ctx := context.Background()
+		pd := &parkingv1alpha1.ParkedDomain{
+			ObjectMeta: metav1.ObjectMeta{Name: "placeholder-domain", Namespace: "default"},
+			Spec:       parkingv1alpha1.ParkedDomainSpec{DomainName: "placeholder.example.com", PlaceholderAddress: "198.51.100.10"},
+		}
+		templateCM := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "parked-domain-templates", Namespace: "default"},
+			Data:       map[string]string{"default.html": "<h1>{{DOMAIN_NAME}}</h1>"},
+		}
+		var records []r53types.ResourceRecordSet
+		r53Mock := &MockR53Client{
+			ChangeResourceRecordSetsFunc: func(ctx context.Context, params *route53.ChangeResourceRecordSetsInput, optFns ...func(*route53.Options)) (*route53.ChangeResourceRecordSetsOutput, error) {
+				for _, change := range params.ChangeBatch.Changes {
+					records = append(records, *change.ResourceRecordSet)
+				}
+				return &route53.ChangeResourceRecordSetsOutput{}, nil
+			},
+		}
+		uploadErr := errors.New("upload interrupted")
+		s3Mock := &MockS3Client{
+			PutObjectFunc: func(ctx context.Context, params *s3.PutObjectInput, optFns ...func(*s3.Options)) (*s3.PutObjectOutput, error) {
+				return nil, uploadErr
+			},
+		}
+		r := newTestReconciler(r53Mock, s3Mock, pd, templateCM)
+
+		By("failing the bucket after the zone is created")
+		_, err := r.Reconcile(ctx, req)
+		Expect(err).To(MatchError(uploadErr))
+		Expect(records).To(HaveLen(2))
+		Expect(aws.ToString(records[0].Name)).To(Equal("placeholder.example.com"))
+		Expect(aws.ToString(records[1].Name)).To(Equal("_pdo-owner.placeholder.example.com"))
+		Expect(records[0].AliasTarget).To(BeNil())
+		Expect(records[0].ResourceRecords).To(HaveLen(1))
+		Expect(aws.ToString(records[0].ResourceRecords[0].Value)).To(Equal("198.51.100.10"))
+
+		pending := &parkingv1alpha1.ParkedDomain{}
+		Expect(r.Get(ctx, req.NamespacedName, pending)).To(Succeed())
+		record := meta.FindStatusCondition(pending.Status.Conditions, parkingv1alpha1.ConditionRecordReady)
+		Expect(record).NotTo(BeNil())
+		Expect(record.Status).To(Equal(metav1.ConditionFalse))
+		Expect(record.Reason).To(Equal("PlaceholderAddress"))
+
+		By("retrying without pointing at the placeholder again")
+		records = nil
+		_, err = r.Reconcile(ctx, req)
+		Expect(err).To(MatchError(uploadErr))
+		Expect(records).To(BeEmpty())
+
+		By("swapping in the alias once the bucket is ready")
+		s3Mock.PutObjectFunc = nil
+		_, err = r.Reconcile(ctx, req)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(records).To(HaveLen(2))
+		Expect(records[0].ResourceRecords).To(BeEmpty())
+		Expect(records[0].AliasTarget).NotTo(BeNil())
+		Expect(aws.ToString(records[0].AliasTarget.DNSName)).To(Equal("placeholder.example.com.s3-website.eu-central-1.amazonaws.com"))
+
+		provisioned := &parkingv1alpha1.ParkedDomain{}
+		Expect(r.Get(ctx, req.NamespacedName, provisioned)).To(Succeed())
+		Expect(provisioned.Status.Ready).To(BeTrue())
+		Expect(meta.IsStatusConditionTrue(provisioned.Status.Conditions, parkingv1alpha1.ConditionRecordReady)).To(BeTrue())
+	})
+})
+
+var _ = Describe("ParkedDomain watched namespaces", func() {
+	inNamespace := func(namespace string) *parkingv1alpha1.ParkedDomain {
+		return &parkingv1alpha1.ParkedDomain{ObjectMeta: metav1.ObjectMeta{Name: "scoped-domain", Namespace: namespace}}
+	}
+
+	It("should ignore ParkedDomains outside the watched namespaces", func() {
+		r := &ParkedDomainReconciler{WatchNamespaces: []string{"team-a", "team-b"}}
+		p := r.watchedNamespacePredicate()
+
+		Expect(p.Create(event.CreateEvent{Object: inNamespace("team-a")})).To(BeTrue())
+		Expect(p.Create(event.CreateEvent{Object: inNamespace("team-b")})).To(BeTrue())
+		Expect(p.Create(event.CreateEvent{Object: inNamespace("team-c")})).To(BeFalse())
+		Expect(p.Update(event.UpdateEvent{ObjectOld: inNamespace("team-c"), ObjectNew: inNamespace("team-c")})).To(BeFalse())
+		Expect(p.Delete(event.DeleteEvent{Object: inNamespace("team-c")})).To(BeFalse())
+	})
+
+	It("should watch every namespace by default", func() {
+		p := (&ParkedDomainReconciler{}).watchedNamespacePredicate()
+
+		Expect(p.Create(event.CreateEvent{Object: inNamespace("team-c")})).To(BeTrue())
+	})
+})
@@ -0,0 +1,262 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	parkingv1alpha1 "github.com/gminiba/parked-domain-operator/api/v1alpha1"
+)
+
+const (
+	// claimFinalizerName keeps a ParkedDomainClaim until the ParkedDomain created for it is gone.
+	claimFinalizerName = parkingv1alpha1.GroupName + "/claim-finalizer"
+	// claimNamespaceLabel labels a claimed ParkedDomain with the namespace of its claim, so the
+	// domains of a namespace can be listed.
+	claimNamespaceLabel = parkingv1alpha1.GroupName + "/claim-namespace"
+	// claimAnnotation records the namespace/name of the claim a ParkedDomain was created for.
+	// Claims only ever act on the ParkedDomain carrying their own key.
+	claimAnnotation = parkingv1alpha1.GroupName + "/claim"
+)
+
+const (
+	// claimReasonBound is the Bound reason of a claim whose ParkedDomain was created.
+	claimReasonBound = "Bound"
+	// claimReasonNameConflict is the Bound reason of a claim whose ParkedDomain name is taken by
+	// a ParkedDomain not created for it.
+	claimReasonNameConflict = "NameConflict"
+	// claimReasonDomainNameChanged is the Bound reason of a claim whose domain name no longer
+	// matches its ParkedDomain's, which never follows such a change.
+	claimReasonDomainNameChanged = "DomainNameChanged"
+)
+
+// errClaimNameConflict is returned when a claim's ParkedDomain name is taken by another ParkedDomain.
+var errClaimNameConflict = errors.New("ParkedDomain exists and was not created for this claim")
+
+// errClaimDomainNameChanged is returned when a claim's domain name differs from its existing
+// ParkedDomain's. Changing the ParkedDomain's would leave the old domain's AWS resources behind.
+var errClaimDomainNameChanged = errors.New("the domain name of a claim cannot change")
+
+// ParkedDomainClaimReconciler reconciles a ParkedDomainClaim object into a ParkedDomain in
+// ClaimNamespace, if the policy of the claim's namespace allows it.
+type ParkedDomainClaimReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+
+	// Recorder, if set, emits Kubernetes events for claims the policy does not allow.
+	Recorder record.EventRecorder
+	// ClaimNamespace is the namespace the ParkedDomains of all claims are created in. Only the
+	// operator and cluster admins should be able to write to it.
+	ClaimNamespace string
+}
+
+// +kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch
+// +kubebuilder:rbac:groups=parking.minibaev.eu,resources=parkeddomainclaims,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=parking.minibaev.eu,resources=parkeddomainclaims/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=parking.minibaev.eu,resources=parkeddomainclaims/finalizers,verbs=update
+
+// Reconcile creates or updates the ParkedDomain of a ParkedDomainClaim and reports its state
+// in the claim's status, or deletes it once the claim is deleted.
+func (r *ParkedDomainClaimReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	claim := &parkingv1alpha1.ParkedDomainClaim{}
+	if err := r.Get(ctx, req.NamespacedName, claim); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	key := client.ObjectKey{Namespace: r.ClaimNamespace, Name: claimedDomainName(claim)}
+
+	if !claim.DeletionTimestamp.IsZero() {
+		return ctrl.Result{}, r.releaseClaim(ctx, claim, key)
+	}
+	if controllerutil.AddFinalizer(claim, claimFinalizerName) {
+		if err := r.Update(ctx, claim); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+	status := claim.Status.DeepCopy()
+	claim.Status.ObservedGeneration = claim.Generation
+
+	namespace := &corev1.Namespace{}
+	if err := r.Get(ctx, client.ObjectKey{Name: claim.Namespace}, namespace); err != nil {
+		return ctrl.Result{}, err
+	}
+	// A claim the policy no longer allows keeps its ParkedDomain, so tightening a policy
+	// never takes a parked domain down; the domain is neither updated nor deleted by it.
+	if violation := checkClaimPolicy(namespace, claim); violation != nil {
+		if setClaimBound(claim, metav1.ConditionFalse, violation.reason, violation.message) && r.Recorder != nil {
+			r.Recorder.Event(claim, corev1.EventTypeWarning, violation.reason, violation.message)
+		}
+		return ctrl.Result{}, r.updateClaimStatus(ctx, claim, status)
+	}
+
+	pd := &parkingv1alpha1.ParkedDomain{ObjectMeta: metav1.ObjectMeta{Name: key.Name, Namespace: key.Namespace}}
+	op, err := controllerutil.CreateOrUpdate(ctx, r.Client, pd, func() error {
+		if pd.ResourceVersion != "" && !claimedBy(pd, claim) {
+			return errClaimNameConflict
+		}
+		if pd.ResourceVersion != "" && pd.Spec.DomainName != claim.Spec.DomainName {
+			return errClaimDomainNameChanged
+		}
+		if pd.Labels == nil {
+			pd.Labels = map[string]string{}
+		}
+		pd.Labels[claimNamespaceLabel] = claim.Namespace
+		if pd.Annotations == nil {
+			pd.Annotations = map[string]string{}
+		}
+		pd.Annotations[claimAnnotation] = client.ObjectKeyFromObject(claim).String()
+		pd.Spec.DomainName = claim.Spec.DomainName
+		pd.Spec.Region = claim.Spec.Region
+		pd.Spec.ParkingMode = claim.Spec.ParkingMode
+		// Only a claim in the claim namespace can own its ParkedDomain; the finalizer
+		// deletes the ParkedDomains of all other claims.
+		_, err := setOwnerRef(claim, pd, r.Scheme)
+		return err
+	})
+	if errors.Is(err, errClaimNameConflict) {
+		setClaimBound(claim, metav1.ConditionFalse, claimReasonNameConflict, fmt.Sprintf("ParkedDomain %s %s", key, err))
+		return ctrl.Result{}, r.updateClaimStatus(ctx, claim, status)
+	}
+	if errors.Is(err, errClaimDomainNameChanged) {
+		message := fmt.Sprintf("ParkedDomain %s parks %s: %s; delete the claim and create a new one for %s",
+			key, pd.Spec.DomainName, err, claim.Spec.DomainName)
+		if setClaimBound(claim, metav1.ConditionFalse, claimReasonDomainNameChanged, message) && r.Recorder != nil {
+			r.Recorder.Event(claim, corev1.EventTypeWarning, claimReasonDomainNameChanged, message)
+		}
+		return ctrl.Result{}, r.updateClaimStatus(ctx, claim, status)
+	}
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if op != controllerutil.OperationResultNone {
+		logger.Info("Materialized ParkedDomain for claim", "parkedDomain", key, "operation", op)
+	}
+
+	claim.Status.ParkedDomainName = pd.Name
+	claim.Status.Ready = pd.Status.Ready
+	claim.Status.NameServers = pd.Status.NameServers
+	claim.Status.WebsiteURL = pd.Status.WebsiteURL
+	setClaimBound(claim, metav1.ConditionTrue, claimReasonBound, fmt.Sprintf("ParkedDomain %s created for the claim", key))
+	return ctrl.Result{}, r.updateClaimStatus(ctx, claim, status)
+}
+
+// releaseClaim deletes the ParkedDomain of a deleted claim and removes the claim's finalizer
+// once the ParkedDomain, and with it its AWS resources, is gone.
+func (r *ParkedDomainClaimReconciler) releaseClaim(ctx context.Context, claim *parkingv1alpha1.ParkedDomainClaim, key client.ObjectKey) error {
+	if !controllerutil.ContainsFinalizer(claim, claimFinalizerName) {
+		return nil
+	}
+	pd := &parkingv1alpha1.ParkedDomain{}
+	err := r.Get(ctx, key, pd)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+	if err == nil && claimedBy(pd, claim) {
+		if pd.DeletionTimestamp.IsZero() {
+			log.FromContext(ctx).Info("Deleting ParkedDomain of deleted claim", "parkedDomain", key)
+			if err := r.Delete(ctx, pd); err != nil && !apierrors.IsNotFound(err) {
+				return err
+			}
+		}
+		// The ParkedDomain watch queues the claim again once its cleanup finished.
+		return nil
+	}
+	controllerutil.RemoveFinalizer(claim, claimFinalizerName)
+	return r.Update(ctx, claim)
+}
+
+// updateClaimStatus writes the claim's status unless it still equals previous.
+func (r *ParkedDomainClaimReconciler) updateClaimStatus(ctx context.Context, claim *parkingv1alpha1.ParkedDomainClaim, previous *parkingv1alpha1.ParkedDomainClaimStatus) error {
+	if equality.Semantic.DeepEqual(&claim.Status, previous) {
+		return nil
+	}
+	return r.Status().Update(ctx, claim)
+}
+
+// setClaimBound sets the claim's Bound condition and reports whether it changed.
+func setClaimBound(claim *parkingv1alpha1.ParkedDomainClaim, status metav1.ConditionStatus, reason, message string) bool {
+	return meta.SetStatusCondition(&claim.Status.Conditions, metav1.Condition{
+		Type:               parkingv1alpha1.ConditionBound,
+		Status:             status,
+		Reason:             reason,
+		Message:            message,
+		ObservedGeneration: claim.Generation,
+	})
+}
+
+// claimedDomainName is the name of the ParkedDomain created for claim.
+func claimedDomainName(claim *parkingv1alpha1.ParkedDomainClaim) string {
+	return claim.Namespace + "-" + claim.Name
+}
+
+// claimedBy reports whether pd was created for claim.
+func claimedBy(pd *parkingv1alpha1.ParkedDomain, claim *parkingv1alpha1.ParkedDomainClaim) bool {
+	return pd.Annotations[claimAnnotation] == client.ObjectKeyFromObject(claim).String()
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *ParkedDomainClaimReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&parkingv1alpha1.ParkedDomainClaim{}).
+		Watches(&parkingv1alpha1.ParkedDomain{}, handler.EnqueueRequestsFromMapFunc(r.claimForDomain)).
+		Watches(&corev1.Namespace{}, handler.EnqueueRequestsFromMapFunc(r.claimsInNamespace)).
+		Complete(r)
+}
+
+// claimForDomain maps a ParkedDomain to the claim it was created for, if any.
+func (r *ParkedDomainClaimReconciler) claimForDomain(ctx context.Context, obj client.Object) []reconcile.Request {
+	if obj.GetNamespace() != r.ClaimNamespace {
+		return nil
+	}
+	namespace, name, ok := strings.Cut(obj.GetAnnotations()[claimAnnotation], "/")
+	if !ok {
+		return nil
+	}
+	return []reconcile.Request{{NamespacedName: client.ObjectKey{Namespace: namespace, Name: name}}}
+}
+
+// claimsInNamespace maps a Namespace to its claims, so they are checked again when its policy changes.
+func (r *ParkedDomainClaimReconciler) claimsInNamespace(ctx context.Context, obj client.Object) []reconcile.Request {
+	var claims parkingv1alpha1.ParkedDomainClaimList
+	if err := r.List(ctx, &claims, client.InNamespace(obj.GetName())); err != nil {
+		log.FromContext(ctx).Error(err, "Failed to list ParkedDomainClaims for namespace", "namespace", obj.GetName())
+		return nil
+	}
+	requests := make([]reconcile.Request, 0, len(claims.Items))
+	for i := range claims.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&claims.Items[i])})
+	}
+	return requests
+}
@@ -0,0 +1,231 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	parkingv1alpha1 "github.com/gminiba/parked-domain-operator/api/v1alpha1"
+)
+
+// newTestClaimReconciler returns a ParkedDomainClaimReconciler creating ParkedDomains in "parking".
+func newTestClaimReconciler(objs ...client.Object) *ParkedDomainClaimReconciler {
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(scheme.Scheme).
+		WithObjects(objs...).
+		WithStatusSubresource(&parkingv1alpha1.ParkedDomainClaim{}, &parkingv1alpha1.ParkedDomain{}).
+		Build()
+	return &ParkedDomainClaimReconciler{
+		Client:         fakeClient,
+		Scheme:         scheme.Scheme,
+		ClaimNamespace: "parking",
+	}
+}
+
+var _ = Describe("ParkedDomainClaim Controller", func() {
+	var (
+		namespace *corev1.Namespace
+		claim     *parkingv1alpha1.ParkedDomainClaim
+	)
+
+	BeforeEach(func() {
+		namespace = &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+			Name: "team-a",
+			Annotations: map[string]string{
+				allowedDomainsAnnotation: "example.com, example.org",
+				allowedRegionsAnnotation: "eu-central-1,eu-west-1",
+			},
+		}}
+		claim = &parkingv1alpha1.ParkedDomainClaim{
+			ObjectMeta: metav1.ObjectMeta{Name: "shop", Namespace: "team-a", Generation: 1},
+			Spec: parkingv1alpha1.ParkedDomainClaimSpec{
+				DomainName:  "shop.example.com",
+				Region:      "eu-west-1",
+				ParkingMode: parkingv1alpha1.ParkingModeComingSoon,
+			},
+		}
+	})
+
+	reconcileClaim := func(r *ParkedDomainClaimReconciler) *parkingv1alpha1.ParkedDomainClaim {
+		ctx := context.Background()
+		_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: client.ObjectKeyFromObject(claim)})
+		Expect(err).NotTo(HaveOccurred())
+		reconciled := &parkingv1alpha1.ParkedDomainClaim{}
+		Expect(r.Get(ctx, client.ObjectKeyFromObject(claim), reconciled)).To(Succeed())
+		return reconciled
+	}
+
+	It("should materialize an allowed claim into a ParkedDomain in the claim namespace", func() {
+		ctx := context.Background()
+		r := newTestClaimReconciler(namespace, claim)
+
+		reconciled := reconcileClaim(r)
+		Expect(reconciled.Finalizers).To(ContainElement(claimFinalizerName))
+		bound := meta.FindStatusCondition(reconciled.Status.Conditions, parkingv1alpha1.ConditionBound)
+		Expect(bound).NotTo(BeNil())
+		Expect(bound.Status).To(Equal(metav1.ConditionTrue))
+		Expect(reconciled.Status.ParkedDomainName).To(Equal("team-a-shop"))
+
+		pd := &parkingv1alpha1.ParkedDomain{}
+		Expect(r.Get(ctx, client.ObjectKey{Namespace: "parking", Name: "team-a-shop"}, pd)).To(Succeed())
+		Expect(pd.Spec.DomainName).To(Equal("shop.example.com"))
+		Expect(pd.Spec.Region).To(Equal("eu-west-1"))
+		Expect(pd.Spec.ParkingMode).To(Equal(parkingv1alpha1.ParkingModeComingSoon))
+		Expect(pd.Labels).To(HaveKeyWithValue(claimNamespaceLabel, "team-a"))
+		Expect(pd.Annotations).To(HaveKeyWithValue(claimAnnotation, "team-a/shop"))
+		Expect(r.claimForDomain(ctx, pd)).To(ConsistOf(ctrl.Request{NamespacedName: client.ObjectKeyFromObject(claim)}))
+
+		By("reporting the ParkedDomain's status in the claim")
+		pd.Status.Ready = true
+		pd.Status.NameServers = []string{"ns-1.awsdns.com"}
+		pd.Status.WebsiteURL = "http://shop.example.com"
+		Expect(r.Status().Update(ctx, pd)).To(Succeed())
+		reconciled = reconcileClaim(r)
+		Expect(reconciled.Status.Ready).To(BeTrue())
+		Expect(reconciled.Status.NameServers).To(Equal([]string{"ns-1.awsdns.com"}))
+		Expect(reconciled.Status.WebsiteURL).To(Equal("http://shop.example.com"))
+
+		By("updating the ParkedDomain when the claim changes")
+		reconciled.Spec.Region = "eu-central-1"
+		Expect(r.Update(ctx, reconciled)).To(Succeed())
+		reconcileClaim(r)
+		Expect(r.Get(ctx, client.ObjectKeyFromObject(pd), pd)).To(Succeed())
+		Expect(pd.Spec.Region).To(Equal("eu-central-1"))
+	})
+
+	It("should not create a ParkedDomain for a domain the namespace may not park", func() {
+		recorder := record.NewFakeRecorder(10)
+		claim.Spec.DomainName = "shop.example.net"
+		r := newTestClaimReconciler(namespace, claim)
+		r.Recorder = recorder
+
+		reconciled := reconcileClaim(r)
+		bound := meta.FindStatusCondition(reconciled.Status.Conditions, parkingv1alpha1.ConditionBound)
+		Expect(bound).NotTo(BeNil())
+		Expect(bound.Status).To(Equal(metav1.ConditionFalse))
+		Expect(bound.Reason).To(Equal(claimReasonDomainNotAllowed))
+		Expect(drainEvents(recorder)).To(ConsistOf(ContainSubstring(claimReasonDomainNotAllowed)))
+
+		var domains parkingv1alpha1.ParkedDomainList
+		Expect(r.List(context.Background(), &domains)).To(Succeed())
+		Expect(domains.Items).To(BeEmpty())
+
+		By("not repeating the event while the claim stays denied")
+		reconcileClaim(r)
+		Expect(drainEvents(recorder)).To(BeEmpty())
+	})
+
+	It("should not create a ParkedDomain in a region the namespace may not use", func() {
+		claim.Spec.Region = "us-east-1"
+		r := newTestClaimReconciler(namespace, claim)
+
+		reconciled := reconcileClaim(r)
+		bound := meta.FindStatusCondition(reconciled.Status.Conditions, parkingv1alpha1.ConditionBound)
+		Expect(bound).NotTo(BeNil())
+		Expect(bound.Reason).To(Equal(claimReasonRegionNotAllowed))
+		err := r.Get(context.Background(), client.ObjectKey{Namespace: "parking", Name: "team-a-shop"}, &parkingv1alpha1.ParkedDomain{})
+		Expect(apierrors.IsNotFound(err)).To(BeTrue())
+	})
+
+	It("should apply the namespace's policy", func() {
+		allowed := func(annotations map[string]string, domain, region string) bool {
+			ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team-a", Annotations: annotations}}
+			c := &parkingv1alpha1.ParkedDomainClaim{Spec: parkingv1alpha1.ParkedDomainClaimSpec{DomainName: domain, Region: region}}
+			return checkClaimPolicy(ns, c) == nil
+		}
+		Expect(allowed(nil, "example.com", "")).To(BeFalse())
+		Expect(allowed(map[string]string{allowedDomainsAnnotation: "example.com"}, "example.com", "us-east-1")).To(BeTrue())
+		Expect(allowed(map[string]string{allowedDomainsAnnotation: "example.com"}, "a.b.Example.com.", "")).To(BeTrue())
+		Expect(allowed(map[string]string{allowedDomainsAnnotation: "example.com"}, "badexample.com", "")).To(BeFalse())
+		Expect(allowed(map[string]string{allowedDomainsAnnotation: "*"}, "anything.net", "")).To(BeTrue())
+		Expect(allowed(map[string]string{allowedDomainsAnnotation: "*", allowedRegionsAnnotation: ""}, "a.net", "eu-west-1")).To(BeFalse())
+	})
+
+	It("should not take over a ParkedDomain that was not created for it", func() {
+		existing := &parkingv1alpha1.ParkedDomain{
+			ObjectMeta: metav1.ObjectMeta{Name: "team-a-shop", Namespace: "parking"},
+			Spec:       parkingv1alpha1.ParkedDomainSpec{DomainName: "other.example.com"},
+		}
+		r := newTestClaimReconciler(namespace, claim, existing)
+
+		reconciled := reconcileClaim(r)
+		bound := meta.FindStatusCondition(reconciled.Status.Conditions, parkingv1alpha1.ConditionBound)
+		Expect(bound).NotTo(BeNil())
+		Expect(bound.Reason).To(Equal(claimReasonNameConflict))
+		Expect(r.Get(context.Background(), client.ObjectKeyFromObject(existing), existing)).To(Succeed())
+		Expect(existing.Spec.DomainName).To(Equal("other.example.com"))
+	})
+
+	It("should not push a changed domain name to its ParkedDomain", func() {
+		ctx := context.Background()
+		recorder := record.NewFakeRecorder(10)
+		r := newTestClaimReconciler(namespace, claim)
+		r.Recorder = recorder
+		reconciled := reconcileClaim(r)
+
+		// Admission rejects the change, unless the claim was stored before the CRD had the rule.
+		reconciled.Spec.DomainName = "www.example.com"
+		Expect(r.Update(ctx, reconciled)).To(Succeed())
+		reconciled = reconcileClaim(r)
+		bound := meta.FindStatusCondition(reconciled.Status.Conditions, parkingv1alpha1.ConditionBound)
+		Expect(bound).NotTo(BeNil())
+		Expect(bound.Status).To(Equal(metav1.ConditionFalse))
+		Expect(bound.Reason).To(Equal(claimReasonDomainNameChanged))
+		Expect(bound.Message).To(ContainSubstring("parks shop.example.com"))
+		Expect(bound.Message).To(ContainSubstring("create a new one for www.example.com"))
+		Expect(drainEvents(recorder)).To(ConsistOf(ContainSubstring(claimReasonDomainNameChanged)))
+
+		pd := &parkingv1alpha1.ParkedDomain{}
+		Expect(r.Get(ctx, client.ObjectKey{Namespace: "parking", Name: "team-a-shop"}, pd)).To(Succeed())
+		Expect(pd.Spec.DomainName).To(Equal("shop.example.com"))
+
+		By("binding again once the domain name is changed back")
+		reconciled.Spec.DomainName = "shop.example.com"
+		Expect(r.Update(ctx, reconciled)).To(Succeed())
+		reconciled = reconcileClaim(r)
+		Expect(meta.IsStatusConditionTrue(reconciled.Status.Conditions, parkingv1alpha1.ConditionBound)).To(BeTrue())
+	})
+
+	It("should delete the ParkedDomain before releasing a deleted claim", func() {
+		ctx := context.Background()
+		r := newTestClaimReconciler(namespace, claim)
+		reconcileClaim(r)
+
+		Expect(r.Delete(ctx, claim)).To(Succeed())
+		reconcileClaim(r)
+		err := r.Get(ctx, client.ObjectKey{Namespace: "parking", Name: "team-a-shop"}, &parkingv1alpha1.ParkedDomain{})
+		Expect(apierrors.IsNotFound(err)).To(BeTrue())
+
+		By("removing the finalizer once the ParkedDomain is gone")
+		_, err = r.Reconcile(ctx, ctrl.Request{NamespacedName: client.ObjectKeyFromObject(claim)})
+		Expect(err).NotTo(HaveOccurred())
+		err = r.Get(ctx, client.ObjectKeyFromObject(claim), &parkingv1alpha1.ParkedDomainClaim{})
+		Expect(apierrors.IsNotFound(err)).To(BeTrue())
+	})
+})
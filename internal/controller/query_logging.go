@@ -0,0 +1,116 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/route53"
+	parkingv1alpha1 "github.com/gminiba/parked-domain-operator/api/v1alpha1"
+	"github.com/gminiba/parked-domain-operator/internal/awserr"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// queryLoggingRegion is the region of the CloudWatch Logs log groups Route 53 logs queries to.
+const queryLoggingRegion = "us-east-1"
+
+// reconcileQueryLogging logs the Hosted Zone's DNS queries to the log group in
+// Spec.QueryLogging, replacing a configuration for another log group, and stops logging when
+// Spec.QueryLogging is unset. The configuration's ID is kept in Status.QueryLoggingConfigID.
+func (r *ParkedDomainReconciler) reconcileQueryLogging(ctx context.Context, pd *parkingv1alpha1.ParkedDomain) error {
+	r53Client, err := r.r53ClientFor(ctx, pd)
+	if err != nil {
+		return err
+	}
+	if pd.Spec.QueryLogging == nil {
+		return deleteQueryLogging(ctx, r53Client, pd)
+	}
+	logGroupARN := pd.Spec.QueryLogging.LogGroupARN
+
+	if id := pd.Status.QueryLoggingConfigID; id != "" {
+		getOutput, err := r53Client.GetQueryLoggingConfig(ctx, &route53.GetQueryLoggingConfigInput{Id: aws.String(id)})
+		switch {
+		case awserr.IsNotFound(err):
+			// Deleted outside the operator, so create it again below.
+			pd.Status.QueryLoggingConfigID = ""
+		case err != nil:
+			return fmt.Errorf("failed to get query logging configuration %s: %w", id, err)
+		case aws.ToString(getOutput.QueryLoggingConfig.CloudWatchLogsLogGroupArn) == logGroupARN:
+			return nil
+		default:
+			// A zone has at most one configuration, so the old one goes first.
+			if err := deleteQueryLogging(ctx, r53Client, pd); err != nil {
+				return err
+			}
+		}
+	}
+
+	createOutput, err := r53Client.CreateQueryLoggingConfig(ctx, &route53.CreateQueryLoggingConfigInput{
+		HostedZoneId:              aws.String(pd.Status.ZoneID),
+		CloudWatchLogsLogGroupArn: aws.String(logGroupARN),
+	})
+	switch awserr.Code(err) {
+	case "":
+		pd.Status.QueryLoggingConfigID = aws.ToString(createOutput.QueryLoggingConfig.Id)
+		log.FromContext(ctx).Info("Enabled Route 53 query logging", "logGroup", logGroupARN)
+		return nil
+	case "QueryLoggingConfigAlreadyExists":
+		// E.g. created by an earlier reconcile whose status write was lost.
+		return adoptQueryLogging(ctx, r53Client, pd, logGroupARN)
+	case "InsufficientCloudWatchLogsResourcePolicy":
+		return fmt.Errorf("the resource policy of log group %s must allow route53.amazonaws.com to call logs:CreateLogStream and logs:PutLogEvents: %w", logGroupARN, err)
+	}
+	return fmt.Errorf("failed to enable query logging: %w", err)
+}
+
+// adoptQueryLogging records the zone's existing query logging configuration when it logs to
+// logGroupARN. A configuration for another log group is left alone, as the operator cannot
+// tell it apart from one set up by someone else.
+func adoptQueryLogging(ctx context.Context, r53Client R53ClientAPI, pd *parkingv1alpha1.ParkedDomain, logGroupARN string) error {
+	listOutput, err := r53Client.ListQueryLoggingConfigs(ctx, &route53.ListQueryLoggingConfigsInput{HostedZoneId: aws.String(pd.Status.ZoneID)})
+	if err != nil {
+		return fmt.Errorf("failed to list query logging configurations: %w", err)
+	}
+	if len(listOutput.QueryLoggingConfigs) == 0 {
+		return fmt.Errorf("query logging configuration of Hosted Zone %s exists but could not be listed", pd.Status.ZoneID)
+	}
+	config := listOutput.QueryLoggingConfigs[0]
+	if existing := aws.ToString(config.CloudWatchLogsLogGroupArn); existing != logGroupARN {
+		return fmt.Errorf("the Hosted Zone %s already logs queries to %s; delete that configuration to log to %s",
+			pd.Status.ZoneID, existing, logGroupARN)
+	}
+	pd.Status.QueryLoggingConfigID = aws.ToString(config.Id)
+	return nil
+}
+
+// deleteQueryLogging deletes the query logging configuration in Status.QueryLoggingConfigID,
+// if any.
+func deleteQueryLogging(ctx context.Context, r53Client R53ClientAPI, pd *parkingv1alpha1.ParkedDomain) error {
+	id := pd.Status.QueryLoggingConfigID
+	if id == "" {
+		return nil
+	}
+	_, err := r53Client.DeleteQueryLoggingConfig(ctx, &route53.DeleteQueryLoggingConfigInput{Id: aws.String(id)})
+	if err != nil && !awserr.IsNotFound(err) {
+		return fmt.Errorf("failed to delete query logging configuration %s: %w", id, err)
+	}
+	log.FromContext(ctx).Info("Disabled Route 53 query logging", "queryLoggingConfigID", id)
+	pd.Status.QueryLoggingConfigID = ""
+	return nil
+}
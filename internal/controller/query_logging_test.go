@@ -0,0 +1,197 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/route53"
+	r53types "github.com/aws/aws-sdk-go-v2/service/route53/types"
+	"github.com/aws/smithy-go"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+
+	parkingv1alpha1 "github.com/gminiba/parked-domain-operator/api/v1alpha1"
+)
+
+var _ = Describe("Route 53 query logging", func() {
+	const (
+		logGroupARN      = "arn:aws:logs:us-east-1:111111111111:log-group:/aws/route53/example.com"
+		otherLogGroupARN = "arn:aws:logs:us-east-1:111111111111:log-group:/aws/route53/other"
+	)
+	var pd *parkingv1alpha1.ParkedDomain
+
+	BeforeEach(func() {
+		pd = &parkingv1alpha1.ParkedDomain{
+			ObjectMeta: metav1.ObjectMeta{Name: "logged-domain", Namespace: "default"},
+			Spec: parkingv1alpha1.ParkedDomainSpec{
+				DomainName:   "example.com",
+				QueryLogging: &parkingv1alpha1.QueryLogging{LogGroupARN: logGroupARN},
+			},
+			Status: parkingv1alpha1.ParkedDomainStatus{ZoneID: "ZLOGGED"},
+		}
+	})
+
+	It("should log the zone's queries to the log group", func() {
+		var created *route53.CreateQueryLoggingConfigInput
+		r53 := &MockR53Client{
+			CreateQueryLoggingConfigFunc: func(ctx context.Context, params *route53.CreateQueryLoggingConfigInput, optFns ...func(*route53.Options)) (*route53.CreateQueryLoggingConfigOutput, error) {
+				created = params
+				return &route53.CreateQueryLoggingConfigOutput{QueryLoggingConfig: &r53types.QueryLoggingConfig{Id: aws.String("QLC-1")}}, nil
+			},
+		}
+		r := &ParkedDomainReconciler{R53Client: r53}
+
+		Expect(r.reconcileQueryLogging(context.Background(), pd)).To(Succeed())
+		Expect(aws.ToString(created.HostedZoneId)).To(Equal("ZLOGGED"))
+		Expect(aws.ToString(created.CloudWatchLogsLogGroupArn)).To(Equal(logGroupARN))
+		Expect(pd.Status.QueryLoggingConfigID).To(Equal("QLC-1"))
+	})
+
+	It("should keep a configuration already logging to the log group", func() {
+		pd.Status.QueryLoggingConfigID = "QLC-1"
+		r53 := &MockR53Client{
+			GetQueryLoggingConfigFunc: func(ctx context.Context, params *route53.GetQueryLoggingConfigInput, optFns ...func(*route53.Options)) (*route53.GetQueryLoggingConfigOutput, error) {
+				return &route53.GetQueryLoggingConfigOutput{QueryLoggingConfig: &r53types.QueryLoggingConfig{
+					Id: params.Id, CloudWatchLogsLogGroupArn: aws.String(logGroupARN),
+				}}, nil
+			},
+			CreateQueryLoggingConfigFunc: func(ctx context.Context, params *route53.CreateQueryLoggingConfigInput, optFns ...func(*route53.Options)) (*route53.CreateQueryLoggingConfigOutput, error) {
+				Fail("query logging should not be configured again")
+				return nil, nil
+			},
+		}
+		r := &ParkedDomainReconciler{R53Client: r53}
+
+		Expect(r.reconcileQueryLogging(context.Background(), pd)).To(Succeed())
+		Expect(pd.Status.QueryLoggingConfigID).To(Equal("QLC-1"))
+	})
+
+	It("should replace a configuration logging to another log group", func() {
+		pd.Status.QueryLoggingConfigID = "QLC-OLD"
+		var deleted string
+		r53 := &MockR53Client{
+			GetQueryLoggingConfigFunc: func(ctx context.Context, params *route53.GetQueryLoggingConfigInput, optFns ...func(*route53.Options)) (*route53.GetQueryLoggingConfigOutput, error) {
+				return &route53.GetQueryLoggingConfigOutput{QueryLoggingConfig: &r53types.QueryLoggingConfig{
+					Id: params.Id, CloudWatchLogsLogGroupArn: aws.String(otherLogGroupARN),
+				}}, nil
+			},
+			DeleteQueryLoggingConfigFunc: func(ctx context.Context, params *route53.DeleteQueryLoggingConfigInput, optFns ...func(*route53.Options)) (*route53.DeleteQueryLoggingConfigOutput, error) {
+				deleted = aws.ToString(params.Id)
+				return &route53.DeleteQueryLoggingConfigOutput{}, nil
+			},
+		}
+		r := &ParkedDomainReconciler{R53Client: r53}
+
+		Expect(r.reconcileQueryLogging(context.Background(), pd)).To(Succeed())
+		Expect(deleted).To(Equal("QLC-OLD"))
+		Expect(pd.Status.QueryLoggingConfigID).To(Equal("QLC-MOCK"))
+	})
+
+	It("should adopt the zone's configuration for the log group", func() {
+		r53 := &MockR53Client{
+			CreateQueryLoggingConfigFunc: func(ctx context.Context, params *route53.CreateQueryLoggingConfigInput, optFns ...func(*route53.Options)) (*route53.CreateQueryLoggingConfigOutput, error) {
+				return nil, &r53types.QueryLoggingConfigAlreadyExists{}
+			},
+			ListQueryLoggingConfigsFunc: func(ctx context.Context, params *route53.ListQueryLoggingConfigsInput, optFns ...func(*route53.Options)) (*route53.ListQueryLoggingConfigsOutput, error) {
+				Expect(aws.ToString(params.HostedZoneId)).To(Equal("ZLOGGED"))
+				return &route53.ListQueryLoggingConfigsOutput{QueryLoggingConfigs: []r53types.QueryLoggingConfig{
+					{Id: aws.String("QLC-LOST"), CloudWatchLogsLogGroupArn: aws.String(logGroupARN)},
+				}}, nil
+			},
+		}
+		r := &ParkedDomainReconciler{R53Client: r53}
+
+		Expect(r.reconcileQueryLogging(context.Background(), pd)).To(Succeed())
+		Expect(pd.Status.QueryLoggingConfigID).To(Equal("QLC-LOST"))
+	})
+
+	It("should not take over a configuration for another log group", func() {
+		r53 := &MockR53Client{
+			CreateQueryLoggingConfigFunc: func(ctx context.Context, params *route53.CreateQueryLoggingConfigInput, optFns ...func(*route53.Options)) (*route53.CreateQueryLoggingConfigOutput, error) {
+				return nil, &r53types.QueryLoggingConfigAlreadyExists{}
+			},
+			ListQueryLoggingConfigsFunc: func(ctx context.Context, params *route53.ListQueryLoggingConfigsInput, optFns ...func(*route53.Options)) (*route53.ListQueryLoggingConfigsOutput, error) {
+				return &route53.ListQueryLoggingConfigsOutput{QueryLoggingConfigs: []r53types.QueryLoggingConfig{
+					{Id: aws.String("QLC-THEIRS"), CloudWatchLogsLogGroupArn: aws.String(otherLogGroupARN)},
+				}}, nil
+			},
+		}
+		r := &ParkedDomainReconciler{R53Client: r53}
+
+		err := r.reconcileQueryLogging(context.Background(), pd)
+		Expect(err).To(MatchError(ContainSubstring(otherLogGroupARN)))
+		Expect(pd.Status.QueryLoggingConfigID).To(BeEmpty())
+	})
+
+	It("should say which resource policy the log group needs", func() {
+		r53 := &MockR53Client{
+			CreateQueryLoggingConfigFunc: func(ctx context.Context, params *route53.CreateQueryLoggingConfigInput, optFns ...func(*route53.Options)) (*route53.CreateQueryLoggingConfigOutput, error) {
+				return nil, &smithy.GenericAPIError{Code: "InsufficientCloudWatchLogsResourcePolicy"}
+			},
+		}
+		r := &ParkedDomainReconciler{R53Client: r53}
+
+		err := r.reconcileQueryLogging(context.Background(), pd)
+		Expect(err).To(MatchError(ContainSubstring("must allow route53.amazonaws.com")))
+	})
+
+	It("should stop logging once query logging is unset", func() {
+		pd.Spec.QueryLogging = nil
+		pd.Status.QueryLoggingConfigID = "QLC-1"
+		var deleted string
+		r53 := &MockR53Client{
+			DeleteQueryLoggingConfigFunc: func(ctx context.Context, params *route53.DeleteQueryLoggingConfigInput, optFns ...func(*route53.Options)) (*route53.DeleteQueryLoggingConfigOutput, error) {
+				deleted = aws.ToString(params.Id)
+				return &route53.DeleteQueryLoggingConfigOutput{}, nil
+			},
+		}
+		r := &ParkedDomainReconciler{R53Client: r53}
+
+		Expect(r.reconcileQueryLogging(context.Background(), pd)).To(Succeed())
+		Expect(deleted).To(Equal("QLC-1"))
+		Expect(pd.Status.QueryLoggingConfigID).To(BeEmpty())
+	})
+
+	It("should remove the configuration before deleting the zone", func() {
+		pd.Status.QueryLoggingConfigID = "QLC-1"
+		var calls []string
+		r53 := &MockR53Client{
+			GetHostedZoneFunc: func(ctx context.Context, params *route53.GetHostedZoneInput, optFns ...func(*route53.Options)) (*route53.GetHostedZoneOutput, error) {
+				return &route53.GetHostedZoneOutput{HostedZone: &r53types.HostedZone{
+					Id: params.Id, CallerReference: aws.String(managedCallerReferencePrefix + "logged-1700000000"),
+				}}, nil
+			},
+			DeleteQueryLoggingConfigFunc: func(ctx context.Context, params *route53.DeleteQueryLoggingConfigInput, optFns ...func(*route53.Options)) (*route53.DeleteQueryLoggingConfigOutput, error) {
+				calls = append(calls, "DeleteQueryLoggingConfig")
+				return &route53.DeleteQueryLoggingConfigOutput{}, nil
+			},
+			DeleteHostedZoneFunc: func(ctx context.Context, params *route53.DeleteHostedZoneInput, optFns ...func(*route53.Options)) (*route53.DeleteHostedZoneOutput, error) {
+				calls = append(calls, "DeleteHostedZone")
+				return &route53.DeleteHostedZoneOutput{}, nil
+			},
+		}
+		r := &ParkedDomainReconciler{R53Client: r53, Recorder: record.NewFakeRecorder(10)}
+
+		Expect(r.cleanupRoute53Zone(context.Background(), pd)).To(BeTrue())
+		Expect(calls).To(Equal([]string{"DeleteQueryLoggingConfig", "DeleteHostedZone"}))
+		Expect(pd.Status.QueryLoggingConfigID).To(BeEmpty())
+	})
+})
@@ -0,0 +1,87 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/route53"
+	r53types "github.com/aws/aws-sdk-go-v2/service/route53/types"
+	parkingv1alpha1 "github.com/gminiba/parked-domain-operator/api/v1alpha1"
+)
+
+const (
+	// ownerRecordPrefix starts the name of the TXT record marking a record the operator
+	// created, e.g. _pdo-owner.shop.example.com for shop.example.com.
+	ownerRecordPrefix = "_pdo-owner."
+	// ownerRecordTTL is the TTL, in seconds, of owner records. Nothing resolves them, so it
+	// only needs to be valid.
+	ownerRecordTTL = 300
+)
+
+// ownerValueFor returns the text of the ParkedDomain's owner records, naming the ParkedDomain
+// so that ParkedDomains sharing a zone tell their records apart.
+func ownerValueFor(pd *parkingv1alpha1.ParkedDomain) string {
+	return "heritage=parked-domain-operator,owner=" + pd.Namespace + "/" + pd.Name
+}
+
+// ownerRecordFor returns the TXT record marking the record called name as the ParkedDomain's.
+func ownerRecordFor(pd *parkingv1alpha1.ParkedDomain, name string) r53types.ResourceRecordSet {
+	return r53types.ResourceRecordSet{
+		Name:            aws.String(ownerRecordPrefix + name),
+		Type:            r53types.RRTypeTxt,
+		TTL:             aws.Int64(ownerRecordTTL),
+		ResourceRecords: []r53types.ResourceRecord{{Value: aws.String(strconv.Quote(ownerValueFor(pd)))}},
+	}
+}
+
+// isOwnerRecord reports whether record is one of the ParkedDomain's owner records.
+func isOwnerRecord(record r53types.ResourceRecordSet, pd *parkingv1alpha1.ParkedDomain) bool {
+	if record.Type != r53types.RRTypeTxt || !strings.HasPrefix(strings.ToLower(aws.ToString(record.Name)), ownerRecordPrefix) {
+		return false
+	}
+	return len(record.ResourceRecords) == 1 && aws.ToString(record.ResourceRecords[0].Value) == strconv.Quote(ownerValueFor(pd))
+}
+
+// ownedRecordName returns the name of the record an owner record marks.
+func ownedRecordName(owner r53types.ResourceRecordSet) string {
+	return aws.ToString(owner.Name)[len(ownerRecordPrefix):]
+}
+
+// deleteOwnerRecord deletes the ParkedDomain's owner record of the record called name, if it exists.
+func deleteOwnerRecord(ctx context.Context, r53Client R53ClientAPI, pd *parkingv1alpha1.ParkedDomain, name string) error {
+	listOutput, err := r53Client.ListResourceRecordSets(ctx, &route53.ListResourceRecordSetsInput{
+		HostedZoneId:    aws.String(pd.Status.ZoneID),
+		StartRecordName: aws.String(ownerRecordPrefix + name),
+		StartRecordType: r53types.RRTypeTxt,
+		MaxItems:        aws.Int32(1),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list records in Hosted Zone: %w", err)
+	}
+	for _, record := range listOutput.ResourceRecordSets {
+		if isOwnerRecord(record, pd) && sameRecordName(ownedRecordName(record), name) {
+			_, err := deleteRecords(ctx, r53Client, pd.Status.ZoneID, record)
+			return err
+		}
+	}
+	return nil
+}
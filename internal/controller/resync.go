@@ -0,0 +1,114 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	parkingv1alpha1 "github.com/gminiba/parked-domain-operator/api/v1alpha1"
+)
+
+// resyncSet holds the ParkedDomains whose next reconcile re-applies every step, ignoring
+// what their status says was already done.
+type resyncSet struct {
+	mu   sync.Mutex
+	keys map[types.NamespacedName]bool
+}
+
+func (s *resyncSet) add(key types.NamespacedName) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.keys == nil {
+		s.keys = map[types.NamespacedName]bool{}
+	}
+	s.keys[key] = true
+}
+
+func (s *resyncSet) has(key types.NamespacedName) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.keys[key]
+}
+
+func (s *resyncSet) remove(key types.NamespacedName) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.keys, key)
+}
+
+// startupResync queues every ParkedDomain for a full reconcile once the manager starts, so
+// drift left behind while the operator was down or by an older version is repaired even for
+// ParkedDomains whose status says they are done.
+type startupResync struct {
+	client     client.Reader
+	namespaces []string
+	// interval spaces out the queued ParkedDomains, so a large fleet does not hit the
+	// AWS request limits at once. Zero queues them all at once.
+	interval time.Duration
+	pending  *resyncSet
+	events   chan<- event.GenericEvent
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable; only the leader reconciles.
+func (s *startupResync) NeedLeaderElection() bool {
+	return true
+}
+
+// Start implements manager.Runnable. It returns once every ParkedDomain was queued.
+func (s *startupResync) Start(ctx context.Context) error {
+	logger := log.FromContext(ctx).WithName("startup-resync")
+
+	namespaces := s.namespaces
+	if len(namespaces) == 0 {
+		namespaces = []string{""}
+	}
+	var domains []parkingv1alpha1.ParkedDomain
+	for _, namespace := range namespaces {
+		list := &parkingv1alpha1.ParkedDomainList{}
+		if err := s.client.List(ctx, list, client.InNamespace(namespace)); err != nil {
+			return err
+		}
+		domains = append(domains, list.Items...)
+	}
+	logger.Info("Resyncing all ParkedDomains", "count", len(domains), "interval", s.interval)
+
+	for i := range domains {
+		if i > 0 && s.interval > 0 {
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-time.After(s.interval):
+			}
+		}
+		pd := &domains[i]
+		s.pending.add(client.ObjectKeyFromObject(pd))
+		select {
+		case <-ctx.Done():
+			return nil
+		case s.events <- event.GenericEvent{Object: pd}:
+		}
+	}
+	logger.Info("Queued all ParkedDomains for resync", "count", len(domains))
+	return nil
+}
@@ -0,0 +1,109 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	parkingv1alpha1 "github.com/gminiba/parked-domain-operator/api/v1alpha1"
+)
+
+var _ = Describe("Startup resync", func() {
+	domain := func(namespace, name string) *parkingv1alpha1.ParkedDomain {
+		return &parkingv1alpha1.ParkedDomain{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace, Generation: 1},
+			Spec: parkingv1alpha1.ParkedDomainSpec{
+				DomainName:     name + ".example.com",
+				DNSEnabled:     aws.Bool(false),
+				InlineTemplate: "<h1>{{DOMAIN_NAME}}</h1>",
+			},
+		}
+	}
+
+	It("should queue every ParkedDomain in the watched namespaces on start", func() {
+		r := newTestReconciler(&MockR53Client{}, &MockS3Client{},
+			domain("default", "one"), domain("default", "two"), domain("team-a", "three"), domain("team-b", "four"))
+		events := make(chan event.GenericEvent, 10)
+		resync := &startupResync{
+			client:     r.Client,
+			namespaces: []string{"default", "team-a"},
+			interval:   10 * time.Millisecond,
+			pending:    &r.resync,
+			events:     events,
+		}
+
+		start := time.Now()
+		Expect(resync.Start(context.Background())).To(Succeed())
+		Expect(time.Since(start)).To(BeNumerically(">=", 20*time.Millisecond))
+		close(events)
+
+		var queued []types.NamespacedName
+		for e := range events {
+			queued = append(queued, client.ObjectKeyFromObject(e.Object))
+		}
+		Expect(queued).To(ConsistOf(
+			types.NamespacedName{Namespace: "default", Name: "one"},
+			types.NamespacedName{Namespace: "default", Name: "two"},
+			types.NamespacedName{Namespace: "team-a", Name: "three"},
+		))
+		for _, key := range queued {
+			Expect(r.resync.has(key)).To(BeTrue())
+		}
+		Expect(r.resync.has(types.NamespacedName{Namespace: "team-b", Name: "four"})).To(BeFalse())
+	})
+
+	It("should re-apply the steps of a ParkedDomain that was already reconciled", func() {
+		ctx := context.Background()
+		req := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "default", Name: "drifted"}}
+		uploads := 0
+		s3Mock := &MockS3Client{
+			PutObjectFunc: func(ctx context.Context, params *s3.PutObjectInput, optFns ...func(*s3.Options)) (*s3.PutObjectOutput, error) {
+				uploads++
+				return &s3.PutObjectOutput{}, nil
+			},
+		}
+		r := newTestReconciler(&MockR53Client{}, s3Mock, domain("default", "drifted"))
+
+		_, err := r.Reconcile(ctx, req)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(uploads).To(BeNumerically(">", 0))
+
+		By("skipping the reconciled generation without a resync")
+		uploads = 0
+		_, err = r.Reconcile(ctx, req)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(uploads).To(BeZero())
+
+		By("uploading the pages again while the resync is pending")
+		r.resync.add(req.NamespacedName)
+		_, err = r.Reconcile(ctx, req)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(uploads).To(BeNumerically(">", 0))
+		Expect(r.resync.has(req.NamespacedName)).To(BeFalse())
+	})
+})
@@ -0,0 +1,73 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	parkingv1alpha1 "github.com/gminiba/parked-domain-operator/api/v1alpha1"
+)
+
+// errRoleNotAllowed is returned when a ParkedDomain names an IAM role the operator may not
+// assume. Only a change to the spec or to the operator's allowlist fixes it, so it is
+// returned as a terminal error that is not retried.
+var errRoleNotAllowed = errors.New("role is not allowed")
+
+// RoleAllowed reports whether one of the allowlist entries covers roleARN. An entry is a
+// role ARN, a 12-digit account ID allowing every role of the account, or "*" for any role.
+func RoleAllowed(allowed []string, roleARN string) bool {
+	parsed, err := arn.Parse(roleARN)
+	return slices.ContainsFunc(allowed, func(entry string) bool {
+		return entry == "*" || entry == roleARN || (err == nil && entry == parsed.AccountID)
+	})
+}
+
+// ValidateAssumedRoles returns an error for each of pd's IAM roles the allowlist, as taken
+// by RoleAllowed, does not cover. An empty allowlist allows no role.
+func ValidateAssumedRoles(pd *parkingv1alpha1.ParkedDomain, allowed []string) field.ErrorList {
+	var allErrs field.ErrorList
+	specPath := field.NewPath("spec")
+	for _, role := range []struct {
+		path *field.Path
+		arn  string
+	}{
+		{specPath.Child("dnsRoleARN"), pd.Spec.DNSRoleARN},
+		{specPath.Child("storageRoleARN"), pd.Spec.StorageRoleARN},
+	} {
+		if role.arn != "" && !RoleAllowed(allowed, role.arn) {
+			allErrs = append(allErrs, field.Forbidden(role.path,
+				fmt.Sprintf("role %s is not allowed by the operator's --allowed-roles", role.arn)))
+		}
+	}
+	return allErrs
+}
+
+// checkRole returns a terminal errRoleNotAllowed unless roleARN is empty or AllowedRoles
+// covers it. It guards every client assuming a role from the spec, so a ParkedDomain cannot
+// act in an account the operator's own role can reach but its namespace should not.
+func (r *ParkedDomainReconciler) checkRole(roleARN string) error {
+	if roleARN == "" || RoleAllowed(r.AllowedRoles, roleARN) {
+		return nil
+	}
+	return reconcile.TerminalError(fmt.Errorf("%w: %s", errRoleNotAllowed, roleARN))
+}
@@ -0,0 +1,59 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	parkingv1alpha1 "github.com/gminiba/parked-domain-operator/api/v1alpha1"
+)
+
+// errSharedBucketNotAllowed is returned when a ParkedDomain names a shared bucket the
+// operator may not publish into. Only a change to the spec or to the operator's allowlist
+// fixes it, so it is returned as a terminal error that is not retried.
+var errSharedBucketNotAllowed = errors.New("shared bucket is not allowed")
+
+// SharedBucketAllowed reports whether one of the allowlist entries, a bucket name or "*" for
+// any bucket, covers bucket.
+func SharedBucketAllowed(allowed []string, bucket string) bool {
+	return slices.ContainsFunc(allowed, func(entry string) bool { return entry == "*" || entry == bucket })
+}
+
+// ValidateSharedBucketAllowed returns an error when pd names a shared bucket the allowlist,
+// as taken by SharedBucketAllowed, does not cover. An empty allowlist allows no bucket.
+func ValidateSharedBucketAllowed(pd *parkingv1alpha1.ParkedDomain, allowed []string) field.ErrorList {
+	if pd.Spec.SharedBucket == nil || SharedBucketAllowed(allowed, pd.Spec.SharedBucket.Name) {
+		return nil
+	}
+	return field.ErrorList{field.Forbidden(field.NewPath("spec", "sharedBucket", "name"),
+		fmt.Sprintf("bucket %s is not allowed by the operator's --allowed-shared-buckets", pd.Spec.SharedBucket.Name))}
+}
+
+// checkSharedBucket returns a terminal errSharedBucketNotAllowed unless pd names no shared
+// bucket or AllowedSharedBuckets covers it. Without it, a ParkedDomain could publish into, and
+// on deletion empty its prefix of, any bucket the operator's credentials can write to.
+func (r *ParkedDomainReconciler) checkSharedBucket(pd *parkingv1alpha1.ParkedDomain) error {
+	if pd.Spec.SharedBucket == nil || SharedBucketAllowed(r.AllowedSharedBuckets, pd.Spec.SharedBucket.Name) {
+		return nil
+	}
+	return reconcile.TerminalError(fmt.Errorf("%w: %s", errSharedBucketNotAllowed, pd.Spec.SharedBucket.Name))
+}
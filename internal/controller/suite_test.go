@@ -32,11 +32,15 @@ func TestControllers(t *testing.T) {
 
 // MockS3ClientFactory produces our mock S3 client for tests.
 type MockS3ClientFactory struct {
-	MockS3 S3ClientAPI
+	MockS3            S3ClientAPI
+	RequestedRoleARN  string
+	RequestedEndpoint string
 }
 
-func (f *MockS3ClientFactory) GetClient(ctx context.Context, region string) (S3ClientAPI, error) {
+func (f *MockS3ClientFactory) GetClient(ctx context.Context, region, roleARN, endpoint string) (S3ClientAPI, error) {
 	// In tests, we just return the single mock client, ignoring the region.
+	f.RequestedRoleARN = roleARN
+	f.RequestedEndpoint = endpoint
 	return f.MockS3, nil
 }
 
@@ -0,0 +1,192 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/route53"
+	r53types "github.com/aws/aws-sdk-go-v2/service/route53/types"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
+	parkingv1alpha1 "github.com/gminiba/parked-domain-operator/api/v1alpha1"
+	"github.com/gminiba/parked-domain-operator/internal/awserr"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// maxTags is the most tags S3 and Route 53 allow on a bucket or Hosted Zone.
+const maxTags = 50
+
+// maxTagChanges is the most tags a single ChangeTagsForResource call may add, and the most
+// it may remove.
+const maxTagChanges = 10
+
+// tagsManaged reports whether the operator tags the ParkedDomain's resources, now or
+// before, so ParkedDomains that never had tags cost no tagging calls.
+func tagsManaged(pd *parkingv1alpha1.ParkedDomain) bool {
+	return len(pd.Spec.Tags) > 0 || len(pd.Status.TagKeys) > 0
+}
+
+// desiredTags returns current, a resource's tags, with Spec.Tags applied and the keys in
+// Status.TagKeys that were removed from the spec dropped. Tags set outside the operator
+// are kept.
+func desiredTags(pd *parkingv1alpha1.ParkedDomain, current map[string]string) map[string]string {
+	desired := maps.Clone(current)
+	if desired == nil {
+		desired = map[string]string{}
+	}
+	for _, key := range pd.Status.TagKeys {
+		if _, ok := pd.Spec.Tags[key]; !ok {
+			delete(desired, key)
+		}
+	}
+	maps.Copy(desired, pd.Spec.Tags)
+	return desired
+}
+
+// tagKeysFor returns the sorted keys of Spec.Tags, recorded in Status.TagKeys once applied.
+func tagKeysFor(pd *parkingv1alpha1.ParkedDomain) []string {
+	if len(pd.Spec.Tags) == 0 {
+		return nil
+	}
+	return slices.Sorted(maps.Keys(pd.Spec.Tags))
+}
+
+// checkTagLimit fails when tags, which include the ones set outside the operator, exceed
+// what AWS allows on a resource.
+func checkTagLimit(resource string, tags map[string]string) error {
+	if len(tags) > maxTags {
+		return fmt.Errorf("%s would have %d tags, more than the %d AWS allows; remove tags from spec.tags or from the resource itself",
+			resource, len(tags), maxTags)
+	}
+	return nil
+}
+
+// reconcileBucketTags converges the bucket's tags on Spec.Tags. S3 only replaces the whole
+// tag set, so the current tags are read first to keep the ones set outside the operator.
+func reconcileBucketTags(ctx context.Context, s3Client S3ClientAPI, pd *parkingv1alpha1.ParkedDomain, bucketName string) error {
+	if !tagsManaged(pd) {
+		return nil
+	}
+	current := map[string]string{}
+	output, err := s3Client.GetBucketTagging(ctx, &s3.GetBucketTaggingInput{Bucket: aws.String(bucketName)})
+	switch {
+	case awserr.IsNotFound(err):
+		// NoSuchTagSet, the bucket has no tags yet.
+	case awserr.IsNotImplemented(err):
+		return nil
+	case err != nil:
+		return fmt.Errorf("failed to get S3 bucket tags: %w", err)
+	default:
+		for _, tag := range output.TagSet {
+			current[aws.ToString(tag.Key)] = aws.ToString(tag.Value)
+		}
+	}
+
+	desired := desiredTags(pd, current)
+	if maps.Equal(current, desired) {
+		return nil
+	}
+	if err := checkTagLimit("S3 bucket "+bucketName, desired); err != nil {
+		return err
+	}
+	if len(desired) == 0 {
+		if _, err := s3Client.DeleteBucketTagging(ctx, &s3.DeleteBucketTaggingInput{Bucket: aws.String(bucketName)}); err != nil {
+			return fmt.Errorf("failed to delete S3 bucket tags: %w", err)
+		}
+	} else {
+		tagSet := make([]s3types.Tag, 0, len(desired))
+		for _, key := range slices.Sorted(maps.Keys(desired)) {
+			tagSet = append(tagSet, s3types.Tag{Key: aws.String(key), Value: aws.String(desired[key])})
+		}
+		_, err := s3Client.PutBucketTagging(ctx, &s3.PutBucketTaggingInput{
+			Bucket:  aws.String(bucketName),
+			Tagging: &s3types.Tagging{TagSet: tagSet},
+		})
+		if err != nil {
+			return fmt.Errorf("failed to apply S3 bucket tags: %w", err)
+		}
+	}
+	log.FromContext(ctx).Info("Updated S3 bucket tags", "tags", len(desired))
+	return nil
+}
+
+// reconcileZoneTags converges the Hosted Zone's tags on Spec.Tags, adding and removing only
+// the tags that differ, in batches of maxTagChanges.
+func (r *ParkedDomainReconciler) reconcileZoneTags(ctx context.Context, pd *parkingv1alpha1.ParkedDomain) error {
+	if !tagsManaged(pd) {
+		return nil
+	}
+	r53Client, err := r.r53ClientFor(ctx, pd)
+	if err != nil {
+		return err
+	}
+	zoneID := pd.Status.ZoneID
+	output, err := r53Client.ListTagsForResource(ctx, &route53.ListTagsForResourceInput{
+		ResourceType: r53types.TagResourceTypeHostedzone,
+		ResourceId:   aws.String(zoneID),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list Hosted Zone tags: %w", err)
+	}
+	current := map[string]string{}
+	if output.ResourceTagSet != nil {
+		for _, tag := range output.ResourceTagSet.Tags {
+			current[aws.ToString(tag.Key)] = aws.ToString(tag.Value)
+		}
+	}
+
+	desired := desiredTags(pd, current)
+	var add []r53types.Tag
+	for _, key := range slices.Sorted(maps.Keys(desired)) {
+		if value, ok := current[key]; !ok || value != desired[key] {
+			add = append(add, r53types.Tag{Key: aws.String(key), Value: aws.String(desired[key])})
+		}
+	}
+	var remove []string
+	for _, key := range slices.Sorted(maps.Keys(current)) {
+		if _, ok := desired[key]; !ok {
+			remove = append(remove, key)
+		}
+	}
+	if len(add) == 0 && len(remove) == 0 {
+		return nil
+	}
+	if err := checkTagLimit("Hosted Zone "+zoneID, desired); err != nil {
+		return err
+	}
+
+	for len(add) > 0 || len(remove) > 0 {
+		input := &route53.ChangeTagsForResourceInput{
+			ResourceType: r53types.TagResourceTypeHostedzone,
+			ResourceId:   aws.String(zoneID),
+		}
+		n := min(len(add), maxTagChanges)
+		input.AddTags, add = add[:n], add[n:]
+		n = min(len(remove), maxTagChanges)
+		input.RemoveTagKeys, remove = remove[:n], remove[n:]
+		if _, err := r53Client.ChangeTagsForResource(ctx, input); err != nil {
+			return fmt.Errorf("failed to change Hosted Zone tags: %w", err)
+		}
+	}
+	log.FromContext(ctx).Info("Updated Hosted Zone tags", "tags", len(desired))
+	return nil
+}
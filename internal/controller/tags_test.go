@@ -0,0 +1,271 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"os"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/route53"
+	r53types "github.com/aws/aws-sdk-go-v2/service/route53/types"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	parkingv1alpha1 "github.com/gminiba/parked-domain-operator/api/v1alpha1"
+)
+
+var _ = Describe("Resource tags", func() {
+	var pd *parkingv1alpha1.ParkedDomain
+
+	BeforeEach(func() {
+		pd = &parkingv1alpha1.ParkedDomain{
+			ObjectMeta: metav1.ObjectMeta{Name: "tagged-domain", Namespace: "default"},
+			Spec:       parkingv1alpha1.ParkedDomainSpec{DomainName: "example.com"},
+			Status:     parkingv1alpha1.ParkedDomainStatus{ZoneID: "ZTAGGED"},
+		}
+	})
+
+	Context("on the S3 bucket", func() {
+		// bucketTags returns a mock whose bucket starts with tags and records what is put.
+		bucketTags := func(tags map[string]string, put *map[string]string) *MockS3Client {
+			return &MockS3Client{
+				GetBucketTaggingFunc: func(ctx context.Context, params *s3.GetBucketTaggingInput, optFns ...func(*s3.Options)) (*s3.GetBucketTaggingOutput, error) {
+					var tagSet []s3types.Tag
+					for key, value := range tags {
+						tagSet = append(tagSet, s3types.Tag{Key: aws.String(key), Value: aws.String(value)})
+					}
+					return &s3.GetBucketTaggingOutput{TagSet: tagSet}, nil
+				},
+				PutBucketTaggingFunc: func(ctx context.Context, params *s3.PutBucketTaggingInput, optFns ...func(*s3.Options)) (*s3.PutBucketTaggingOutput, error) {
+					*put = map[string]string{}
+					for _, tag := range params.Tagging.TagSet {
+						(*put)[aws.ToString(tag.Key)] = aws.ToString(tag.Value)
+					}
+					return &s3.PutBucketTaggingOutput{}, nil
+				},
+			}
+		}
+
+		It("should add tags to an untagged bucket", func() {
+			pd.Spec.Tags = map[string]string{"team": "web"}
+			var put map[string]string
+			s3Client := bucketTags(nil, &put)
+			s3Client.GetBucketTaggingFunc = nil
+
+			Expect(reconcileBucketTags(context.Background(), s3Client, pd, "example.com")).To(Succeed())
+			Expect(put).To(Equal(map[string]string{"team": "web"}))
+		})
+
+		It("should update changed values and keep tags set outside the operator", func() {
+			pd.Spec.Tags = map[string]string{"team": "shop"}
+			pd.Status.TagKeys = []string{"team"}
+			var put map[string]string
+			s3Client := bucketTags(map[string]string{"team": "web", "billing": "marketing"}, &put)
+
+			Expect(reconcileBucketTags(context.Background(), s3Client, pd, "example.com")).To(Succeed())
+			Expect(put).To(Equal(map[string]string{"team": "shop", "billing": "marketing"}))
+		})
+
+		It("should remove tags dropped from the spec", func() {
+			pd.Spec.Tags = map[string]string{"team": "web"}
+			pd.Status.TagKeys = []string{"campaign", "team"}
+			var put map[string]string
+			s3Client := bucketTags(map[string]string{"team": "web", "campaign": "spring", "billing": "marketing"}, &put)
+
+			Expect(reconcileBucketTags(context.Background(), s3Client, pd, "example.com")).To(Succeed())
+			Expect(put).To(Equal(map[string]string{"team": "web", "billing": "marketing"}))
+		})
+
+		It("should delete the tag set once its last tag is removed", func() {
+			pd.Status.TagKeys = []string{"team"}
+			deleted := false
+			s3Client := bucketTags(map[string]string{"team": "web"}, new(map[string]string))
+			s3Client.DeleteBucketTaggingFunc = func(ctx context.Context, params *s3.DeleteBucketTaggingInput, optFns ...func(*s3.Options)) (*s3.DeleteBucketTaggingOutput, error) {
+				deleted = true
+				return &s3.DeleteBucketTaggingOutput{}, nil
+			}
+
+			Expect(reconcileBucketTags(context.Background(), s3Client, pd, "example.com")).To(Succeed())
+			Expect(deleted).To(BeTrue())
+		})
+
+		It("should not touch a bucket whose tags match", func() {
+			pd.Spec.Tags = map[string]string{"team": "web"}
+			s3Client := bucketTags(map[string]string{"team": "web"}, new(map[string]string))
+			s3Client.PutBucketTaggingFunc = func(ctx context.Context, params *s3.PutBucketTaggingInput, optFns ...func(*s3.Options)) (*s3.PutBucketTaggingOutput, error) {
+				Fail("tags should not be put again")
+				return nil, nil
+			}
+
+			Expect(reconcileBucketTags(context.Background(), s3Client, pd, "example.com")).To(Succeed())
+		})
+
+		It("should not read the tags of a ParkedDomain that never had any", func() {
+			s3Client := &MockS3Client{
+				GetBucketTaggingFunc: func(ctx context.Context, params *s3.GetBucketTaggingInput, optFns ...func(*s3.Options)) (*s3.GetBucketTaggingOutput, error) {
+					Fail("tags should not be read")
+					return nil, nil
+				},
+			}
+
+			Expect(reconcileBucketTags(context.Background(), s3Client, pd, "example.com")).To(Succeed())
+		})
+
+		It("should fail when tags set outside the operator push the bucket over the limit", func() {
+			pd.Spec.Tags = map[string]string{"team": "web"}
+			s3Client := bucketTags(manyTags(maxTags), new(map[string]string))
+
+			err := reconcileBucketTags(context.Background(), s3Client, pd, "example.com")
+			Expect(err).To(MatchError(ContainSubstring("S3 bucket example.com would have 51 tags, more than the 50 AWS allows")))
+		})
+	})
+
+	Context("on the Hosted Zone", func() {
+		// zoneTags returns a mock whose zone starts with tags and records every tag change.
+		zoneTags := func(tags map[string]string, changes *[]*route53.ChangeTagsForResourceInput) *MockR53Client {
+			return &MockR53Client{
+				ListTagsForResourceFunc: func(ctx context.Context, params *route53.ListTagsForResourceInput, optFns ...func(*route53.Options)) (*route53.ListTagsForResourceOutput, error) {
+					Expect(params.ResourceType).To(Equal(r53types.TagResourceTypeHostedzone))
+					Expect(aws.ToString(params.ResourceId)).To(Equal("ZTAGGED"))
+					tagSet := &r53types.ResourceTagSet{}
+					for key, value := range tags {
+						tagSet.Tags = append(tagSet.Tags, r53types.Tag{Key: aws.String(key), Value: aws.String(value)})
+					}
+					return &route53.ListTagsForResourceOutput{ResourceTagSet: tagSet}, nil
+				},
+				ChangeTagsForResourceFunc: func(ctx context.Context, params *route53.ChangeTagsForResourceInput, optFns ...func(*route53.Options)) (*route53.ChangeTagsForResourceOutput, error) {
+					*changes = append(*changes, params)
+					return &route53.ChangeTagsForResourceOutput{}, nil
+				},
+			}
+		}
+
+		It("should add, update and remove only the tags that differ", func() {
+			pd.Spec.Tags = map[string]string{"team": "shop", "env": "prod"}
+			pd.Status.TagKeys = []string{"campaign", "team"}
+			var changes []*route53.ChangeTagsForResourceInput
+			r := &ParkedDomainReconciler{R53Client: zoneTags(map[string]string{
+				"team": "web", "campaign": "spring", "billing": "marketing",
+			}, &changes)}
+
+			Expect(r.reconcileZoneTags(context.Background(), pd)).To(Succeed())
+			Expect(changes).To(HaveLen(1))
+			Expect(changes[0].AddTags).To(Equal([]r53types.Tag{
+				{Key: aws.String("env"), Value: aws.String("prod")},
+				{Key: aws.String("team"), Value: aws.String("shop")},
+			}))
+			Expect(changes[0].RemoveTagKeys).To(Equal([]string{"campaign"}))
+		})
+
+		It("should change tags in batches Route 53 accepts", func() {
+			pd.Spec.Tags = manyTags(25)
+			var changes []*route53.ChangeTagsForResourceInput
+			r := &ParkedDomainReconciler{R53Client: zoneTags(nil, &changes)}
+
+			Expect(r.reconcileZoneTags(context.Background(), pd)).To(Succeed())
+			Expect(changes).To(HaveLen(3))
+			added := 0
+			for _, change := range changes {
+				Expect(len(change.AddTags)).To(BeNumerically("<=", maxTagChanges))
+				added += len(change.AddTags)
+			}
+			Expect(added).To(Equal(25))
+		})
+
+		It("should not change a zone whose tags match", func() {
+			pd.Spec.Tags = map[string]string{"team": "web"}
+			var changes []*route53.ChangeTagsForResourceInput
+			r := &ParkedDomainReconciler{R53Client: zoneTags(map[string]string{"team": "web", "billing": "marketing"}, &changes)}
+
+			Expect(r.reconcileZoneTags(context.Background(), pd)).To(Succeed())
+			Expect(changes).To(BeEmpty())
+		})
+
+		It("should fail when tags set outside the operator push the zone over the limit", func() {
+			pd.Spec.Tags = map[string]string{"team": "web"}
+			var changes []*route53.ChangeTagsForResourceInput
+			r := &ParkedDomainReconciler{R53Client: zoneTags(manyTags(maxTags), &changes)}
+
+			err := r.reconcileZoneTags(context.Background(), pd)
+			Expect(err).To(MatchError(ContainSubstring("Hosted Zone ZTAGGED would have 51 tags")))
+			Expect(changes).To(BeEmpty())
+		})
+	})
+
+	It("should remember the applied keys once the ParkedDomain is reconciled", func() {
+		Expect(os.Setenv("TEMPLATE_CONFIGMAP_NAME", "parked-domain-templates")).To(Succeed())
+		DeferCleanup(os.Unsetenv, "TEMPLATE_CONFIGMAP_NAME")
+		ctx := context.Background()
+		pd.Status = parkingv1alpha1.ParkedDomainStatus{}
+		pd.Spec.Tags = map[string]string{"team": "web", "env": "prod"}
+		templateCM := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "parked-domain-templates", Namespace: "default"},
+			Data:       map[string]string{"default.html": "<h1>{{DOMAIN_NAME}}</h1>"},
+		}
+		var zoneChanges []*route53.ChangeTagsForResourceInput
+		var bucketTags map[string]string
+		r53Mock := &MockR53Client{
+			ChangeTagsForResourceFunc: func(ctx context.Context, params *route53.ChangeTagsForResourceInput, optFns ...func(*route53.Options)) (*route53.ChangeTagsForResourceOutput, error) {
+				zoneChanges = append(zoneChanges, params)
+				return &route53.ChangeTagsForResourceOutput{}, nil
+			},
+		}
+		s3Mock := &MockS3Client{
+			PutBucketTaggingFunc: func(ctx context.Context, params *s3.PutBucketTaggingInput, optFns ...func(*s3.Options)) (*s3.PutBucketTaggingOutput, error) {
+				bucketTags = map[string]string{}
+				for _, tag := range params.Tagging.TagSet {
+					bucketTags[aws.ToString(tag.Key)] = aws.ToString(tag.Value)
+				}
+				return &s3.PutBucketTaggingOutput{}, nil
+			},
+		}
+		r := newTestReconciler(r53Mock, s3Mock, pd, templateCM)
+		req := ctrl.Request{NamespacedName: types.NamespacedName{Name: "tagged-domain", Namespace: "default"}}
+
+		_, err := r.Reconcile(ctx, req)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(zoneChanges).To(HaveLen(1))
+		Expect(zoneChanges[0].AddTags).To(HaveLen(2))
+		Expect(bucketTags).To(Equal(map[string]string{"team": "web", "env": "prod"}))
+
+		reconciled := &parkingv1alpha1.ParkedDomain{}
+		Expect(r.Get(ctx, req.NamespacedName, reconciled)).To(Succeed())
+		Expect(reconciled.Status.TagKeys).To(Equal([]string{"env", "team"}))
+	})
+})
+
+var _ = Describe("desiredTags", func() {
+	It("should only drop removed keys the operator applied", func() {
+		pd := &parkingv1alpha1.ParkedDomain{
+			Spec:   parkingv1alpha1.ParkedDomainSpec{Tags: map[string]string{"a": "1"}},
+			Status: parkingv1alpha1.ParkedDomainStatus{TagKeys: []string{"a", "b"}},
+		}
+		current := map[string]string{"a": "0", "b": "2", "c": "3"}
+
+		Expect(desiredTags(pd, current)).To(Equal(map[string]string{"a": "1", "c": "3"}))
+		Expect(current).To(HaveLen(3), "the current tags must not be modified")
+		Expect(tagKeysFor(pd)).To(Equal([]string{"a"}))
+		Expect(desiredTags(pd, nil)).To(Equal(map[string]string{"a": "1"}))
+	})
+})
@@ -0,0 +1,259 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/netip"
+	"os"
+	"strings"
+	"syscall"
+	"text/template"
+	"time"
+
+	parkingv1alpha1 "github.com/gminiba/parked-domain-operator/api/v1alpha1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/util/retry"
+)
+
+const (
+	// templateFetchTimeout bounds a single HTTP request for Spec.TemplateURL.
+	templateFetchTimeout = 10 * time.Second
+	// maxTemplateSize is the largest template accepted from Spec.TemplateURL.
+	maxTemplateSize = 1 << 20
+)
+
+// errTemplateConfigMapMissing is returned by loadTemplate when the template ConfigMap does not
+// exist yet. Creating it triggers a reconcile, so callers wait for it instead of failing.
+var errTemplateConfigMapMissing = errors.New("template ConfigMap not found")
+
+// errTemplateAddressBlocked is returned when Spec.TemplateURL, or a redirect it answers with,
+// resolves to an address inside the cluster or the node, such as the instance metadata service.
+// The page is published, so fetching it would expose what such an address serves.
+var errTemplateAddressBlocked = errors.New("template URLs may only address public hosts")
+
+// sharedAddressSpace is the carrier-grade NAT range of RFC 6598, which some cluster networks use.
+var sharedAddressSpace = netip.MustParsePrefix("100.64.0.0/10")
+
+// templateHTTPClient is used to fetch templates from Spec.TemplateURL. It checks the address of
+// every connection it opens, after name resolution and for each redirect, and ignores proxy
+// settings, so no host name or redirect can lead it to a non-public address.
+var templateHTTPClient = &http.Client{
+	Timeout: templateFetchTimeout,
+	Transport: &http.Transport{
+		DialContext:         (&net.Dialer{Timeout: templateFetchTimeout, Control: dialPublicOnly}).DialContext,
+		TLSHandshakeTimeout: templateFetchTimeout,
+	},
+}
+
+// dialPublicOnly refuses connections to loopback, private, link-local, shared and unspecified
+// addresses.
+func dialPublicOnly(network, address string, _ syscall.RawConn) error {
+	addrPort, err := netip.ParseAddrPort(address)
+	if err != nil {
+		return err
+	}
+	if !publicAddress(addrPort.Addr()) {
+		return fmt.Errorf("%w: %s", errTemplateAddressBlocked, addrPort.Addr())
+	}
+	return nil
+}
+
+// publicAddress reports whether addr is routable on the internet rather than inside the
+// cluster, the node or its VPC.
+func publicAddress(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	return addr.IsGlobalUnicast() && !addr.IsPrivate() && !sharedAddressSpace.Contains(addr)
+}
+
+// templateFetchBackoff bounds the retries of transient template fetch failures.
+var templateFetchBackoff = wait.Backoff{Steps: 3, Duration: 500 * time.Millisecond, Factor: 2}
+
+// retriableFetchError marks template fetch failures worth retrying, such as
+// network errors and 5xx responses.
+type retriableFetchError struct {
+	err error
+}
+
+func (e *retriableFetchError) Error() string { return e.err.Error() }
+func (e *retriableFetchError) Unwrap() error { return e.err }
+
+// parkingModeTemplates maps each parking mode to its default template key in the ConfigMap.
+var parkingModeTemplates = map[parkingv1alpha1.ParkingMode]string{
+	parkingv1alpha1.ParkingModeComingSoon:  "coming-soon.html",
+	parkingv1alpha1.ParkingModeForSale:     "for-sale.html",
+	parkingv1alpha1.ParkingModeMaintenance: "maintenance.html",
+}
+
+// defaultTemplateName returns the ConfigMap key used when no TemplateName is given.
+func defaultTemplateName(mode parkingv1alpha1.ParkingMode) string {
+	if name, ok := parkingModeTemplates[mode]; ok {
+		return name
+	}
+	return "default.html" // Default template key in the ConfigMap.
+}
+
+// templateNameFor returns the name of the template pd is rendered from.
+func templateNameFor(pd *parkingv1alpha1.ParkedDomain) string {
+	if pd.Spec.TemplateName != "" {
+		return pd.Spec.TemplateName
+	}
+	return defaultTemplateName(pd.Spec.ParkingMode)
+}
+
+// loadTemplate returns the raw page template for the ParkedDomain from its template source.
+func (r *ParkedDomainReconciler) loadTemplate(ctx context.Context, pd *parkingv1alpha1.ParkedDomain) (string, error) {
+	content, _, err := r.loadTemplates(ctx, pd)
+	return content, err
+}
+
+// loadTemplates returns the raw page template for the ParkedDomain and the templates of
+// Spec.Locales keyed by locale, all read from its template source at once.
+func (r *ParkedDomainReconciler) loadTemplates(ctx context.Context, pd *parkingv1alpha1.ParkedDomain) (string, map[string]string, error) {
+	source, err := r.templateSourceFor(pd)
+	if err != nil {
+		return "", nil, err
+	}
+	templates, err := source.Fetch(ctx, pd)
+	if err != nil {
+		return "", nil, err
+	}
+
+	lookup := func(templateName string) (string, error) {
+		templateContent, ok := templates[templateName]
+		if !ok {
+			return "", fmt.Errorf("template key '%s' not found in the %s template source", templateName, templateSourceType(pd))
+		}
+		return string(templateContent), nil
+	}
+	content, err := lookup(templateNameFor(pd))
+	if err != nil {
+		return "", nil, err
+	}
+	var localeTemplates map[string]string
+	for _, locale := range pd.Spec.Locales {
+		localeContent, err := lookup(localizedName(templateNameFor(pd), locale))
+		if err != nil {
+			return "", nil, err
+		}
+		if localeTemplates == nil {
+			localeTemplates = make(map[string]string, len(pd.Spec.Locales))
+		}
+		localeTemplates[locale] = localeContent
+	}
+	return content, localeTemplates, nil
+}
+
+// fetchTemplateURL downloads a template over HTTP(S), retrying transient failures a bounded number of times.
+func fetchTemplateURL(ctx context.Context, templateURL string) (string, error) {
+	var content string
+	err := retry.OnError(templateFetchBackoff, func(err error) bool {
+		var rfe *retriableFetchError
+		return errors.As(err, &rfe)
+	}, func() error {
+		var fetchErr error
+		content, fetchErr = fetchTemplateOnce(ctx, templateURL)
+		return fetchErr
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch template from '%s': %w", templateURL, err)
+	}
+	return content, nil
+}
+
+func fetchTemplateOnce(ctx context.Context, templateURL string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, templateURL, nil)
+	if err != nil {
+		return "", err
+	}
+	resp, err := templateHTTPClient.Do(req)
+	if errors.Is(err, errTemplateAddressBlocked) {
+		return "", err
+	}
+	if err != nil {
+		return "", &retriableFetchError{err: err}
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode >= http.StatusInternalServerError {
+		return "", &retriableFetchError{err: fmt.Errorf("unexpected response status %s", resp.Status)}
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("unexpected response status %s", resp.Status)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxTemplateSize+1))
+	if err != nil {
+		return "", &retriableFetchError{err: err}
+	}
+	if len(body) > maxTemplateSize {
+		return "", fmt.Errorf("template is larger than %d bytes", maxTemplateSize)
+	}
+	return string(body), nil
+}
+
+// templateData is the data a page template is executed with.
+type templateData struct {
+	Domain string
+	Region string
+}
+
+// renderTemplate executes the page template for the ParkedDomain. Besides the fields of
+// templateData, templates can use a small set of functions: upper, lower, now, default and
+// env, which only reads variables listed in TEMPLATE_ENV_ALLOWLIST. The legacy
+// {{DOMAIN_NAME}} placeholder keeps working as a function returning the domain name.
+func renderTemplate(content string, pd *parkingv1alpha1.ParkedDomain) (string, error) {
+	funcs := template.FuncMap{
+		"DOMAIN_NAME": func() string { return recordNameFor(pd) },
+		"upper":       strings.ToUpper,
+		"lower":       strings.ToLower,
+		"now":         time.Now,
+		"default": func(fallback, value string) string {
+			if value == "" {
+				return fallback
+			}
+			return value
+		},
+		"env": templateEnv,
+	}
+
+	tmpl, err := template.New("page").Option("missingkey=error").Funcs(funcs).Parse(content)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse template: %w", err)
+	}
+	var out strings.Builder
+	if err := tmpl.Execute(&out, templateData{Domain: recordNameFor(pd), Region: regionFor(pd)}); err != nil {
+		return "", fmt.Errorf("failed to render template: %w", err)
+	}
+	return out.String(), nil
+}
+
+// templateEnv returns the value of an operator environment variable named in the
+// comma-separated TEMPLATE_ENV_ALLOWLIST, so templates cannot read credentials.
+func templateEnv(name string) (string, error) {
+	for _, allowed := range strings.Split(os.Getenv("TEMPLATE_ENV_ALLOWLIST"), ",") {
+		if strings.TrimSpace(allowed) == name && name != "" {
+			return os.Getenv(name), nil
+		}
+	}
+	return "", fmt.Errorf("environment variable %q is not in TEMPLATE_ENV_ALLOWLIST", name)
+}
@@ -0,0 +1,481 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"os"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	parkingv1alpha1 "github.com/gminiba/parked-domain-operator/api/v1alpha1"
+)
+
+var _ = Describe("Template loading", func() {
+	Context("When Spec.TemplateURL is set", func() {
+		var (
+			server *httptest.Server
+			req    ctrl.Request
+		)
+
+		BeforeEach(func() {
+			server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/page.html" {
+					http.NotFound(w, r)
+					return
+				}
+				_, _ = io.WriteString(w, "<h1>Remote {{DOMAIN_NAME}}</h1>")
+			}))
+			req = ctrl.Request{NamespacedName: types.NamespacedName{Name: "remote-template", Namespace: "default"}}
+			// The test server listens on loopback, which templateHTTPClient refuses.
+			saved := templateHTTPClient
+			templateHTTPClient = server.Client()
+			DeferCleanup(func() { templateHTTPClient = saved })
+		})
+
+		AfterEach(func() {
+			server.Close()
+		})
+
+		newParkedDomain := func(templateURL string) *parkingv1alpha1.ParkedDomain {
+			return &parkingv1alpha1.ParkedDomain{
+				ObjectMeta: metav1.ObjectMeta{Name: req.Name, Namespace: req.Namespace},
+				Spec: parkingv1alpha1.ParkedDomainSpec{
+					DomainName:  "remote.example.com",
+					TemplateURL: templateURL,
+				},
+			}
+		}
+
+		It("should upload the rendered remote template without a ConfigMap", func() {
+			ctx := context.Background()
+			var uploaded string
+			s3Client := &MockS3Client{
+				PutObjectFunc: func(ctx context.Context, params *s3.PutObjectInput, optFns ...func(*s3.Options)) (*s3.PutObjectOutput, error) {
+					body, err := io.ReadAll(params.Body)
+					Expect(err).NotTo(HaveOccurred())
+					uploaded = string(body)
+					return &s3.PutObjectOutput{}, nil
+				},
+			}
+			r := newTestReconciler(&MockR53Client{}, s3Client, newParkedDomain(server.URL+"/page.html"))
+
+			_, err := r.Reconcile(ctx, req)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(uploaded).To(Equal("<h1>Remote remote.example.com</h1>"))
+		})
+
+		It("should report a ContentReady=False condition when the fetch fails", func() {
+			ctx := context.Background()
+			r := newTestReconciler(&MockR53Client{}, &MockS3Client{}, newParkedDomain(server.URL+"/missing.html"))
+
+			_, err := r.Reconcile(ctx, req)
+			Expect(err).To(MatchError(ContainSubstring("404")))
+
+			failed := &parkingv1alpha1.ParkedDomain{}
+			Expect(r.Get(ctx, req.NamespacedName, failed)).To(Succeed())
+			cond := meta.FindStatusCondition(failed.Status.Conditions, parkingv1alpha1.ConditionContentReady)
+			Expect(cond).NotTo(BeNil())
+			Expect(cond.Status).To(Equal(metav1.ConditionFalse))
+			Expect(cond.Reason).To(Equal("TemplateUnavailable"))
+		})
+	})
+
+	Context("When Spec.TemplateURL addresses the cluster or the node", func() {
+		It("should refuse the instance metadata service and loopback without retrying", func() {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				Fail("a loopback template URL should not be fetched")
+			}))
+			defer server.Close()
+
+			for _, templateURL := range []string{
+				"http://169.254.169.254/latest/meta-data/iam/security-credentials/",
+				"http://[fd00:ec2::254]/latest/meta-data/",
+				server.URL + "/page.html",
+			} {
+				start := time.Now()
+				_, err := fetchTemplateURL(context.Background(), templateURL)
+				Expect(err).To(MatchError(errTemplateAddressBlocked), templateURL)
+				Expect(time.Since(start)).To(BeNumerically("<", templateFetchBackoff.Duration), templateURL)
+			}
+		})
+
+		It("should refuse a redirect to a non-public address", func() {
+			target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				Fail("the redirect target should not be fetched")
+			}))
+			defer target.Close()
+			// The redirecting server is reached with a client of its own, as if it were public;
+			// the redirect is then followed with the template client's dialer.
+			redirecting := httptest.NewServer(http.RedirectHandler(target.URL+"/page.html", http.StatusFound))
+			defer redirecting.Close()
+			transport := templateHTTPClient.Transport.(*http.Transport).Clone()
+			dialPublic := transport.DialContext
+			transport.DialContext = func(ctx context.Context, network, address string) (net.Conn, error) {
+				if "http://"+address == redirecting.URL {
+					return (&net.Dialer{}).DialContext(ctx, network, address)
+				}
+				return dialPublic(ctx, network, address)
+			}
+			saved := templateHTTPClient
+			templateHTTPClient = &http.Client{Transport: transport}
+			DeferCleanup(func() { templateHTTPClient = saved })
+
+			_, err := fetchTemplateOnce(context.Background(), redirecting.URL+"/page.html")
+			Expect(err).To(MatchError(errTemplateAddressBlocked))
+		})
+	})
+
+	DescribeTable("telling public addresses apart",
+		func(address string, public bool) {
+			Expect(publicAddress(netip.MustParseAddr(address))).To(Equal(public))
+		},
+		Entry("a public IPv4 address", "93.184.215.14", true),
+		Entry("a public IPv6 address", "2606:2800:21f:cb07:6820:80da:af6b:8b2c", true),
+		Entry("the instance metadata service", "169.254.169.254", false),
+		Entry("the IPv6 instance metadata service", "fd00:ec2::254", false),
+		Entry("loopback", "127.0.0.1", false),
+		Entry("IPv6 loopback", "::1", false),
+		Entry("a private address", "10.96.0.1", false),
+		Entry("a shared address", "100.64.0.10", false),
+		Entry("the unspecified address", "0.0.0.0", false),
+		Entry("an IPv4-mapped private address", "::ffff:192.168.0.1", false),
+	)
+
+	Context("When the ConfigMap template is empty", func() {
+		BeforeEach(func() {
+			Expect(os.Setenv("TEMPLATE_CONFIGMAP_NAME", "parked-domain-templates")).To(Succeed())
+		})
+
+		AfterEach(func() {
+			Expect(os.Unsetenv("TEMPLATE_CONFIGMAP_NAME")).To(Succeed())
+		})
+
+		It("should refuse to publish a blank page", func() {
+			ctx := context.Background()
+			templateCM := &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: "parked-domain-templates", Namespace: "default"},
+				Data:       map[string]string{"default.html": " \n\t"},
+			}
+			pd := &parkingv1alpha1.ParkedDomain{
+				ObjectMeta: metav1.ObjectMeta{Name: "blank", Namespace: "default"},
+				Spec:       parkingv1alpha1.ParkedDomainSpec{DomainName: "blank.example.com"},
+			}
+			s3Client := &MockS3Client{
+				PutObjectFunc: func(ctx context.Context, params *s3.PutObjectInput, optFns ...func(*s3.Options)) (*s3.PutObjectOutput, error) {
+					Fail("a blank page should not be uploaded")
+					return nil, nil
+				},
+			}
+			r := newTestReconciler(&MockR53Client{}, s3Client, pd, templateCM)
+			blankReq := ctrl.Request{NamespacedName: types.NamespacedName{Name: "blank", Namespace: "default"}}
+
+			_, err := r.Reconcile(ctx, blankReq)
+			Expect(err).To(MatchError(ContainSubstring("template is empty")))
+
+			failed := &parkingv1alpha1.ParkedDomain{}
+			Expect(r.Get(ctx, blankReq.NamespacedName, failed)).To(Succeed())
+			Expect(failed.Status.Status).To(Equal("Error: S3 Bucket"))
+			cond := meta.FindStatusCondition(failed.Status.Conditions, parkingv1alpha1.ConditionContentReady)
+			Expect(cond).NotTo(BeNil())
+			Expect(cond.Reason).To(Equal("TemplateEmpty"))
+		})
+	})
+
+	Context("When Spec.ParkingMode is set", func() {
+		BeforeEach(func() {
+			Expect(os.Setenv("TEMPLATE_CONFIGMAP_NAME", "parked-domain-templates")).To(Succeed())
+		})
+
+		AfterEach(func() {
+			Expect(os.Unsetenv("TEMPLATE_CONFIGMAP_NAME")).To(Succeed())
+		})
+
+		It("should default to the mode's template unless TemplateName is set", func() {
+			templateCM := &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: "parked-domain-templates", Namespace: "default"},
+				Data: map[string]string{
+					"default.html":  "default",
+					"for-sale.html": "for sale",
+					"custom.html":   "custom",
+				},
+			}
+			r := newTestReconciler(&MockR53Client{}, &MockS3Client{}, templateCM)
+			pd := &parkingv1alpha1.ParkedDomain{
+				ObjectMeta: metav1.ObjectMeta{Name: "mode", Namespace: "default"},
+				Spec:       parkingv1alpha1.ParkedDomainSpec{DomainName: "mode.example.com", ParkingMode: parkingv1alpha1.ParkingModeForSale},
+			}
+
+			Expect(r.loadTemplate(context.Background(), pd)).To(Equal("for sale"))
+
+			pd.Spec.TemplateName = "custom.html"
+			Expect(r.loadTemplate(context.Background(), pd)).To(Equal("custom"))
+		})
+	})
+
+	Context("When Spec.TemplateConfigMapRef is set", func() {
+		var templateCM *corev1.ConfigMap
+
+		BeforeEach(func() {
+			Expect(os.Setenv("TEMPLATE_CONFIGMAP_NAME", "parked-domain-templates")).To(Succeed())
+			templateCM = &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: "team-templates", Namespace: "team-a"},
+				Data:       map[string]string{"default.html": "team a"},
+			}
+		})
+
+		AfterEach(func() {
+			Expect(os.Unsetenv("TEMPLATE_CONFIGMAP_NAME")).To(Succeed())
+		})
+
+		It("should read the referenced ConfigMap instead of the operator default", func() {
+			defaultCM := &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: "parked-domain-templates", Namespace: "team-a"},
+				Data:       map[string]string{"default.html": "operator default"},
+			}
+			r := newTestReconciler(&MockR53Client{}, &MockS3Client{}, templateCM, defaultCM)
+			pd := &parkingv1alpha1.ParkedDomain{
+				ObjectMeta: metav1.ObjectMeta{Name: "ref", Namespace: "team-a"},
+				Spec: parkingv1alpha1.ParkedDomainSpec{
+					DomainName:           "ref.example.com",
+					TemplateConfigMapRef: &parkingv1alpha1.TemplateConfigMapRef{Name: "team-templates"},
+				},
+			}
+
+			Expect(r.loadTemplate(context.Background(), pd)).To(Equal("team a"))
+
+			pd.Spec.TemplateConfigMapRef = nil
+			Expect(r.loadTemplate(context.Background(), pd)).To(Equal("operator default"))
+		})
+
+		It("should only read another namespace's ConfigMap when allowed", func() {
+			r := newTestReconciler(&MockR53Client{}, &MockS3Client{}, templateCM)
+			pd := &parkingv1alpha1.ParkedDomain{
+				ObjectMeta: metav1.ObjectMeta{Name: "ref", Namespace: "team-b"},
+				Spec: parkingv1alpha1.ParkedDomainSpec{
+					DomainName:           "ref.example.com",
+					TemplateConfigMapRef: &parkingv1alpha1.TemplateConfigMapRef{Name: "team-templates", Namespace: "team-a"},
+				},
+			}
+
+			_, err := r.loadTemplate(context.Background(), pd)
+			Expect(err).To(MatchError(ContainSubstring("cross-namespace templates are disabled")))
+
+			r.AllowCrossNamespaceTemplates = true
+			Expect(r.loadTemplate(context.Background(), pd)).To(Equal("team a"))
+		})
+	})
+
+	Context("When Spec.TemplateSource is Secret", func() {
+		var templateSecret *corev1.Secret
+
+		BeforeEach(func() {
+			templateSecret = &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "private-templates",
+					Namespace: "team-a",
+					Labels:    map[string]string{templateSecretLabel: "true"},
+				},
+				Data: map[string][]byte{"default.html": []byte("secret page")},
+			}
+		})
+
+		newParkedDomain := func(namespace string, ref *parkingv1alpha1.TemplateSecretRef) *parkingv1alpha1.ParkedDomain {
+			return &parkingv1alpha1.ParkedDomain{
+				ObjectMeta: metav1.ObjectMeta{Name: "secret", Namespace: namespace},
+				Spec: parkingv1alpha1.ParkedDomainSpec{
+					DomainName:        "secret.example.com",
+					TemplateSource:    parkingv1alpha1.TemplateSourceSecret,
+					TemplateSecretRef: ref,
+				},
+			}
+		}
+
+		It("should read the template from the referenced Secret", func() {
+			r := newTestReconciler(&MockR53Client{}, &MockS3Client{}, templateSecret)
+			r.AllowSecretTemplates = true
+			pd := newParkedDomain("team-a", &parkingv1alpha1.TemplateSecretRef{Name: "private-templates"})
+
+			Expect(r.loadTemplate(context.Background(), pd)).To(Equal("secret page"))
+		})
+
+		It("should only read Secrets both the operator and the Secret opted in to", func() {
+			pd := newParkedDomain("team-a", &parkingv1alpha1.TemplateSecretRef{Name: "private-templates"})
+			r := newTestReconciler(&MockR53Client{}, &MockS3Client{}, templateSecret)
+			_, err := r.loadTemplate(context.Background(), pd)
+			Expect(err).To(MatchError(ContainSubstring("--allow-secret-templates")))
+
+			templateSecret.Labels = nil
+			r = newTestReconciler(&MockR53Client{}, &MockS3Client{}, templateSecret)
+			r.AllowSecretTemplates = true
+			_, err = r.loadTemplate(context.Background(), pd)
+			Expect(err).To(MatchError(ContainSubstring("is not labeled parking.minibaev.eu/template=true")))
+		})
+
+		It("should only read another namespace's Secret when allowed", func() {
+			r := newTestReconciler(&MockR53Client{}, &MockS3Client{}, templateSecret)
+			r.AllowSecretTemplates = true
+			pd := newParkedDomain("team-b", &parkingv1alpha1.TemplateSecretRef{Name: "private-templates", Namespace: "team-a"})
+
+			_, err := r.loadTemplate(context.Background(), pd)
+			Expect(err).To(MatchError(ContainSubstring("cross-namespace templates are disabled")))
+
+			r.AllowCrossNamespaceTemplates = true
+			Expect(r.loadTemplate(context.Background(), pd)).To(Equal("secret page"))
+		})
+
+		It("should fail without a Secret ref", func() {
+			r := newTestReconciler(&MockR53Client{}, &MockS3Client{})
+
+			_, err := r.loadTemplate(context.Background(), newParkedDomain("team-a", nil))
+			Expect(err).To(MatchError(ContainSubstring("templateSecretRef must be set")))
+		})
+	})
+
+	Context("When Spec.TemplateSource is Inline", func() {
+		It("should upload the rendered inline template without a ConfigMap", func() {
+			ctx := context.Background()
+			var uploaded string
+			s3Client := &MockS3Client{
+				PutObjectFunc: func(ctx context.Context, params *s3.PutObjectInput, optFns ...func(*s3.Options)) (*s3.PutObjectOutput, error) {
+					body, err := io.ReadAll(params.Body)
+					Expect(err).NotTo(HaveOccurred())
+					uploaded = string(body)
+					return &s3.PutObjectOutput{}, nil
+				},
+			}
+			pd := &parkingv1alpha1.ParkedDomain{
+				ObjectMeta: metav1.ObjectMeta{Name: "inline", Namespace: "default"},
+				Spec: parkingv1alpha1.ParkedDomainSpec{
+					DomainName:     "inline.example.com",
+					TemplateSource: parkingv1alpha1.TemplateSourceInline,
+					InlineTemplate: "<h1>Inline {{DOMAIN_NAME}}</h1>",
+				},
+			}
+			r := newTestReconciler(&MockR53Client{}, s3Client, pd)
+
+			_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Name: "inline", Namespace: "default"}})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(uploaded).To(Equal("<h1>Inline inline.example.com</h1>"))
+		})
+
+		It("should be selected by inlineTemplate alone, before templateURL and the ConfigMap", func() {
+			os.Setenv("TEMPLATE_CONFIGMAP_NAME", "parked-domain-templates")
+			DeferCleanup(os.Unsetenv, "TEMPLATE_CONFIGMAP_NAME")
+			templateCM := &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: "parked-domain-templates", Namespace: "default"},
+				Data:       map[string]string{"default.html": "<h1>ConfigMap</h1>"},
+			}
+			r := newTestReconciler(&MockR53Client{}, &MockS3Client{}, templateCM)
+			pd := &parkingv1alpha1.ParkedDomain{
+				ObjectMeta: metav1.ObjectMeta{Name: "inline", Namespace: "default"},
+				Spec: parkingv1alpha1.ParkedDomainSpec{
+					DomainName:     "inline.example.com",
+					InlineTemplate: "<h1>Inline</h1>",
+					// Never fetched, as inlineTemplate takes precedence.
+					TemplateURL: "http://127.0.0.1:1/index.html",
+				},
+			}
+
+			Expect(r.loadTemplate(context.Background(), pd)).To(Equal("<h1>Inline</h1>"))
+
+			pd.Spec.InlineTemplate = ""
+			pd.Spec.TemplateURL = ""
+			Expect(r.loadTemplate(context.Background(), pd)).To(Equal("<h1>ConfigMap</h1>"))
+		})
+	})
+
+	DescribeTable("inferring the template source",
+		func(spec parkingv1alpha1.ParkedDomainSpec, expected parkingv1alpha1.TemplateSourceType) {
+			Expect(templateSourceType(&parkingv1alpha1.ParkedDomain{Spec: spec})).To(Equal(expected))
+		},
+		Entry("nothing set", parkingv1alpha1.ParkedDomainSpec{}, parkingv1alpha1.TemplateSourceConfigMap),
+		Entry("a template URL", parkingv1alpha1.ParkedDomainSpec{TemplateURL: "https://example.org/a.html"}, parkingv1alpha1.TemplateSourceURL),
+		Entry("an inline template and a template URL",
+			parkingv1alpha1.ParkedDomainSpec{InlineTemplate: "<h1>parked</h1>", TemplateURL: "https://example.org/a.html"}, parkingv1alpha1.TemplateSourceInline),
+		Entry("an explicit source over an inline template",
+			parkingv1alpha1.ParkedDomainSpec{TemplateSource: parkingv1alpha1.TemplateSourceSecret, InlineTemplate: "<h1>parked</h1>"}, parkingv1alpha1.TemplateSourceSecret),
+	)
+
+	Context("When a template source is registered", func() {
+		It("should use it instead of the built-in source", func() {
+			r := newTestReconciler(&MockR53Client{}, &MockS3Client{})
+			r.TemplateSources = map[parkingv1alpha1.TemplateSourceType]TemplateSource{
+				parkingv1alpha1.TemplateSourceConfigMap: stubTemplateSource{"for-sale.html": []byte("stub page")},
+			}
+			pd := &parkingv1alpha1.ParkedDomain{
+				ObjectMeta: metav1.ObjectMeta{Name: "stub", Namespace: "default"},
+				Spec:       parkingv1alpha1.ParkedDomainSpec{DomainName: "stub.example.com", ParkingMode: parkingv1alpha1.ParkingModeForSale},
+			}
+
+			Expect(r.loadTemplate(context.Background(), pd)).To(Equal("stub page"))
+
+			pd.Spec.TemplateName = "missing.html"
+			_, err := r.loadTemplate(context.Background(), pd)
+			Expect(err).To(MatchError(ContainSubstring("template key 'missing.html' not found in the ConfigMap template source")))
+		})
+	})
+})
+
+// stubTemplateSource serves a fixed set of templates.
+type stubTemplateSource map[string][]byte
+
+func (s stubTemplateSource) Fetch(ctx context.Context, pd *parkingv1alpha1.ParkedDomain) (map[string][]byte, error) {
+	return s, nil
+}
+
+var _ = Describe("Template rendering", func() {
+	var pd *parkingv1alpha1.ParkedDomain
+
+	BeforeEach(func() {
+		pd = &parkingv1alpha1.ParkedDomain{Spec: parkingv1alpha1.ParkedDomainSpec{DomainName: "render.example.com"}}
+	})
+
+	It("should keep replacing the DOMAIN_NAME placeholder", func() {
+		Expect(renderTemplate("<h1>{{DOMAIN_NAME}}</h1>", pd)).To(Equal("<h1>render.example.com</h1>"))
+	})
+
+	It("should provide the curated template functions", func() {
+		page, err := renderTemplate(`{{ upper .Domain }} {{ default "eu" "" }} &copy; {{ now.Year }}`, pd)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(page).To(Equal(fmt.Sprintf("RENDER.EXAMPLE.COM eu &copy; %d", time.Now().Year())))
+	})
+
+	It("should only read allowlisted environment variables", func() {
+		GinkgoT().Setenv("TEMPLATE_ENV_ALLOWLIST", "COMPANY_NAME")
+		GinkgoT().Setenv("COMPANY_NAME", "Example Corp")
+		GinkgoT().Setenv("AWS_SECRET_ACCESS_KEY", "secret")
+
+		Expect(renderTemplate(`{{ env "COMPANY_NAME" | lower }}`, pd)).To(Equal("example corp"))
+		_, err := renderTemplate(`{{ env "AWS_SECRET_ACCESS_KEY" }}`, pd)
+		Expect(err).To(MatchError(ContainSubstring("not in TEMPLATE_ENV_ALLOWLIST")))
+	})
+})
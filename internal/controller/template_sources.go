@@ -0,0 +1,188 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	parkingv1alpha1 "github.com/gminiba/parked-domain-operator/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// templateSecretLabel marks a Secret whose data may be published as page templates. Without
+// it, a ParkedDomain could publish any Secret of its namespace to a public bucket.
+const templateSecretLabel = parkingv1alpha1.GroupName + "/template"
+
+// TemplateSource fetches the page templates of a ParkedDomain, keyed by template name.
+// Sources holding a single template key it by the name the ParkedDomain looks up.
+type TemplateSource interface {
+	Fetch(ctx context.Context, pd *parkingv1alpha1.ParkedDomain) (map[string][]byte, error)
+}
+
+// templateSourceType returns the template source pd reads from, inferring it from the
+// spec when Spec.TemplateSource is unset.
+func templateSourceType(pd *parkingv1alpha1.ParkedDomain) parkingv1alpha1.TemplateSourceType {
+	switch {
+	case pd.Spec.TemplateSource != "":
+		return pd.Spec.TemplateSource
+	case pd.Spec.InlineTemplate != "":
+		return parkingv1alpha1.TemplateSourceInline
+	case pd.Spec.TemplateURL != "":
+		return parkingv1alpha1.TemplateSourceURL
+	default:
+		return parkingv1alpha1.TemplateSourceConfigMap
+	}
+}
+
+// templateSourceFor returns the source of pd's templates, preferring one registered in
+// r.TemplateSources over the built-in one.
+func (r *ParkedDomainReconciler) templateSourceFor(pd *parkingv1alpha1.ParkedDomain) (TemplateSource, error) {
+	sourceType := templateSourceType(pd)
+	if source, ok := r.TemplateSources[sourceType]; ok {
+		return source, nil
+	}
+	switch sourceType {
+	case parkingv1alpha1.TemplateSourceConfigMap:
+		return &configMapTemplateSource{reader: r.Client, allowCrossNamespace: r.AllowCrossNamespaceTemplates}, nil
+	case parkingv1alpha1.TemplateSourceSecret:
+		return &secretTemplateSource{reader: r.Client, enabled: r.AllowSecretTemplates, allowCrossNamespace: r.AllowCrossNamespaceTemplates}, nil
+	case parkingv1alpha1.TemplateSourceURL:
+		return urlTemplateSource{}, nil
+	case parkingv1alpha1.TemplateSourceInline:
+		return inlineTemplateSource{}, nil
+	}
+	return nil, fmt.Errorf("unknown template source %q", sourceType)
+}
+
+// configMapTemplateSource reads templates from Spec.TemplateConfigMapRef, or from the
+// operator-wide TEMPLATE_CONFIGMAP_NAME default.
+type configMapTemplateSource struct {
+	reader              client.Reader
+	allowCrossNamespace bool
+}
+
+func (s *configMapTemplateSource) Fetch(ctx context.Context, pd *parkingv1alpha1.ParkedDomain) (map[string][]byte, error) {
+	cmName, cmNamespace, err := s.configMapKey(pd)
+	if err != nil {
+		return nil, err
+	}
+
+	templateCM := &corev1.ConfigMap{}
+	if err := s.reader.Get(ctx, types.NamespacedName{Name: cmName, Namespace: cmNamespace}, templateCM); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, fmt.Errorf("%w: '%s' in namespace '%s'", errTemplateConfigMapMissing, cmName, cmNamespace)
+		}
+		return nil, fmt.Errorf("failed to get template ConfigMap '%s' in namespace '%s': %w", cmName, cmNamespace, err)
+	}
+
+	templates := make(map[string][]byte, len(templateCM.Data)+len(templateCM.BinaryData))
+	for name, content := range templateCM.BinaryData {
+		templates[name] = content
+	}
+	for name, content := range templateCM.Data {
+		templates[name] = []byte(content)
+	}
+	return templates, nil
+}
+
+// configMapKey returns the name and namespace of the ConfigMap holding pd's templates:
+// Spec.TemplateConfigMapRef when set, otherwise the operator-wide TEMPLATE_CONFIGMAP_NAME default.
+func (s *configMapTemplateSource) configMapKey(pd *parkingv1alpha1.ParkedDomain) (string, string, error) {
+	if ref := pd.Spec.TemplateConfigMapRef; ref != nil {
+		namespace, err := templateRefNamespace(pd, "ConfigMap", ref.Name, ref.Namespace, s.allowCrossNamespace)
+		return ref.Name, namespace, err
+	}
+
+	cmName := os.Getenv("TEMPLATE_CONFIGMAP_NAME")
+	if cmName == "" {
+		return "", "", errors.New("TEMPLATE_CONFIGMAP_NAME environment variable must be set")
+	}
+
+	cmNamespace := os.Getenv("TEMPLATE_CONFIGMAP_NAMESPACE")
+	if cmNamespace == "" {
+		cmNamespace = pd.Namespace // Default to the CR's namespace.
+	}
+	return cmName, cmNamespace, nil
+}
+
+// secretTemplateSource reads templates from Spec.TemplateSecretRef, for pages that must not be
+// readable by everyone allowed to read ConfigMaps. It refuses all Secrets unless enabled, and
+// Secrets not labeled templateSecretLabel=true even then.
+type secretTemplateSource struct {
+	reader              client.Reader
+	enabled             bool
+	allowCrossNamespace bool
+}
+
+func (s *secretTemplateSource) Fetch(ctx context.Context, pd *parkingv1alpha1.ParkedDomain) (map[string][]byte, error) {
+	ref := pd.Spec.TemplateSecretRef
+	if ref == nil {
+		return nil, errors.New("templateSecretRef must be set to read templates from a Secret")
+	}
+	if !s.enabled {
+		return nil, errors.New("templates from Secrets are disabled; start the operator with --allow-secret-templates")
+	}
+	namespace, err := templateRefNamespace(pd, "Secret", ref.Name, ref.Namespace, s.allowCrossNamespace)
+	if err != nil {
+		return nil, err
+	}
+
+	secret := &corev1.Secret{}
+	if err := s.reader.Get(ctx, types.NamespacedName{Name: ref.Name, Namespace: namespace}, secret); err != nil {
+		return nil, fmt.Errorf("failed to get template Secret '%s' in namespace '%s': %w", ref.Name, namespace, err)
+	}
+	if secret.Labels[templateSecretLabel] != "true" {
+		return nil, fmt.Errorf("template Secret '%s' in namespace '%s' is not labeled %s=true", ref.Name, namespace, templateSecretLabel)
+	}
+	return secret.Data, nil
+}
+
+// templateRefNamespace returns the namespace of a referenced template object, defaulting to
+// pd's own, and refuses other namespaces unless cross-namespace templates are allowed.
+func templateRefNamespace(pd *parkingv1alpha1.ParkedDomain, kind, name, namespace string, allowCrossNamespace bool) (string, error) {
+	if namespace == "" {
+		namespace = pd.Namespace
+	}
+	if namespace != pd.Namespace && !allowCrossNamespace {
+		return "", fmt.Errorf("template %s '%s' in namespace '%s' is outside the ParkedDomain's namespace '%s' and cross-namespace templates are disabled", kind, name, namespace, pd.Namespace)
+	}
+	return namespace, nil
+}
+
+// urlTemplateSource fetches the template from Spec.TemplateURL.
+type urlTemplateSource struct{}
+
+func (urlTemplateSource) Fetch(ctx context.Context, pd *parkingv1alpha1.ParkedDomain) (map[string][]byte, error) {
+	content, err := fetchTemplateURL(ctx, pd.Spec.TemplateURL)
+	if err != nil {
+		return nil, err
+	}
+	return map[string][]byte{templateNameFor(pd): []byte(content)}, nil
+}
+
+// inlineTemplateSource serves the template in Spec.InlineTemplate.
+type inlineTemplateSource struct{}
+
+func (inlineTemplateSource) Fetch(ctx context.Context, pd *parkingv1alpha1.ParkedDomain) (map[string][]byte, error) {
+	return map[string][]byte{templateNameFor(pd): []byte(pd.Spec.InlineTemplate)}, nil
+}
@@ -0,0 +1,123 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	parkingv1alpha1 "github.com/gminiba/parked-domain-operator/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// templateConfigMapPredicate passes the creation and deletion of ConfigMaps, which make the
+// ParkedDomains rendered from them report a missing template or recover from it, and updates
+// of their data, which the ParkedDomains publish again. Metadata-only updates are dropped.
+func templateConfigMapPredicate() predicate.Predicate {
+	return predicate.Funcs{
+		CreateFunc: func(event.CreateEvent) bool { return true },
+		DeleteFunc: func(event.DeleteEvent) bool { return true },
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldCM, ok := e.ObjectOld.(*corev1.ConfigMap)
+			if !ok {
+				return false
+			}
+			newCM, ok := e.ObjectNew.(*corev1.ConfigMap)
+			if !ok {
+				return false
+			}
+			return !equality.Semantic.DeepEqual(oldCM.Data, newCM.Data) || !equality.Semantic.DeepEqual(oldCM.BinaryData, newCM.BinaryData)
+		},
+		GenericFunc: func(event.GenericEvent) bool { return false },
+	}
+}
+
+// domainsForTemplateConfigMap maps a ConfigMap to the ParkedDomains whose template is read from it.
+func (r *ParkedDomainReconciler) domainsForTemplateConfigMap(ctx context.Context, obj client.Object) []reconcile.Request {
+	var domains parkingv1alpha1.ParkedDomainList
+	if err := r.List(ctx, &domains); err != nil {
+		log.FromContext(ctx).Error(err, "Failed to list ParkedDomains for template ConfigMap", "configMap", client.ObjectKeyFromObject(obj))
+		return nil
+	}
+	var requests []reconcile.Request
+	for i := range domains.Items {
+		pd := &domains.Items[i]
+		if key, ok := r.templateConfigMapKey(pd); ok && key == client.ObjectKeyFromObject(obj) {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(pd)})
+		}
+	}
+	return requests
+}
+
+// templateConfigMapKey returns the key of the ConfigMap pd's template is read from, if it is
+// read from one by the built-in ConfigMap template source.
+func (r *ParkedDomainReconciler) templateConfigMapKey(pd *parkingv1alpha1.ParkedDomain) (types.NamespacedName, bool) {
+	if templateSourceType(pd) != parkingv1alpha1.TemplateSourceConfigMap {
+		return types.NamespacedName{}, false
+	}
+	if _, ok := r.TemplateSources[parkingv1alpha1.TemplateSourceConfigMap]; ok {
+		return types.NamespacedName{}, false
+	}
+	source := &configMapTemplateSource{allowCrossNamespace: r.AllowCrossNamespaceTemplates}
+	name, namespace, err := source.configMapKey(pd)
+	if err != nil {
+		return types.NamespacedName{}, false
+	}
+	return types.NamespacedName{Name: name, Namespace: namespace}, true
+}
+
+// checkTemplateConfigMap reports a deleted template ConfigMap in the ContentSourceMissing
+// condition of a ParkedDomain that needs no rendering, so it is noticed before the page next
+// has to be rendered. It returns whether the condition changed and the resourceVersion of the
+// ConfigMap, "" when it is missing or pd's template is not read from one.
+func (r *ParkedDomainReconciler) checkTemplateConfigMap(ctx context.Context, pd *parkingv1alpha1.ParkedDomain) (bool, string) {
+	key, ok := r.templateConfigMapKey(pd)
+	if !ok || !storageEnabled(pd) {
+		return meta.RemoveStatusCondition(&pd.Status.Conditions, parkingv1alpha1.ConditionContentSourceMissing), ""
+	}
+	cm := &corev1.ConfigMap{}
+	err := r.Get(ctx, key, cm)
+	switch {
+	case apierrors.IsNotFound(err):
+		return setContentSourceMissing(pd, fmt.Errorf("%w: '%s' in namespace '%s'", errTemplateConfigMapMissing, key.Name, key.Namespace)), ""
+	case err != nil:
+		log.FromContext(ctx).Error(err, "Failed to check template ConfigMap", "configMap", key)
+		return false, ""
+	}
+	return meta.RemoveStatusCondition(&pd.Status.Conditions, parkingv1alpha1.ConditionContentSourceMissing), cm.ResourceVersion
+}
+
+// setContentSourceMissing sets the ContentSourceMissing condition and returns whether it changed.
+func setContentSourceMissing(pd *parkingv1alpha1.ParkedDomain, err error) bool {
+	return meta.SetStatusCondition(&pd.Status.Conditions, metav1.Condition{
+		Type:               parkingv1alpha1.ConditionContentSourceMissing,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: pd.Generation,
+		Reason:             "TemplateConfigMapMissing",
+		Message:            err.Error(),
+	})
+}
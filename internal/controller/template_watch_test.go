@@ -0,0 +1,154 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"os"
+
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	parkingv1alpha1 "github.com/gminiba/parked-domain-operator/api/v1alpha1"
+)
+
+var _ = Describe("Template ConfigMap watch", func() {
+	var templateCM *corev1.ConfigMap
+
+	BeforeEach(func() {
+		os.Setenv("TEMPLATE_CONFIGMAP_NAME", "parked-domain-templates")
+		DeferCleanup(os.Unsetenv, "TEMPLATE_CONFIGMAP_NAME")
+		templateCM = &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "parked-domain-templates", Namespace: "default"},
+			Data:       map[string]string{"default.html": "<h1>{{DOMAIN_NAME}}</h1>"},
+		}
+	})
+
+	It("should pass edits of a ConfigMap's data but not of its metadata", func() {
+		predicate := templateConfigMapPredicate()
+		edited := templateCM.DeepCopy()
+		edited.Data["default.html"] = "<h1>For sale</h1>"
+		Expect(predicate.Update(event.UpdateEvent{ObjectOld: templateCM, ObjectNew: edited})).To(BeTrue())
+
+		binary := templateCM.DeepCopy()
+		binary.BinaryData = map[string][]byte{"logo.png": {0x89}}
+		Expect(predicate.Update(event.UpdateEvent{ObjectOld: templateCM, ObjectNew: binary})).To(BeTrue())
+
+		labeled := templateCM.DeepCopy()
+		labeled.Labels = map[string]string{"team": "web"}
+		Expect(predicate.Update(event.UpdateEvent{ObjectOld: templateCM, ObjectNew: labeled})).To(BeFalse())
+	})
+
+	It("should map a template ConfigMap to the ParkedDomains rendered from it", func() {
+		fromDefault := &parkingv1alpha1.ParkedDomain{
+			ObjectMeta: metav1.ObjectMeta{Name: "from-default", Namespace: "default"},
+			Spec:       parkingv1alpha1.ParkedDomainSpec{DomainName: "default.example.com"},
+		}
+		fromOwn := &parkingv1alpha1.ParkedDomain{
+			ObjectMeta: metav1.ObjectMeta{Name: "from-own", Namespace: "default"},
+			Spec: parkingv1alpha1.ParkedDomainSpec{
+				DomainName:           "own.example.com",
+				TemplateConfigMapRef: &parkingv1alpha1.TemplateConfigMapRef{Name: "team-templates"},
+			},
+		}
+		inline := &parkingv1alpha1.ParkedDomain{
+			ObjectMeta: metav1.ObjectMeta{Name: "inline", Namespace: "default"},
+			Spec:       parkingv1alpha1.ParkedDomainSpec{DomainName: "inline.example.com", InlineTemplate: "<h1>parked</h1>"},
+		}
+		r := newTestReconciler(&MockR53Client{}, &MockS3Client{}, fromDefault, fromOwn, inline)
+
+		requests := r.domainsForTemplateConfigMap(context.Background(), templateCM)
+		Expect(requests).To(ConsistOf(ctrl.Request{NamespacedName: types.NamespacedName{Name: "from-default", Namespace: "default"}}))
+	})
+
+	It("should report a deleted template ConfigMap on a reconciled ParkedDomain and clear it once restored", func() {
+		ctx := context.Background()
+		req := ctrl.Request{NamespacedName: types.NamespacedName{Name: "watched", Namespace: "default"}}
+		pd := &parkingv1alpha1.ParkedDomain{
+			ObjectMeta: metav1.ObjectMeta{Name: "watched", Namespace: "default"},
+			Spec:       parkingv1alpha1.ParkedDomainSpec{DomainName: "watched.example.com"},
+		}
+		r := newTestReconciler(&MockR53Client{}, &MockS3Client{}, pd, templateCM)
+		_, err := r.Reconcile(ctx, req)
+		Expect(err).NotTo(HaveOccurred())
+
+		By("deleting the ConfigMap")
+		Expect(r.Delete(ctx, templateCM)).To(Succeed())
+		Expect(r.domainsForTemplateConfigMap(ctx, templateCM)).To(ConsistOf(req))
+		_, err = r.Reconcile(ctx, req)
+		Expect(err).NotTo(HaveOccurred())
+		reported := &parkingv1alpha1.ParkedDomain{}
+		Expect(r.Get(ctx, req.NamespacedName, reported)).To(Succeed())
+		missing := meta.FindStatusCondition(reported.Status.Conditions, parkingv1alpha1.ConditionContentSourceMissing)
+		Expect(missing).NotTo(BeNil())
+		Expect(missing.Status).To(Equal(metav1.ConditionTrue))
+		Expect(missing.Message).To(ContainSubstring("'parked-domain-templates' in namespace 'default'"))
+		Expect(reported.Status.Ready).To(BeTrue())
+
+		By("restoring the ConfigMap")
+		templateCM.ResourceVersion = ""
+		Expect(r.Create(ctx, templateCM)).To(Succeed())
+		_, err = r.Reconcile(ctx, req)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(r.Get(ctx, req.NamespacedName, reported)).To(Succeed())
+		Expect(meta.FindStatusCondition(reported.Status.Conditions, parkingv1alpha1.ConditionContentSourceMissing)).To(BeNil())
+	})
+
+	It("should render the page again once the template ConfigMap changed", func() {
+		ctx := context.Background()
+		req := ctrl.Request{NamespacedName: types.NamespacedName{Name: "rendered", Namespace: "default"}}
+		pd := &parkingv1alpha1.ParkedDomain{
+			ObjectMeta: metav1.ObjectMeta{Name: "rendered", Namespace: "default"},
+			Spec:       parkingv1alpha1.ParkedDomainSpec{DomainName: "rendered.example.com"},
+		}
+		renders := 0
+		s3Client := &MockS3Client{
+			HeadBucketFunc: func(ctx context.Context, params *s3.HeadBucketInput, optFns ...func(*s3.Options)) (*s3.HeadBucketOutput, error) {
+				renders++
+				return &s3.HeadBucketOutput{}, nil
+			},
+		}
+		r := newTestReconciler(&MockR53Client{}, s3Client, pd, templateCM)
+		_, err := r.Reconcile(ctx, req)
+		Expect(err).NotTo(HaveOccurred())
+		reconciled := &parkingv1alpha1.ParkedDomain{}
+		Expect(r.Get(ctx, req.NamespacedName, reconciled)).To(Succeed())
+		Expect(reconciled.Status.TemplateConfigMapVersion).NotTo(BeEmpty())
+
+		By("skipping the bucket while the ConfigMap is unchanged")
+		renders = 0
+		_, err = r.Reconcile(ctx, req)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(renders).To(BeZero())
+
+		By("configuring the bucket again after an edit")
+		templateCM.Data["default.html"] = "<h1>{{DOMAIN_NAME}} is for sale</h1>"
+		Expect(r.Update(ctx, templateCM)).To(Succeed())
+		_, err = r.Reconcile(ctx, req)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(renders).To(BeNumerically(">", 0))
+		Expect(r.Get(ctx, req.NamespacedName, reconciled)).To(Succeed())
+		Expect(reconciled.Status.TemplateConfigMapVersion).To(Equal(templateCM.ResourceVersion))
+	})
+})
@@ -0,0 +1,575 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"net"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
+	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
+	parkingv1alpha1 "github.com/gminiba/parked-domain-operator/api/v1alpha1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// errInvalidSpec is returned when a ParkedDomain fails ValidateParkedDomain, e.g. because it
+// was created while the webhook was not deployed. Only a change to the spec fixes it, so it
+// is returned as a terminal error that is not retried.
+var errInvalidSpec = errors.New("invalid spec")
+
+// hostedZoneIDPattern matches Route 53 hosted zone IDs.
+var hostedZoneIDPattern = regexp.MustCompile(`^Z[0-9A-Z]+$`)
+
+// maxObjectMetadataSize is the largest total size, in bytes, of the user-defined metadata S3
+// stores with an object, counted as the sum of the key and value lengths.
+const maxObjectMetadataSize = 2048
+
+// storageClasses are the S3 storage classes the page can be uploaded with. Archive classes are
+// left out, as S3 cannot serve their objects without a restore.
+var storageClasses = []s3types.StorageClass{
+	s3types.StorageClassStandard,
+	s3types.StorageClassIntelligentTiering,
+	s3types.StorageClassStandardIa,
+	s3types.StorageClassOnezoneIa,
+	s3types.StorageClassGlacierIr,
+}
+
+// maxInlineTemplateSize is the largest Spec.InlineTemplate accepted, in bytes, keeping the
+// ParkedDomain well below the size etcd stores objects up to.
+const maxInlineTemplateSize = 256 << 10
+
+// localePattern matches the language tags accepted in Spec.Locales, e.g. "de" or "pt-BR".
+var localePattern = regexp.MustCompile(`^[a-z]{2,3}(-[A-Za-z0-9]{2,8})*$`)
+
+// maxLocales is the most locales a page is published in.
+const maxLocales = 20
+
+// maxRecordCommentLength is the longest Spec.RecordComment that still fits the comment of a
+// Route 53 change batch after managedComment and its separator.
+const maxRecordCommentLength = maxChangeCommentLength - len(managedComment+": ")
+
+// maxBucketNameLength is the longest name S3 accepts for a bucket. The domain
+// name is used as the bucket name, so it is bound by the same limit.
+const maxBucketNameLength = 63
+
+// ValidateParkedDomain checks a ParkedDomain for mistakes the CRD schema cannot
+// catch: domain name syntax, region support and mutually exclusive fields.
+// It needs no cluster or AWS access, so it can run offline.
+func ValidateParkedDomain(pd *parkingv1alpha1.ParkedDomain) field.ErrorList {
+	var allErrs field.ErrorList
+	specPath := field.NewPath("spec")
+
+	domainPath := specPath.Child("domainName")
+	domain := strings.TrimSuffix(pd.Spec.DomainName, ".")
+	switch {
+	case domain == "":
+		allErrs = append(allErrs, field.Required(domainPath, "a domain name to park is required"))
+	case len(validation.IsDNS1123Subdomain(domain)) > 0:
+		allErrs = append(allErrs, field.Invalid(domainPath, pd.Spec.DomainName, strings.Join(validation.IsDNS1123Subdomain(domain), "; ")))
+	case !strings.Contains(domain, "."):
+		allErrs = append(allErrs, field.Invalid(domainPath, pd.Spec.DomainName, "must be a fully qualified domain name"))
+	case len(domain) > maxBucketNameLength:
+		allErrs = append(allErrs, field.TooLong(domainPath, pd.Spec.DomainName, maxBucketNameLength))
+	}
+
+	if pd.Spec.RecordName != "" {
+		recordPath := specPath.Child("recordName")
+		record := strings.TrimSuffix(pd.Spec.RecordName, ".")
+		switch {
+		case len(validation.IsDNS1123Subdomain(record)) > 0:
+			allErrs = append(allErrs, field.Invalid(recordPath, pd.Spec.RecordName, strings.Join(validation.IsDNS1123Subdomain(record), "; ")))
+		case domain != "" && !strings.EqualFold(record, domain) && !strings.HasSuffix(strings.ToLower(record), "."+strings.ToLower(domain)):
+			allErrs = append(allErrs, field.Invalid(recordPath, pd.Spec.RecordName, "must be "+domain+" or a host within it"))
+		case len(record) > maxBucketNameLength:
+			allErrs = append(allErrs, field.TooLong(recordPath, pd.Spec.RecordName, maxBucketNameLength))
+		}
+	}
+
+	regionPath := specPath.Child("region")
+	region := regionFor(pd)
+	if _, err := partitionForRegion(region); err != nil {
+		allErrs = append(allErrs, field.Invalid(regionPath, pd.Spec.Region, err.Error()))
+	} else if _, err := getS3WebsiteHostedZoneID(region); err != nil {
+		allErrs = append(allErrs, field.NotSupported(regionPath, pd.Spec.Region, supportedRegions()))
+	}
+
+	if mode := pd.Spec.ParkingMode; mode != "" {
+		if _, ok := parkingModeTemplates[mode]; !ok {
+			allErrs = append(allErrs, field.NotSupported(specPath.Child("parkingMode"), mode, []parkingv1alpha1.ParkingMode{
+				parkingv1alpha1.ParkingModeComingSoon, parkingv1alpha1.ParkingModeForSale, parkingv1alpha1.ParkingModeMaintenance,
+			}))
+		}
+	}
+
+	if ref := pd.Spec.TemplateConfigMapRef; ref != nil && ref.Name == "" {
+		allErrs = append(allErrs, field.Required(specPath.Child("templateConfigMapRef", "name"), "must be set"))
+	}
+	if ref := pd.Spec.TemplateSecretRef; ref != nil && ref.Name == "" {
+		allErrs = append(allErrs, field.Required(specPath.Child("templateSecretRef", "name"), "must be set"))
+	}
+	allErrs = append(allErrs, validateTemplateSource(pd, specPath)...)
+
+	if pd.Spec.PrivateZone != nil && !strings.HasPrefix(pd.Spec.PrivateZone.VPCID, "vpc-") {
+		allErrs = append(allErrs, field.Invalid(specPath.Child("privateZone", "vpcID"), pd.Spec.PrivateZone.VPCID, "must be a VPC ID, e.g. vpc-0123456789abcdef0"))
+	}
+
+	seenVPCs := map[string]bool{}
+	if pd.Spec.PrivateZone != nil {
+		seenVPCs[pd.Spec.PrivateZone.VPCID] = true
+	}
+	for i, vpc := range pd.Spec.AdditionalVPCs {
+		vpcPath := specPath.Child("additionalVPCs").Index(i).Child("vpcID")
+		switch {
+		case !strings.HasPrefix(vpc.VPCID, "vpc-"):
+			allErrs = append(allErrs, field.Invalid(vpcPath, vpc.VPCID, "must be a VPC ID, e.g. vpc-0123456789abcdef0"))
+		case seenVPCs[vpc.VPCID]:
+			allErrs = append(allErrs, field.Duplicate(vpcPath, vpc.VPCID))
+		}
+		seenVPCs[vpc.VPCID] = true
+	}
+
+	if at := pd.Spec.AliasTarget; at != nil {
+		allErrs = append(allErrs, validateAliasTarget(at, specPath.Child("aliasTarget"))...)
+	}
+	if addr := pd.Spec.PlaceholderAddress; addr != "" {
+		if ip := net.ParseIP(addr); ip == nil || ip.To4() == nil {
+			allErrs = append(allErrs, field.Invalid(specPath.Child("placeholderAddress"), addr, "must be an IPv4 address, e.g. 198.51.100.10"))
+		}
+	}
+	if len(pd.Spec.RecordComment) > maxRecordCommentLength {
+		allErrs = append(allErrs, field.TooLong(specPath.Child("recordComment"), pd.Spec.RecordComment, maxRecordCommentLength))
+	}
+	if window := pd.Spec.MaintenanceWindow; window != nil {
+		windowPath := specPath.Child("maintenanceWindow")
+		if _, err := parseWindowTime(window.Start); err != nil {
+			allErrs = append(allErrs, field.Invalid(windowPath.Child("start"), window.Start, "must be a time of day in UTC, e.g. 02:00"))
+		}
+		if _, err := parseWindowTime(window.End); err != nil {
+			allErrs = append(allErrs, field.Invalid(windowPath.Child("end"), window.End, "must be a time of day in UTC, e.g. 04:00"))
+		}
+	}
+	if alarm := pd.Spec.Alarm; alarm != nil {
+		allErrs = append(allErrs, validateAlarmTopic(alarm.SNSTopicARN, regionFor(pd), specPath.Child("alarm", "snsTopicARN"))...)
+	}
+	if ql := pd.Spec.QueryLogging; ql != nil {
+		allErrs = append(allErrs, validateLogGroupARN(ql.LogGroupARN, specPath.Child("queryLogging", "logGroupARN"))...)
+	}
+	if export := pd.Spec.NameServersExport; export != nil && export.Name != "" {
+		if errs := validation.IsDNS1123Subdomain(export.Name); len(errs) > 0 {
+			allErrs = append(allErrs, field.Invalid(specPath.Child("nameServersExport", "name"), export.Name, strings.Join(errs, "; ")))
+		}
+	}
+
+	allErrs = append(allErrs, validateFeatureCompatibility(pd, specPath)...)
+
+	if value, ok := pd.Annotations[cleanupBatchSizeAnnotation]; ok {
+		if _, err := parseCleanupBatchSize(value); err != nil {
+			allErrs = append(allErrs, field.Invalid(field.NewPath("metadata", "annotations").Key(cleanupBatchSizeAnnotation), value,
+				fmt.Sprintf("must be a number from 1 to %d, the most keys S3 deletes in one call", maxCleanupBatchSize)))
+		}
+	}
+
+	allErrs = append(allErrs, validateObjectMetadata(pd.Spec.ObjectMetadata, specPath.Child("objectMetadata"))...)
+	allErrs = append(allErrs, validateTags(pd.Spec.Tags, specPath.Child("tags"))...)
+	allErrs = append(allErrs, validateLocales(pd.Spec.Locales, specPath.Child("locales"))...)
+	if key := pd.Spec.ManifestKey; key != "" && slices.Contains(pageKeysFor(pd), key) {
+		allErrs = append(allErrs, field.Invalid(specPath.Child("manifestKey"), key, "must not be the key of a published page"))
+	}
+
+	if sc := s3types.StorageClass(pd.Spec.StorageClass); sc != "" && !slices.Contains(storageClasses, sc) {
+		allErrs = append(allErrs, field.NotSupported(specPath.Child("storageClass"), sc, storageClasses))
+	}
+
+	if enc := pd.Spec.Encryption; enc != nil {
+		encPath := specPath.Child("encryption")
+		switch s3types.ServerSideEncryption(enc.Algorithm) {
+		case s3types.ServerSideEncryptionAes256:
+			if enc.KMSKeyID != "" {
+				allErrs = append(allErrs, field.Forbidden(encPath.Child("kmsKeyID"), "requires algorithm aws:kms"))
+			}
+		case s3types.ServerSideEncryptionAwsKms:
+		default:
+			allErrs = append(allErrs, field.NotSupported(encPath.Child("algorithm"), enc.Algorithm, []s3types.ServerSideEncryption{
+				s3types.ServerSideEncryptionAes256, s3types.ServerSideEncryptionAwsKms,
+			}))
+		}
+	}
+
+	for i, rule := range pd.Spec.LifecycleRules {
+		if rule.ExpirationDays == nil && rule.NoncurrentVersionExpirationDays == nil {
+			allErrs = append(allErrs, field.Required(specPath.Child("lifecycleRules").Index(i),
+				"at least one of expirationDays or noncurrentVersionExpirationDays is required"))
+		}
+	}
+
+	return allErrs
+}
+
+// validateAliasTarget checks that an alias target names a hosted zone ID and a DNS name Route 53
+// can alias, and that CloudFront targets use CloudFront's hosted zone.
+func validateAliasTarget(at *parkingv1alpha1.AliasTarget, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if !hostedZoneIDPattern.MatchString(at.HostedZoneID) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("hostedZoneID"), at.HostedZoneID, "must be a Route 53 hosted zone ID, e.g. Z35SXDOTRQ7X7K"))
+	} else if at.Type == parkingv1alpha1.AliasTargetCloudFront && at.HostedZoneID != cloudFrontHostedZoneID {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("hostedZoneID"), at.HostedZoneID, "CloudFront distributions use hosted zone "+cloudFrontHostedZoneID))
+	}
+	if errs := validation.IsDNS1123Subdomain(strings.ToLower(strings.TrimSuffix(at.DNSName, "."))); len(errs) > 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("dnsName"), at.DNSName, strings.Join(errs, "; ")))
+	}
+	switch at.Type {
+	case parkingv1alpha1.AliasTargetLoadBalancer, parkingv1alpha1.AliasTargetAPIGateway, parkingv1alpha1.AliasTargetCloudFront:
+	default:
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("type"), at.Type, []parkingv1alpha1.AliasTargetType{
+			parkingv1alpha1.AliasTargetLoadBalancer, parkingv1alpha1.AliasTargetAPIGateway, parkingv1alpha1.AliasTargetCloudFront,
+		}))
+	}
+	return allErrs
+}
+
+// validateLogGroupARN checks that a query logging destination is the ARN of a CloudWatch Logs
+// log group in us-east-1, the only region Route 53 logs queries to.
+func validateLogGroupARN(logGroupARN string, fldPath *field.Path) field.ErrorList {
+	parsed, err := arn.Parse(logGroupARN)
+	switch {
+	case err != nil:
+		return field.ErrorList{field.Invalid(fldPath, logGroupARN, err.Error())}
+	case parsed.Service != "logs" || !strings.HasPrefix(parsed.Resource, "log-group:"):
+		return field.ErrorList{field.Invalid(fldPath, logGroupARN, "must be the ARN of a CloudWatch Logs log group, e.g. arn:aws:logs:us-east-1:111111111111:log-group:/aws/route53/example.com")}
+	case parsed.Region != queryLoggingRegion:
+		return field.ErrorList{field.Invalid(fldPath, logGroupARN, "Route 53 only logs queries to log groups in "+queryLoggingRegion)}
+	}
+	return nil
+}
+
+// validateAlarmTopic checks that an alarm's topic is an SNS topic ARN in the bucket's region,
+// the only region CloudWatch notifies from the bucket's metrics.
+func validateAlarmTopic(topicARN, region string, fldPath *field.Path) field.ErrorList {
+	parsed, err := arn.Parse(topicARN)
+	switch {
+	case err != nil:
+		return field.ErrorList{field.Invalid(fldPath, topicARN, err.Error())}
+	case parsed.Service != "sns":
+		return field.ErrorList{field.Invalid(fldPath, topicARN, "must be the ARN of an SNS topic, e.g. arn:aws:sns:"+region+":111111111111:parked-domains")}
+	case parsed.Region != region:
+		return field.ErrorList{field.Invalid(fldPath, topicARN, "CloudWatch only notifies topics in the bucket's region "+region)}
+	}
+	return nil
+}
+
+// validateObjectMetadata checks that metadata keys are valid HTTP header names and values can be
+// sent as header values, within S3's size limit for user-defined metadata.
+func validateObjectMetadata(metadata map[string]string, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	size := 0
+	for _, key := range slices.Sorted(maps.Keys(metadata)) {
+		value := metadata[key]
+		size += len(key) + len(value)
+		keyPath := fldPath.Key(key)
+		switch {
+		case key == "" || strings.IndexFunc(key, func(r rune) bool { return !isHeaderTokenChar(r) }) >= 0:
+			allErrs = append(allErrs, field.Invalid(keyPath, key, "must be a valid HTTP header name"))
+		case strings.HasPrefix(strings.ToLower(key), "x-amz-meta-"):
+			allErrs = append(allErrs, field.Invalid(keyPath, key, "must not include the x-amz-meta- prefix, it is added by S3"))
+		}
+		if strings.IndexFunc(value, func(r rune) bool { return r < ' ' || r > '~' }) >= 0 {
+			allErrs = append(allErrs, field.Invalid(keyPath, value, "must only contain printable ASCII characters"))
+		}
+	}
+	if size > maxObjectMetadataSize {
+		allErrs = append(allErrs, field.TooLong(fldPath, size, maxObjectMetadataSize))
+	}
+	return allErrs
+}
+
+// validateTags checks tags against the limits S3 and Route 53 share: at most maxTags tags, keys
+// of 1 to 128 and values of up to 256 characters, and no keys in the reserved aws: namespace.
+func validateTags(tags map[string]string, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if len(tags) > maxTags {
+		allErrs = append(allErrs, field.TooMany(fldPath, len(tags), maxTags))
+	}
+	for _, key := range slices.Sorted(maps.Keys(tags)) {
+		keyPath := fldPath.Key(key)
+		switch {
+		case key == "" || utf8.RuneCountInString(key) > 128:
+			allErrs = append(allErrs, field.Invalid(keyPath, key, "must be 1 to 128 characters long"))
+		case strings.HasPrefix(strings.ToLower(key), "aws:"):
+			allErrs = append(allErrs, field.Invalid(keyPath, key, "must not start with aws:, it is reserved for AWS"))
+		}
+		if utf8.RuneCountInString(tags[key]) > 256 {
+			allErrs = append(allErrs, field.TooLong(keyPath, tags[key], 256))
+		}
+	}
+	return allErrs
+}
+
+// validateLocales checks that locales are language tags such as "de" or "pt-BR", which end up in
+// template and object names, and that none is listed twice.
+func validateLocales(locales []string, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if len(locales) > maxLocales {
+		allErrs = append(allErrs, field.TooMany(fldPath, len(locales), maxLocales))
+	}
+	for i, locale := range locales {
+		switch {
+		case !localePattern.MatchString(locale):
+			allErrs = append(allErrs, field.Invalid(fldPath.Index(i), locale, "must be a language tag such as de or pt-BR"))
+		case slices.Contains(locales[:i], locale):
+			allErrs = append(allErrs, field.Duplicate(fldPath.Index(i), locale))
+		}
+	}
+	return allErrs
+}
+
+// isHeaderTokenChar reports whether r may appear in an HTTP header name (an RFC 9110 token).
+func isHeaderTokenChar(r rune) bool {
+	switch {
+	case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+		return true
+	}
+	return strings.ContainsRune("!#$%&'*+-.^_`|~", r)
+}
+
+// validateTemplateSource checks that the fields of the selected template source are set, and
+// that no other source's fields are, as they would be silently ignored. Conflicts with
+// templateURL are reported by validateFeatureCompatibility.
+func validateTemplateSource(pd *parkingv1alpha1.ParkedDomain, specPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	source := templateSourceType(pd)
+	switch source {
+	case parkingv1alpha1.TemplateSourceConfigMap, parkingv1alpha1.TemplateSourceSecret,
+		parkingv1alpha1.TemplateSourceURL, parkingv1alpha1.TemplateSourceInline:
+	default:
+		return append(allErrs, field.NotSupported(specPath.Child("templateSource"), source, []parkingv1alpha1.TemplateSourceType{
+			parkingv1alpha1.TemplateSourceConfigMap, parkingv1alpha1.TemplateSourceSecret,
+			parkingv1alpha1.TemplateSourceURL, parkingv1alpha1.TemplateSourceInline,
+		}))
+	}
+
+	requires := func(set bool, fieldName string, wanted parkingv1alpha1.TemplateSourceType) {
+		switch {
+		case source == wanted && !set:
+			allErrs = append(allErrs, field.Required(specPath.Child(fieldName), "must be set when templateSource is "+string(wanted)))
+		case source != wanted && set:
+			allErrs = append(allErrs, field.Forbidden(specPath.Child(fieldName), "is only used when templateSource is "+string(wanted)))
+		}
+	}
+	requires(pd.Spec.TemplateURL != "", "templateURL", parkingv1alpha1.TemplateSourceURL)
+	requires(pd.Spec.TemplateSecretRef != nil, "templateSecretRef", parkingv1alpha1.TemplateSourceSecret)
+	requires(pd.Spec.InlineTemplate != "", "inlineTemplate", parkingv1alpha1.TemplateSourceInline)
+	inlinePath := specPath.Child("inlineTemplate")
+	switch {
+	case pd.Spec.InlineTemplate != "" && strings.TrimSpace(pd.Spec.InlineTemplate) == "":
+		allErrs = append(allErrs, field.Invalid(inlinePath, pd.Spec.InlineTemplate, "must not be blank"))
+	case len(pd.Spec.InlineTemplate) > maxInlineTemplateSize:
+		allErrs = append(allErrs, field.TooLong(inlinePath, len(pd.Spec.InlineTemplate), maxInlineTemplateSize))
+	}
+	if pd.Spec.TemplateConfigMapRef != nil && source != parkingv1alpha1.TemplateSourceConfigMap && source != parkingv1alpha1.TemplateSourceURL {
+		allErrs = append(allErrs, field.Forbidden(specPath.Child("templateConfigMapRef"), "is only used when templateSource is ConfigMap"))
+	}
+	if len(pd.Spec.Locales) > 0 && (source == parkingv1alpha1.TemplateSourceURL || source == parkingv1alpha1.TemplateSourceInline) {
+		allErrs = append(allErrs, field.Forbidden(specPath.Child("locales"),
+			"needs a template per locale, which only the ConfigMap and Secret template sources hold"))
+	}
+	if pd.Spec.TemplateName != "" && source == parkingv1alpha1.TemplateSourceInline {
+		allErrs = append(allErrs, field.Forbidden(specPath.Child("templateName"), "may not be set together with inlineTemplate"))
+	}
+	return allErrs
+}
+
+// validateFeatureCompatibility reports combinations of fields that are valid on their own
+// but cannot work together, with a message saying which field to change.
+func validateFeatureCompatibility(pd *parkingv1alpha1.ParkedDomain, specPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if pd.Spec.TemplateURL != "" {
+		if pd.Spec.TemplateName != "" {
+			allErrs = append(allErrs, field.Forbidden(specPath.Child("templateName"),
+				"may not be set together with templateURL; remove one of the two template sources"))
+		}
+		if pd.Spec.TemplateConfigMapRef != nil {
+			allErrs = append(allErrs, field.Forbidden(specPath.Child("templateConfigMapRef"),
+				"may not be set together with templateURL; remove one of the two template sources"))
+		}
+	}
+
+	if pd.Spec.PrivateZone != nil && pd.Spec.DelegationSetID != "" {
+		allErrs = append(allErrs, field.Forbidden(specPath.Child("delegationSetID"),
+			"private zones cannot use a reusable delegation set; remove delegationSetID or privateZone"))
+	}
+	if pd.Spec.PrivateZone == nil && len(pd.Spec.AdditionalVPCs) > 0 {
+		allErrs = append(allErrs, field.Forbidden(specPath.Child("additionalVPCs"),
+			"public zones cannot be associated with VPCs; remove additionalVPCs or set privateZone"))
+	}
+	if pd.Spec.PrivateZone == nil && pd.Spec.DNSFirewallRuleGroupID != "" {
+		allErrs = append(allErrs, field.Forbidden(specPath.Child("dnsFirewallRuleGroupID"),
+			"DNS Firewall filters the queries of a private zone's VPC; remove dnsFirewallRuleGroupID or set privateZone"))
+	}
+	if pd.Spec.PrivateZone != nil && pd.Spec.QueryLogging != nil {
+		allErrs = append(allErrs, field.Forbidden(specPath.Child("queryLogging"),
+			"Route 53 only logs queries for public zones; remove queryLogging or privateZone"))
+	}
+	if pd.Spec.PrivateZone != nil && delegatesToParent(pd) {
+		allErrs = append(allErrs, field.Forbidden(specPath.Child("privateZone"),
+			"private zones are not delegated from a parent zone; remove privateZone or parentZoneID and delegateInParentZone"))
+	}
+	if !dnsEnabled(pd) {
+		if pd.Spec.PrivateZone != nil {
+			allErrs = append(allErrs, field.Forbidden(specPath.Child("privateZone"),
+				"requires a Hosted Zone; remove privateZone or set dnsEnabled to true"))
+		}
+		if pd.Spec.DelegationSetID != "" {
+			allErrs = append(allErrs, field.Forbidden(specPath.Child("delegationSetID"),
+				"requires a Hosted Zone; remove delegationSetID or set dnsEnabled to true"))
+		}
+		if pd.Spec.ParentZoneID != "" {
+			allErrs = append(allErrs, field.Forbidden(specPath.Child("parentZoneID"),
+				"requires a Hosted Zone; remove parentZoneID or set dnsEnabled to true"))
+		}
+		if pd.Spec.DelegateInParentZone {
+			allErrs = append(allErrs, field.Forbidden(specPath.Child("delegateInParentZone"),
+				"requires a Hosted Zone; remove delegateInParentZone or set dnsEnabled to true"))
+		}
+		if pd.Spec.QueryLogging != nil {
+			allErrs = append(allErrs, field.Forbidden(specPath.Child("queryLogging"),
+				"requires a Hosted Zone; remove queryLogging or set dnsEnabled to true"))
+		}
+		if pd.Spec.PlaceholderAddress != "" {
+			allErrs = append(allErrs, field.Forbidden(specPath.Child("placeholderAddress"),
+				"requires a Hosted Zone; remove placeholderAddress or set dnsEnabled to true"))
+		}
+		if pd.Spec.NameServersExport != nil {
+			allErrs = append(allErrs, field.Forbidden(specPath.Child("nameServersExport"),
+				"requires a Hosted Zone; remove nameServersExport or set dnsEnabled to true"))
+		}
+	}
+	if pd.Spec.AutoSuffixBucket && dnsEnabled(pd) {
+		allErrs = append(allErrs, field.Forbidden(specPath.Child("autoSuffixBucket"),
+			"S3 website endpoints only serve the bucket named after the requested host, so the domain's alias record cannot reach a suffixed bucket; set dnsEnabled to false"))
+	}
+	if pd.Spec.AccessLogBucket != "" && pd.Spec.AccessLogBucket == recordNameFor(pd) {
+		allErrs = append(allErrs, field.Forbidden(specPath.Child("accessLogBucket"),
+			"a bucket cannot log to itself, as every log delivery would be logged again; use another bucket"))
+	}
+	if pd.Spec.AccessLogBucket == "" {
+		if pd.Spec.CreateAccessLogBucket {
+			allErrs = append(allErrs, field.Forbidden(specPath.Child("createAccessLogBucket"),
+				"requires accessLogBucket; set accessLogBucket or remove createAccessLogBucket"))
+		}
+		if pd.Spec.AccessLogPrefix != "" {
+			allErrs = append(allErrs, field.Forbidden(specPath.Child("accessLogPrefix"),
+				"requires accessLogBucket; set accessLogBucket or remove accessLogPrefix"))
+		}
+	}
+	if pd.Spec.AliasTarget != nil {
+		if !dnsEnabled(pd) {
+			allErrs = append(allErrs, field.Forbidden(specPath.Child("aliasTarget"),
+				"requires a Hosted Zone; remove aliasTarget or set dnsEnabled to true"))
+		}
+		if pd.Spec.StorageEnabled != nil && *pd.Spec.StorageEnabled && pd.Spec.SharedBucket == nil {
+			allErrs = append(allErrs, field.Forbidden(specPath.Child("storageEnabled"),
+				"no bucket is provisioned for an alias target; remove storageEnabled or aliasTarget"))
+		}
+	}
+	if pd.Spec.SharedBucket != nil {
+		allErrs = append(allErrs, validateSharedBucket(pd, specPath)...)
+	}
+	if pd.Spec.StorageEndpoint != "" && dnsEnabled(pd) {
+		allErrs = append(allErrs, field.Forbidden(specPath.Child("storageEndpoint"),
+			"Route 53 alias records can only target AWS S3 website endpoints; set dnsEnabled to false"))
+	}
+	if pd.Spec.ForceHTTPS && (pd.Spec.AliasTarget == nil || pd.Spec.AliasTarget.Type != parkingv1alpha1.AliasTargetCloudFront) {
+		allErrs = append(allErrs, field.Forbidden(specPath.Child("forceHTTPS"),
+			"S3 website endpoints cannot redirect to HTTPS; set aliasTarget to a CloudFront distribution using the viewer protocol policy redirect-to-https"))
+	}
+	if !storageEnabled(pd) && pd.Spec.VerifyHTTP {
+		allErrs = append(allErrs, field.Forbidden(specPath.Child("verifyHTTP"),
+			"requires the website bucket; remove verifyHTTP or set storageEnabled to true"))
+	}
+	if pd.Spec.Alarm != nil {
+		if !storageEnabled(pd) {
+			allErrs = append(allErrs, field.Forbidden(specPath.Child("alarm"),
+				"requires the website bucket; remove alarm or set storageEnabled to true"))
+		} else if pd.Spec.StorageEndpoint != "" {
+			allErrs = append(allErrs, field.Forbidden(specPath.Child("alarm"),
+				"S3-compatible services publish no CloudWatch metrics; remove alarm or storageEndpoint"))
+		}
+	}
+
+	// The bucket is named after the domain, so it always contains dots.
+	if pd.Spec.TransferAcceleration != nil && *pd.Spec.TransferAcceleration {
+		allErrs = append(allErrs, field.Forbidden(specPath.Child("transferAcceleration"),
+			"transfer acceleration is not supported for bucket names containing dots"))
+	}
+	if pd.Spec.RequesterPays != nil && *pd.Spec.RequesterPays {
+		allErrs = append(allErrs, field.Forbidden(specPath.Child("requesterPays"),
+			"website endpoints cannot serve Requester Pays buckets"))
+	}
+
+	return allErrs
+}
+
+// validateSharedBucket checks that a ParkedDomain publishing into a shared bucket is served by a
+// CloudFront distribution and sets none of the bucket's own configuration, which the
+// ParkedDomains sharing it would fight over.
+func validateSharedBucket(pd *parkingv1alpha1.ParkedDomain, specPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if at := pd.Spec.AliasTarget; at == nil || at.Type != parkingv1alpha1.AliasTargetCloudFront {
+		allErrs = append(allErrs, field.Forbidden(specPath.Child("sharedBucket"),
+			"a shared bucket serves no website; set aliasTarget to the CloudFront distribution in front of it"))
+	}
+	bucketFields := []struct {
+		name string
+		set  bool
+	}{
+		{"autoSuffixBucket", pd.Spec.AutoSuffixBucket},
+		{"storageEndpoint", pd.Spec.StorageEndpoint != ""},
+		{"objectOwnership", pd.Spec.ObjectOwnership != ""},
+		{"lifecycleRules", len(pd.Spec.LifecycleRules) > 0},
+		{"accessLogBucket", pd.Spec.AccessLogBucket != ""},
+		{"requireCDNWhenBPAEnforced", pd.Spec.RequireCDNWhenBPAEnforced},
+		{"alarm", pd.Spec.Alarm != nil},
+		{"objectLock", pd.Spec.ObjectLock != nil},
+		{"manifestKey", pd.Spec.ManifestKey != ""},
+		{"spaFallback", pd.Spec.SPAFallback},
+	}
+	for _, f := range bucketFields {
+		if f.set {
+			allErrs = append(allErrs, field.Forbidden(specPath.Child(f.name),
+				"configures the bucket, which a shared bucket leaves to its owner; remove "+f.name+" or sharedBucket"))
+		}
+	}
+	return allErrs
+}
+
+// supportedRegions returns the regions the operator can create alias records for, sorted.
+func supportedRegions() []string {
+	regions := make([]string, 0, len(s3WebsiteHostedZoneIDs))
+	for region := range s3WebsiteHostedZoneIDs {
+		regions = append(regions, region)
+	}
+	slices.Sort(regions)
+	return regions
+}
@@ -0,0 +1,379 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	parkingv1alpha1 "github.com/gminiba/parked-domain-operator/api/v1alpha1"
+)
+
+var _ = Describe("ValidateParkedDomain", func() {
+	DescribeTable("reports problems with the spec",
+		func(spec parkingv1alpha1.ParkedDomainSpec, expected []string) {
+			pd := &parkingv1alpha1.ParkedDomain{Spec: spec}
+			errs := ValidateParkedDomain(pd)
+
+			fields := make([]string, 0, len(errs))
+			for _, err := range errs {
+				fields = append(fields, err.Field)
+			}
+			Expect(fields).To(ConsistOf(expected))
+		},
+		Entry("a valid spec", parkingv1alpha1.ParkedDomainSpec{DomainName: "example.com", Region: "eu-west-1"}, []string{}),
+		Entry("a trailing dot", parkingv1alpha1.ParkedDomainSpec{DomainName: "example.com."}, []string{}),
+		Entry("a missing domain", parkingv1alpha1.ParkedDomainSpec{}, []string{"spec.domainName"}),
+		Entry("an invalid domain", parkingv1alpha1.ParkedDomainSpec{DomainName: "Example_.com"}, []string{"spec.domainName"}),
+		Entry("a single label", parkingv1alpha1.ParkedDomainSpec{DomainName: "localhost"}, []string{"spec.domainName"}),
+		Entry("a domain too long for a bucket name",
+			parkingv1alpha1.ParkedDomainSpec{DomainName: "a-very-long-subdomain-label-for-a-parked-page.example-domain.com"}, []string{"spec.domainName"}),
+		Entry("a host within the zone",
+			parkingv1alpha1.ParkedDomainSpec{DomainName: "example.com", RecordName: "shop.example.com"}, []string{}),
+		Entry("a host outside the zone",
+			parkingv1alpha1.ParkedDomainSpec{DomainName: "example.com", RecordName: "shop.example.org"}, []string{"spec.recordName"}),
+		Entry("a host sharing only a suffix with the zone",
+			parkingv1alpha1.ParkedDomainSpec{DomainName: "example.com", RecordName: "myexample.com"}, []string{"spec.recordName"}),
+		Entry("a load balancer alias target",
+			parkingv1alpha1.ParkedDomainSpec{DomainName: "example.com", AliasTarget: &parkingv1alpha1.AliasTarget{
+				HostedZoneID: "Z32O12XQLNTSW2", DNSName: "landing-123.eu-west-1.elb.amazonaws.com", Type: parkingv1alpha1.AliasTargetLoadBalancer,
+			}}, []string{}),
+		Entry("an alias target with a malformed hosted zone ID and DNS name",
+			parkingv1alpha1.ParkedDomainSpec{DomainName: "example.com", AliasTarget: &parkingv1alpha1.AliasTarget{
+				HostedZoneID: "/hostedzone/Z32O12XQLNTSW2", DNSName: "landing_app", Type: parkingv1alpha1.AliasTargetLoadBalancer,
+			}}, []string{"spec.aliasTarget.hostedZoneID", "spec.aliasTarget.dnsName"}),
+		Entry("a CloudFront alias target outside CloudFront's hosted zone",
+			parkingv1alpha1.ParkedDomainSpec{DomainName: "example.com", AliasTarget: &parkingv1alpha1.AliasTarget{
+				HostedZoneID: "Z32O12XQLNTSW2", DNSName: "d111111abcdef8.cloudfront.net", Type: parkingv1alpha1.AliasTargetCloudFront,
+			}}, []string{"spec.aliasTarget.hostedZoneID"}),
+		Entry("an unsupported region", parkingv1alpha1.ParkedDomainSpec{DomainName: "example.com", Region: "mars-1"}, []string{"spec.region"}),
+		Entry("an isolated partition region", parkingv1alpha1.ParkedDomainSpec{DomainName: "example.com", Region: "us-iso-east-1"}, []string{"spec.region"}),
+		Entry("a known parking mode",
+			parkingv1alpha1.ParkedDomainSpec{DomainName: "example.com", ParkingMode: parkingv1alpha1.ParkingModeMaintenance}, []string{}),
+		Entry("an unknown parking mode",
+			parkingv1alpha1.ParkedDomainSpec{DomainName: "example.com", ParkingMode: "Auction"}, []string{"spec.parkingMode"}),
+		Entry("a private zone",
+			parkingv1alpha1.ParkedDomainSpec{DomainName: "example.com", PrivateZone: &parkingv1alpha1.PrivateZone{VPCID: "vpc-0abc"}}, []string{}),
+		Entry("a private zone with a delegation set",
+			parkingv1alpha1.ParkedDomainSpec{DomainName: "example.com", DelegationSetID: "N1", PrivateZone: &parkingv1alpha1.PrivateZone{VPCID: "subnet-0abc"}},
+			[]string{"spec.privateZone.vpcID", "spec.delegationSetID"}),
+		Entry("both template sources",
+			parkingv1alpha1.ParkedDomainSpec{DomainName: "example.com", TemplateName: "a.html", TemplateURL: "https://example.org/a.html"}, []string{"spec.templateName"}),
+		Entry("a template ConfigMap ref with a URL",
+			parkingv1alpha1.ParkedDomainSpec{DomainName: "example.com", TemplateURL: "https://example.org/a.html", TemplateConfigMapRef: &parkingv1alpha1.TemplateConfigMapRef{}},
+			[]string{"spec.templateConfigMapRef", "spec.templateConfigMapRef.name"}),
+		Entry("an inline template",
+			parkingv1alpha1.ParkedDomainSpec{DomainName: "example.com", TemplateSource: parkingv1alpha1.TemplateSourceInline, InlineTemplate: "<h1>parked</h1>"}, []string{}),
+		Entry("an inline template source without a template",
+			parkingv1alpha1.ParkedDomainSpec{DomainName: "example.com", TemplateSource: parkingv1alpha1.TemplateSourceInline}, []string{"spec.inlineTemplate"}),
+		Entry("an inline template without a template source",
+			parkingv1alpha1.ParkedDomainSpec{DomainName: "example.com", InlineTemplate: "<h1>parked</h1>"}, []string{}),
+		Entry("an inline template with a template URL",
+			parkingv1alpha1.ParkedDomainSpec{DomainName: "example.com", InlineTemplate: "<h1>parked</h1>", TemplateURL: "https://example.org/a.html"},
+			[]string{"spec.templateURL"}),
+		Entry("an inline template with another source",
+			parkingv1alpha1.ParkedDomainSpec{DomainName: "example.com", TemplateSource: parkingv1alpha1.TemplateSourceConfigMap, InlineTemplate: "<h1>parked</h1>"},
+			[]string{"spec.inlineTemplate"}),
+		Entry("a blank inline template",
+			parkingv1alpha1.ParkedDomainSpec{DomainName: "example.com", InlineTemplate: " \n"}, []string{"spec.inlineTemplate"}),
+		Entry("an inline template over 256 KiB",
+			parkingv1alpha1.ParkedDomainSpec{DomainName: "example.com", InlineTemplate: strings.Repeat("x", 256<<10+1)}, []string{"spec.inlineTemplate"}),
+		Entry("a template Secret",
+			parkingv1alpha1.ParkedDomainSpec{DomainName: "example.com", TemplateSource: parkingv1alpha1.TemplateSourceSecret, TemplateSecretRef: &parkingv1alpha1.TemplateSecretRef{Name: "t"}}, []string{}),
+		Entry("a template Secret source without a ref",
+			parkingv1alpha1.ParkedDomainSpec{DomainName: "example.com", TemplateSource: parkingv1alpha1.TemplateSourceSecret}, []string{"spec.templateSecretRef"}),
+		Entry("a template Secret ref with a ConfigMap ref",
+			parkingv1alpha1.ParkedDomainSpec{DomainName: "example.com", TemplateSource: parkingv1alpha1.TemplateSourceSecret,
+				TemplateSecretRef: &parkingv1alpha1.TemplateSecretRef{Name: "t"}, TemplateConfigMapRef: &parkingv1alpha1.TemplateConfigMapRef{Name: "t"}},
+			[]string{"spec.templateConfigMapRef"}),
+		Entry("a URL template source without a URL",
+			parkingv1alpha1.ParkedDomainSpec{DomainName: "example.com", TemplateSource: parkingv1alpha1.TemplateSourceURL}, []string{"spec.templateURL"}),
+		Entry("a template URL with another source",
+			parkingv1alpha1.ParkedDomainSpec{DomainName: "example.com", TemplateSource: parkingv1alpha1.TemplateSourceConfigMap, TemplateURL: "https://example.org/a.html"},
+			[]string{"spec.templateURL"}),
+		Entry("transfer settings turned off",
+			parkingv1alpha1.ParkedDomainSpec{DomainName: "example.com", TransferAcceleration: aws.Bool(false), RequesterPays: aws.Bool(false)}, []string{}),
+		Entry("transfer acceleration turned on",
+			parkingv1alpha1.ParkedDomainSpec{DomainName: "example.com", TransferAcceleration: aws.Bool(true)}, []string{"spec.transferAcceleration"}),
+		Entry("requester pays turned on",
+			parkingv1alpha1.ParkedDomainSpec{DomainName: "example.com", RequesterPays: aws.Bool(true)}, []string{"spec.requesterPays"}),
+		Entry("object metadata",
+			parkingv1alpha1.ParkedDomainSpec{DomainName: "example.com", ObjectMetadata: map[string]string{"campaign": "spring-2025", "Cache-Tag": "parked"}}, []string{}),
+		Entry("object metadata with invalid keys and values",
+			parkingv1alpha1.ParkedDomainSpec{DomainName: "example.com", ObjectMetadata: map[string]string{
+				"x-amz-meta-owner": "web", "bad key": "v", "note": "caf\u00e9",
+			}}, []string{"spec.objectMetadata[x-amz-meta-owner]", "spec.objectMetadata[bad key]", "spec.objectMetadata[note]"}),
+		Entry("object metadata over the size limit",
+			parkingv1alpha1.ParkedDomainSpec{DomainName: "example.com", ObjectMetadata: map[string]string{"blob": strings.Repeat("a", 2048)}}, []string{"spec.objectMetadata"}),
+		Entry("additional VPCs for a private zone",
+			parkingv1alpha1.ParkedDomainSpec{DomainName: "example.com", PrivateZone: &parkingv1alpha1.PrivateZone{VPCID: "vpc-1"},
+				AdditionalVPCs: []parkingv1alpha1.VPCRef{{VPCID: "vpc-2"}, {VPCID: "vpc-3", VPCRegion: "us-east-1"}}}, []string{}),
+		Entry("additional VPCs for a public zone",
+			parkingv1alpha1.ParkedDomainSpec{DomainName: "example.com", AdditionalVPCs: []parkingv1alpha1.VPCRef{{VPCID: "vpc-2"}}},
+			[]string{"spec.additionalVPCs"}),
+		Entry("additional VPCs repeating the zone's VPC or malformed",
+			parkingv1alpha1.ParkedDomainSpec{DomainName: "example.com", PrivateZone: &parkingv1alpha1.PrivateZone{VPCID: "vpc-1"},
+				AdditionalVPCs: []parkingv1alpha1.VPCRef{{VPCID: "vpc-1"}, {VPCID: "subnet-2"}}},
+			[]string{"spec.additionalVPCs[0].vpcID", "spec.additionalVPCs[1].vpcID"}),
+		Entry("a DNS Firewall rule group for a private zone",
+			parkingv1alpha1.ParkedDomainSpec{DomainName: "example.com", PrivateZone: &parkingv1alpha1.PrivateZone{VPCID: "vpc-1"},
+				DNSFirewallRuleGroupID: "rslvr-frg-0123456789abcdef"}, []string{}),
+		Entry("a DNS Firewall rule group for a public zone",
+			parkingv1alpha1.ParkedDomainSpec{DomainName: "example.com", DNSFirewallRuleGroupID: "rslvr-frg-0123456789abcdef"},
+			[]string{"spec.dnsFirewallRuleGroupID"}),
+		Entry("an alarm notifying a topic in the bucket's region",
+			parkingv1alpha1.ParkedDomainSpec{DomainName: "example.com", Region: "eu-west-1",
+				Alarm: &parkingv1alpha1.Alarm{SNSTopicARN: "arn:aws:sns:eu-west-1:111111111111:parked-domains"}}, []string{}),
+		Entry("an alarm notifying a topic in another region or no topic at all",
+			parkingv1alpha1.ParkedDomainSpec{DomainName: "example.com",
+				Alarm: &parkingv1alpha1.Alarm{SNSTopicARN: "arn:aws:sns:us-east-1:111111111111:parked-domains"}},
+			[]string{"spec.alarm.snsTopicARN"}),
+		Entry("an alarm without a bucket",
+			parkingv1alpha1.ParkedDomainSpec{DomainName: "example.com", StorageEnabled: aws.Bool(false),
+				Alarm: &parkingv1alpha1.Alarm{SNSTopicARN: "arn:aws:sqs:eu-central-1:111111111111:parked-domains"}},
+			[]string{"spec.alarm", "spec.alarm.snsTopicARN"}),
+		Entry("locales",
+			parkingv1alpha1.ParkedDomainSpec{DomainName: "example.com", Locales: []string{"en", "de", "pt-BR"}}, []string{}),
+		Entry("malformed and repeated locales",
+			parkingv1alpha1.ParkedDomainSpec{DomainName: "example.com", Locales: []string{"en", "../de", "en"}},
+			[]string{"spec.locales[1]", "spec.locales[2]"}),
+		Entry("locales with an inline template",
+			parkingv1alpha1.ParkedDomainSpec{DomainName: "example.com", InlineTemplate: "<h1>parked</h1>", Locales: []string{"de"}},
+			[]string{"spec.locales"}),
+		Entry("a manifest key",
+			parkingv1alpha1.ParkedDomainSpec{DomainName: "example.com", ManifestKey: ".pdo-manifest.json"}, []string{}),
+		Entry("a manifest key of a published page",
+			parkingv1alpha1.ParkedDomainSpec{DomainName: "example.com", Locales: []string{"de"}, ManifestKey: "index.de.html"},
+			[]string{"spec.manifestKey"}),
+		Entry("tags",
+			parkingv1alpha1.ParkedDomainSpec{DomainName: "example.com", Tags: map[string]string{"team": "web", "cost-center": ""}}, []string{}),
+		Entry("tags with reserved, empty and long keys and values",
+			parkingv1alpha1.ParkedDomainSpec{DomainName: "example.com", Tags: map[string]string{
+				"aws:cloudformation:stack-name": "parked", "": "v", "note": strings.Repeat("a", 257),
+			}}, []string{"spec.tags[aws:cloudformation:stack-name]", "spec.tags[]", "spec.tags[note]"}),
+		Entry("more tags than AWS allows",
+			parkingv1alpha1.ParkedDomainSpec{DomainName: "example.com", Tags: manyTags(51)}, []string{"spec.tags"}),
+		Entry("an infrequent access storage class",
+			parkingv1alpha1.ParkedDomainSpec{DomainName: "example.com", StorageClass: "INTELLIGENT_TIERING"}, []string{}),
+		Entry("an archive storage class",
+			parkingv1alpha1.ParkedDomainSpec{DomainName: "example.com", StorageClass: "DEEP_ARCHIVE"}, []string{"spec.storageClass"}),
+		Entry("S3 managed encryption",
+			parkingv1alpha1.ParkedDomainSpec{DomainName: "example.com", Encryption: &parkingv1alpha1.Encryption{Algorithm: "AES256"}}, []string{}),
+		Entry("KMS encryption with a key",
+			parkingv1alpha1.ParkedDomainSpec{DomainName: "example.com", Encryption: &parkingv1alpha1.Encryption{Algorithm: "aws:kms", KMSKeyID: "alias/parked"}}, []string{}),
+		Entry("S3 managed encryption with a KMS key",
+			parkingv1alpha1.ParkedDomainSpec{DomainName: "example.com", Encryption: &parkingv1alpha1.Encryption{Algorithm: "AES256", KMSKeyID: "alias/parked"}},
+			[]string{"spec.encryption.kmsKeyID"}),
+		Entry("an unknown encryption algorithm",
+			parkingv1alpha1.ParkedDomainSpec{DomainName: "example.com", Encryption: &parkingv1alpha1.Encryption{Algorithm: "rot13"}}, []string{"spec.encryption.algorithm"}),
+		Entry("query logging to a log group in us-east-1",
+			parkingv1alpha1.ParkedDomainSpec{DomainName: "example.com", QueryLogging: &parkingv1alpha1.QueryLogging{
+				LogGroupARN: "arn:aws:logs:us-east-1:111111111111:log-group:/aws/route53/example.com",
+			}}, []string{}),
+		Entry("query logging to a log group in another region",
+			parkingv1alpha1.ParkedDomainSpec{DomainName: "example.com", QueryLogging: &parkingv1alpha1.QueryLogging{
+				LogGroupARN: "arn:aws:logs:eu-west-1:111111111111:log-group:/aws/route53/example.com",
+			}}, []string{"spec.queryLogging.logGroupARN"}),
+		Entry("query logging to something other than a log group",
+			parkingv1alpha1.ParkedDomainSpec{DomainName: "example.com", QueryLogging: &parkingv1alpha1.QueryLogging{
+				LogGroupARN: "arn:aws:s3:::example-logs",
+			}}, []string{"spec.queryLogging.logGroupARN"}),
+		Entry("query logging to a malformed ARN",
+			parkingv1alpha1.ParkedDomainSpec{DomainName: "example.com", QueryLogging: &parkingv1alpha1.QueryLogging{LogGroupARN: "/aws/route53/example.com"}},
+			[]string{"spec.queryLogging.logGroupARN"}),
+		Entry("a placeholder address",
+			parkingv1alpha1.ParkedDomainSpec{DomainName: "example.com", PlaceholderAddress: "198.51.100.10"}, []string{}),
+		Entry("a nameserver export",
+			parkingv1alpha1.ParkedDomainSpec{DomainName: "example.com", NameServersExport: &parkingv1alpha1.NameServersExport{Name: "example-ns"}}, []string{}),
+		Entry("a nameserver export to an invalid name",
+			parkingv1alpha1.ParkedDomainSpec{DomainName: "example.com", NameServersExport: &parkingv1alpha1.NameServersExport{Name: "Example_NS"}},
+			[]string{"spec.nameServersExport.name"}),
+		Entry("a maintenance window spanning midnight",
+			parkingv1alpha1.ParkedDomainSpec{DomainName: "example.com", MaintenanceWindow: &parkingv1alpha1.MaintenanceWindow{Start: "22:00", End: "02:00"}}, []string{}),
+		Entry("a maintenance window with a 12-hour time",
+			parkingv1alpha1.ParkedDomainSpec{DomainName: "example.com", MaintenanceWindow: &parkingv1alpha1.MaintenanceWindow{Start: "2am", End: "04:00"}},
+			[]string{"spec.maintenanceWindow.start"}),
+		Entry("a record comment",
+			parkingv1alpha1.ParkedDomainSpec{DomainName: "example.com", RecordComment: "team-web JIRA-123"}, []string{}),
+		Entry("a record comment too long for a change batch",
+			parkingv1alpha1.ParkedDomainSpec{DomainName: "example.com", RecordComment: strings.Repeat("x", 223)}, []string{"spec.recordComment"}),
+		Entry("an IPv6 placeholder address",
+			parkingv1alpha1.ParkedDomainSpec{DomainName: "example.com", PlaceholderAddress: "2001:db8::10"}, []string{"spec.placeholderAddress"}),
+		Entry("a placeholder host name",
+			parkingv1alpha1.ParkedDomainSpec{DomainName: "example.com", PlaceholderAddress: "maintenance.example.org"}, []string{"spec.placeholderAddress"}),
+		Entry("a lifecycle rule with no expiration",
+			parkingv1alpha1.ParkedDomainSpec{DomainName: "example.com", LifecycleRules: []parkingv1alpha1.LifecycleRule{
+				{ID: "logs", ExpirationDays: aws.Int32(30)},
+				{ID: "empty"},
+			}}, []string{"spec.lifecycleRules[1]"}),
+	)
+
+	It("reports the supported regions for an unknown region", func() {
+		pd := &parkingv1alpha1.ParkedDomain{Spec: parkingv1alpha1.ParkedDomainSpec{DomainName: "example.com", Region: "mars-1"}}
+		errs := ValidateParkedDomain(pd)
+		Expect(errs).To(HaveLen(1))
+		Expect(errs[0].Type).To(Equal(field.ErrorTypeNotSupported))
+		Expect(errs[0].Detail).To(ContainSubstring(`"eu-central-1"`))
+	})
+})
+
+var _ = Describe("validateFeatureCompatibility", func() {
+	DescribeTable("reports fields that cannot be combined",
+		func(spec parkingv1alpha1.ParkedDomainSpec, expected []string) {
+			pd := &parkingv1alpha1.ParkedDomain{Spec: spec}
+			errs := validateFeatureCompatibility(pd, field.NewPath("spec"))
+
+			fields := make([]string, 0, len(errs))
+			for _, err := range errs {
+				Expect(err.Type).To(Equal(field.ErrorTypeForbidden))
+				fields = append(fields, err.Field)
+			}
+			Expect(fields).To(ConsistOf(expected))
+		},
+		Entry("the defaults", parkingv1alpha1.ParkedDomainSpec{}, []string{}),
+		Entry("a template URL with a ConfigMap ref",
+			parkingv1alpha1.ParkedDomainSpec{TemplateURL: "https://example.org/a.html", TemplateConfigMapRef: &parkingv1alpha1.TemplateConfigMapRef{Name: "t"}},
+			[]string{"spec.templateConfigMapRef"}),
+		Entry("a private zone without DNS",
+			parkingv1alpha1.ParkedDomainSpec{DNSEnabled: aws.Bool(false), PrivateZone: &parkingv1alpha1.PrivateZone{VPCID: "vpc-0abc"}},
+			[]string{"spec.privateZone"}),
+		Entry("a delegation set without DNS",
+			parkingv1alpha1.ParkedDomainSpec{DNSEnabled: aws.Bool(false), DelegationSetID: "N1"}, []string{"spec.delegationSetID"}),
+		Entry("a parent zone without DNS",
+			parkingv1alpha1.ParkedDomainSpec{DNSEnabled: aws.Bool(false), ParentZoneID: "Z1"}, []string{"spec.parentZoneID"}),
+		Entry("a private zone with a parent zone",
+			parkingv1alpha1.ParkedDomainSpec{ParentZoneID: "Z1", PrivateZone: &parkingv1alpha1.PrivateZone{VPCID: "vpc-0abc"}}, []string{"spec.privateZone"}),
+		Entry("a private zone delegated in its parent zone",
+			parkingv1alpha1.ParkedDomainSpec{DelegateInParentZone: true, PrivateZone: &parkingv1alpha1.PrivateZone{VPCID: "vpc-0abc"}}, []string{"spec.privateZone"}),
+		Entry("parent zone delegation without DNS",
+			parkingv1alpha1.ParkedDomainSpec{DNSEnabled: aws.Bool(false), DelegateInParentZone: true}, []string{"spec.delegateInParentZone"}),
+		Entry("query logging without DNS",
+			parkingv1alpha1.ParkedDomainSpec{DNSEnabled: aws.Bool(false), QueryLogging: &parkingv1alpha1.QueryLogging{}}, []string{"spec.queryLogging"}),
+		Entry("a nameserver export without DNS",
+			parkingv1alpha1.ParkedDomainSpec{DNSEnabled: aws.Bool(false), NameServersExport: &parkingv1alpha1.NameServersExport{}},
+			[]string{"spec.nameServersExport"}),
+		Entry("query logging for a private zone",
+			parkingv1alpha1.ParkedDomainSpec{QueryLogging: &parkingv1alpha1.QueryLogging{}, PrivateZone: &parkingv1alpha1.PrivateZone{VPCID: "vpc-0abc"}},
+			[]string{"spec.queryLogging"}),
+		Entry("a suffixed bucket without DNS",
+			parkingv1alpha1.ParkedDomainSpec{DNSEnabled: aws.Bool(false), AutoSuffixBucket: true}, []string{}),
+		Entry("a suffixed bucket behind the domain's alias record",
+			parkingv1alpha1.ParkedDomainSpec{AutoSuffixBucket: true}, []string{"spec.autoSuffixBucket"}),
+		Entry("a placeholder address without DNS",
+			parkingv1alpha1.ParkedDomainSpec{DNSEnabled: aws.Bool(false), PlaceholderAddress: "198.51.100.10"}, []string{"spec.placeholderAddress"}),
+		Entry("endpoint verification without storage",
+			parkingv1alpha1.ParkedDomainSpec{StorageEnabled: aws.Bool(false), VerifyHTTP: true}, []string{"spec.verifyHTTP"}),
+		Entry("an alias target without DNS",
+			parkingv1alpha1.ParkedDomainSpec{DNSEnabled: aws.Bool(false), AliasTarget: &parkingv1alpha1.AliasTarget{}}, []string{"spec.aliasTarget"}),
+		Entry("an alias target with storage turned on",
+			parkingv1alpha1.ParkedDomainSpec{StorageEnabled: aws.Bool(true), AliasTarget: &parkingv1alpha1.AliasTarget{}}, []string{"spec.storageEnabled"}),
+		Entry("an S3-compatible endpoint with DNS",
+			parkingv1alpha1.ParkedDomainSpec{StorageEndpoint: "http://minio.internal:9000"}, []string{"spec.storageEndpoint"}),
+		Entry("an S3-compatible endpoint without DNS",
+			parkingv1alpha1.ParkedDomainSpec{DNSEnabled: aws.Bool(false), StorageEndpoint: "http://minio.internal:9000"}, []string{}),
+		Entry("access logs delivered to the bucket itself",
+			parkingv1alpha1.ParkedDomainSpec{DomainName: "example.com", AccessLogBucket: "example.com"}, []string{"spec.accessLogBucket"}),
+		Entry("access logs delivered to another bucket",
+			parkingv1alpha1.ParkedDomainSpec{DomainName: "example.com", AccessLogBucket: "example-logs", CreateAccessLogBucket: true}, []string{}),
+		Entry("access log options without a log bucket",
+			parkingv1alpha1.ParkedDomainSpec{CreateAccessLogBucket: true, AccessLogPrefix: "logs/"},
+			[]string{"spec.createAccessLogBucket", "spec.accessLogPrefix"}),
+		Entry("HTTPS forced at an S3 website endpoint",
+			parkingv1alpha1.ParkedDomainSpec{ForceHTTPS: true}, []string{"spec.forceHTTPS"}),
+		Entry("HTTPS forced by a CloudFront distribution",
+			parkingv1alpha1.ParkedDomainSpec{SharedBucket: &parkingv1alpha1.SharedBucket{Name: "parked-pages"},
+				AliasTarget: &parkingv1alpha1.AliasTarget{Type: parkingv1alpha1.AliasTargetCloudFront}, ForceHTTPS: true}, []string{}),
+		Entry("endpoint verification with storage",
+			parkingv1alpha1.ParkedDomainSpec{VerifyHTTP: true}, []string{}),
+		Entry("a shared bucket behind a CloudFront distribution",
+			parkingv1alpha1.ParkedDomainSpec{SharedBucket: &parkingv1alpha1.SharedBucket{Name: "parked-pages"},
+				AliasTarget: &parkingv1alpha1.AliasTarget{Type: parkingv1alpha1.AliasTargetCloudFront}, VerifyHTTP: true}, []string{}),
+		Entry("a shared bucket without a distribution",
+			parkingv1alpha1.ParkedDomainSpec{SharedBucket: &parkingv1alpha1.SharedBucket{Name: "parked-pages"}}, []string{"spec.sharedBucket"}),
+		Entry("a shared bucket with bucket configuration",
+			parkingv1alpha1.ParkedDomainSpec{SharedBucket: &parkingv1alpha1.SharedBucket{Name: "parked-pages"},
+				AliasTarget:     &parkingv1alpha1.AliasTarget{Type: parkingv1alpha1.AliasTargetCloudFront},
+				ObjectOwnership: "BucketOwnerEnforced", Alarm: &parkingv1alpha1.Alarm{}, ManifestKey: ".pdo-manifest.json", SPAFallback: true},
+			[]string{"spec.objectOwnership", "spec.alarm", "spec.manifestKey", "spec.spaFallback"}),
+	)
+
+	It("reports a cleanup batch size beyond the S3 limit", func() {
+		for value, valid := range map[string]bool{"1": true, "1000": true, "0": false, "1001": false, "ten": false} {
+			pd := &parkingv1alpha1.ParkedDomain{
+				ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{cleanupBatchSizeAnnotation: value}},
+				Spec:       parkingv1alpha1.ParkedDomainSpec{DomainName: "example.com"},
+			}
+			if valid {
+				Expect(ValidateParkedDomain(pd)).To(BeEmpty(), value)
+			} else {
+				Expect(ValidateParkedDomain(pd)).To(ConsistOf(HaveField("Field", "metadata.annotations[parking.minibaev.eu/cleanup-batch-size]")), value)
+			}
+		}
+	})
+
+	It("says how to resolve the conflict", func() {
+		pd := &parkingv1alpha1.ParkedDomain{Spec: parkingv1alpha1.ParkedDomainSpec{StorageEnabled: aws.Bool(false), VerifyHTTP: true}}
+		errs := validateFeatureCompatibility(pd, field.NewPath("spec"))
+		Expect(errs).To(HaveLen(1))
+		Expect(errs[0].Detail).To(ContainSubstring("set storageEnabled to true"))
+	})
+})
+
+var _ = Describe("ValidateAssumedRoles", func() {
+	const (
+		dnsRole     = "arn:aws:iam::111111111111:role/parked-dns"
+		storageRole = "arn:aws:iam::222222222222:role/parked-storage"
+	)
+	pd := &parkingv1alpha1.ParkedDomain{Spec: parkingv1alpha1.ParkedDomainSpec{
+		DomainName: "example.com", DNSRoleARN: dnsRole, StorageRoleARN: storageRole,
+	}}
+
+	DescribeTable("reports the roles the allowlist does not cover",
+		func(allowed []string, fields []string) {
+			errs := ValidateAssumedRoles(pd, allowed)
+			Expect(errs).To(HaveLen(len(fields)))
+			for i, f := range fields {
+				Expect(errs[i].Field).To(Equal(f))
+				Expect(errs[i].Type).To(Equal(field.ErrorTypeForbidden))
+			}
+		},
+		Entry("an empty allowlist", nil, []string{"spec.dnsRoleARN", "spec.storageRoleARN"}),
+		Entry("any role", []string{"*"}, nil),
+		Entry("both role ARNs", []string{dnsRole, storageRole}, nil),
+		Entry("one account", []string{"222222222222"}, []string{"spec.dnsRoleARN"}),
+		Entry("another role of the account", []string{"arn:aws:iam::111111111111:role/other", storageRole}, []string{"spec.dnsRoleARN"}),
+	)
+
+	It("allows a ParkedDomain without roles", func() {
+		Expect(ValidateAssumedRoles(&parkingv1alpha1.ParkedDomain{}, nil)).To(BeEmpty())
+	})
+})
+
+// manyTags returns n distinct tags.
+func manyTags(n int) map[string]string {
+	tags := make(map[string]string, n)
+	for i := range n {
+		tags["tag-"+strconv.Itoa(i)] = "v"
+	}
+	return tags
+}
@@ -0,0 +1,115 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/route53"
+	r53types "github.com/aws/aws-sdk-go-v2/service/route53/types"
+	parkingv1alpha1 "github.com/gminiba/parked-domain-operator/api/v1alpha1"
+	"github.com/gminiba/parked-domain-operator/internal/awserr"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// reconcileVPCAssociations associates Spec.AdditionalVPCs with the private Hosted Zone and
+// disassociates the VPCs removed from the spec since, as recorded in Status.AssociatedVPCs.
+// VPCs associated outside the operator are left alone.
+func (r *ParkedDomainReconciler) reconcileVPCAssociations(ctx context.Context, pd *parkingv1alpha1.ParkedDomain) error {
+	if len(pd.Spec.AdditionalVPCs) == 0 && len(pd.Status.AssociatedVPCs) == 0 {
+		return nil
+	}
+	r53Client, err := r.r53ClientFor(ctx, pd)
+	if err != nil {
+		return err
+	}
+	zoneID := pd.Status.ZoneID
+	getZoneOutput, err := r53Client.GetHostedZone(ctx, &route53.GetHostedZoneInput{Id: aws.String(zoneID)})
+	if err != nil {
+		return fmt.Errorf("failed to get details for hosted zone: %w", err)
+	}
+
+	associated := make([]parkingv1alpha1.VPCRef, 0, len(pd.Spec.AdditionalVPCs))
+	for _, vpc := range pd.Spec.AdditionalVPCs {
+		if vpc.VPCRegion == "" {
+			vpc.VPCRegion = regionFor(pd)
+		}
+		if !zoneHasVPC(getZoneOutput.VPCs, vpc.VPCID) {
+			_, err := r53Client.AssociateVPCWithHostedZone(ctx, &route53.AssociateVPCWithHostedZoneInput{
+				HostedZoneId: aws.String(zoneID),
+				VPC:          &r53types.VPC{VPCId: aws.String(vpc.VPCID), VPCRegion: r53types.VPCRegion(vpc.VPCRegion)},
+			})
+			switch {
+			case awserr.Code(err) == "NotAuthorizedException":
+				return fmt.Errorf("VPC %s belongs to another AWS account, which must first allow the association with CreateVPCAssociationAuthorization: %w", vpc.VPCID, err)
+			case err != nil:
+				return fmt.Errorf("failed to associate VPC %s with Hosted Zone: %w", vpc.VPCID, err)
+			}
+			log.FromContext(ctx).Info("Associated VPC with Hosted Zone", "vpcID", vpc.VPCID)
+		}
+		associated = append(associated, vpc)
+	}
+
+	for _, vpc := range pd.Status.AssociatedVPCs {
+		inSpec := slices.ContainsFunc(pd.Spec.AdditionalVPCs, func(ref parkingv1alpha1.VPCRef) bool { return ref.VPCID == vpc.VPCID })
+		if inSpec || isPrimaryVPC(pd, vpc.VPCID) || !zoneHasVPC(getZoneOutput.VPCs, vpc.VPCID) {
+			continue
+		}
+		if err := disassociateVPC(ctx, r53Client, zoneID, vpc); err != nil {
+			return err
+		}
+	}
+	pd.Status.AssociatedVPCs = associated
+	return nil
+}
+
+// disassociateVPCs disassociates the VPCs in Status.AssociatedVPCs from a Hosted Zone the
+// operator keeps on deletion, as it did not create it.
+func disassociateVPCs(ctx context.Context, r53Client R53ClientAPI, pd *parkingv1alpha1.ParkedDomain) error {
+	for _, vpc := range pd.Status.AssociatedVPCs {
+		if isPrimaryVPC(pd, vpc.VPCID) {
+			continue
+		}
+		if err := disassociateVPC(ctx, r53Client, pd.Status.ZoneID, vpc); err != nil {
+			return err
+		}
+	}
+	pd.Status.AssociatedVPCs = nil
+	return nil
+}
+
+// disassociateVPC disassociates vpc from the Hosted Zone, if it still is associated.
+func disassociateVPC(ctx context.Context, r53Client R53ClientAPI, zoneID string, vpc parkingv1alpha1.VPCRef) error {
+	_, err := r53Client.DisassociateVPCFromHostedZone(ctx, &route53.DisassociateVPCFromHostedZoneInput{
+		HostedZoneId: aws.String(zoneID),
+		VPC:          &r53types.VPC{VPCId: aws.String(vpc.VPCID), VPCRegion: r53types.VPCRegion(vpc.VPCRegion)},
+	})
+	if err != nil && awserr.Code(err) != "VPCAssociationNotFound" {
+		return fmt.Errorf("failed to disassociate VPC %s from Hosted Zone: %w", vpc.VPCID, err)
+	}
+	log.FromContext(ctx).Info("Disassociated VPC from Hosted Zone", "vpcID", vpc.VPCID)
+	return nil
+}
+
+// isPrimaryVPC reports whether vpcID is the VPC the private zone was created with, which a
+// private zone cannot be left without.
+func isPrimaryVPC(pd *parkingv1alpha1.ParkedDomain, vpcID string) bool {
+	return pd.Spec.PrivateZone != nil && pd.Spec.PrivateZone.VPCID == vpcID
+}
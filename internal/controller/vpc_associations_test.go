@@ -0,0 +1,134 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/route53"
+	r53types "github.com/aws/aws-sdk-go-v2/service/route53/types"
+	"github.com/aws/smithy-go"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+
+	parkingv1alpha1 "github.com/gminiba/parked-domain-operator/api/v1alpha1"
+)
+
+var _ = Describe("Route 53 VPC associations", func() {
+	var pd *parkingv1alpha1.ParkedDomain
+
+	// zoneWithVPCs returns a mock whose private zone is associated with vpcIDs and records
+	// the VPCs associated and disassociated.
+	zoneWithVPCs := func(associated, disassociated *[]string, vpcIDs ...string) *MockR53Client {
+		return &MockR53Client{
+			GetHostedZoneFunc: func(ctx context.Context, params *route53.GetHostedZoneInput, optFns ...func(*route53.Options)) (*route53.GetHostedZoneOutput, error) {
+				output := &route53.GetHostedZoneOutput{HostedZone: &r53types.HostedZone{
+					Id: params.Id, CallerReference: aws.String("terraform-20240101"),
+				}}
+				for _, vpcID := range vpcIDs {
+					output.VPCs = append(output.VPCs, r53types.VPC{VPCId: aws.String(vpcID)})
+				}
+				return output, nil
+			},
+			AssociateVPCWithHostedZoneFunc: func(ctx context.Context, params *route53.AssociateVPCWithHostedZoneInput, optFns ...func(*route53.Options)) (*route53.AssociateVPCWithHostedZoneOutput, error) {
+				*associated = append(*associated, aws.ToString(params.VPC.VPCId)+"/"+string(params.VPC.VPCRegion))
+				return &route53.AssociateVPCWithHostedZoneOutput{}, nil
+			},
+			DisassociateVPCFromHostedZoneFunc: func(ctx context.Context, params *route53.DisassociateVPCFromHostedZoneInput, optFns ...func(*route53.Options)) (*route53.DisassociateVPCFromHostedZoneOutput, error) {
+				*disassociated = append(*disassociated, aws.ToString(params.VPC.VPCId))
+				return &route53.DisassociateVPCFromHostedZoneOutput{}, nil
+			},
+		}
+	}
+
+	BeforeEach(func() {
+		pd = &parkingv1alpha1.ParkedDomain{
+			ObjectMeta: metav1.ObjectMeta{Name: "split-horizon", Namespace: "default"},
+			Spec: parkingv1alpha1.ParkedDomainSpec{
+				DomainName:  "internal.example.com",
+				Region:      "eu-west-1",
+				PrivateZone: &parkingv1alpha1.PrivateZone{VPCID: "vpc-primary"},
+			},
+			Status: parkingv1alpha1.ParkedDomainStatus{ZoneID: "ZPRIVATE"},
+		}
+	})
+
+	It("should associate the additional VPCs the zone is not associated with yet", func() {
+		pd.Spec.AdditionalVPCs = []parkingv1alpha1.VPCRef{{VPCID: "vpc-shared"}, {VPCID: "vpc-peered", VPCRegion: "us-east-1"}}
+		var associated, disassociated []string
+		r := &ParkedDomainReconciler{R53Client: zoneWithVPCs(&associated, &disassociated, "vpc-primary", "vpc-shared")}
+
+		Expect(r.reconcileVPCAssociations(context.Background(), pd)).To(Succeed())
+		Expect(associated).To(Equal([]string{"vpc-peered/us-east-1"}))
+		Expect(disassociated).To(BeEmpty())
+		Expect(pd.Status.AssociatedVPCs).To(Equal([]parkingv1alpha1.VPCRef{
+			{VPCID: "vpc-shared", VPCRegion: "eu-west-1"},
+			{VPCID: "vpc-peered", VPCRegion: "us-east-1"},
+		}))
+	})
+
+	It("should disassociate VPCs removed from the spec and keep the ones associated outside the operator", func() {
+		pd.Status.AssociatedVPCs = []parkingv1alpha1.VPCRef{{VPCID: "vpc-shared", VPCRegion: "eu-west-1"}}
+		var associated, disassociated []string
+		r := &ParkedDomainReconciler{R53Client: zoneWithVPCs(&associated, &disassociated, "vpc-primary", "vpc-shared", "vpc-theirs")}
+
+		Expect(r.reconcileVPCAssociations(context.Background(), pd)).To(Succeed())
+		Expect(disassociated).To(Equal([]string{"vpc-shared"}))
+		Expect(pd.Status.AssociatedVPCs).To(BeEmpty())
+	})
+
+	It("should not look at the zone when no VPCs were ever added", func() {
+		r := &ParkedDomainReconciler{R53Client: &MockR53Client{
+			GetHostedZoneFunc: func(ctx context.Context, params *route53.GetHostedZoneInput, optFns ...func(*route53.Options)) (*route53.GetHostedZoneOutput, error) {
+				Fail("the zone should not be read")
+				return nil, nil
+			},
+		}}
+
+		Expect(r.reconcileVPCAssociations(context.Background(), pd)).To(Succeed())
+	})
+
+	It("should say how to associate a VPC of another account", func() {
+		pd.Spec.AdditionalVPCs = []parkingv1alpha1.VPCRef{{VPCID: "vpc-other"}}
+		var associated, disassociated []string
+		r53 := zoneWithVPCs(&associated, &disassociated, "vpc-primary")
+		r53.AssociateVPCWithHostedZoneFunc = func(ctx context.Context, params *route53.AssociateVPCWithHostedZoneInput, optFns ...func(*route53.Options)) (*route53.AssociateVPCWithHostedZoneOutput, error) {
+			return nil, &smithy.GenericAPIError{Code: "NotAuthorizedException"}
+		}
+		r := &ParkedDomainReconciler{R53Client: r53}
+
+		err := r.reconcileVPCAssociations(context.Background(), pd)
+		Expect(err).To(MatchError(ContainSubstring("CreateVPCAssociationAuthorization")))
+		Expect(pd.Status.AssociatedVPCs).To(BeEmpty())
+	})
+
+	It("should disassociate the added VPCs from a zone kept on deletion", func() {
+		pd.Status.AssociatedVPCs = []parkingv1alpha1.VPCRef{{VPCID: "vpc-shared", VPCRegion: "eu-west-1"}}
+		var associated, disassociated []string
+		r := &ParkedDomainReconciler{
+			R53Client: zoneWithVPCs(&associated, &disassociated, "vpc-primary", "vpc-shared"),
+			Recorder:  record.NewFakeRecorder(10),
+		}
+
+		Expect(r.cleanupRoute53Zone(context.Background(), pd)).To(BeTrue())
+		Expect(disassociated).To(Equal([]string{"vpc-shared"}))
+		Expect(pd.Status.AssociatedVPCs).To(BeEmpty())
+	})
+})
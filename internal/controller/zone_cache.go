@@ -0,0 +1,81 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"sync"
+	"time"
+
+	r53types "github.com/aws/aws-sdk-go-v2/service/route53/types"
+	parkingv1alpha1 "github.com/gminiba/parked-domain-operator/api/v1alpha1"
+)
+
+// cachedZone is what the operator last learned about a Hosted Zone from GetHostedZone.
+type cachedZone struct {
+	private    bool
+	vpcs       []r53types.VPC
+	verifiedAt time.Time
+}
+
+// zoneCache remembers the Hosted Zones in the ParkedDomains' status that were confirmed to
+// exist, keyed by zone ID, so a zone found once is not looked up by name again and is only
+// confirmed again once it is due. Reconciles of different ParkedDomains share it.
+type zoneCache struct {
+	mu    sync.Mutex
+	zones map[string]cachedZone
+}
+
+// store records that the zone exists, associated with vpcs if it is private.
+func (c *zoneCache) store(zoneID string, private bool, vpcs []r53types.VPC) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.zones == nil {
+		c.zones = map[string]cachedZone{}
+	}
+	c.zones[zoneID] = cachedZone{
+		private:    private,
+		vpcs:       vpcs,
+		verifiedAt: time.Now(),
+	}
+}
+
+// fresh reports whether the zone was confirmed less than maxAge ago.
+func (c *zoneCache) fresh(zoneID string, maxAge time.Duration) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	zone, ok := c.zones[zoneID]
+	return ok && time.Since(zone.verifiedAt) < maxAge
+}
+
+// matches reports whether the zone is known to exist with the visibility, and for a private
+// zone the VPC, that pd asks for.
+func (c *zoneCache) matches(zoneID string, pd *parkingv1alpha1.ParkedDomain) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	zone, ok := c.zones[zoneID]
+	if !ok || zone.private != (pd.Spec.PrivateZone != nil) {
+		return false
+	}
+	return !zone.private || zoneHasVPC(zone.vpcs, pd.Spec.PrivateZone.VPCID)
+}
+
+// forget drops the zone, so it is confirmed again before it is trusted.
+func (c *zoneCache) forget(zoneID string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.zones, zoneID)
+}
@@ -0,0 +1,139 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/route53"
+	r53types "github.com/aws/aws-sdk-go-v2/service/route53/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	parkingv1alpha1 "github.com/gminiba/parked-domain-operator/api/v1alpha1"
+)
+
+var _ = Describe("Hosted Zone cache", func() {
+	var (
+		pd           *parkingv1alpha1.ParkedDomain
+		r53Mock      *MockR53Client
+		lookups      int
+		verifies     int
+		zoneNotFound bool
+	)
+
+	BeforeEach(func() {
+		lookups, verifies, zoneNotFound = 0, 0, false
+		pd = &parkingv1alpha1.ParkedDomain{
+			ObjectMeta: metav1.ObjectMeta{Name: "cached", Namespace: "default", Generation: 2},
+			Spec:       parkingv1alpha1.ParkedDomainSpec{DomainName: "cached.example.com"},
+			Status: parkingv1alpha1.ParkedDomainStatus{
+				ZoneID:      "ZCACHED",
+				NameServers: []string{"ns-1.awsdns.com", "ns-2.awsdns.com"},
+			},
+		}
+		r53Mock = &MockR53Client{
+			ListHostedZonesByNameFunc: func(ctx context.Context, params *route53.ListHostedZonesByNameInput, optFns ...func(*route53.Options)) (*route53.ListHostedZonesByNameOutput, error) {
+				lookups++
+				return &route53.ListHostedZonesByNameOutput{}, nil
+			},
+			GetHostedZoneFunc: func(ctx context.Context, params *route53.GetHostedZoneInput, optFns ...func(*route53.Options)) (*route53.GetHostedZoneOutput, error) {
+				verifies++
+				if zoneNotFound {
+					return nil, &r53types.NoSuchHostedZone{}
+				}
+				return &route53.GetHostedZoneOutput{
+					HostedZone:    &r53types.HostedZone{Id: params.Id, Config: &r53types.HostedZoneConfig{}},
+					DelegationSet: &r53types.DelegationSet{NameServers: []string{"ns-1.awsdns.com", "ns-2.awsdns.com"}},
+				}, nil
+			},
+		}
+	})
+
+	It("should skip the lookup by name when the zone ID is cached", func() {
+		ctx := context.Background()
+		r := newTestReconciler(r53Mock, &MockS3Client{}, pd)
+
+		_, err := r.refreshNameServers(ctx, pd)
+		Expect(err).NotTo(HaveOccurred())
+		zoneID, nameservers, err := r.reconcileRoute53Zone(ctx, pd)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(zoneID).To(Equal("ZCACHED"))
+		Expect(nameservers).To(Equal(pd.Status.NameServers))
+		Expect(lookups).To(BeZero())
+		Expect(verifies).To(Equal(1))
+	})
+
+	It("should only confirm the zone again once it is due", func() {
+		ctx := context.Background()
+		r := newTestReconciler(r53Mock, &MockS3Client{}, pd)
+		r.ZoneVerifyInterval = time.Hour
+
+		for range 3 {
+			_, err := r.refreshNameServers(ctx, pd)
+			Expect(err).NotTo(HaveOccurred())
+		}
+		Expect(verifies).To(Equal(1))
+
+		By("confirming it on every reconcile without an interval")
+		r.ZoneVerifyInterval = 0
+		_, err := r.refreshNameServers(ctx, pd)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(verifies).To(Equal(2))
+	})
+
+	It("should look the zone up again once it is not found", func() {
+		ctx := context.Background()
+		r := newTestReconciler(r53Mock, &MockS3Client{}, pd)
+		r.ZoneVerifyInterval = time.Hour
+		_, err := r.refreshNameServers(ctx, pd)
+		Expect(err).NotTo(HaveOccurred())
+
+		By("dropping the zone after a step failed on a missing resource")
+		_, err = r.failStep(ctx, pd, parkingv1alpha1.ConditionRecordReady, "Error: Route53 Record", &r53types.NoSuchHostedZone{})
+		Expect(err).To(HaveOccurred())
+		zoneNotFound = true
+		changed, err := r.refreshNameServers(ctx, pd)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(changed).To(BeTrue())
+		Expect(verifies).To(Equal(2))
+		Expect(pd.Status.ZoneID).To(BeEmpty())
+
+		_, _, err = r.reconcileRoute53Zone(ctx, pd)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(lookups).To(Equal(1))
+	})
+
+	It("should look the zone up when its visibility no longer matches", func() {
+		ctx := context.Background()
+		r := newTestReconciler(r53Mock, &MockS3Client{}, pd)
+		_, err := r.refreshNameServers(ctx, pd)
+		Expect(err).NotTo(HaveOccurred())
+
+		pd.Spec.PrivateZone = &parkingv1alpha1.PrivateZone{VPCID: "vpc-0abc"}
+		r53Mock.CreateHostedZoneFunc = func(ctx context.Context, params *route53.CreateHostedZoneInput, optFns ...func(*route53.Options)) (*route53.CreateHostedZoneOutput, error) {
+			return &route53.CreateHostedZoneOutput{HostedZone: &r53types.HostedZone{Id: aws.String("/hostedzone/ZPRIVATE")}}, nil
+		}
+		zoneID, _, err := r.reconcileRoute53Zone(ctx, pd)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(zoneID).To(Equal("ZPRIVATE"))
+		Expect(lookups).To(Equal(1))
+	})
+})
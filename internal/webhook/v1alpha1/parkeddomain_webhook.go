@@ -0,0 +1,188 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	parkingv1alpha1 "github.com/gminiba/parked-domain-operator/api/v1alpha1"
+	"github.com/gminiba/parked-domain-operator/internal/controller"
+)
+
+// parkeddomainlog is for logging in this package.
+var parkeddomainlog = logf.Log.WithName("parkeddomain-resource")
+
+// SetupParkedDomainWebhookWithManager registers the webhook for ParkedDomain in the manager.
+// suffixConfigMap, if set, is the ConfigMap listing the domain suffixes allowed in each
+// namespace, allowedRoles the IAM roles ParkedDomains may have the operator assume and
+// allowedSharedBuckets the shared buckets they may publish into.
+func SetupParkedDomainWebhookWithManager(mgr ctrl.Manager, suffixConfigMap types.NamespacedName, allowedRoles, allowedSharedBuckets []string) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(&parkingv1alpha1.ParkedDomain{}).
+		WithValidator(&ParkedDomainCustomValidator{
+			Client:               mgr.GetClient(),
+			SuffixConfigMap:      suffixConfigMap,
+			AllowedRoles:         allowedRoles,
+			AllowedSharedBuckets: allowedSharedBuckets,
+		}).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/validate-parking-minibaev-eu-v1alpha1-parkeddomain,mutating=false,failurePolicy=fail,sideEffects=None,groups=parking.minibaev.eu,resources=parkeddomains,verbs=create;update,versions=v1alpha1,name=vparkeddomain-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// ParkedDomainCustomValidator rejects updates changing a ParkedDomain's domain, which would
+// leave the old domain's resources behind. If SuffixConfigMap is set, it also rejects
+// ParkedDomains whose domain, record or shared bucket is not below one of the suffixes the
+// ConfigMap allows for their namespace. Each key of the ConfigMap is a namespace, its value a comma-separated list of
+// suffixes, or "*" for any domain. A namespace that is not listed may not park any domain.
+// IAM roles AllowedRoles does not cover, shared buckets AllowedSharedBuckets does not cover
+// and specs failing controller.ValidateParkedDomain are rejected as the reconciler would
+// refuse them.
+type ParkedDomainCustomValidator struct {
+	Client               client.Reader
+	SuffixConfigMap      types.NamespacedName
+	AllowedRoles         []string
+	AllowedSharedBuckets []string
+}
+
+var _ webhook.CustomValidator = &ParkedDomainCustomValidator{}
+
+// ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the type ParkedDomain.
+func (v *ParkedDomainCustomValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	pd, ok := obj.(*parkingv1alpha1.ParkedDomain)
+	if !ok {
+		return nil, fmt.Errorf("expected a ParkedDomain object but got %T", obj)
+	}
+	parkeddomainlog.V(1).Info("Validation for ParkedDomain upon creation", "name", pd.GetName())
+
+	allErrs := append(controller.ValidateParkedDomain(pd), controller.ValidateAssumedRoles(pd, v.AllowedRoles)...)
+	allErrs = append(allErrs, controller.ValidateSharedBucketAllowed(pd, v.AllowedSharedBuckets)...)
+	if len(allErrs) > 0 {
+		return nil, apierrors.NewInvalid(parkingv1alpha1.GroupVersion.WithKind("ParkedDomain").GroupKind(), pd.Name, allErrs)
+	}
+	if v.SuffixConfigMap.Name == "" {
+		return nil, nil
+	}
+	return nil, v.validateDomainSuffix(ctx, pd, suffixFields(pd))
+}
+
+// ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type ParkedDomain.
+// Only a changed record or shared bucket is checked against the suffixes again, so a policy
+// tightened later never blocks other updates, e.g. the removal of the finalizer once the
+// domain's resources are cleaned up.
+func (v *ParkedDomainCustomValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	oldPD, ok := oldObj.(*parkingv1alpha1.ParkedDomain)
+	if !ok {
+		return nil, fmt.Errorf("expected a ParkedDomain object for the oldObj but got %T", oldObj)
+	}
+	pd, ok := newObj.(*parkingv1alpha1.ParkedDomain)
+	if !ok {
+		return nil, fmt.Errorf("expected a ParkedDomain object for the newObj but got %T", newObj)
+	}
+	parkeddomainlog.V(1).Info("Validation for ParkedDomain upon update", "name", pd.GetName())
+
+	var allErrs field.ErrorList
+	if pd.Spec.DomainName != oldPD.Spec.DomainName {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "domainName"), fmt.Sprintf(
+			"is immutable; delete the ParkedDomain and create a new one for %s, so the resources of %s are cleaned up",
+			pd.Spec.DomainName, oldPD.Spec.DomainName)))
+	}
+	// A ParkedDomain being deleted only loses its finalizer, which must not be blocked.
+	if pd.DeletionTimestamp.IsZero() {
+		allErrs = append(allErrs, controller.ValidateParkedDomain(pd)...)
+		allErrs = append(allErrs, controller.ValidateAssumedRoles(pd, v.AllowedRoles)...)
+		allErrs = append(allErrs, controller.ValidateSharedBucketAllowed(pd, v.AllowedSharedBuckets)...)
+	}
+	if len(allErrs) > 0 {
+		return nil, apierrors.NewInvalid(parkingv1alpha1.GroupVersion.WithKind("ParkedDomain").GroupKind(), pd.Name, allErrs)
+	}
+	if v.SuffixConfigMap.Name == "" || !pd.DeletionTimestamp.IsZero() {
+		return nil, nil
+	}
+	oldFields := suffixFields(oldPD)
+	changed := slices.DeleteFunc(suffixFields(pd), func(f suffixField) bool {
+		return slices.ContainsFunc(oldFields, func(old suffixField) bool { return old.path.String() == f.path.String() && old.name == f.name })
+	})
+	if len(changed) == 0 {
+		return nil, nil
+	}
+	return nil, v.validateDomainSuffix(ctx, pd, changed)
+}
+
+// ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type ParkedDomain.
+func (v *ParkedDomainCustomValidator) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// suffixField is a name in a ParkedDomain's spec that must be below an allowed suffix.
+type suffixField struct {
+	path *field.Path
+	name string
+}
+
+// suffixFields returns the names in pd's spec that must be below an allowed suffix: the
+// domain, the record, which also names the bucket of its own, and the shared bucket.
+func suffixFields(pd *parkingv1alpha1.ParkedDomain) []suffixField {
+	specPath := field.NewPath("spec")
+	fields := []suffixField{{specPath.Child("domainName"), pd.Spec.DomainName}}
+	if pd.Spec.RecordName != "" {
+		fields = append(fields, suffixField{specPath.Child("recordName"), pd.Spec.RecordName})
+	}
+	if pd.Spec.SharedBucket != nil {
+		fields = append(fields, suffixField{specPath.Child("sharedBucket", "name"), pd.Spec.SharedBucket.Name})
+	}
+	return fields
+}
+
+// validateDomainSuffix returns an Invalid error unless each of fields is below a suffix
+// allowed for pd's namespace. It fails closed when the ConfigMap cannot be read.
+func (v *ParkedDomainCustomValidator) validateDomainSuffix(ctx context.Context, pd *parkingv1alpha1.ParkedDomain, fields []suffixField) error {
+	cm := &corev1.ConfigMap{}
+	if err := v.Client.Get(ctx, v.SuffixConfigMap, cm); err != nil {
+		return fmt.Errorf("failed to read the allowed domain suffixes from ConfigMap %s: %w", v.SuffixConfigMap, err)
+	}
+	suffixes := controller.SplitPolicyList(cm.Data[pd.Namespace])
+	message := fmt.Sprintf("no domain suffixes are allowed in namespace %s", pd.Namespace)
+	if len(suffixes) > 0 {
+		message = fmt.Sprintf("must be one of or below the domain suffixes allowed in namespace %s: %s",
+			pd.Namespace, strings.Join(suffixes, ", "))
+	}
+
+	var allErrs field.ErrorList
+	for _, f := range fields {
+		if !slices.ContainsFunc(suffixes, func(suffix string) bool { return controller.DomainAllowed(suffix, f.name) }) {
+			allErrs = append(allErrs, field.Invalid(f.path, f.name, message))
+		}
+	}
+	if len(allErrs) == 0 {
+		return nil
+	}
+	return apierrors.NewInvalid(parkingv1alpha1.GroupVersion.WithKind("ParkedDomain").GroupKind(), pd.Name, allErrs)
+}
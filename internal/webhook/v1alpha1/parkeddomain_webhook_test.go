@@ -0,0 +1,260 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	parkingv1alpha1 "github.com/gminiba/parked-domain-operator/api/v1alpha1"
+)
+
+var _ = Describe("ParkedDomain Webhook", func() {
+	var (
+		suffixes  *corev1.ConfigMap
+		validator *ParkedDomainCustomValidator
+	)
+
+	domain := func(namespace, name string) *parkingv1alpha1.ParkedDomain {
+		return &parkingv1alpha1.ParkedDomain{
+			ObjectMeta: metav1.ObjectMeta{Name: "parked", Namespace: namespace},
+			Spec:       parkingv1alpha1.ParkedDomainSpec{DomainName: name},
+		}
+	}
+	sharedDomain := func(namespace, name, bucket string) *parkingv1alpha1.ParkedDomain {
+		pd := domain(namespace, name)
+		pd.Spec.SharedBucket = &parkingv1alpha1.SharedBucket{Name: bucket}
+		pd.Spec.AliasTarget = &parkingv1alpha1.AliasTarget{
+			HostedZoneID: "Z2FDTNDATAQYW2",
+			DNSName:      "d111111abcdef8.cloudfront.net",
+			Type:         parkingv1alpha1.AliasTargetCloudFront,
+		}
+		return pd
+	}
+
+	BeforeEach(func() {
+		suffixes = &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "domain-suffixes", Namespace: "parking-system"},
+			Data: map[string]string{
+				"team-a": "example.com, example.org",
+				"team-b": "shop.example.net",
+				"admins": "*",
+			},
+		}
+		validator = &ParkedDomainCustomValidator{
+			Client:          fake.NewClientBuilder().WithObjects(suffixes).Build(),
+			SuffixConfigMap: types.NamespacedName{Namespace: "parking-system", Name: "domain-suffixes"},
+		}
+	})
+
+	Context("When creating a ParkedDomain", func() {
+		It("should admit domains below a suffix allowed for its namespace", func() {
+			ctx := context.Background()
+			for _, pd := range []*parkingv1alpha1.ParkedDomain{
+				domain("team-a", "example.com"),
+				domain("team-a", "www.example.org."),
+				domain("team-b", "eu.shop.example.net"),
+				domain("admins", "anything.io"),
+			} {
+				_, err := validator.ValidateCreate(ctx, pd)
+				Expect(err).NotTo(HaveOccurred(), pd.Spec.DomainName)
+			}
+		})
+
+		It("should reject domains outside the suffixes allowed for its namespace", func() {
+			ctx := context.Background()
+			_, err := validator.ValidateCreate(ctx, domain("team-a", "badexample.com"))
+			Expect(apierrors.IsInvalid(err)).To(BeTrue())
+			Expect(err.Error()).To(ContainSubstring("spec.domainName"))
+			Expect(err.Error()).To(ContainSubstring("allowed in namespace team-a: example.com, example.org"))
+
+			By("rejecting another namespace's suffix")
+			_, err = validator.ValidateCreate(ctx, domain("team-b", "example.com"))
+			Expect(err).To(MatchError(ContainSubstring("shop.example.net")))
+			_, err = validator.ValidateCreate(ctx, domain("team-b", "example.net"))
+			Expect(apierrors.IsInvalid(err)).To(BeTrue())
+
+			By("rejecting every domain in a namespace that is not listed")
+			_, err = validator.ValidateCreate(ctx, domain("team-c", "example.com"))
+			Expect(err).To(MatchError(ContainSubstring("no domain suffixes are allowed in namespace team-c")))
+		})
+
+		It("should reject shared buckets outside the suffixes allowed for its namespace", func() {
+			ctx := context.Background()
+			validator.AllowedSharedBuckets = []string{"*"}
+			_, err := validator.ValidateCreate(ctx, sharedDomain("team-a", "example.com", "pages.example.org"))
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = validator.ValidateCreate(ctx, sharedDomain("team-a", "example.com", "pages.shop.example.net"))
+			Expect(apierrors.IsInvalid(err)).To(BeTrue())
+			Expect(err.Error()).To(ContainSubstring("spec.sharedBucket.name"))
+			Expect(err.Error()).NotTo(ContainSubstring("spec.domainName"))
+		})
+
+		It("should reject every domain when the ConfigMap is missing", func() {
+			validator.Client = fake.NewClientBuilder().Build()
+			_, err := validator.ValidateCreate(context.Background(), domain("team-a", "example.com"))
+			Expect(err).To(MatchError(ContainSubstring("ConfigMap parking-system/domain-suffixes")))
+		})
+
+		It("should admit any domain without a suffix policy", func() {
+			validator = &ParkedDomainCustomValidator{Client: fake.NewClientBuilder().Build()}
+			_, err := validator.ValidateCreate(context.Background(), domain("team-c", "example.com"))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should only force HTTPS behind a CloudFront distribution", func() {
+			ctx := context.Background()
+			pd := domain("team-a", "example.com")
+			pd.Spec.ForceHTTPS = true
+			_, err := validator.ValidateCreate(ctx, pd)
+			Expect(apierrors.IsInvalid(err)).To(BeTrue())
+			Expect(err.Error()).To(ContainSubstring("spec.forceHTTPS"))
+
+			pd.Spec.AliasTarget = &parkingv1alpha1.AliasTarget{
+				HostedZoneID: "Z2FDTNDATAQYW2",
+				DNSName:      "d111111abcdef8.cloudfront.net",
+				Type:         parkingv1alpha1.AliasTargetCloudFront,
+			}
+			_, err = validator.ValidateCreate(ctx, pd)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should reject shared buckets the operator does not allow", func() {
+			ctx := context.Background()
+			pd := sharedDomain("team-a", "example.com", "pages.example.org")
+			_, err := validator.ValidateCreate(ctx, pd)
+			Expect(apierrors.IsInvalid(err)).To(BeTrue())
+			Expect(err.Error()).To(ContainSubstring("spec.sharedBucket.name"))
+			Expect(err.Error()).To(ContainSubstring("--allowed-shared-buckets"))
+
+			validator.AllowedSharedBuckets = []string{"pages.example.org"}
+			_, err = validator.ValidateCreate(ctx, pd)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should reject specs the reconciler would refuse", func() {
+			ctx := context.Background()
+			pd := domain("team-a", "team.example.com")
+			pd.Spec.RecordName = "www.victim.org"
+			_, err := validator.ValidateCreate(ctx, pd)
+			Expect(apierrors.IsInvalid(err)).To(BeTrue())
+			Expect(err.Error()).To(ContainSubstring("spec.recordName"))
+
+			By("rejecting them without a suffix policy too")
+			validator.SuffixConfigMap = types.NamespacedName{}
+			_, err = validator.ValidateCreate(ctx, pd)
+			Expect(apierrors.IsInvalid(err)).To(BeTrue())
+		})
+
+		It("should reject roles the operator may not assume", func() {
+			ctx := context.Background()
+			pd := domain("team-a", "example.com")
+			pd.Spec.DNSRoleARN = "arn:aws:iam::999999999999:role/admin"
+			_, err := validator.ValidateCreate(ctx, pd)
+			Expect(apierrors.IsInvalid(err)).To(BeTrue())
+			Expect(err.Error()).To(ContainSubstring("spec.dnsRoleARN"))
+
+			validator.AllowedRoles = []string{"999999999999"}
+			_, err = validator.ValidateCreate(ctx, pd)
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+
+	Context("When updating a ParkedDomain", func() {
+		It("should allow changes other than the domain, even outside the allowed suffixes", func() {
+			ctx := context.Background()
+			oldPD := domain("team-c", "example.com")
+			newPD := oldPD.DeepCopy()
+			newPD.Finalizers = nil
+			newPD.Spec.InlineTemplate = "<h1>For sale</h1>"
+			_, err := validator.ValidateUpdate(ctx, oldPD, newPD)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should reject a change of the domain", func() {
+			ctx := context.Background()
+			oldPD := domain("team-a", "example.com")
+			newPD := oldPD.DeepCopy()
+			newPD.Spec.DomainName = "www.example.com"
+			_, err := validator.ValidateUpdate(ctx, oldPD, newPD)
+			Expect(apierrors.IsInvalid(err)).To(BeTrue())
+			Expect(err.Error()).To(ContainSubstring("spec.domainName: Forbidden: is immutable"))
+			Expect(err.Error()).To(ContainSubstring("delete the ParkedDomain and create a new one for www.example.com"))
+
+			By("rejecting it without a suffix policy too")
+			validator.SuffixConfigMap = types.NamespacedName{}
+			_, err = validator.ValidateUpdate(ctx, oldPD, newPD)
+			Expect(apierrors.IsInvalid(err)).To(BeTrue())
+		})
+
+		It("should check a changed shared bucket against the suffixes, but not an unchanged one", func() {
+			ctx := context.Background()
+			validator.AllowedSharedBuckets = []string{"*"}
+			oldPD := sharedDomain("team-a", "example.com", "pages.example.org")
+			newPD := oldPD.DeepCopy()
+			newPD.Spec.SharedBucket.Name = "pages.shop.example.net"
+			_, err := validator.ValidateUpdate(ctx, oldPD, newPD)
+			Expect(apierrors.IsInvalid(err)).To(BeTrue())
+			Expect(err.Error()).To(ContainSubstring("spec.sharedBucket.name"))
+
+			By("letting a bucket admitted under an older policy stay")
+			oldPD = sharedDomain("team-c", "example.com", "pages.example.org")
+			newPD = oldPD.DeepCopy()
+			newPD.Spec.InlineTemplate = "<h1>For sale</h1>"
+			_, err = validator.ValidateUpdate(ctx, oldPD, newPD)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should reject a record outside the domain", func() {
+			ctx := context.Background()
+			oldPD := domain("team-a", "team.example.com")
+			newPD := oldPD.DeepCopy()
+			newPD.Spec.RecordName = "www.victim.org"
+			_, err := validator.ValidateUpdate(ctx, oldPD, newPD)
+			Expect(apierrors.IsInvalid(err)).To(BeTrue())
+			Expect(err.Error()).To(ContainSubstring("spec.recordName"))
+		})
+
+		It("should reject forcing HTTPS without a CloudFront distribution, unless the domain is being deleted", func() {
+			ctx := context.Background()
+			oldPD := domain("team-a", "example.com")
+			newPD := oldPD.DeepCopy()
+			newPD.Spec.ForceHTTPS = true
+			_, err := validator.ValidateUpdate(ctx, oldPD, newPD)
+			Expect(apierrors.IsInvalid(err)).To(BeTrue())
+			Expect(err.Error()).To(ContainSubstring("spec.forceHTTPS"))
+
+			By("letting the finalizer go")
+			oldPD = newPD.DeepCopy()
+			now := metav1.Now()
+			oldPD.DeletionTimestamp = &now
+			oldPD.Finalizers = []string{"parking.minibaev.eu/finalizer"}
+			newPD = oldPD.DeepCopy()
+			newPD.Finalizers = nil
+			_, err = validator.ValidateUpdate(ctx, oldPD, newPD)
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+})